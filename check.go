@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// copyFolder copies the files below src into dst, so -check can generate
+// over the output without touching it. A missing src copies nothing.
+func copyFolder(src, dst string) error {
+	err := filepath.WalkDir(src, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if entry.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, 0644)
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// outputDifferences compares the files generated into scratch with the same
+// files in dir, returning the ones that are missing or differ. When prune is
+// set, files of the previous run that would be removed count as well.
+func outputDifferences(dir, scratch string, files []string, previous generationManifest, prune bool) ([]string, error) {
+	var differences []string
+	generated := make(map[string]bool, len(files))
+	for _, file := range files {
+		generated[file] = true
+		want, err := os.ReadFile(filepath.Join(scratch, filepath.FromSlash(file)))
+		if err != nil {
+			return nil, err
+		}
+		got, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(file)))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		if err != nil || !bytes.Equal(got, want) {
+			differences = append(differences, file)
+		}
+	}
+
+	if prune {
+		for _, file := range previous.files() {
+			if generated[file] || !prunable(file) {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(file))); err == nil {
+				differences = append(differences, file)
+			}
+		}
+	}
+	sort.Strings(differences)
+	return differences, nil
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestMain runs main instead of the tests when DTOFORGE_TEST_ARGS is set, so
+// tests can run dtoforge as a process and check its exit code
+func TestMain(m *testing.M) {
+	if args, ok := os.LookupEnv("DTOFORGE_TEST_ARGS"); ok {
+		os.Args = append([]string{"dtoforge"}, strings.Split(args, "\n")...)
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// runDtoForge runs dtoforge with args in dir and returns its exit code and output
+func runDtoForge(t *testing.T, dir string, args ...string) (int, string) {
+	t.Helper()
+	cmd := exec.Command(os.Args[0])
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "DTOFORGE_TEST_ARGS="+strings.Join(args, "\n"))
+	output, err := cmd.CombinedOutput()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), string(output)
+	}
+	if err != nil {
+		t.Fatalf("running dtoforge %v: %v", args, err)
+	}
+	return 0, string(output)
+}
+
+func TestExitCodes(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"api.yaml": `openapi: 3.0.0
+info: {title: Test, version: "1"}
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name: {type: string}
+`,
+		"untyped.yaml": `openapi: 3.0.0
+info: {title: Test, version: "1"}
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        extra: {}
+`,
+		"bad.config.yaml":      "output:\n  mode: bogus\n",
+		"bad-lang.config.yaml": "output:\n  mode: bogus\nlang: java\n",
+		"broken.config.yaml":   "naming: [\n",
+		"existing.config.yaml": "lang: typescript\n",
+		"file":                 "not a folder\n",
+	})
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"success", []string{"-no-config", "-openapi", "api.yaml", "-out", "out"}, 0},
+		{"unknown language", []string{"-no-config", "-openapi", "api.yaml", "-lang", "bogus"}, exitUsage},
+		{"unknown flag", []string{"-bogus"}, exitUsage},
+		{"unreadable spec", []string{"-no-config", "-openapi", "missing.yaml"}, exitSpecError},
+		{"invalid config", []string{"-config", "bad.config.yaml", "-openapi", "api.yaml", "-out", "out"}, exitConfigError},
+		{"invalid shared config for another language", []string{"-config", "bad-lang.config.yaml", "-openapi", "api.yaml", "-out", "java"}, exitConfigError},
+		{"output is a file", []string{"-no-config", "-openapi", "api.yaml", "-out", "file/out"}, exitGenerationError},
+		{"warnings", []string{"-no-config", "-openapi", "untyped.yaml", "-out", "untyped", "-fail-on-warning"}, exitWarnings},
+		{"list unreadable spec", []string{"list", "-no-config", "-openapi", "missing.yaml"}, exitSpecError},
+		{"list invalid config", []string{"list", "-config", "broken.config.yaml", "-openapi", "api.yaml"}, exitConfigError},
+		{"init existing config", []string{"init", "-config", "existing.config.yaml", "-yes"}, exitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, output := runDtoForge(t, dir, tt.args...); got != tt.want {
+				t.Errorf("exit code = %d, want %d\n%s", got, tt.want, output)
+			}
+		})
+	}
+}
+
+func TestExitCodes_Check(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{"api.yaml": `openapi: 3.0.0
+info: {title: Test, version: "1"}
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name: {type: string}
+`})
+	args := []string{"-no-config", "-openapi", "api.yaml", "-out", "out"}
+
+	if got, output := runDtoForge(t, dir, append(args, "-check")...); got != exitDiff {
+		t.Errorf("-check before generating: exit code = %d, want %d\n%s", got, exitDiff, output)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "out")); !os.IsNotExist(err) {
+		t.Errorf("-check created the output folder: %v", err)
+	}
+
+	if got, output := runDtoForge(t, dir, args...); got != 0 {
+		t.Fatalf("generating: exit code = %d\n%s", got, output)
+	}
+	if got, output := runDtoForge(t, dir, append(args, "-check", "-force")...); got != 0 {
+		t.Errorf("-check after generating: exit code = %d, want 0\n%s", got, output)
+	}
+
+	writeTestFiles(t, dir, map[string]string{"out/pet.ts": "export {};\n"})
+	got, output := runDtoForge(t, dir, append(args, "-check")...)
+	if got != exitDiff || !strings.Contains(output, "  pet.ts") {
+		t.Errorf("-check after editing pet.ts: exit code = %d, want %d listing pet.ts\n%s", got, exitDiff, output)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "out", "pet.ts")); string(data) != "export {};\n" {
+		t.Errorf("-check changed pet.ts to %q", data)
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -10,6 +11,7 @@ import (
 	"dtoForge/internal/generator"
 	"dtoForge/internal/testutils"
 	"dtoForge/internal/typescript"
+	"dtoForge/internal/zod"
 )
 
 func TestGoldenFiles(t *testing.T) {
@@ -53,6 +55,170 @@ func TestGoldenFiles(t *testing.T) {
 	}
 }
 
+// TestGoldenFilesCompile type-checks generated code with tsc under strict
+// and exactOptionalPropertyTypes, for io-ts, Zod 3 and Zod 4. It runs when
+// DTOFORGE_TSC_DIR names a directory whose node_modules holds typescript,
+// io-ts, io-ts-types, io-ts-reporters, fp-ts and Zod 3, with Zod 4 in the
+// node_modules of its zod4 subdirectory, as the typecheck CI job sets up.
+// custom-formats imports a hand-written branded-types module, so it is not
+// compiled; strict-api covers recursion, defaults and optional properties.
+func TestGoldenFilesCompile(t *testing.T) {
+	tscDir, tsc := requireTSC(t)
+
+	strictTypes := "typescript-zod:\n  generation:\n    strictTypes: true\n"
+	generators := []struct {
+		name    string
+		gen     generator.Generator
+		config  string
+		modules string // subdirectory of tscDir whose node_modules come first
+	}{
+		{name: "io-ts", gen: typescript.NewTypeScriptGenerator()},
+		{name: "zod3", gen: zod.NewZodGenerator(), config: strictTypes},
+		{name: "zod4", gen: zod.NewZod4Generator(), config: strictTypes, modules: "zod4"},
+	}
+	specs := []struct {
+		name        string
+		openAPIFile string
+	}{
+		{name: "basic-schemas", openAPIFile: "testdata/basic-api.yaml"},
+		{name: "strict-schemas", openAPIFile: "testdata/strict-api.yaml"},
+	}
+
+	for _, tc := range generators {
+		for _, spec := range specs {
+			t.Run(tc.name+"/"+spec.name, func(t *testing.T) {
+				// Generate inside tscDir so the modules resolve from its node_modules
+				outputDir, err := os.MkdirTemp(filepath.Join(tscDir, tc.modules), "golden-")
+				if err != nil {
+					t.Fatalf("Failed to create output directory: %v", err)
+				}
+				t.Cleanup(func() { os.RemoveAll(outputDir) })
+
+				openAPI, err := readOpenAPISpec(spec.openAPIFile)
+				if err != nil {
+					t.Fatalf("Failed to read spec: %v", err)
+				}
+				dtos, _, err := convertToGeneratorDTOs(openAPI, ConversionOptions{})
+				if err != nil {
+					t.Fatalf("Conversion failed: %v", err)
+				}
+
+				config := generator.Config{OutputFolder: outputDir, PackageName: "generated-schemas", TargetLanguage: tc.gen.Language()}
+				if tc.config != "" {
+					config.ConfigFile = testutils.WriteFile(t, testutils.TempDir(t), "config.yaml", tc.config)
+				}
+				if err := tc.gen.Generate(dtos, config); err != nil {
+					t.Fatalf("Generation failed: %v", err)
+				}
+
+				testutils.WriteFile(t, outputDir, "tsconfig.json", `{
+  "compilerOptions": {
+    "strict": true,
+    "exactOptionalPropertyTypes": true,
+    "target": "es2020",
+    "module": "commonjs",
+    "moduleResolution": "node",
+    "esModuleInterop": true,
+    "skipLibCheck": true,
+    "noEmit": true
+  },
+  "include": ["**/*.ts"]
+}`)
+				output, err := exec.Command(tsc, "-p", outputDir).CombinedOutput()
+				if err != nil {
+					t.Errorf("tsc failed: %v\n%s", err, output)
+				}
+			})
+		}
+	}
+}
+
+// requireTSC returns DTOFORGE_TSC_DIR and the tsc binary in it. Without
+// them the test is skipped locally, but fails in CI, where the typecheck
+// job must provide them and the other jobs skip these tests by name.
+func requireTSC(t *testing.T) (string, string) {
+	t.Helper()
+	missing := t.Skipf
+	if os.Getenv("CI") != "" {
+		missing = t.Fatalf
+	}
+
+	tscDir := os.Getenv("DTOFORGE_TSC_DIR")
+	if tscDir == "" {
+		missing("DTOFORGE_TSC_DIR is not set")
+	}
+	tsc := filepath.Join(tscDir, "node_modules", ".bin", "tsc")
+	if _, err := os.Stat(tsc); err != nil {
+		missing("tsc not found in DTOFORGE_TSC_DIR: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tscDir, "zod4", "node_modules", "zod")); err != nil {
+		missing("Zod 4 not found in DTOFORGE_TSC_DIR/zod4: %v", err)
+	}
+	return tscDir, tsc
+}
+
+// TestGeneratedBigIntDecodes runs the io-ts codec of a bigint ID with node,
+// checking that JSON numbers decode as well as numeric strings. It needs
+// the same DTOFORGE_TSC_DIR as TestGoldenFilesCompile.
+func TestGeneratedBigIntDecodes(t *testing.T) {
+	tscDir, tsc := requireTSC(t)
+
+	outputDir, err := os.MkdirTemp(tscDir, "bigint-")
+	if err != nil {
+		t.Fatalf("Failed to create output directory: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(outputDir) })
+
+	dto := generator.DTO{
+		Name: "Account",
+		Type: "object",
+		Properties: []generator.Property{
+			{Name: "id", Type: generator.PrimitiveType{Name: "bigint", Format: "int64"}, Required: true},
+		},
+	}
+	config := generator.Config{OutputFolder: outputDir, PackageName: "generated-schemas", TargetLanguage: "typescript"}
+	if err := typescript.NewTypeScriptGenerator().Generate([]generator.DTO{dto}, config); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+
+	testutils.WriteFile(t, outputDir, "tsconfig.json", `{
+  "compilerOptions": {
+    "strict": true,
+    "target": "es2020",
+    "module": "commonjs",
+    "moduleResolution": "node",
+    "esModuleInterop": true,
+    "skipLibCheck": true,
+    "outDir": "dist"
+  },
+  "include": ["**/*.ts"]
+}`)
+	if output, err := exec.Command(tsc, "-p", outputDir).CombinedOutput(); err != nil {
+		t.Fatalf("tsc failed: %v\n%s", err, output)
+	}
+
+	script := `
+const { AccountCodec } = require('./dist/account');
+const decoded = [42, '9007199254740993'].map((id) => AccountCodec.decode({ id }));
+const rejected = [1.5, 'abc'].map((id) => AccountCodec.decode({ id }));
+console.log(JSON.stringify([
+  decoded.map((r) => r._tag === 'Right' ? r.right.id.toString() : 'Left'),
+  rejected.map((r) => r._tag),
+  AccountCodec.encode({ id: 42n }),
+]));
+`
+	cmd := exec.Command("node", "-e", script)
+	cmd.Dir = outputDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("node failed: %v\n%s", err, output)
+	}
+	want := `[["42","9007199254740993"],["Left","Left"],{"id":"42"}]`
+	if got := strings.TrimSpace(string(output)); got != want {
+		t.Errorf("decode results = %s, want %s", got, want)
+	}
+}
+
 // runDtoForgeGeneration performs the same logic as main() but in a testable way
 func runDtoForgeGeneration(openAPIFile, outputDir, configFile string) error {
 	// Read and parse OpenAPI spec
@@ -62,7 +228,7 @@ func runDtoForgeGeneration(openAPIFile, outputDir, configFile string) error {
 	}
 
 	// Convert to generator DTOs
-	dtos, err := convertToGeneratorDTOs(spec)
+	dtos, _, err := convertToGeneratorDTOs(spec, ConversionOptions{})
 	if err != nil {
 		return err
 	}
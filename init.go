@@ -0,0 +1,280 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// initOptions are the answers dtoforge init writes into the config file
+type initOptions struct {
+	Lang    string
+	OpenAPI string // spec path, relative to the config file
+	Out     string
+}
+
+// openAPIMarker matches the top-level version key of an OpenAPI or Swagger
+// document in YAML or JSON
+var openAPIMarker = regexp.MustCompile(`(?m)^\s*"?(openapi|swagger)"?\s*:`)
+
+// initSkipDirs are folders never searched for specs
+var initSkipDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"generated":    true,
+	"dist":         true,
+	"build":        true,
+	"target":       true,
+}
+
+// runInit implements dtoforge init: it writes a config file for the chosen
+// target language that records the spec, so later runs need no flags. Values
+// not given as flags are asked for when interactive, or defaulted otherwise.
+func runInit(args []string, in io.Reader, out io.Writer, interactive bool) error {
+	flags := flag.NewFlagSet("init", flag.ContinueOnError)
+	flags.SetOutput(out)
+	lang := flags.String("lang", "", "Target language (default typescript)")
+	openAPIFile := flags.String("openapi", "", "Path to the OpenAPI spec (default: detected in the current folder)")
+	outputFolder := flags.String("out", "", "Output folder for generated files (default ./generated)")
+	configPath := flags.String("config", "dtoforge.config.yaml", "Config file to write")
+	force := flags.Bool("force", false, "Overwrite an existing config file")
+	yes := flags.Bool("yes", false, "Use defaults instead of asking")
+	flags.Usage = func() {
+		fmt.Fprintf(out, "Usage: dtoforge init [options]\n\nOptions:\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return &usageError{err}
+	}
+
+	if _, err := os.Stat(*configPath); err == nil && !*force {
+		return &usageError{fmt.Errorf("%s already exists, use -force to overwrite it", *configPath)}
+	}
+
+	configDir := filepath.Dir(*configPath)
+	specs, err := findOpenAPIFiles(configDir)
+	if err != nil {
+		return fmt.Errorf("failed to search for OpenAPI specs: %w", err)
+	}
+
+	options := initOptions{Lang: *lang, OpenAPI: *openAPIFile, Out: *outputFolder}
+	if options.OpenAPI != "" {
+		if rel, err := filepath.Rel(configDir, options.OpenAPI); err == nil && !filepath.IsAbs(options.OpenAPI) {
+			options.OpenAPI = filepath.ToSlash(rel)
+		}
+	}
+
+	defaultSpec := ""
+	if len(specs) > 0 {
+		defaultSpec = specs[0]
+	}
+	languages := newRegistry().Available()
+	sort.Strings(languages)
+
+	if interactive && !*yes {
+		reader := bufio.NewReader(in)
+		if len(specs) > 1 && options.OpenAPI == "" {
+			fmt.Fprintf(out, "Found OpenAPI specs: %s\n", strings.Join(specs, ", "))
+		}
+		if options.Lang == "" {
+			fmt.Fprintf(out, "Languages: %s\n", strings.Join(languages, ", "))
+			options.Lang = prompt(reader, out, "Target language", "typescript")
+		}
+		if options.OpenAPI == "" {
+			options.OpenAPI = prompt(reader, out, "OpenAPI spec", defaultSpec)
+		}
+		if options.Out == "" {
+			options.Out = prompt(reader, out, "Output folder", "./generated")
+		}
+	}
+
+	if options.Lang == "" {
+		options.Lang = "typescript"
+	}
+	if options.OpenAPI == "" {
+		options.OpenAPI = defaultSpec
+	}
+	if options.Out == "" {
+		options.Out = "./generated"
+	}
+	if !slices.Contains(languages, options.Lang) {
+		return &usageError{fmt.Errorf("unsupported language '%s', must be one of %s", options.Lang, strings.Join(languages, ", "))}
+	}
+
+	if err := os.WriteFile(*configPath, []byte(initConfig(options)), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", *configPath, err)
+	}
+
+	fmt.Fprintf(out, "✅ Wrote %s for %s\n", *configPath, options.Lang)
+	if options.OpenAPI == "" {
+		fmt.Fprintf(out, "No OpenAPI spec found, set openapi in %s or pass -openapi\n", *configPath)
+	} else {
+		fmt.Fprintf(out, "Run dtoforge to generate %s into %s\n", options.OpenAPI, options.Out)
+	}
+	return nil
+}
+
+// prompt asks for a value, returning fallback for an empty answer
+func prompt(reader *bufio.Reader, out io.Writer, question, fallback string) string {
+	if fallback != "" {
+		fmt.Fprintf(out, "%s [%s]: ", question, fallback)
+	} else {
+		fmt.Fprintf(out, "%s: ", question)
+	}
+	answer, _ := reader.ReadString('\n')
+	if answer = strings.TrimSpace(answer); answer != "" {
+		return answer
+	}
+	return fallback
+}
+
+// isTerminal reports whether f is an interactive terminal. /dev/null is a
+// character device too, so it is ruled out explicitly.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return false
+	}
+	null, err := os.Stat(os.DevNull)
+	return err != nil || !os.SameFile(info, null)
+}
+
+// findOpenAPIFiles lists the OpenAPI and Swagger documents below root,
+// shallowest first, as slash-separated paths relative to root. Hidden and
+// dependency folders are not searched.
+func findOpenAPIFiles(root string) ([]string, error) {
+	var specs []string
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != root && (strings.HasPrefix(entry.Name(), ".") || initSkipDirs[entry.Name()]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch filepath.Ext(path) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+		if entry.Name() == "package.json" || entry.Name() == "dtoforge.config.yaml" {
+			return nil
+		}
+		if !isOpenAPIFile(path) {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		specs = append(specs, filepath.ToSlash(rel))
+		return nil
+	})
+	sort.SliceStable(specs, func(i, j int) bool {
+		return strings.Count(specs[i], "/") < strings.Count(specs[j], "/")
+	})
+	return specs, err
+}
+
+// isOpenAPIFile reports whether the start of the file declares an OpenAPI or
+// Swagger version
+func isOpenAPIFile(path string) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	head := make([]byte, 4096)
+	n, _ := io.ReadFull(file, head)
+	return openAPIMarker.Match(head[:n])
+}
+
+// initConfig renders the config file written by dtoforge init
+func initConfig(options initOptions) string {
+	var b strings.Builder
+	b.WriteString("# DtoForge configuration, see the README for every option\n\n")
+	if options.OpenAPI != "" {
+		fmt.Fprintf(&b, "# OpenAPI spec, relative to this file (-openapi overrides it)\nopenapi: %s\n\n", options.OpenAPI)
+	} else {
+		b.WriteString("# OpenAPI spec, relative to this file (-openapi overrides it)\n# openapi: ./api.yaml\n\n")
+	}
+	fmt.Fprintf(&b, "# Target language (-lang overrides it)\nlang: %s\n\n", options.Lang)
+	fmt.Fprintf(&b, "output:\n  # Output folder (-out overrides it)\n  folder: %q\n", options.Out)
+	b.WriteString(initLanguageConfig[options.Lang])
+	return b.String()
+}
+
+// zodInitConfig is shared by Zod 3 and 4, which read the same section
+const zodInitConfig = `
+typescript-zod:
+  output:
+    # "multiple" (one file per schema) or "single" (all in one file)
+    mode: "multiple"
+  generation:
+    generatePackageJson: true
+    generateHelpers: true
+    # Unknown keys: strip, strict (reject) or passthrough (keep)
+    objectMode: strip
+`
+
+// initLanguageConfig holds the settings init writes for each language, after
+// the output folder. They show the most used options at their defaults.
+var initLanguageConfig = map[string]string{
+	"typescript": `  # "multiple" (one file per schema) or "single" (all in one file)
+  mode: "multiple"
+
+generation:
+  generatePackageJson: true
+  generatePartialCodecs: true
+  generateHelpers: true
+`,
+	"typescript-zod":  zodInitConfig,
+	"typescript-zod4": zodInitConfig,
+	"typescript-nestjs": `
+typescript-nestjs:
+  generation:
+    generatePackageJson: true
+    # Appended to class names, e.g. "Dto"
+    classSuffix: ""
+`,
+	"java": `
+java:
+  generation:
+    # Java package of the generated types (-package overrides it)
+    package: generated
+    # "record" or "class"
+    style: record
+`,
+	"proto": `
+proto:
+  generation:
+    # proto package (-package overrides it)
+    package: dtoforge
+    fileName: schemas.proto
+`,
+	"graphql": `
+graphql:
+  generation:
+    fileName: schema.graphql
+    # Also emit an input type per object
+    inputs: true
+`,
+	"prisma": `
+prisma:
+  generation:
+    fileName: schema.prisma
+    # Datasource provider, e.g. postgresql or mysql
+    provider: postgresql
+`,
+}
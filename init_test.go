@@ -0,0 +1,119 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRunInit(t *testing.T) {
+	dir := t.TempDir()
+	for path, content := range map[string]string{
+		"specs/api.yaml":              "openapi: 3.0.0\ninfo:\n  title: API\n",
+		"node_modules/pkg/api.yaml":   "openapi: 3.0.0\n",
+		"docker-compose.yml":          "services: {}\n",
+		"specs/nested/swagger.json":   "{\n  \"swagger\": \"2.0\"\n}\n",
+		".github/workflows/spec.yaml": "openapi: 3.0.0\n",
+	} {
+		path = filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	specs, err := findOpenAPIFiles(dir)
+	if err != nil {
+		t.Fatalf("findOpenAPIFiles: %v", err)
+	}
+	if want := []string{"specs/api.yaml", "specs/nested/swagger.json"}; !reflect.DeepEqual(specs, want) {
+		t.Errorf("findOpenAPIFiles = %v, want %v", specs, want)
+	}
+
+	configPath := filepath.Join(dir, "dtoforge.config.yaml")
+	var out strings.Builder
+	answers := strings.NewReader("typescript-zod\n\n./src/types\n")
+	if err := runInit([]string{"-config", configPath}, answers, &out, true); err != nil {
+		t.Fatalf("runInit: %v", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"openapi: specs/api.yaml",
+		"lang: typescript-zod",
+		`folder: "./src/types"`,
+		"typescript-zod:\n  output:",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("config missing %q:\n%s", want, data)
+		}
+	}
+
+	options, err := loadProjectOptions(configPath)
+	if err != nil {
+		t.Fatalf("loadProjectOptions: %v", err)
+	}
+	if want := (ProjectOptions{OpenAPI: filepath.Join(dir, "specs", "api.yaml"), Lang: "typescript-zod"}); options != want {
+		t.Errorf("loadProjectOptions = %+v, want %+v", options, want)
+	}
+
+	if err := runInit([]string{"-config", configPath}, strings.NewReader(""), &out, false); err == nil {
+		t.Error("runInit over an existing config: want an error")
+	}
+	if err := runInit([]string{"-config", configPath, "-force", "-lang", "cobol"}, strings.NewReader(""), &out, false); err == nil {
+		t.Error("runInit with an unknown language: want an error")
+	}
+}
+
+func TestRunInit_Defaults(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "dtoforge.config.yaml")
+	var out strings.Builder
+	if err := runInit([]string{"-config", configPath, "-lang", "java"}, strings.NewReader(""), &out, false); err != nil {
+		t.Fatalf("runInit: %v", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"# openapi: ./api.yaml", "lang: java", `folder: "./generated"`, "java:\n  generation:"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("config missing %q:\n%s", want, data)
+		}
+	}
+	if !strings.Contains(out.String(), "No OpenAPI spec found") {
+		t.Errorf("output = %q, want a hint that no spec was found", out.String())
+	}
+}
+
+func TestApplyProjectOptions(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "dtoforge.config.yaml")
+	if err := os.WriteFile(configPath, []byte("openapi: api.yaml\nlang: java\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config := Config{TargetLanguage: "typescript"}
+	if err := applyProjectOptions(&config, configPath); err != nil {
+		t.Fatalf("applyProjectOptions: %v", err)
+	}
+	if config.OpenAPIFile != filepath.Join(dir, "api.yaml") || config.TargetLanguage != "java" {
+		t.Errorf("config = %+v, want the spec and language of the config file", config)
+	}
+
+	// Flags win over the config file
+	config = Config{OpenAPIFile: "other.yaml", TargetLanguage: "prisma", SetFlags: map[string]bool{"lang": true, "openapi": true}}
+	if err := applyProjectOptions(&config, configPath); err != nil {
+		t.Fatalf("applyProjectOptions: %v", err)
+	}
+	if config.OpenAPIFile != "other.yaml" || config.TargetLanguage != "prisma" {
+		t.Errorf("config = %+v, want the flags kept", config)
+	}
+}
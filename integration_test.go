@@ -44,10 +44,10 @@ components:
 			wantFiles: []string{"index.ts", "user.ts", "package.json"},
 			wantContent: map[string][]string{
 				"user.ts": {
-					"export const UserCodec = t.type({",
+					"export const UserCodec = t.intersection([t.type({",
 					"id: t.string,",
 					"name: t.string,",
-					"email: t.union([t.string, t.undefined]),",
+					"}), t.partial({\n  email: t.string,\n})]);",
 					"export type User = t.TypeOf<typeof UserCodec>;",
 				},
 				"index.ts": {
@@ -94,7 +94,7 @@ customTypes:
 					"import { UUID } from './branded-types';",
 					"import { DateTimeString } from './branded-types';",
 					"id: UUID,",
-					"createdAt: t.union([DateTimeString, t.undefined]),",
+					"createdAt: DateTimeString,",
 				},
 			},
 		},
@@ -124,7 +124,7 @@ customTypes:
 			}
 
 			// Convert to DTOs
-			dtos, err := convertToGeneratorDTOs(spec)
+			dtos, _, err := convertToGeneratorDTOs(spec, ConversionOptions{})
 			if err != nil {
 				t.Fatalf("Failed to convert to DTOs: %v", err)
 			}
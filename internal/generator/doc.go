@@ -0,0 +1,44 @@
+package generator
+
+import "strings"
+
+// DescriptionLines splits a description into comment lines. Lines are kept as
+// written, so paragraphs and bullet lists survive; surrounding blank lines and
+// trailing whitespace are dropped.
+func DescriptionLines(description string) []string {
+	description = strings.ReplaceAll(description, "\r\n", "\n")
+	description = strings.Trim(description, "\n")
+	if strings.TrimSpace(description) == "" {
+		return nil
+	}
+
+	lines := strings.Split(description, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return lines
+}
+
+// DocComment renders a description followed by tags (e.g. "@deprecated") as a
+// JSDoc block at the given indent, ending in a newline. "*/" in the text is
+// escaped so it cannot close the comment early. It returns "" when there is
+// nothing to document.
+func DocComment(indent, description string, tags ...string) string {
+	lines := append(DescriptionLines(description), tags...)
+	if len(lines) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(indent + "/**\n")
+	for _, line := range lines {
+		line = strings.ReplaceAll(line, "*/", "*\\/")
+		if line == "" {
+			b.WriteString(indent + " *\n")
+		} else {
+			b.WriteString(indent + " * " + line + "\n")
+		}
+	}
+	b.WriteString(indent + " */\n")
+	return b.String()
+}
@@ -0,0 +1,36 @@
+package generator
+
+import (
+	"testing"
+)
+
+func TestDocComment(t *testing.T) {
+	tests := []struct {
+		name        string
+		indent      string
+		description string
+		tags        []string
+		expected    string
+	}{
+		{"Empty", "", "  ", nil, ""},
+		{"Single line", "", "A user", nil, "/**\n * A user\n */\n"},
+		{"Tags only", "  ", "", []string{"@deprecated"}, "  /**\n   * @deprecated\n   */\n"},
+		{
+			"Paragraphs and bullets",
+			"",
+			"Summary line.\n\nDetails:\n- first\n- second\n",
+			[]string{"@deprecated"},
+			"/**\n * Summary line.\n *\n * Details:\n * - first\n * - second\n * @deprecated\n */\n",
+		},
+		{"Comment terminator", "", "Matches /* and */ literally", nil, "/**\n * Matches /* and *\\/ literally\n */\n"},
+		{"Windows line endings", "", "one\r\ntwo", nil, "/**\n * one\n * two\n */\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DocComment(tt.indent, tt.description, tt.tags...); got != tt.expected {
+				t.Errorf("DocComment() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
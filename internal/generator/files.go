@@ -0,0 +1,59 @@
+package generator
+
+import (
+	"os"
+	"sort"
+	"sync"
+)
+
+// FileRecorder records the paths of the files a generator writes, so callers
+// know exactly what a run generated. DTO files may be written in parallel, so
+// it is safe for concurrent use. A nil recorder records nothing.
+type FileRecorder struct {
+	mu    sync.Mutex
+	paths map[string]bool
+}
+
+// Record records that the file at path was written
+func (r *FileRecorder) Record(path string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.paths == nil {
+		r.paths = make(map[string]bool)
+	}
+	r.paths[path] = true
+}
+
+// Paths returns the recorded paths, sorted
+func (r *FileRecorder) Paths() []string {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	paths := make([]string, 0, len(r.paths))
+	for path := range r.paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// KeepFile reports whether the file of a DTO at path is kept from the
+// previous run instead of written: the DTO is unchanged and the file is
+// still there. A kept file is recorded as generated, so it is not pruned.
+// Only the DTO's own file may be kept; files shared by several DTOs, like an
+// index, are always written.
+func (c Config) KeepFile(name, path string) bool {
+	if !c.Unchanged[name] {
+		return false
+	}
+	if _, err := os.Stat(path); err != nil {
+		return false
+	}
+	c.Files.Record(path)
+	return true
+}
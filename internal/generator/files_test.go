@@ -0,0 +1,61 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestFileRecorder(t *testing.T) {
+	recorder := &FileRecorder{}
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			recorder.Record(fmt.Sprintf("out/dto%d.ts", i%3))
+		}()
+	}
+	wg.Wait()
+
+	want := []string{"out/dto0.ts", "out/dto1.ts", "out/dto2.ts"}
+	if paths := recorder.Paths(); !reflect.DeepEqual(paths, want) {
+		t.Errorf("Paths() = %v, want %v", paths, want)
+	}
+
+	var nilRecorder *FileRecorder
+	nilRecorder.Record("out/dto.ts")
+	if paths := nilRecorder.Paths(); paths != nil {
+		t.Errorf("nil recorder Paths() = %v, want nil", paths)
+	}
+}
+
+func TestConfig_KeepFile(t *testing.T) {
+	dir := t.TempDir()
+	pet := filepath.Join(dir, "pet.ts")
+	if err := os.WriteFile(pet, []byte("export {};\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config := Config{Files: &FileRecorder{}, Unchanged: map[string]bool{"Pet": true, "Owner": true}}
+
+	if !config.KeepFile("Pet", pet) {
+		t.Error("KeepFile(Pet) = false, want true for an unchanged DTO with its file")
+	}
+	// A missing file is written again, and changed DTOs are always written
+	if config.KeepFile("Owner", filepath.Join(dir, "owner.ts")) {
+		t.Error("KeepFile(Owner) = true, want false for a missing file")
+	}
+	if config.KeepFile("Tag", filepath.Join(dir, "tag.ts")) {
+		t.Error("KeepFile(Tag) = true, want false for a changed DTO")
+	}
+	if (Config{}).KeepFile("Pet", pet) {
+		t.Error("KeepFile without unchanged DTOs = true, want false")
+	}
+
+	if paths := config.Files.Paths(); !reflect.DeepEqual(paths, []string{pet}) {
+		t.Errorf("recorded paths = %v, want the kept file", paths)
+	}
+}
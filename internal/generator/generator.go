@@ -6,24 +6,79 @@ import (
 
 // DTO represents a Data Transfer Object in our IR.
 type DTO struct {
-	Name        string            `json:"name"`
-	Description string            `json:"description"`
-	Properties  []Property        `json:"properties"`
-	Required    []string          `json:"required"`
-	Type        string            `json:"type"` // object, enum, etc.
-	EnumValues  []string          `json:"enumValues,omitempty"`
-	Metadata    map[string]string `json:"metadata,omitempty"`
+	Name          string     `json:"name"`
+	Description   string     `json:"description"`
+	Properties    []Property `json:"properties"`
+	Required      []string   `json:"required"`
+	Type          string     `json:"type"` // object, enum or union
+	EnumValues    []string   `json:"enumValues,omitempty"`
+	EnumValueType string     `json:"enumValueType,omitempty"` // "integer" or "number" if EnumValues are numbers
+	UnionMembers  []IRType   `json:"unionMembers,omitempty"`  // member types of a union DTO (oneOf/anyOf)
+	Discriminator string     `json:"discriminator,omitempty"` // property telling union members apart
+	Extends       []string   `json:"extends,omitempty"`       // DTOs this one inherits from (allOf $ref)
+	// AdditionalProperties is the value type of keys not declared in
+	// Properties, nil if the schema does not allow them
+	AdditionalProperties IRType          `json:"additionalProperties,omitempty"`
+	Tags                 []string        `json:"tags,omitempty"` // tags of the operations that use this DTO
+	Examples             []any           `json:"examples,omitempty"`
+	Deprecated           bool            `json:"deprecated,omitempty"`
+	Metadata             Metadata        `json:"metadata,omitempty"`
+	Source               *SourceLocation `json:"source,omitempty"` // where the schema is defined, nil if unknown
+}
+
+// IRTypes returns the types a DTO is built from: its property types, the
+// type of its additional properties and, for a union DTO, its members
+func (d DTO) IRTypes() []IRType {
+	types := make([]IRType, 0, len(d.Properties)+len(d.UnionMembers)+1)
+	for _, prop := range d.Properties {
+		types = append(types, prop.Type)
+	}
+	if d.AdditionalProperties != nil {
+		types = append(types, d.AdditionalProperties)
+	}
+	return append(types, d.UnionMembers...)
+}
+
+// NumericEnum reports whether the DTO is an enum of numbers
+func (d DTO) NumericEnum() bool {
+	return d.Type == "enum" && IsNumericType(d.EnumValueType)
+}
+
+// Union returns the type of a union DTO: its only member, or a UnionType of
+// all of them
+func (d DTO) Union() IRType {
+	if len(d.UnionMembers) == 1 {
+		return d.UnionMembers[0]
+	}
+	return UnionType{Types: d.UnionMembers, Discriminator: d.Discriminator}
 }
 
 // Property represents a field within a DTO.
 type Property struct {
-	Name          string            `json:"name"`
-	Type          IRType            `json:"type"`
-	Description   string            `json:"description"`
-	Nullable      bool              `json:"nullable"`
-	Required      bool              `json:"required"`
-	CustomBranded string            `json:"customBranded,omitempty"`
-	Metadata      map[string]string `json:"metadata,omitempty"`
+	Name          string          `json:"name"`
+	Type          IRType          `json:"type"`
+	Description   string          `json:"description"`
+	Nullable      bool            `json:"nullable"`
+	Required      bool            `json:"required"`
+	CustomBranded string          `json:"customBranded,omitempty"`
+	Default       any             `json:"default,omitempty"` // the schema's default value, nil if none
+	Examples      []any           `json:"examples,omitempty"`
+	Deprecated    bool            `json:"deprecated,omitempty"`
+	ReadOnly      bool            `json:"readOnly,omitempty"`  // only sent in responses
+	WriteOnly     bool            `json:"writeOnly,omitempty"` // only sent in requests
+	Metadata      Metadata        `json:"metadata,omitempty"`
+	Source        *SourceLocation `json:"source,omitempty"`
+}
+
+// SourceLocation is a position in the OpenAPI document
+type SourceLocation struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+func (l SourceLocation) String() string {
+	return fmt.Sprintf("%s:%d:%d", l.File, l.Line, l.Column)
 }
 
 // IRType is an interface for our type representations.
@@ -36,7 +91,28 @@ type Config struct {
 	OutputFolder   string
 	PackageName    string
 	TargetLanguage string
-	ConfigFile     string // Path to the custom types config file
+	ConfigFile     string            // Path to the custom types config file
+	Warnings       *WarningCollector // Receives generation warnings, may be nil
+	Parallel       int               // DTO files generated at once in multiple-file mode, <= 1 for one by one
+	Files          *FileRecorder     // Receives the paths of the files written, may be nil
+	Naming         Naming            // How DTOs named after reserved words are renamed
+	Unchanged      map[string]bool   // DTOs whose files from the previous run are kept, see KeepFile
+}
+
+// ConfigError is returned by Generate when the config file cannot be loaded
+// or holds settings that cannot be generated, so callers can tell a bad
+// config from a failure to generate
+type ConfigError struct {
+	Path string
+	Err  error
+}
+
+func (e *ConfigError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.Err
 }
 
 // Generator is the interface that all language generators must implement
@@ -0,0 +1,239 @@
+package generator
+
+import "sort"
+
+// Dependencies returns the sorted names of the DTOs a DTO extends or
+// references from its properties or union members
+func Dependencies(dto DTO) []string {
+	refs := make(map[string]bool)
+	for _, base := range dto.Extends {
+		refs[base] = true
+	}
+	for _, irType := range dto.IRTypes() {
+		collectRefs(irType, refs)
+	}
+
+	names := make([]string, 0, len(refs))
+	for name := range refs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// collectRefs walks an IRType and records every named DTO it refers to
+func collectRefs(irType IRType, refs map[string]bool) {
+	switch t := irType.(type) {
+	case ReferenceType:
+		refs[t.RefName] = true
+	case ObjectType:
+		if t.RefName != "" {
+			refs[t.RefName] = true
+		} else if t.DTORef != nil {
+			for _, irType := range t.DTORef.IRTypes() {
+				collectRefs(irType, refs)
+			}
+		}
+	case ArrayType:
+		collectRefs(t.ElementType, refs)
+	case TupleType:
+		for _, elem := range t.ElementTypes {
+			collectRefs(elem, refs)
+		}
+		if t.Rest != nil {
+			collectRefs(t.Rest, refs)
+		}
+	case UnionType:
+		for _, member := range t.Types {
+			collectRefs(member, refs)
+		}
+	case MapType:
+		collectRefs(t.KeyType, refs)
+		collectRefs(t.ValueType, refs)
+	}
+}
+
+// Graph is the reference graph of a DTO set: the DTOs each DTO extends or
+// references, and the DTOs that reference it. References to names outside
+// the set are left out. All name lists are sorted.
+type Graph struct {
+	dtos         map[string]DTO
+	names        []string
+	dependencies map[string][]string
+	dependents   map[string][]string
+}
+
+// BuildGraph returns the reference graph of dtos
+func BuildGraph(dtos []DTO) *Graph {
+	g := &Graph{
+		dtos:         make(map[string]DTO, len(dtos)),
+		dependencies: make(map[string][]string, len(dtos)),
+		dependents:   make(map[string][]string, len(dtos)),
+	}
+	for _, dto := range dtos {
+		if _, seen := g.dtos[dto.Name]; !seen {
+			g.names = append(g.names, dto.Name)
+		}
+		g.dtos[dto.Name] = dto
+	}
+	sort.Strings(g.names)
+
+	for _, name := range g.names {
+		for _, dep := range Dependencies(g.dtos[name]) {
+			if _, known := g.dtos[dep]; !known {
+				continue
+			}
+			g.dependencies[name] = append(g.dependencies[name], dep)
+			g.dependents[dep] = append(g.dependents[dep], name)
+		}
+	}
+	return g
+}
+
+// Names returns the names of every DTO in the graph
+func (g *Graph) Names() []string {
+	return append([]string(nil), g.names...)
+}
+
+// DTO returns the DTO with the given name
+func (g *Graph) DTO(name string) (DTO, bool) {
+	dto, ok := g.dtos[name]
+	return dto, ok
+}
+
+// Dependencies returns the DTOs that name extends or references directly
+func (g *Graph) Dependencies(name string) []string {
+	return append([]string(nil), g.dependencies[name]...)
+}
+
+// Dependents returns the DTOs that extend or reference name directly
+func (g *Graph) Dependents(name string) []string {
+	return append([]string(nil), g.dependents[name]...)
+}
+
+// Closure returns the given DTOs and every DTO they depend on, directly or
+// transitively, e.g. everything a feature's bundle must include
+func (g *Graph) Closure(names ...string) []string {
+	seen := make(map[string]bool)
+	var visit func(name string)
+	visit = func(name string) {
+		if _, known := g.dtos[name]; !known || seen[name] {
+			return
+		}
+		seen[name] = true
+		for _, dep := range g.dependencies[name] {
+			visit(dep)
+		}
+	}
+	for _, name := range names {
+		visit(name)
+	}
+
+	closure := make([]string, 0, len(seen))
+	for name := range seen {
+		closure = append(closure, name)
+	}
+	sort.Strings(closure)
+	return closure
+}
+
+// Sorted orders the DTOs so that every DTO comes after the DTOs it
+// references, breaking ties alphabetically. Members of a reference cycle
+// cannot all precede each other, so generators must emit lazy references for
+// them (see Cycles).
+func (g *Graph) Sorted() []DTO {
+	sorted := make([]DTO, 0, len(g.names))
+	visited := make(map[string]bool, len(g.names))
+
+	var visit func(name string)
+	visit = func(name string) {
+		if visited[name] {
+			return
+		}
+		// Marking before recursing stops at back-edges of cycles
+		visited[name] = true
+		for _, dep := range g.dependencies[name] {
+			visit(dep)
+		}
+		sorted = append(sorted, g.dtos[name])
+	}
+
+	for _, name := range g.names {
+		visit(name)
+	}
+
+	return sorted
+}
+
+// Cycles returns the names of DTOs that take part in a reference cycle,
+// including DTOs that reference themselves
+func (g *Graph) Cycles() map[string]bool {
+	// Tarjan's strongly connected components
+	index := 0
+	indices := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	cyclic := make(map[string]bool)
+
+	var visit func(name string)
+	visit = func(name string) {
+		indices[name] = index
+		lowlink[name] = index
+		index++
+		stack = append(stack, name)
+		onStack[name] = true
+
+		for _, dep := range g.dependencies[name] {
+			if _, visited := indices[dep]; !visited {
+				visit(dep)
+				lowlink[name] = min(lowlink[name], lowlink[dep])
+			} else if onStack[dep] {
+				lowlink[name] = min(lowlink[name], indices[dep])
+			}
+			if dep == name {
+				cyclic[name] = true
+			}
+		}
+
+		if lowlink[name] != indices[name] {
+			return
+		}
+
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == name {
+				break
+			}
+		}
+		if len(component) > 1 {
+			for _, member := range component {
+				cyclic[member] = true
+			}
+		}
+	}
+
+	for _, name := range g.names {
+		if _, visited := indices[name]; !visited {
+			visit(name)
+		}
+	}
+
+	return cyclic
+}
+
+// SortByDependency orders DTOs so that every DTO comes after the DTOs it
+// references (see Graph.Sorted)
+func SortByDependency(dtos []DTO) []DTO {
+	return BuildGraph(dtos).Sorted()
+}
+
+// FindCycles returns the names of DTOs that take part in a reference cycle
+// (see Graph.Cycles)
+func FindCycles(dtos []DTO) map[string]bool {
+	return BuildGraph(dtos).Cycles()
+}
@@ -0,0 +1,98 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func refDTO(name string, refs ...IRType) DTO {
+	dto := DTO{Name: name, Type: "object"}
+	for i, ref := range refs {
+		dto.Properties = append(dto.Properties, Property{Name: string(rune('a' + i)), Type: ref})
+	}
+	return dto
+}
+
+func TestDependencies(t *testing.T) {
+	dto := refDTO("Order",
+		ReferenceType{RefName: "User"},
+		ArrayType{ElementType: ReferenceType{RefName: "LineItem"}},
+		ObjectType{RefName: "Address"},
+		UnionType{Types: []IRType{ReferenceType{RefName: "Card"}, ReferenceType{RefName: "User"}}},
+		PrimitiveType{Name: "string"},
+	)
+	dto.Extends = []string{"Entity"}
+
+	expected := []string{"Address", "Card", "Entity", "LineItem", "User"}
+	if got := Dependencies(dto); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Dependencies() = %v, want %v", got, expected)
+	}
+}
+
+func TestFindCycles(t *testing.T) {
+	dtos := []DTO{
+		refDTO("Category", ArrayType{ElementType: ReferenceType{RefName: "Category"}}),
+		refDTO("Order", ReferenceType{RefName: "User"}),
+		refDTO("User", ArrayType{ElementType: ReferenceType{RefName: "Order"}}),
+		refDTO("Product", ReferenceType{RefName: "Category"}),
+		refDTO("Tag"),
+		refDTO("Dangling", ReferenceType{RefName: "Missing"}),
+	}
+
+	expected := map[string]bool{"Category": true, "Order": true, "User": true}
+	if got := FindCycles(dtos); !reflect.DeepEqual(got, expected) {
+		t.Errorf("FindCycles() = %v, want %v", got, expected)
+	}
+}
+
+func TestSortByDependency(t *testing.T) {
+	dtos := []DTO{
+		refDTO("User", ReferenceType{RefName: "Address"}),
+		refDTO("Order", ReferenceType{RefName: "User"}, ArrayType{ElementType: ReferenceType{RefName: "Product"}}),
+		refDTO("Address"),
+		refDTO("Product", ReferenceType{RefName: "Category"}),
+		refDTO("Category", ArrayType{ElementType: ReferenceType{RefName: "Category"}}),
+		refDTO("Node", ReferenceType{RefName: "Edge"}),
+		refDTO("Edge", ReferenceType{RefName: "Node"}),
+	}
+
+	var got []string
+	for _, dto := range SortByDependency(dtos) {
+		got = append(got, dto.Name)
+	}
+
+	expected := []string{"Address", "Category", "Node", "Edge", "Product", "User", "Order"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("SortByDependency() = %v, want %v", got, expected)
+	}
+}
+
+func TestBuildGraph(t *testing.T) {
+	dtos := []DTO{
+		refDTO("Order", ReferenceType{RefName: "User"}, ArrayType{ElementType: ReferenceType{RefName: "LineItem"}}),
+		refDTO("LineItem", ReferenceType{RefName: "Product"}),
+		refDTO("User", ReferenceType{RefName: "Missing"}),
+		refDTO("Product"),
+		refDTO("Tag"),
+	}
+	graph := BuildGraph(dtos)
+
+	if got := graph.Names(); !reflect.DeepEqual(got, []string{"LineItem", "Order", "Product", "Tag", "User"}) {
+		t.Errorf("Names() = %v", got)
+	}
+	if got := graph.Dependencies("Order"); !reflect.DeepEqual(got, []string{"LineItem", "User"}) {
+		t.Errorf("Dependencies(Order) = %v", got)
+	}
+	if got := graph.Dependencies("User"); len(got) != 0 {
+		t.Errorf("Dependencies(User) = %v, want unknown refs left out", got)
+	}
+	if got := graph.Dependents("Product"); !reflect.DeepEqual(got, []string{"LineItem"}) {
+		t.Errorf("Dependents(Product) = %v", got)
+	}
+	if got := graph.Closure("Order"); !reflect.DeepEqual(got, []string{"LineItem", "Order", "Product", "User"}) {
+		t.Errorf("Closure(Order) = %v", got)
+	}
+	if _, ok := graph.DTO("Tag"); !ok {
+		t.Error("DTO(Tag) not found")
+	}
+}
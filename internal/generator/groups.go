@@ -0,0 +1,80 @@
+package generator
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SharedGroup is the folder for DTOs used by several tags or by none
+const SharedGroup = "shared"
+
+// TagGroups assigns every DTO to the output folder of its tag. DTOs with a
+// single tag go to that tag's folder; all others go to SharedGroup.
+func TagGroups(dtos []DTO) map[string]string {
+	groups := make(map[string]string, len(dtos))
+	for _, dto := range dtos {
+		if len(dto.Tags) == 1 {
+			groups[dto.Name] = GroupFolder(dto.Tags[0])
+		} else {
+			groups[dto.Name] = SharedGroup
+		}
+	}
+	return groups
+}
+
+// GroupFolder turns a tag into a folder name, e.g. "Billing Accounts" into
+// "billing-accounts"
+func GroupFolder(tag string) string {
+	var b strings.Builder
+	var prev rune
+	for _, r := range tag {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			prev = '-'
+			continue
+		}
+		wordBreak := prev == '-' || (unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev)))
+		if wordBreak && b.Len() > 0 {
+			b.WriteRune('-')
+		}
+		b.WriteRune(unicode.ToLower(r))
+		prev = r
+	}
+	if b.Len() == 0 {
+		return SharedGroup
+	}
+	return b.String()
+}
+
+// RelativeModule returns the import path of module in folder toGroup as seen
+// from a file in folder fromGroup. An empty group is the output root.
+func RelativeModule(fromGroup, toGroup, module string) string {
+	if fromGroup == toGroup {
+		return "./" + module
+	}
+	prefix := "./"
+	if fromGroup != "" {
+		prefix = "../"
+	}
+	if toGroup != "" {
+		module = toGroup + "/" + module
+	}
+	return prefix + module
+}
+
+// RebaseImport adjusts a relative import statement written for the output
+// root so it still resolves from a file in group. Package imports are
+// returned unchanged.
+func RebaseImport(statement, group string) string {
+	if group == "" {
+		return statement
+	}
+	for _, quote := range []string{"'", `"`} {
+		for _, relative := range []string{"./", "../"} {
+			from := "from " + quote + relative
+			if strings.Contains(statement, from) {
+				return strings.Replace(statement, from, "from "+quote+"../"+strings.TrimPrefix(relative, "./"), 1)
+			}
+		}
+	}
+	return statement
+}
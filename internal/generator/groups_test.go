@@ -0,0 +1,67 @@
+package generator
+
+import "testing"
+
+func TestTagGroups(t *testing.T) {
+	groups := TagGroups([]DTO{
+		{Name: "User", Tags: []string{"Users"}},
+		{Name: "Address", Tags: []string{"Billing", "Users"}},
+		{Name: "Orphan"},
+	})
+
+	expected := map[string]string{"User": "users", "Address": SharedGroup, "Orphan": SharedGroup}
+	for name, want := range expected {
+		if got := groups[name]; got != want {
+			t.Errorf("groups[%s] = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestGroupFolder(t *testing.T) {
+	tests := map[string]string{
+		"users":            "users",
+		"Billing Accounts": "billing-accounts",
+		"userProfiles":     "user-profiles",
+		"API v2":           "api-v2",
+		"pet_store":        "pet-store",
+		"--":               SharedGroup,
+	}
+	for tag, want := range tests {
+		if got := GroupFolder(tag); got != want {
+			t.Errorf("GroupFolder(%q) = %q, want %q", tag, got, want)
+		}
+	}
+}
+
+func TestRelativeModule(t *testing.T) {
+	tests := []struct {
+		from, to, expected string
+	}{
+		{"", "", "./user"},
+		{"users", "users", "./user"},
+		{"", "users", "./users/user"},
+		{"billing", "users", "../users/user"},
+		{"billing", "", "../user"},
+	}
+	for _, tt := range tests {
+		if got := RelativeModule(tt.from, tt.to, "user"); got != tt.expected {
+			t.Errorf("RelativeModule(%q, %q) = %q, want %q", tt.from, tt.to, got, tt.expected)
+		}
+	}
+}
+
+func TestRebaseImport(t *testing.T) {
+	tests := []struct {
+		statement, group, expected string
+	}{
+		{"import { UUID } from './branded-types';", "", "import { UUID } from './branded-types';"},
+		{"import { UUID } from './branded-types';", "users", "import { UUID } from '../branded-types';"},
+		{`import { UUID } from "../types";`, "users", `import { UUID } from "../../types";`},
+		{"import { DateFromISOString } from 'io-ts-types';", "users", "import { DateFromISOString } from 'io-ts-types';"},
+	}
+	for _, tt := range tests {
+		if got := RebaseImport(tt.statement, tt.group); got != tt.expected {
+			t.Errorf("RebaseImport(%q, %q) = %q, want %q", tt.statement, tt.group, got, tt.expected)
+		}
+	}
+}
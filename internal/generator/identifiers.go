@@ -0,0 +1,160 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// reservedWords are names that cannot be used for a TypeScript type alias,
+// interface, enum or imported binding
+var reservedWords = map[string]bool{
+	// Reserved words
+	"break": true, "case": true, "catch": true, "class": true, "const": true,
+	"continue": true, "debugger": true, "default": true, "delete": true, "do": true,
+	"else": true, "enum": true, "export": true, "extends": true, "false": true,
+	"finally": true, "for": true, "function": true, "if": true, "import": true,
+	"in": true, "instanceof": true, "new": true, "null": true, "return": true,
+	"super": true, "switch": true, "this": true, "throw": true, "true": true,
+	"try": true, "typeof": true, "var": true, "void": true, "while": true,
+	"with": true,
+	// Strict mode and module reserved words
+	"await": true, "implements": true, "interface": true, "let": true,
+	"package": true, "private": true, "protected": true, "public": true,
+	"static": true, "yield": true,
+	// Predefined type names
+	"any": true, "bigint": true, "boolean": true, "never": true, "number": true,
+	"object": true, "string": true, "symbol": true, "undefined": true,
+	"unknown": true,
+}
+
+// IsReservedWord reports whether name cannot be used as a TypeScript type name
+func IsReservedWord(name string) bool {
+	return reservedWords[name]
+}
+
+// Naming controls how generators rename DTOs whose names are reserved in
+// the target language
+type Naming struct {
+	ReservedWordStrategy string // "suffix" (default) or "prefix"
+	ReservedWordAffix    string // added to reserved names, "_" if empty
+}
+
+// apply adds the affix to name according to the strategy
+func (n Naming) apply(name string) string {
+	affix := n.ReservedWordAffix
+	if affix == "" {
+		affix = "_"
+	}
+	if n.ReservedWordStrategy == "prefix" {
+		return affix + name
+	}
+	return name + affix
+}
+
+// RenameReserved renames the DTOs whose names reserved reports according to
+// config.Naming and updates every reference to them. A new name that is
+// taken gets a numeric suffix. Each rename is reported as a reserved-name
+// warning. It returns the DTOs and the spec name of each renamed DTO, keyed
+// by its new name; dtos itself is not modified.
+func RenameReserved(dtos []DTO, reserved func(string) bool, language string, config Config) ([]DTO, map[string]string) {
+	taken := make(map[string]bool, len(dtos))
+	for _, dto := range dtos {
+		taken[dto.Name] = true
+	}
+
+	renames := make(map[string]string)
+	originals := make(map[string]string)
+	for _, dto := range dtos {
+		if !reserved(dto.Name) {
+			continue
+		}
+		base := config.Naming.apply(dto.Name)
+		renamed := base
+		for i := 2; taken[renamed]; i++ {
+			renamed = fmt.Sprintf("%s%d", base, i)
+		}
+		taken[renamed] = true
+		renames[dto.Name] = renamed
+		originals[renamed] = dto.Name
+		config.Warnings.Add(Warning{
+			Schema:  dto.Name,
+			Code:    "reserved-name",
+			Message: fmt.Sprintf("'%s' is a reserved %s name, renamed to '%s'", dto.Name, language, renamed),
+			Source:  dto.Source,
+		})
+	}
+	if len(renames) == 0 {
+		return dtos, originals
+	}
+
+	result := make([]DTO, len(dtos))
+	for i, dto := range dtos {
+		if renamed, ok := renames[dto.Name]; ok {
+			dto.Name = renamed
+		}
+		result[i] = renameRefs(dto, renames)
+	}
+	return result, originals
+}
+
+// renameRefs returns dto with the references to renamed DTOs updated
+func renameRefs(dto DTO, renames map[string]string) DTO {
+	if len(dto.Extends) > 0 {
+		extends := make([]string, len(dto.Extends))
+		for i, base := range dto.Extends {
+			extends[i] = renamedRef(base, renames)
+		}
+		dto.Extends = extends
+	}
+	return TransformTypes(dto, func(irType IRType) IRType {
+		switch t := irType.(type) {
+		case ReferenceType:
+			t.RefName = renamedRef(t.RefName, renames)
+			return t
+		case ObjectType:
+			if t.RefName != "" {
+				t.RefName = renamedRef(t.RefName, renames)
+			}
+			return t
+		}
+		return irType
+	})
+}
+
+func renamedRef(name string, renames map[string]string) string {
+	if renamed, ok := renames[name]; ok {
+		return renamed
+	}
+	return name
+}
+
+// HelperNames returns the name each DTO's helper functions (isUser,
+// parseUser, ...) are built from: the PascalCase form of its spec name, so a
+// renamed class_ still gets isClass. A DTO whose PascalCase form is shared
+// with another DTO keeps its own name. originals is the map RenameReserved
+// returns.
+func HelperNames(dtos []DTO, originals map[string]string) map[string]string {
+	pascal := make(map[string]string, len(dtos))
+	counts := make(map[string]int, len(dtos))
+	for _, dto := range dtos {
+		name := dto.Name
+		if original, ok := originals[name]; ok {
+			name = original
+		}
+		if name != "" {
+			name = strings.ToUpper(name[:1]) + name[1:]
+		}
+		pascal[dto.Name] = name
+		counts[name]++
+	}
+
+	names := make(map[string]string, len(dtos))
+	for _, dto := range dtos {
+		if name := pascal[dto.Name]; counts[name] == 1 {
+			names[dto.Name] = name
+		} else {
+			names[dto.Name] = dto.Name
+		}
+	}
+	return names
+}
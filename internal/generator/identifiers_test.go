@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"testing"
+)
+
+func TestNaming_Apply(t *testing.T) {
+	tests := []struct {
+		name     string
+		naming   Naming
+		input    string
+		expected string
+	}{
+		{"Default suffix", Naming{}, "class", "class_"},
+		{"Suffix", Naming{ReservedWordStrategy: "suffix", ReservedWordAffix: "Model"}, "new", "newModel"},
+		{"Prefix", Naming{ReservedWordStrategy: "prefix", ReservedWordAffix: "I"}, "interface", "Iinterface"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.naming.apply(tt.input); got != tt.expected {
+				t.Errorf("apply() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRenameReserved(t *testing.T) {
+	dtos := []DTO{
+		{
+			Name:    "default",
+			Type:    "object",
+			Extends: []string{"class"},
+			Properties: []Property{
+				// Property names are valid object keys and stay as they are
+				{Name: "class", Type: ReferenceType{RefName: "class"}},
+				{Name: "items", Type: ArrayType{ElementType: ObjectType{RefName: "class"}}},
+				{Name: "inline", Type: ObjectType{Inline: true, DTORef: &DTO{
+					Name:       "inline",
+					Properties: []Property{{Name: "byKey", Type: MapType{KeyType: PrimitiveType{Name: "string"}, ValueType: ReferenceType{RefName: "class"}}}},
+				}}},
+			},
+		},
+		{Name: "class", Type: "enum", EnumValues: []string{"a", "b"}},
+		{Name: "class_", Type: "object"},
+		{Name: "Choice", Type: "union", UnionMembers: []IRType{ReferenceType{RefName: "class"}, ReferenceType{RefName: "class_"}}},
+	}
+
+	warnings := &WarningCollector{}
+	renamed, originals := RenameReserved(dtos, IsReservedWord, "TypeScript", Config{Warnings: warnings})
+
+	names := []string{renamed[0].Name, renamed[1].Name, renamed[2].Name, renamed[3].Name}
+	expected := []string{"default_", "class_2", "class_", "Choice"}
+	for i := range names {
+		if names[i] != expected[i] {
+			t.Errorf("DTO %d renamed to %v, want %v", i, names[i], expected[i])
+		}
+	}
+	if originals["class_2"] != "class" || originals["default_"] != "default" || len(originals) != 2 {
+		t.Errorf("originals = %v", originals)
+	}
+
+	holder := renamed[0]
+	if holder.Extends[0] != "class_2" {
+		t.Errorf("extends = %v, want class_2", holder.Extends)
+	}
+	if holder.Properties[0].Name != "class" {
+		t.Errorf("property name = %v, want class", holder.Properties[0].Name)
+	}
+	if got := Dependencies(holder); len(got) != 1 || got[0] != "class_2" {
+		t.Errorf("dependencies = %v, want [class_2]", got)
+	}
+	if got := Dependencies(renamed[3]); len(got) != 2 || got[0] != "class_" || got[1] != "class_2" {
+		t.Errorf("union dependencies = %v, want [class_ class_2]", got)
+	}
+
+	// The input is left as it was
+	if dtos[0].Name != "default" || dtos[0].Extends[0] != "class" || Dependencies(dtos[0])[0] != "class" {
+		t.Errorf("RenameReserved modified its input: %+v", dtos[0])
+	}
+
+	if len(warnings.Warnings()) != 2 {
+		t.Fatalf("Expected 2 warnings, got %v", warnings.Warnings())
+	}
+	for _, warning := range warnings.Warnings() {
+		if warning.Code != "reserved-name" {
+			t.Errorf("Unexpected warning: %+v", warning)
+		}
+	}
+	if got := warnings.Warnings()[1].Message; got != "'class' is a reserved TypeScript name, renamed to 'class_2'" {
+		t.Errorf("message = %q", got)
+	}
+
+	// Another target has its own reserved words
+	renamed, _ = RenameReserved(dtos, func(name string) bool { return name == "Choice" }, "Java", Config{Naming: Naming{ReservedWordStrategy: "prefix", ReservedWordAffix: "Api"}})
+	if renamed[0].Name != "default" || renamed[3].Name != "ApiChoice" {
+		t.Errorf("Unexpected names: %v, %v", renamed[0].Name, renamed[3].Name)
+	}
+}
+
+func TestHelperNames(t *testing.T) {
+	dtos := []DTO{{Name: "class_2"}, {Name: "class_"}, {Name: "pet"}, {Name: "User"}, {Name: "user"}}
+	names := HelperNames(dtos, map[string]string{"class_2": "class"})
+
+	expected := map[string]string{
+		"class_2": "Class",
+		"class_":  "Class_",
+		"pet":     "Pet",
+		// Both would be User, so each keeps its own name
+		"User": "User",
+		"user": "user",
+	}
+	for name, want := range expected {
+		if got := names[name]; got != want {
+			t.Errorf("HelperNames()[%s] = %v, want %v", name, got, want)
+		}
+	}
+}
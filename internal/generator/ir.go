@@ -0,0 +1,254 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// IRVersion is the version of the serialized IR. It changes whenever the JSON
+// form changes incompatibly, and readers reject any other version.
+const IRVersion = 1
+
+// IRDocument is the serialized form of a conversion result, as written by
+// --emit-ir and read by --from-ir or external plugins
+type IRDocument struct {
+	Version int   `json:"version"`
+	DTOs    []DTO `json:"dtos"`
+}
+
+// MarshalIR serializes DTOs as an IRDocument of the current version
+func MarshalIR(dtos []DTO) ([]byte, error) {
+	if dtos == nil {
+		dtos = []DTO{}
+	}
+	return json.MarshalIndent(IRDocument{Version: IRVersion, DTOs: dtos}, "", "  ")
+}
+
+// UnmarshalIR reads DTOs from an IRDocument, rejecting other IR versions
+func UnmarshalIR(data []byte) ([]DTO, error) {
+	var header struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("invalid IR document: %w", err)
+	}
+	if header.Version != IRVersion {
+		return nil, fmt.Errorf("unsupported IR version %d, expected %d", header.Version, IRVersion)
+	}
+
+	var doc IRDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid IR document: %w", err)
+	}
+	return doc.DTOs, nil
+}
+
+// IRType values are written as JSON objects with a "kind" discriminator, so
+// they can be decoded back into the right concrete type
+
+func (p PrimitiveType) MarshalJSON() ([]byte, error) {
+	type plain PrimitiveType
+	return marshalKind("primitive", plain(p))
+}
+
+func (o ObjectType) MarshalJSON() ([]byte, error) {
+	type plain ObjectType
+	return marshalKind("object", plain(o))
+}
+
+func (a ArrayType) MarshalJSON() ([]byte, error) {
+	type plain ArrayType
+	return marshalKind("array", plain(a))
+}
+
+func (t TupleType) MarshalJSON() ([]byte, error) {
+	type plain TupleType
+	return marshalKind("tuple", plain(t))
+}
+
+func (r ReferenceType) MarshalJSON() ([]byte, error) {
+	type plain ReferenceType
+	return marshalKind("reference", plain(r))
+}
+
+func (e EnumType) MarshalJSON() ([]byte, error) {
+	type plain EnumType
+	return marshalKind("enum", plain(e))
+}
+
+func (u UnionType) MarshalJSON() ([]byte, error) {
+	type plain UnionType
+	return marshalKind("union", plain(u))
+}
+
+func (m MapType) MarshalJSON() ([]byte, error) {
+	type plain MapType
+	return marshalKind("map", plain(m))
+}
+
+func (l LiteralType) MarshalJSON() ([]byte, error) {
+	type plain LiteralType
+	return marshalKind("literal", plain(l))
+}
+
+// marshalKind marshals v, which must encode as a JSON object, with a leading
+// "kind" field
+func marshalKind(kind string, v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	prefix := fmt.Sprintf(`{"kind":%q`, kind)
+	if bytes.Equal(data, []byte("{}")) {
+		return []byte(prefix + "}"), nil
+	}
+	return append([]byte(prefix+","), data[1:]...), nil
+}
+
+// UnmarshalJSON decodes a DTO, including its union members and the type of
+// its additional properties
+func (d *DTO) UnmarshalJSON(data []byte) error {
+	type plain DTO
+	var raw struct {
+		plain
+		UnionMembers         []json.RawMessage `json:"unionMembers"`
+		AdditionalProperties json.RawMessage   `json:"additionalProperties"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	members, err := decodeIRTypes(raw.UnionMembers)
+	if err != nil {
+		return fmt.Errorf("DTO '%s': %w", raw.Name, err)
+	}
+	additional, err := decodeIRType(raw.AdditionalProperties)
+	if err != nil {
+		return fmt.Errorf("DTO '%s' additional properties: %w", raw.Name, err)
+	}
+	*d = DTO(raw.plain)
+	d.UnionMembers = members
+	d.AdditionalProperties = additional
+	return nil
+}
+
+// UnmarshalJSON decodes a property, including its IRType
+func (p *Property) UnmarshalJSON(data []byte) error {
+	type plain Property
+	var raw struct {
+		plain
+		Type json.RawMessage `json:"type"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	irType, err := decodeIRType(raw.Type)
+	if err != nil {
+		return fmt.Errorf("property '%s': %w", raw.Name, err)
+	}
+	*p = Property(raw.plain)
+	p.Type = irType
+	return nil
+}
+
+// decodeIRType decodes an IRType from its discriminated JSON form. Empty or
+// null input decodes to nil.
+func decodeIRType(data json.RawMessage) (IRType, error) {
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+
+	var head struct {
+		Kind string `json:"kind"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, err
+	}
+
+	switch head.Kind {
+	case "primitive":
+		var t PrimitiveType
+		err := json.Unmarshal(data, &t)
+		return t, err
+	case "object":
+		var t ObjectType
+		err := json.Unmarshal(data, &t)
+		return t, err
+	case "reference":
+		var t ReferenceType
+		err := json.Unmarshal(data, &t)
+		return t, err
+	case "enum":
+		var t EnumType
+		err := json.Unmarshal(data, &t)
+		return t, err
+	case "literal":
+		var t LiteralType
+		err := json.Unmarshal(data, &t)
+		return t, err
+	case "array":
+		var raw struct {
+			ElementType json.RawMessage `json:"elementType"`
+			Constraints *Constraints    `json:"constraints"`
+		}
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, err
+		}
+		elem, err := decodeIRType(raw.ElementType)
+		return ArrayType{ElementType: elem, Constraints: raw.Constraints}, err
+	case "tuple":
+		var raw struct {
+			ElementTypes []json.RawMessage `json:"elementTypes"`
+			Rest         json.RawMessage   `json:"rest"`
+		}
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, err
+		}
+		elems, err := decodeIRTypes(raw.ElementTypes)
+		if err != nil {
+			return nil, err
+		}
+		rest, err := decodeIRType(raw.Rest)
+		return TupleType{ElementTypes: elems, Rest: rest}, err
+	case "union":
+		var raw struct {
+			Types         []json.RawMessage `json:"types"`
+			Discriminator string            `json:"discriminator"`
+		}
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, err
+		}
+		members, err := decodeIRTypes(raw.Types)
+		return UnionType{Types: members, Discriminator: raw.Discriminator}, err
+	case "map":
+		var raw struct {
+			KeyType   json.RawMessage `json:"keyType"`
+			ValueType json.RawMessage `json:"valueType"`
+		}
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, err
+		}
+		key, err := decodeIRType(raw.KeyType)
+		if err != nil {
+			return nil, err
+		}
+		value, err := decodeIRType(raw.ValueType)
+		return MapType{KeyType: key, ValueType: value}, err
+	case "":
+		return nil, fmt.Errorf("IR type has no kind")
+	default:
+		return nil, fmt.Errorf("unknown IR type kind '%s'", head.Kind)
+	}
+}
+
+func decodeIRTypes(data []json.RawMessage) ([]IRType, error) {
+	var types []IRType
+	for _, item := range data {
+		irType, err := decodeIRType(item)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, irType)
+	}
+	return types, nil
+}
@@ -0,0 +1,81 @@
+package generator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIR_RoundTrip(t *testing.T) {
+	minLength := 1
+	address := DTO{
+		Name:       "Address",
+		Type:       "object",
+		Properties: []Property{{Name: "city", Type: PrimitiveType{Name: "string"}, Required: true}},
+		Required:   []string{"city"},
+	}
+	dtos := []DTO{
+		address,
+		{
+			Name:                 "Pet",
+			Type:                 "object",
+			Required:             []string{"name"},
+			Tags:                 []string{"pets"},
+			AdditionalProperties: ReferenceType{RefName: "Tag"},
+			Properties: []Property{
+				{Name: "name", Type: PrimitiveType{Name: "string", Constraints: &Constraints{MinLength: &minLength}}, Required: true},
+				{Name: "tags", Type: ArrayType{ElementType: ReferenceType{RefName: "Tag"}}},
+				{Name: "point", Type: TupleType{ElementTypes: []IRType{PrimitiveType{Name: "number"}}, Rest: PrimitiveType{Name: "string"}}},
+				{Name: "either", Type: UnionType{Types: []IRType{PrimitiveType{Name: "string"}, LiteralType{Value: float64(1), Kind: "number"}}, Discriminator: "kind"}},
+				{Name: "labels", Type: MapType{KeyType: PrimitiveType{Name: "string"}, ValueType: PrimitiveType{Name: "unknown"}}},
+				{Name: "status", Type: EnumType{Name: "Status", UnderlyingType: "string", Values: []string{"a", "b"}}},
+				{Name: "address", Type: ObjectType{DTORef: &address, Inline: true}},
+				{Name: "kind", Type: LiteralType{Value: "pet", Kind: "string"}, Default: "pet"},
+			},
+		},
+		{
+			Name:         "Shape",
+			Type:         "union",
+			Properties:   []Property{},
+			UnionMembers: []IRType{ReferenceType{RefName: "Circle"}, LiteralType{Kind: "null"}},
+		},
+	}
+
+	data, err := MarshalIR(dtos)
+	if err != nil {
+		t.Fatalf("MarshalIR() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"kind": "tuple"`) {
+		t.Errorf("expected kind discriminators, got:\n%s", data)
+	}
+
+	got, err := UnmarshalIR(data)
+	if err != nil {
+		t.Fatalf("UnmarshalIR() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, dtos) {
+		t.Errorf("round trip mismatch:\n got %#v\nwant %#v", got, dtos)
+	}
+}
+
+func TestUnmarshalIR_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"wrong version", `{"version": 99, "dtos": []}`, "unsupported IR version 99"},
+		{"missing version", `{"dtos": []}`, "unsupported IR version 0"},
+		{"unknown kind", `{"version": 1, "dtos": [{"name": "A", "properties": [{"name": "x", "type": {"kind": "set"}}]}]}`, "unknown IR type kind 'set'"},
+		{"invalid json", `{`, "invalid IR document"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := UnmarshalIR([]byte(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("UnmarshalIR() error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
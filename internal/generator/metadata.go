@@ -0,0 +1,80 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Metadata holds the annotations of a DTO or property that have no dedicated
+// field, keyed by name: vendor extensions ("x-internal") and the JSON text of
+// "example", "examples" and "not", plus "extends" for flattened DTOs.
+// Extensions keep the value decoded from the spec, so structured extensions
+// stay structured; String gives the flat text form older templates relied on.
+// A nil Metadata reads as empty.
+type Metadata map[string]any
+
+// Get returns the value stored under key
+func (m Metadata) Get(key string) (any, bool) {
+	value, ok := m[key]
+	return value, ok
+}
+
+// Has reports whether key is set
+func (m Metadata) Has(key string) bool {
+	_, ok := m[key]
+	return ok
+}
+
+// Set stores value under key
+func (m Metadata) Set(key string, value any) {
+	m[key] = value
+}
+
+// String returns the value under key as text: strings as they are, other
+// values JSON encoded, and "" if the key is not set
+func (m Metadata) String(key string) string {
+	value, ok := m[key]
+	if !ok || value == nil {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// Bool returns the value under key as a boolean, accepting true and "true"
+func (m Metadata) Bool(key string) bool {
+	switch value := m[key].(type) {
+	case bool:
+		return value
+	case string:
+		return value == "true"
+	}
+	return false
+}
+
+// Extension returns a vendor extension value, or nil if absent. The name may
+// be given with or without its x- prefix.
+func (m Metadata) Extension(name string) any {
+	if !strings.HasPrefix(name, "x-") {
+		name = "x-" + name
+	}
+	return m[name]
+}
+
+// Extensions returns every vendor extension, keyed by its x- name
+func (m Metadata) Extensions() map[string]any {
+	extensions := make(map[string]any)
+	for key, value := range m {
+		if strings.HasPrefix(key, "x-") {
+			extensions[key] = value
+		}
+	}
+	return extensions
+}
@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMetadata_Accessors(t *testing.T) {
+	metadata := Metadata{
+		"x-internal": true,
+		"x-owner":    "billing",
+		"x-mask":     map[string]interface{}{"keep": 4},
+		"not":        `{"enum":["unknown"]}`,
+	}
+
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"x-internal", "true"},
+		{"x-owner", "billing"},
+		{"x-mask", `{"keep":4}`},
+		{"not", `{"enum":["unknown"]}`},
+		{"missing", ""},
+	}
+	for _, tt := range tests {
+		if got := metadata.String(tt.key); got != tt.expected {
+			t.Errorf("String(%q) = %v, want %v", tt.key, got, tt.expected)
+		}
+	}
+
+	if !metadata.Bool("x-internal") || metadata.Bool("x-owner") {
+		t.Error("Bool() should only be true for true values")
+	}
+	if !metadata.Has("not") || metadata.Has("missing") {
+		t.Error("Has() reports the wrong keys")
+	}
+	if got := metadata.Extension("mask"); !reflect.DeepEqual(got, map[string]interface{}{"keep": 4}) {
+		t.Errorf("Extension(mask) = %v", got)
+	}
+	if got := metadata.Extensions(); len(got) != 3 || got["x-owner"] != "billing" {
+		t.Errorf("Extensions() = %v", got)
+	}
+
+	var empty Metadata
+	if empty.String("x-owner") != "" || empty.Has("x-owner") || empty.Extension("owner") != nil {
+		t.Error("a nil Metadata should read as empty")
+	}
+}
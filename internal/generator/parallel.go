@@ -0,0 +1,42 @@
+package generator
+
+import "sync"
+
+// ForEachDTO calls generate for every DTO, on up to workers goroutines at a
+// time. With workers <= 1 the DTOs are handled one by one, in order. The
+// error of the first DTO in dtos that failed is returned.
+func ForEachDTO(dtos []DTO, workers int, generate func(DTO) error) error {
+	if workers <= 1 {
+		for _, dto := range dtos {
+			if err := generate(dto); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	errs := make([]error, len(dtos))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range min(workers, len(dtos)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				errs[i] = generate(dtos[i])
+			}
+		}()
+	}
+	for i := range dtos {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestForEachDTO(t *testing.T) {
+	var dtos []DTO
+	for i := range 50 {
+		dtos = append(dtos, DTO{Name: fmt.Sprintf("DTO%d", i)})
+	}
+
+	for _, workers := range []int{0, 1, 4, 100} {
+		var mu sync.Mutex
+		seen := make(map[string]int)
+		err := ForEachDTO(dtos, workers, func(dto DTO) error {
+			mu.Lock()
+			defer mu.Unlock()
+			seen[dto.Name]++
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("workers %d: %v", workers, err)
+		}
+		if len(seen) != len(dtos) {
+			t.Errorf("workers %d: generated %d DTOs, want %d", workers, len(seen), len(dtos))
+		}
+		for name, count := range seen {
+			if count != 1 {
+				t.Errorf("workers %d: %s generated %d times", workers, name, count)
+			}
+		}
+	}
+}
+
+func TestForEachDTO_FirstError(t *testing.T) {
+	dtos := []DTO{{Name: "A"}, {Name: "B"}, {Name: "C"}, {Name: "D"}}
+	for _, workers := range []int{1, 4} {
+		err := ForEachDTO(dtos, workers, func(dto DTO) error {
+			if dto.Name == "B" || dto.Name == "D" {
+				return fmt.Errorf("failed %s", dto.Name)
+			}
+			return nil
+		})
+		if err == nil || err.Error() != "failed B" {
+			t.Errorf("workers %d: err = %v, want the error of B", workers, err)
+		}
+	}
+}
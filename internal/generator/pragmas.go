@@ -0,0 +1,38 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ValidatePragmas checks that every pragma is a single-line comment, e.g.
+// "/* eslint-disable */" or "// @ts-nocheck", so that prepending it to a
+// generated file cannot change the file's code
+func ValidatePragmas(pragmas []string) error {
+	for _, pragma := range pragmas {
+		lineComment := strings.HasPrefix(pragma, "//")
+		blockComment := strings.HasPrefix(pragma, "/*") && len(pragma) >= 4 && strings.Index(pragma[2:], "*/") == len(pragma)-4
+		if strings.ContainsAny(pragma, "\r\n") || (!lineComment && !blockComment) {
+			return fmt.Errorf("invalid pragma '%s', must be a single-line // or /* */ comment", pragma)
+		}
+	}
+	return nil
+}
+
+// CreateFile creates a generated source file that starts with pragmas, one
+// per line, and records it in c.Files
+func (c Config) CreateFile(path string, pragmas []string) (*os.File, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	c.Files.Record(path)
+	for _, pragma := range pragmas {
+		if _, err := fmt.Fprintln(file, pragma); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+	return file, nil
+}
@@ -0,0 +1,42 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidatePragmas(t *testing.T) {
+	valid := []string{"/* eslint-disable */", "// @ts-nocheck", "// prettier-ignore"}
+	if err := ValidatePragmas(valid); err != nil {
+		t.Errorf("ValidatePragmas(%v) = %v, want nil", valid, err)
+	}
+
+	for _, pragma := range []string{"'use strict';", "/* eslint-disable */ export {}", "/* unclosed", "/*/", "// one\n// two"} {
+		if err := ValidatePragmas([]string{pragma}); err == nil {
+			t.Errorf("ValidatePragmas(%q) = nil, want an error", pragma)
+		}
+	}
+}
+
+func TestCreateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "user.ts")
+	config := Config{Files: &FileRecorder{}}
+	file, err := config.CreateFile(path, []string{"/* eslint-disable */", "// @ts-nocheck"})
+	if err != nil {
+		t.Fatalf("CreateFile() error = %v", err)
+	}
+	file.WriteString("export {};\n")
+	file.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "/* eslint-disable */\n// @ts-nocheck\nexport {};\n"; string(data) != want {
+		t.Errorf("file = %q, want %q", data, want)
+	}
+	if paths := config.Files.Paths(); len(paths) != 1 || paths[0] != path {
+		t.Errorf("recorded paths = %v, want [%s]", paths, path)
+	}
+}
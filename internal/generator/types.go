@@ -1,19 +1,41 @@
 package generator
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 )
 
-// PrimitiveType represents basic types like string, number, etc.
+// PrimitiveType represents basic types: string, number, integer, bigint,
+// binary, boolean or unknown. Binary is file content, with Format "binary"
+// for raw bytes or "byte" for base64 text.
 type PrimitiveType struct {
-	Name   string `json:"name"`
-	Format string `json:"format,omitempty"` // date-time, uuid, email, etc.
+	Name        string       `json:"name"`
+	Format      string       `json:"format,omitempty"` // date-time, uuid, email, etc.
+	Constraints *Constraints `json:"constraints,omitempty"`
 }
 
 func (p PrimitiveType) TypeName() string  { return p.Name }
 func (p PrimitiveType) GetFormat() string { return p.Format } // Added this method
 
+// Constraints holds the validation keywords of a string, number or array
+// schema. Unset bounds are nil. ExclusiveMinimum and ExclusiveMaximum are
+// bounds themselves, as in OpenAPI 3.1; the 3.0 boolean form is converted.
+type Constraints struct {
+	MinLength        *int     `json:"minLength,omitempty"`
+	MaxLength        *int     `json:"maxLength,omitempty"`
+	Pattern          string   `json:"pattern,omitempty"`
+	Minimum          *float64 `json:"minimum,omitempty"`
+	Maximum          *float64 `json:"maximum,omitempty"`
+	ExclusiveMinimum *float64 `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum *float64 `json:"exclusiveMaximum,omitempty"`
+	MultipleOf       *float64 `json:"multipleOf,omitempty"`
+	MinItems         *int     `json:"minItems,omitempty"`
+	MaxItems         *int     `json:"maxItems,omitempty"`
+	UniqueItems      bool     `json:"uniqueItems,omitempty"`
+}
+
 // ObjectType represents a nested object type.
 type ObjectType struct {
 	DTORef  *DTO   `json:"dtoRef,omitempty"`
@@ -33,13 +55,33 @@ func (o ObjectType) TypeName() string {
 
 // ArrayType represents an array of elements.
 type ArrayType struct {
-	ElementType IRType `json:"elementType"`
+	ElementType IRType       `json:"elementType"`
+	Constraints *Constraints `json:"constraints,omitempty"`
 }
 
 func (a ArrayType) TypeName() string {
 	return fmt.Sprintf("Array<%s>", a.ElementType.TypeName())
 }
 
+// TupleType represents an array with positional element types (prefixItems,
+// or array-form items in older drafts). Rest, if set, is the type of any
+// elements after the positional ones; otherwise the length is fixed.
+type TupleType struct {
+	ElementTypes []IRType `json:"elementTypes"`
+	Rest         IRType   `json:"rest,omitempty"`
+}
+
+func (t TupleType) TypeName() string {
+	var typeNames []string
+	for _, elem := range t.ElementTypes {
+		typeNames = append(typeNames, elem.TypeName())
+	}
+	if t.Rest != nil {
+		typeNames = append(typeNames, fmt.Sprintf("...%s[]", t.Rest.TypeName()))
+	}
+	return fmt.Sprintf("[%s]", strings.Join(typeNames, ", "))
+}
+
 // ReferenceType represents a reference to an already defined DTO.
 type ReferenceType struct {
 	RefName string `json:"refName"`
@@ -47,7 +89,8 @@ type ReferenceType struct {
 
 func (r ReferenceType) TypeName() string { return r.RefName }
 
-// EnumType represents an enum type.
+// EnumType represents an enum type. The values of a numeric enum
+// (UnderlyingType "integer" or "number") are number literals.
 type EnumType struct {
 	Name           string   `json:"name"`
 	UnderlyingType string   `json:"underlyingType"`
@@ -56,9 +99,125 @@ type EnumType struct {
 
 func (e EnumType) TypeName() string { return e.Name }
 
-// UnionType represents oneOf/anyOf schemas
+// Numeric reports whether the enum values are numbers
+func (e EnumType) Numeric() bool {
+	return IsNumericType(e.UnderlyingType)
+}
+
+// IsNumericType reports whether an enum value type is "integer" or "number"
+func IsNumericType(valueType string) bool {
+	return valueType == "integer" || valueType == "number"
+}
+
+// EnumLiterals returns the values of a numeric enum as number literals
+func EnumLiterals(values []string) []IRType {
+	literals := make([]IRType, 0, len(values))
+	for _, value := range values {
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			literals = append(literals, NewLiteralType(n))
+		} else if f, err := strconv.ParseFloat(value, 64); err == nil {
+			literals = append(literals, NewLiteralType(f))
+		}
+	}
+	return literals
+}
+
+// Int32Values reports whether every value of an integer enum fits in 32 bits
+func Int32Values(values []string) bool {
+	for _, value := range values {
+		if _, err := strconv.ParseInt(value, 10, 32); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// LiteralEnums returns dtos with every numeric enum replaced by the union of
+// its values, for targets that generate enums keyed by string values. dtos
+// itself is not modified.
+func LiteralEnums(dtos []DTO) []DTO {
+	result := make([]DTO, len(dtos))
+	for i, dto := range dtos {
+		if dto.NumericEnum() {
+			dto.Type = "union"
+			dto.UnionMembers = EnumLiterals(dto.EnumValues)
+			dto.EnumValues = nil
+			dto.EnumValueType = ""
+		}
+		result[i] = TransformTypes(dto, func(irType IRType) IRType {
+			if enum, ok := irType.(EnumType); ok && enum.Numeric() {
+				return UnionType{Types: EnumLiterals(enum.Values)}
+			}
+			return irType
+		})
+	}
+	return result
+}
+
+// TransformTypes returns dto with fn applied to every type it is built from,
+// including the types nested in arrays, tuples, unions, maps and inline
+// objects. fn sees a type after its nested types have been transformed.
+func TransformTypes(dto DTO, fn func(IRType) IRType) DTO {
+	if len(dto.Properties) > 0 {
+		properties := make([]Property, len(dto.Properties))
+		for i, prop := range dto.Properties {
+			prop.Type = TransformType(prop.Type, fn)
+			properties[i] = prop
+		}
+		dto.Properties = properties
+	}
+	if dto.AdditionalProperties != nil {
+		dto.AdditionalProperties = TransformType(dto.AdditionalProperties, fn)
+	}
+	if len(dto.UnionMembers) > 0 {
+		dto.UnionMembers = transformTypes(dto.UnionMembers, fn)
+	}
+	return dto
+}
+
+// TransformType returns irType with fn applied to it and every type nested
+// in it, innermost first
+func TransformType(irType IRType, fn func(IRType) IRType) IRType {
+	switch t := irType.(type) {
+	case ObjectType:
+		if t.RefName == "" && t.DTORef != nil {
+			inline := TransformTypes(*t.DTORef, fn)
+			t.DTORef = &inline
+		}
+		irType = t
+	case ArrayType:
+		t.ElementType = TransformType(t.ElementType, fn)
+		irType = t
+	case TupleType:
+		t.ElementTypes = transformTypes(t.ElementTypes, fn)
+		if t.Rest != nil {
+			t.Rest = TransformType(t.Rest, fn)
+		}
+		irType = t
+	case UnionType:
+		t.Types = transformTypes(t.Types, fn)
+		irType = t
+	case MapType:
+		t.KeyType = TransformType(t.KeyType, fn)
+		t.ValueType = TransformType(t.ValueType, fn)
+		irType = t
+	}
+	return fn(irType)
+}
+
+func transformTypes(types []IRType, fn func(IRType) IRType) []IRType {
+	transformed := make([]IRType, len(types))
+	for i, irType := range types {
+		transformed[i] = TransformType(irType, fn)
+	}
+	return transformed
+}
+
+// UnionType represents oneOf/anyOf schemas. Discriminator is the property
+// that tells the members apart (discriminator.propertyName), "" if none.
 type UnionType struct {
-	Types []IRType `json:"types"`
+	Types         []IRType `json:"types"`
+	Discriminator string   `json:"discriminator,omitempty"`
 }
 
 func (u UnionType) TypeName() string {
@@ -68,3 +227,102 @@ func (u UnionType) TypeName() string {
 	}
 	return fmt.Sprintf("(%s)", strings.Join(typeNames, " | "))
 }
+
+// MapType represents dictionary-style objects (additionalProperties)
+type MapType struct {
+	KeyType   IRType `json:"keyType"`
+	ValueType IRType `json:"valueType"`
+}
+
+func (m MapType) TypeName() string {
+	return fmt.Sprintf("Record<%s, %s>", m.KeyType.TypeName(), m.ValueType.TypeName())
+}
+
+// LiteralType represents a single allowed value (const, or an enum with one
+// value). Kind is "string", "number", "boolean" or "null".
+type LiteralType struct {
+	Value any    `json:"value"`
+	Kind  string `json:"valueKind"`
+}
+
+// NewLiteralType returns the literal for a value decoded from the spec
+func NewLiteralType(value any) LiteralType {
+	switch value.(type) {
+	case string:
+		return LiteralType{Value: value, Kind: "string"}
+	case bool:
+		return LiteralType{Value: value, Kind: "boolean"}
+	case nil:
+		return LiteralType{Value: nil, Kind: "null"}
+	default:
+		return LiteralType{Value: value, Kind: "number"}
+	}
+}
+
+// IsLiteralValue reports whether a value decoded from the spec has a literal
+// type: a string, number, boolean or null. Objects and arrays do not.
+func IsLiteralValue(value any) bool {
+	switch value.(type) {
+	case string, bool, nil, int, int64, uint64, float64:
+		return true
+	}
+	return false
+}
+
+func (l LiteralType) TypeName() string { return l.Literal() }
+
+// ValueLiteral returns a value decoded from the spec as a TypeScript
+// expression, e.g. 'active', 42, ['a'] or {"size":1}
+func ValueLiteral(value any) string {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return "undefined"
+		}
+		return string(data)
+	}
+	return NewLiteralType(value).Literal()
+}
+
+// Literal returns the value as a TypeScript literal, e.g. 'active', 42 or true
+func (l LiteralType) Literal() string {
+	switch l.Kind {
+	case "string":
+		return quoteString(fmt.Sprint(l.Value))
+	case "null":
+		return "null"
+	default:
+		return fmt.Sprint(l.Value)
+	}
+}
+
+// quoteString returns s as a single-quoted TypeScript string literal. Line
+// terminators and other control characters are escaped, since a string
+// literal cannot span lines.
+func quoteString(s string) string {
+	var b strings.Builder
+	b.WriteByte('\'')
+	for _, r := range s {
+		switch r {
+		case '\\':
+			b.WriteString(`\\`)
+		case '\'':
+			b.WriteString(`\'`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f || r == '\u2028' || r == '\u2029' {
+				fmt.Fprintf(&b, `\u%04x`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('\'')
+	return b.String()
+}
@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -43,3 +44,115 @@ func TestPrimitiveType_GetFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestMapType_TypeName(t *testing.T) {
+	m := MapType{KeyType: PrimitiveType{Name: "string"}, ValueType: ReferenceType{RefName: "Tag"}}
+
+	if got := m.TypeName(); got != "Record<string, Tag>" {
+		t.Errorf("TypeName() = %v, want %v", got, "Record<string, Tag>")
+	}
+}
+
+func TestLiteralType_Literal(t *testing.T) {
+	tests := []struct {
+		value    any
+		kind     string
+		expected string
+	}{
+		{"active", "string", "'active'"},
+		{"it's", "string", `'it\'s'`},
+		{"line one\r\nline two", "string", `'line one\r\nline two'`},
+		{"tab\there\x00 \u2028", "string", `'tab\there\u0000 \u2028'`},
+		{`C:\dir`, "string", `'C:\\dir'`},
+		{42, "number", "42"},
+		{1.5, "number", "1.5"},
+		{true, "boolean", "true"},
+		{nil, "null", "null"},
+	}
+
+	for _, tt := range tests {
+		literal := NewLiteralType(tt.value)
+		if literal.Kind != tt.kind {
+			t.Errorf("NewLiteralType(%v).Kind = %v, want %v", tt.value, literal.Kind, tt.kind)
+		}
+		if got := literal.Literal(); got != tt.expected {
+			t.Errorf("Literal() = %v, want %v", got, tt.expected)
+		}
+	}
+}
+
+func TestIsLiteralValue(t *testing.T) {
+	for _, value := range []any{"a", 1, 1.5, true, nil} {
+		if !IsLiteralValue(value) {
+			t.Errorf("IsLiteralValue(%v) = false, want true", value)
+		}
+	}
+	for _, value := range []any{map[string]interface{}{"a": 1}, []interface{}{"a"}} {
+		if IsLiteralValue(value) {
+			t.Errorf("IsLiteralValue(%v) = true, want false", value)
+		}
+	}
+}
+
+func TestValueLiteral(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected string
+	}{
+		{"dark", "'dark'"},
+		{20, "20"},
+		{false, "false"},
+		{[]interface{}{"a", 1}, `["a",1]`},
+		{map[string]interface{}{"size": 1}, `{"size":1}`},
+	}
+
+	for _, tt := range tests {
+		if got := ValueLiteral(tt.value); got != tt.expected {
+			t.Errorf("ValueLiteral(%v) = %v, want %v", tt.value, got, tt.expected)
+		}
+	}
+}
+
+func TestTupleType_TypeName(t *testing.T) {
+	tuple := TupleType{ElementTypes: []IRType{
+		PrimitiveType{Name: "number"},
+		ArrayType{ElementType: PrimitiveType{Name: "string"}},
+		ReferenceType{RefName: "Point"},
+	}}
+
+	if got := tuple.TypeName(); got != "[number, Array<string>, Point]" {
+		t.Errorf("TypeName() = %v, want %v", got, "[number, Array<string>, Point]")
+	}
+
+	tuple.Rest = PrimitiveType{Name: "boolean"}
+	if got := tuple.TypeName(); got != "[number, Array<string>, Point, ...boolean[]]" {
+		t.Errorf("TypeName() = %v, want %v", got, "[number, Array<string>, Point, ...boolean[]]")
+	}
+}
+
+func TestLiteralEnums(t *testing.T) {
+	dtos := []DTO{
+		{Name: "Priority", Type: "enum", EnumValues: []string{"1", "-2"}, EnumValueType: "integer"},
+		{Name: "Status", Type: "enum", EnumValues: []string{"1", "2"}},
+		{Name: "Task", Type: "object", Properties: []Property{
+			{Name: "weights", Type: ArrayType{ElementType: EnumType{Values: []string{"0.5"}, UnderlyingType: "number"}}},
+		}},
+	}
+
+	converted := LiteralEnums(dtos)
+	want := []IRType{NewLiteralType(int64(1)), NewLiteralType(int64(-2))}
+	if converted[0].Type != "union" || !reflect.DeepEqual(converted[0].UnionMembers, want) || converted[0].EnumValues != nil {
+		t.Errorf("Priority = %+v, want a union of 1, -2", converted[0])
+	}
+	// String enums stay as they are, even if their values look like numbers
+	if converted[1].Type != "enum" {
+		t.Errorf("Status = %+v, want an enum", converted[1])
+	}
+	weights := converted[2].Properties[0].Type.(ArrayType).ElementType
+	if !reflect.DeepEqual(weights, UnionType{Types: []IRType{NewLiteralType(0.5)}}) {
+		t.Errorf("weights element = %#v, want a union of 0.5", weights)
+	}
+	if _, ok := dtos[2].Properties[0].Type.(ArrayType).ElementType.(EnumType); !ok || dtos[0].Type != "enum" {
+		t.Error("LiteralEnums modified its input")
+	}
+}
@@ -0,0 +1,114 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Warning describes a spec construct that could not be represented exactly
+// in the generated code.
+type Warning struct {
+	Schema   string          `json:"schema"`
+	Property string          `json:"property,omitempty"`
+	Code     string          `json:"code"`
+	Message  string          `json:"message"`
+	Source   *SourceLocation `json:"source,omitempty"`
+}
+
+// Location returns the schema path the warning refers to
+func (w Warning) Location() string {
+	if w.Property != "" {
+		return fmt.Sprintf("%s.%s", w.Schema, w.Property)
+	}
+	return w.Schema
+}
+
+func (w Warning) String() string {
+	if w.Source != nil {
+		return fmt.Sprintf("%s: %s (%s)", w.Location(), w.Message, w.Source)
+	}
+	return fmt.Sprintf("%s: %s", w.Location(), w.Message)
+}
+
+// WarningCollector gathers the warnings of every stage of a run, from spec
+// validation through generation. A nil collector discards warnings, so
+// stages can report unconditionally.
+type WarningCollector struct {
+	warnings []Warning
+}
+
+// Add records a warning
+func (c *WarningCollector) Add(warning Warning) {
+	if c == nil {
+		return
+	}
+	c.warnings = append(c.warnings, warning)
+}
+
+// Warnings returns the recorded warnings in the order they were added
+func (c *WarningCollector) Warnings() []Warning {
+	if c == nil {
+		return nil
+	}
+	return c.warnings
+}
+
+// Summary renders the warnings grouped by code, or "" if there are none
+func (c *WarningCollector) Summary() string {
+	warnings := c.Warnings()
+	if len(warnings) == 0 {
+		return ""
+	}
+
+	byCode := make(map[string][]Warning)
+	var codes []string
+	for _, warning := range warnings {
+		if _, seen := byCode[warning.Code]; !seen {
+			codes = append(codes, warning.Code)
+		}
+		byCode[warning.Code] = append(byCode[warning.Code], warning)
+	}
+	sort.Strings(codes)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "⚠️  %d warning(s):\n", len(warnings))
+	for _, code := range codes {
+		fmt.Fprintf(&b, "  %s (%d)\n", code, len(byCode[code]))
+		for _, warning := range byCode[code] {
+			fmt.Fprintf(&b, "    %s\n", warning)
+		}
+	}
+	return b.String()
+}
+
+// StringFormats returns the formats of the string and binary primitives in
+// an IRType, including array and tuple elements and union members
+func StringFormats(irType IRType) []string {
+	switch t := irType.(type) {
+	case PrimitiveType:
+		if (t.Name == "string" || t.Name == "binary") && t.Format != "" {
+			return []string{t.Format}
+		}
+	case ArrayType:
+		return StringFormats(t.ElementType)
+	case TupleType:
+		var formats []string
+		for _, elem := range t.ElementTypes {
+			formats = append(formats, StringFormats(elem)...)
+		}
+		if t.Rest != nil {
+			formats = append(formats, StringFormats(t.Rest)...)
+		}
+		return formats
+	case UnionType:
+		var formats []string
+		for _, member := range t.Types {
+			formats = append(formats, StringFormats(member)...)
+		}
+		return formats
+	case MapType:
+		return append(StringFormats(t.KeyType), StringFormats(t.ValueType)...)
+	}
+	return nil
+}
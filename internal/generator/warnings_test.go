@@ -0,0 +1,75 @@
+package generator
+
+import "testing"
+
+func TestWarning_String(t *testing.T) {
+	tests := []struct {
+		name     string
+		warning  Warning
+		expected string
+	}{
+		{
+			name:     "Schema warning",
+			warning:  Warning{Schema: "Pet", Code: "unsupported-not", Message: "'not' is not supported"},
+			expected: "Pet: 'not' is not supported",
+		},
+		{
+			name:     "Property warning",
+			warning:  Warning{Schema: "Pet", Property: "tag", Code: "unsupported-not", Message: "'not' is not supported"},
+			expected: "Pet.tag: 'not' is not supported",
+		},
+		{
+			name:     "Warning with source",
+			warning:  Warning{Schema: "Pet", Property: "tag", Message: "'not' is not supported", Source: &SourceLocation{File: "api.yaml", Line: 12, Column: 9}},
+			expected: "Pet.tag: 'not' is not supported (api.yaml:12:9)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.warning.String(); got != tt.expected {
+				t.Errorf("String() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWarningCollector_Summary(t *testing.T) {
+	var nilCollector *WarningCollector
+	nilCollector.Add(Warning{Schema: "Pet", Code: "unsupported-not"})
+	if nilCollector.Summary() != "" || nilCollector.Warnings() != nil {
+		t.Error("A nil collector should discard warnings")
+	}
+
+	collector := &WarningCollector{}
+	if collector.Summary() != "" {
+		t.Error("Summary() of an empty collector should be empty")
+	}
+
+	collector.Add(Warning{Schema: "Pet", Property: "tag", Code: "unsupported-not", Message: "'not' is not supported"})
+	collector.Add(Warning{Schema: "Host", Property: "name", Code: "unknown-format", Message: "format 'hostname' has no mapping"})
+	collector.Add(Warning{Schema: "Pet", Code: "unsupported-not", Message: "'not' is not supported"})
+
+	expected := "⚠️  3 warning(s):\n" +
+		"  unknown-format (1)\n" +
+		"    Host.name: format 'hostname' has no mapping\n" +
+		"  unsupported-not (2)\n" +
+		"    Pet.tag: 'not' is not supported\n" +
+		"    Pet: 'not' is not supported\n"
+	if got := collector.Summary(); got != expected {
+		t.Errorf("Summary() = %q, want %q", got, expected)
+	}
+}
+
+func TestStringFormats(t *testing.T) {
+	irType := TupleType{ElementTypes: []IRType{
+		PrimitiveType{Name: "string", Format: "uuid"},
+		ArrayType{ElementType: PrimitiveType{Name: "string", Format: "hostname"}},
+		PrimitiveType{Name: "integer", Format: "int64"},
+	}}
+
+	got := StringFormats(irType)
+	if len(got) != 2 || got[0] != "uuid" || got[1] != "hostname" {
+		t.Errorf("StringFormats() = %v, want [uuid hostname]", got)
+	}
+}
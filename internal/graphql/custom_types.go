@@ -0,0 +1,121 @@
+package graphql
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GenerationConfig defines what to generate
+type GenerationConfig struct {
+	FileName    string `yaml:"fileName"`    // the SDL file holding every definition
+	Inputs      *bool  `yaml:"inputs"`      // also emit an input type per object, default true
+	InputSuffix string `yaml:"inputSuffix"` // appended to object names for their input types
+}
+
+// CustomTypeMapping defines how to map an OpenAPI format to a GraphQL type.
+// Types that are not built-in scalars are declared as custom scalars.
+type CustomTypeMapping struct {
+	GraphQLType string `yaml:"graphqlType"`
+}
+
+// GraphQLConfig represents the graphql section in YAML configuration
+type GraphQLConfig struct {
+	CustomTypes map[string]CustomTypeMapping `yaml:"customTypes"`
+	Generation  GenerationConfig             `yaml:"generation"`
+}
+
+// FullConfig represents the complete YAML configuration structure
+type FullConfig struct {
+	GraphQL GraphQLConfig `yaml:"graphql"`
+}
+
+// CustomTypeRegistry holds all format mappings and config for GraphQL
+type CustomTypeRegistry struct {
+	mappings   map[string]CustomTypeMapping
+	generation GenerationConfig
+}
+
+// NewCustomTypeRegistry creates a new registry with default mappings and config
+func NewCustomTypeRegistry() *CustomTypeRegistry {
+	inputs := true
+	registry := &CustomTypeRegistry{
+		mappings: make(map[string]CustomTypeMapping),
+		generation: GenerationConfig{
+			FileName:    "schema.graphql",
+			Inputs:      &inputs,
+			InputSuffix: "Input",
+		},
+	}
+
+	registry.addDefaultMappings()
+	return registry
+}
+
+// GetGenerationConfig returns the generation configuration
+func (r *CustomTypeRegistry) GetGenerationConfig() GenerationConfig {
+	return r.generation
+}
+
+// GeneratesInputs returns true if input types should be emitted
+func (r *CustomTypeRegistry) GeneratesInputs() bool {
+	return r.generation.Inputs == nil || *r.generation.Inputs
+}
+
+// addDefaultMappings adds the built-in format mappings for GraphQL
+func (r *CustomTypeRegistry) addDefaultMappings() {
+	r.mappings["uuid"] = CustomTypeMapping{GraphQLType: "ID"}
+	r.mappings["date-time"] = CustomTypeMapping{GraphQLType: "DateTime"}
+	r.mappings["date"] = CustomTypeMapping{GraphQLType: "Date"}
+	r.mappings["binary"] = CustomTypeMapping{GraphQLType: "Upload"}
+}
+
+// Register adds or updates a custom type mapping
+func (r *CustomTypeRegistry) Register(format string, mapping CustomTypeMapping) {
+	r.mappings[format] = mapping
+}
+
+// Get retrieves a mapping for a given format
+func (r *CustomTypeRegistry) Get(format string) (CustomTypeMapping, bool) {
+	mapping, exists := r.mappings[format]
+	return mapping, exists
+}
+
+// LoadFromConfig loads custom mappings from a YAML configuration file
+func (r *CustomTypeRegistry) LoadFromConfig(configPath string) error {
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		return nil // Config file is optional
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", configPath, err)
+	}
+
+	var config FullConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", configPath, err)
+	}
+
+	gqlConfig := config.GraphQL
+
+	if gqlConfig.Generation.FileName != "" {
+		r.generation.FileName = gqlConfig.Generation.FileName
+	}
+	if gqlConfig.Generation.Inputs != nil {
+		r.generation.Inputs = gqlConfig.Generation.Inputs
+	}
+	if gqlConfig.Generation.InputSuffix != "" {
+		r.generation.InputSuffix = gqlConfig.Generation.InputSuffix
+	}
+
+	for format, mapping := range gqlConfig.CustomTypes {
+		if !isName(mapping.GraphQLType) {
+			return fmt.Errorf("custom type '%s' must have a valid graphqlType", format)
+		}
+		r.Register(format, mapping)
+	}
+
+	return nil
+}
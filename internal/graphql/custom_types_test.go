@@ -0,0 +1,42 @@
+package graphql
+
+import (
+	"testing"
+
+	"dtoForge/internal/testutils"
+)
+
+func TestCustomTypeRegistry_LoadFromConfig(t *testing.T) {
+	configPath := testutils.WriteFile(t, testutils.TempDir(t), "dtoforge.config.yaml", `graphql:
+  customTypes:
+    email:
+      graphqlType: EmailAddress
+  generation:
+    fileName: api.graphql
+    inputs: false
+    inputSuffix: Params
+`)
+
+	registry := NewCustomTypeRegistry()
+	if !registry.GeneratesInputs() {
+		t.Error("expected inputs by default")
+	}
+	if err := registry.LoadFromConfig(configPath); err != nil {
+		t.Fatalf("LoadFromConfig() error = %v", err)
+	}
+
+	if mapping, exists := registry.Get("email"); !exists || mapping.GraphQLType != "EmailAddress" {
+		t.Errorf("email mapping = %+v, exists = %v", mapping, exists)
+	}
+	genConfig := registry.GetGenerationConfig()
+	if genConfig.FileName != "api.graphql" || genConfig.InputSuffix != "Params" || registry.GeneratesInputs() {
+		t.Errorf("generation config = %+v", genConfig)
+	}
+}
+
+func TestCustomTypeRegistry_LoadFromConfig_InvalidType(t *testing.T) {
+	configPath := testutils.WriteFile(t, testutils.TempDir(t), "dtoforge.config.yaml", "graphql:\n  customTypes:\n    money:\n      graphqlType: \"Money!\"\n")
+	if err := NewCustomTypeRegistry().LoadFromConfig(configPath); err == nil {
+		t.Error("expected an error for an invalid graphqlType")
+	}
+}
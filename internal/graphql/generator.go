@@ -0,0 +1,624 @@
+package graphql
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"dtoForge/internal/generator"
+)
+
+// GraphQLGenerator implements the Generator interface for GraphQL SDL
+type GraphQLGenerator struct {
+	customTypes *CustomTypeRegistry
+	dtos        map[string]generator.DTO
+	props       map[string][]generator.Property // object DTOs with inherited properties
+	scalars     map[string]bool
+	declared    map[string]bool
+	decls       []gqlDecl
+	warnings    *generator.WarningCollector
+}
+
+// NewGraphQLGenerator creates a new GraphQL generator
+func NewGraphQLGenerator() *GraphQLGenerator {
+	return &GraphQLGenerator{}
+}
+
+// Language returns the target language name
+func (g *GraphQLGenerator) Language() string {
+	return "graphql"
+}
+
+// FileExtension returns the file extension for GraphQL SDL files
+func (g *GraphQLGenerator) FileExtension() string {
+	return ".graphql"
+}
+
+// gqlDecl is a type system definition to render
+type gqlDecl struct {
+	Kind        string // "type", "input", "enum", "union" or "scalar"
+	Name        string
+	Description string
+	Fields      []gqlField
+	Values      []string
+	Members     []string
+}
+
+// gqlField is a field of a type or input
+type gqlField struct {
+	Name        string
+	Type        string
+	Description string
+	Deprecated  bool
+}
+
+// builtinScalars need no declaration
+var builtinScalars = map[string]bool{"String": true, "Int": true, "Float": true, "Boolean": true, "ID": true}
+
+// isReservedTypeName reports whether name cannot be used for a type: it is a
+// built-in scalar, or starts with the "__" reserved for introspection
+func isReservedTypeName(name string) bool {
+	return builtinScalars[name] || strings.HasPrefix(name, "__")
+}
+
+// Generate writes one SDL file with every DTO
+func (g *GraphQLGenerator) Generate(dtos []generator.DTO, config generator.Config) error {
+	// Initialize custom type registry
+	g.customTypes = NewCustomTypeRegistry()
+
+	// Load custom config if specified
+	if config.ConfigFile != "" {
+		if err := g.customTypes.LoadFromConfig(config.ConfigFile); err != nil {
+			return &generator.ConfigError{Path: config.ConfigFile, Err: err}
+		}
+	}
+
+	// Schemas named after built-in scalars get a new name
+	dtos, _ = generator.RenameReserved(dtos, isReservedTypeName, "GraphQL", config)
+
+	g.warnings = config.Warnings
+	g.scalars = make(map[string]bool)
+	g.declared = make(map[string]bool)
+	g.decls = nil
+	g.dtos = make(map[string]generator.DTO, len(dtos))
+	g.props = make(map[string][]generator.Property, len(dtos))
+	for _, dto := range dtos {
+		g.dtos[dto.Name] = dto
+	}
+	for _, dto := range dtos {
+		if dto.Type != "enum" && dto.Type != "union" {
+			g.props[dto.Name] = g.inheritedProperties(dto, make(map[string]bool))
+		}
+	}
+
+	for _, dto := range generator.SortByDependency(dtos) {
+		description := blockDescription("", generator.DescriptionLines(dto.Description))
+		switch dto.Type {
+		case "enum":
+			if dto.NumericEnum() {
+				// Referencing fields use the scalar, see namedRef
+				g.warnNumericEnum(dto.Name, "", g.numericEnumType(dto.EnumValues, dto.EnumValueType))
+				continue
+			}
+			g.declare(gqlDecl{Kind: "enum", Name: dto.Name, Description: description, Values: enumValues(dto.EnumValues)})
+		case "union":
+			g.declareUnion(dto.Name, description, dto.UnionMembers)
+		default:
+			g.declareObject(dto.Name, description, g.props[dto.Name])
+		}
+	}
+
+	if err := os.MkdirAll(config.OutputFolder, 0755); err != nil {
+		return err
+	}
+
+	file, err := config.CreateFile(filepath.Join(config.OutputFolder, g.customTypes.GetGenerationConfig().FileName), nil)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	tmpl, err := template.New("schema").Funcs(template.FuncMap{"join": strings.Join}).Parse(schemaTemplate)
+	if err != nil {
+		return err
+	}
+
+	// Custom scalars come first, then the definitions in dependency order
+	var decls []gqlDecl
+	for _, name := range sortedKeys(g.scalars) {
+		if !g.declared[name] {
+			decls = append(decls, gqlDecl{Kind: "scalar", Name: name})
+		}
+	}
+	decls = append(decls, g.decls...)
+
+	return tmpl.Execute(file, decls)
+}
+
+// inheritedProperties returns the properties of the DTOs dto extends followed
+// by its own, since GraphQL types cannot extend each other
+func (g *GraphQLGenerator) inheritedProperties(dto generator.DTO, visiting map[string]bool) []generator.Property {
+	if visiting[dto.Name] {
+		return nil
+	}
+	visiting[dto.Name] = true
+
+	var props []generator.Property
+	index := make(map[string]int)
+	add := func(prop generator.Property) {
+		// Own properties override inherited ones of the same name
+		if i, seen := index[prop.Name]; seen {
+			props[i] = prop
+			return
+		}
+		index[prop.Name] = len(props)
+		props = append(props, prop)
+	}
+
+	for _, base := range dto.Extends {
+		if baseDTO, ok := g.dtos[base]; ok {
+			for _, prop := range g.inheritedProperties(baseDTO, visiting) {
+				add(prop)
+			}
+		}
+	}
+	for _, prop := range dto.Properties {
+		add(prop)
+	}
+	return props
+}
+
+// declare adds a definition unless one of the same name exists
+func (g *GraphQLGenerator) declare(decl gqlDecl) {
+	if g.declared[decl.Name] {
+		return
+	}
+	g.declared[decl.Name] = true
+	g.decls = append(g.decls, decl)
+}
+
+// declareObject adds the type of an object and, if enabled, its input type.
+// Write-only properties are left out of the type and read-only ones out of
+// the input. GraphQL types need at least one field, so objects without any
+// become custom scalars.
+func (g *GraphQLGenerator) declareObject(name, description string, props []generator.Property) {
+	if g.declared[name] {
+		return
+	}
+
+	var fields, inputFields []generator.Property
+	for _, prop := range props {
+		if !prop.WriteOnly {
+			fields = append(fields, prop)
+		}
+		if !prop.ReadOnly {
+			inputFields = append(inputFields, prop)
+		}
+	}
+
+	if len(fields) == 0 {
+		g.declare(gqlDecl{Kind: "scalar", Name: name, Description: description})
+	} else {
+		g.declared[name] = true
+		decl := gqlDecl{Kind: "type", Name: name, Description: description}
+		decl.Fields = g.buildFields(name, fields, false)
+		g.decls = append(g.decls, decl)
+	}
+
+	if g.hasInput(props) {
+		inputName := name + g.customTypes.GetGenerationConfig().InputSuffix
+		g.declared[inputName] = true
+		decl := gqlDecl{Kind: "input", Name: inputName, Description: description}
+		decl.Fields = g.buildFields(name, inputFields, true)
+		g.decls = append(g.decls, decl)
+	}
+}
+
+// hasInput reports whether an object with props gets an input type
+func (g *GraphQLGenerator) hasInput(props []generator.Property) bool {
+	if !g.customTypes.GeneratesInputs() {
+		return false
+	}
+	for _, prop := range props {
+		if !prop.ReadOnly {
+			return true
+		}
+	}
+	return false
+}
+
+// declareUnion adds a union of object types, or a custom scalar if a member
+// is not an object type, as GraphQL unions can only hold those
+func (g *GraphQLGenerator) declareUnion(name, description string, members []generator.IRType) {
+	if objects, ok := g.objectMembers(members); ok {
+		g.declare(gqlDecl{Kind: "union", Name: name, Description: description, Members: objects})
+		return
+	}
+	g.warnUntyped(name, "", generator.UnionType{Types: members}, "a custom scalar")
+	g.declare(gqlDecl{Kind: "scalar", Name: name, Description: description})
+}
+
+// objectMembers returns the names of union members if they are all object types
+func (g *GraphQLGenerator) objectMembers(members []generator.IRType) ([]string, bool) {
+	var names []string
+	for _, member := range members {
+		var name string
+		switch t := member.(type) {
+		case generator.ReferenceType:
+			name = t.RefName
+		case generator.ObjectType:
+			name = t.RefName
+		case generator.LiteralType:
+			if t.Kind == "null" {
+				continue
+			}
+		}
+		if _, isObject := g.props[name]; name == "" || !isObject {
+			return nil, false
+		}
+		names = append(names, name)
+	}
+	return names, len(names) > 0
+}
+
+// buildFields turns properties into the fields of a type or input
+func (g *GraphQLGenerator) buildFields(owner string, props []generator.Property, input bool) []gqlField {
+	var fields []gqlField
+	taken := make(map[string]bool)
+	for _, prop := range props {
+		irType, nullable := nonNull(prop.Type)
+		gqlType := g.typeRef(owner, prop.Name, irType, input)
+		if prop.Required && !prop.Nullable && !nullable {
+			gqlType += "!"
+		}
+		fields = append(fields, gqlField{
+			Name:        uniqueName(fieldName(prop.Name), taken),
+			Type:        gqlType,
+			Description: inlineDescription("  ", generator.DescriptionLines(prop.Description)),
+			Deprecated:  prop.Deprecated,
+		})
+	}
+	return fields
+}
+
+// typeRef returns the nullable GraphQL type of an IRType, declaring inline
+// enums, objects and unions under names derived from owner and the property
+func (g *GraphQLGenerator) typeRef(owner, propName string, irType generator.IRType, input bool) string {
+	switch t := irType.(type) {
+	case generator.PrimitiveType:
+		return g.primitiveToGraphQL(t)
+	case generator.ReferenceType:
+		return g.namedRef(owner, propName, t.RefName, input)
+	case generator.ObjectType:
+		if t.RefName != "" {
+			return g.namedRef(owner, propName, t.RefName, input)
+		}
+		if t.DTORef == nil {
+			return g.scalar("JSON")
+		}
+		name := owner + pascalCase(propName)
+		g.props[name] = t.DTORef.Properties
+		g.declareObject(name, blockDescription("", generator.DescriptionLines(t.DTORef.Description)), t.DTORef.Properties)
+		return g.namedRef(owner, propName, name, input)
+	case generator.EnumType:
+		if t.Numeric() {
+			scalar := g.numericEnumType(t.Values, t.UnderlyingType)
+			if !input {
+				g.warnNumericEnum(owner, propName, scalar)
+			}
+			return scalar
+		}
+		name := t.Name
+		if name == "" {
+			name = owner + pascalCase(propName)
+		}
+		g.declare(gqlDecl{Kind: "enum", Name: name, Values: enumValues(t.Values)})
+		return name
+	case generator.ArrayType:
+		element, nullable := nonNull(t.ElementType)
+		elementType := g.typeRef(owner, propName, element, input)
+		if !nullable {
+			elementType += "!"
+		}
+		return "[" + elementType + "]"
+	case generator.UnionType:
+		if !input {
+			if objects, ok := g.objectMembers(t.Types); ok {
+				name := owner + pascalCase(propName)
+				g.declare(gqlDecl{Kind: "union", Name: name, Members: objects})
+				return name
+			}
+		}
+		g.warnUntypedOnce(owner, propName, t, input)
+		return g.scalar("JSON")
+	case generator.TupleType:
+		g.warnUntypedOnce(owner, propName, t, input)
+		return g.scalar("JSON")
+	case generator.LiteralType:
+		switch t.Kind {
+		case "string":
+			return "String"
+		case "number":
+			return "Float"
+		case "boolean":
+			return "Boolean"
+		}
+		return g.scalar("JSON")
+	default:
+		// Maps have no GraphQL equivalent beyond a JSON object
+		return g.scalar("JSON")
+	}
+}
+
+// namedRef refers to a DTO by name; objects are referenced through their
+// input type from inputs, and unions, which inputs cannot hold, as JSON
+func (g *GraphQLGenerator) namedRef(owner, propName, name string, input bool) string {
+	if dto := g.dtos[name]; dto.NumericEnum() {
+		return g.numericEnumType(dto.EnumValues, dto.EnumValueType)
+	}
+	if !input {
+		return name
+	}
+	if dto, ok := g.dtos[name]; ok && dto.Type == "union" {
+		if _, isUnion := g.objectMembers(dto.UnionMembers); isUnion {
+			g.warnUntyped(owner, propName, generator.ReferenceType{RefName: name}, "JSON in inputs")
+			return g.scalar("JSON")
+		}
+		return name
+	}
+	if props, isObject := g.props[name]; isObject {
+		if !g.hasInput(props) {
+			return g.scalar("JSON")
+		}
+		return name + g.customTypes.GetGenerationConfig().InputSuffix
+	}
+	return name
+}
+
+// primitiveToGraphQL maps a primitive, honoring format mappings first
+func (g *GraphQLGenerator) primitiveToGraphQL(prim generator.PrimitiveType) string {
+	if prim.Format != "" {
+		if mapping, exists := g.customTypes.Get(prim.Format); exists {
+			return g.scalar(mapping.GraphQLType)
+		}
+	}
+
+	switch prim.Name {
+	case "string":
+		return "String"
+	case "integer":
+		// Int is 32-bit in GraphQL
+		if prim.Format == "int64" || prim.Format == "uint32" || prim.Format == "uint64" {
+			return g.scalar("BigInt")
+		}
+		return "Int"
+	case "bigint":
+		return g.scalar("BigInt")
+	case "number":
+		return "Float"
+	case "binary":
+		// format: byte is base64 text
+		return "String"
+	case "boolean":
+		return "Boolean"
+	default:
+		return g.scalar("JSON")
+	}
+}
+
+// scalar returns a type name, recording it for declaration if it is not built in
+func (g *GraphQLGenerator) scalar(name string) string {
+	if !builtinScalars[name] {
+		g.scalars[name] = true
+	}
+	return name
+}
+
+// warnUntyped reports a type GraphQL cannot express
+func (g *GraphQLGenerator) warnUntyped(schema, property string, irType generator.IRType, fallback string) {
+	g.warnings.Add(generator.Warning{
+		Schema:   schema,
+		Property: property,
+		Code:     "untyped-graphql",
+		Message:  fmt.Sprintf("GraphQL has no equivalent of %s and it is generated as %s", irType.TypeName(), fallback),
+	})
+}
+
+// warnNumericEnum reports a numeric enum generated as a scalar, whose values
+// are not enforced
+func (g *GraphQLGenerator) warnNumericEnum(schema, property, scalar string) {
+	g.warnings.Add(generator.Warning{
+		Schema:   schema,
+		Property: property,
+		Code:     "numeric-enum",
+		Message:  fmt.Sprintf("GraphQL enums cannot hold numbers, the enum is generated as %s without restricting its values", scalar),
+	})
+}
+
+// numericEnumType returns the scalar of a numeric enum
+func (g *GraphQLGenerator) numericEnumType(values []string, valueType string) string {
+	if valueType == "number" {
+		return "Float"
+	}
+	// Int is 32-bit in GraphQL
+	if !generator.Int32Values(values) {
+		return g.scalar("BigInt")
+	}
+	return "Int"
+}
+
+// warnUntypedOnce reports a type generated as JSON while building the output
+// type, so properties shared with the input type are reported once
+func (g *GraphQLGenerator) warnUntypedOnce(schema, property string, irType generator.IRType, input bool) {
+	if !input {
+		g.warnUntyped(schema, property, irType, "JSON")
+	}
+}
+
+// nonNull returns the single non-null member of a nullable union and true,
+// or irType and false
+func nonNull(irType generator.IRType) (generator.IRType, bool) {
+	union, ok := irType.(generator.UnionType)
+	if !ok {
+		return irType, false
+	}
+	var members []generator.IRType
+	for _, member := range union.Types {
+		if literal, ok := member.(generator.LiteralType); ok && literal.Kind == "null" {
+			continue
+		}
+		members = append(members, member)
+	}
+	if len(members) == 1 {
+		return members[0], true
+	}
+	return irType, false
+}
+
+// enumValues names enum values in UPPER_SNAKE_CASE, e.g. "in-progress"
+// becomes IN_PROGRESS
+func enumValues(values []string) []string {
+	taken := make(map[string]bool)
+	var names []string
+	for _, value := range values {
+		name := strings.ToUpper(strings.Join(words(value), "_"))
+		switch {
+		case name == "":
+			name = "EMPTY"
+		case unicode.IsDigit([]rune(name)[0]):
+			name = "_" + name
+		case name == "TRUE" || name == "FALSE" || name == "NULL":
+			// Only the lowercase forms are reserved, but keep the names uniform
+			name += "_"
+		}
+		names = append(names, uniqueName(name, taken))
+	}
+	return names
+}
+
+// blockDescription renders description lines as a block string
+func blockDescription(indent string, lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(indent + `"""` + "\n")
+	for _, line := range lines {
+		line = strings.ReplaceAll(line, `"""`, `\"""`)
+		if line == "" {
+			b.WriteString("\n")
+		} else {
+			b.WriteString(indent + line + "\n")
+		}
+	}
+	b.WriteString(indent + `"""` + "\n")
+	return b.String()
+}
+
+// inlineDescription renders a one-line description as a string and longer
+// ones as a block string
+func inlineDescription(indent string, lines []string) string {
+	if len(lines) != 1 {
+		return blockDescription(indent, lines)
+	}
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return indent + `"` + replacer.Replace(lines[0]) + `"` + "\n"
+}
+
+// words splits a name into its alphanumeric words, also at case changes
+func words(s string) []string {
+	var result []string
+	var current []rune
+	runes := []rune(s)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(current) > 0 {
+				result = append(result, string(current))
+				current = nil
+			}
+			continue
+		}
+		if len(current) > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				result = append(result, string(current))
+				current = nil
+			}
+		}
+		current = append(current, r)
+	}
+	if len(current) > 0 {
+		result = append(result, string(current))
+	}
+	return result
+}
+
+// pascalCase joins the words of a name, e.g. shipping_address becomes ShippingAddress
+func pascalCase(s string) string {
+	var b strings.Builder
+	for _, word := range words(s) {
+		runes := []rune(word)
+		b.WriteRune(unicode.ToUpper(runes[0]))
+		b.WriteString(string(runes[1:]))
+	}
+	return b.String()
+}
+
+// fieldName keeps a property name that is a valid GraphQL name and
+// camelCases any other, e.g. Content-Type becomes contentType
+func fieldName(name string) string {
+	if isName(name) && !strings.HasPrefix(name, "__") {
+		return name
+	}
+	pascal := pascalCase(name)
+	if pascal == "" {
+		return "value"
+	}
+	runes := []rune(pascal)
+	field := string(unicode.ToLower(runes[0])) + string(runes[1:])
+	if unicode.IsDigit(runes[0]) {
+		field = "_" + field
+	}
+	return field
+}
+
+// isName reports whether s matches GraphQL's Name: /[_A-Za-z][_0-9A-Za-z]*/
+func isName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case r == '_', 'A' <= r && r <= 'Z', 'a' <= r && r <= 'z':
+		case i > 0 && '0' <= r && r <= '9':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// uniqueName returns name, or name with a number appended if it is taken
+func uniqueName(name string, taken map[string]bool) string {
+	unique := name
+	for i := 2; taken[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	taken[unique] = true
+	return unique
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
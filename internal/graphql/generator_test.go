@@ -0,0 +1,127 @@
+package graphql
+
+import (
+	"path/filepath"
+	"testing"
+
+	"dtoForge/internal/generator"
+	"dtoForge/internal/testutils"
+)
+
+func TestGraphQLGenerator_Language(t *testing.T) {
+	gen := NewGraphQLGenerator()
+	if got := gen.Language(); got != "graphql" {
+		t.Errorf("Language() = %v, want %v", got, "graphql")
+	}
+	if got := gen.FileExtension(); got != ".graphql" {
+		t.Errorf("FileExtension() = %v, want %v", got, ".graphql")
+	}
+}
+
+func TestGraphQLGenerator_Generate(t *testing.T) {
+	tempDir := testutils.TempDir(t)
+
+	pet := testutils.CreateTestDTO("Pet")
+	pet.Properties = append(pet.Properties,
+		generator.Property{Name: "createdAt", Type: generator.PrimitiveType{Name: "string", Format: "date-time"}, ReadOnly: true},
+		generator.Property{Name: "password", Type: generator.PrimitiveType{Name: "string"}, WriteOnly: true, Required: true},
+		generator.Property{Name: "owner", Type: generator.ReferenceType{RefName: "Owner"}, Required: true},
+		generator.Property{Name: "nickname", Required: true, Type: generator.UnionType{Types: []generator.IRType{
+			generator.PrimitiveType{Name: "string"},
+			generator.NewLiteralType(nil),
+		}}},
+		generator.Property{Name: "tags", Required: true, Type: generator.ArrayType{ElementType: generator.PrimitiveType{Name: "string"}}},
+		generator.Property{Name: "status", Type: generator.ReferenceType{RefName: "Status"}, Deprecated: true},
+	)
+	owner := generator.DTO{Name: "Owner", Type: "object", Properties: []generator.Property{
+		{Name: "Content-Type", Type: generator.PrimitiveType{Name: "integer", Format: "int64"}},
+	}}
+	status := generator.DTO{Name: "Status", Type: "enum", EnumValues: []string{"active", "in-progress"}, Description: "Lifecycle state"}
+	shape := generator.DTO{Name: "Shape", Type: "union", UnionMembers: []generator.IRType{
+		generator.ReferenceType{RefName: "Pet"},
+		generator.ReferenceType{RefName: "Owner"},
+	}}
+
+	gen := NewGraphQLGenerator()
+	config := generator.Config{OutputFolder: tempDir}
+	if err := gen.Generate([]generator.DTO{pet, owner, status, shape}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	schemaFile := filepath.Join(tempDir, "schema.graphql")
+	testutils.AssertFileContains(t, schemaFile, "scalar BigInt\n\nscalar DateTime\n")
+	testutils.AssertFileContains(t, schemaFile, "type Owner {\n  contentType: BigInt\n}")
+	testutils.AssertFileContains(t, schemaFile, "input OwnerInput {\n  contentType: BigInt\n}")
+	testutils.AssertFileContains(t, schemaFile, "\"\"\"\nLifecycle state\n\"\"\"\nenum Status {\n  ACTIVE\n  IN_PROGRESS\n}")
+	testutils.AssertFileContains(t, schemaFile, "type Pet {\n  \"Identifier\"\n  id: String!\n")
+	testutils.AssertFileContains(t, schemaFile, "  createdAt: DateTime\n  owner: Owner!\n  nickname: String\n  tags: [String!]!\n  status: Status @deprecated\n}")
+	testutils.AssertFileContains(t, schemaFile, "input PetInput {")
+	testutils.AssertFileContains(t, schemaFile, "  password: String!\n  owner: OwnerInput!\n")
+	testutils.AssertFileContains(t, schemaFile, "union Shape = Pet | Owner")
+}
+
+func TestGraphQLGenerator_ScalarUnions(t *testing.T) {
+	tempDir := testutils.TempDir(t)
+	configPath := testutils.WriteFile(t, tempDir, "dtoforge.config.yaml", "graphql:\n  generation:\n    inputs: false\n")
+
+	dto := generator.DTO{Name: "Setting", Type: "object", Properties: []generator.Property{{
+		Name: "value",
+		Type: generator.UnionType{Types: []generator.IRType{generator.PrimitiveType{Name: "string"}, generator.PrimitiveType{Name: "number"}}},
+	}}}
+	id := generator.DTO{Name: "Identifier", Type: "union", UnionMembers: []generator.IRType{
+		generator.PrimitiveType{Name: "string"},
+		generator.PrimitiveType{Name: "integer"},
+	}}
+
+	warnings := &generator.WarningCollector{}
+	gen := NewGraphQLGenerator()
+	config := generator.Config{OutputFolder: tempDir, ConfigFile: configPath, Warnings: warnings}
+	if err := gen.Generate([]generator.DTO{dto, id}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	schemaFile := filepath.Join(tempDir, "schema.graphql")
+	testutils.AssertFileContains(t, schemaFile, "scalar JSON")
+	testutils.AssertFileContains(t, schemaFile, "  value: JSON\n")
+	testutils.AssertFileContains(t, schemaFile, "scalar Identifier")
+	testutils.AssertFileNotContains(t, schemaFile, "input ")
+
+	if got := warnings.Warnings(); len(got) != 2 || got[0].Code != "untyped-graphql" {
+		t.Errorf("warnings = %v, want two untyped-graphql warnings", got)
+	}
+}
+
+func TestEnumValues(t *testing.T) {
+	got := enumValues([]string{"active", "inProgress", "1st", "", "null", "active!"})
+	expected := []string{"ACTIVE", "IN_PROGRESS", "_1ST", "EMPTY", "NULL_", "ACTIVE2"}
+	if len(got) != len(expected) {
+		t.Fatalf("enumValues() = %v, want %v", got, expected)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("enumValues()[%d] = %v, want %v", i, got[i], expected[i])
+		}
+	}
+}
+
+func TestGraphQLGenerator_NumericEnums(t *testing.T) {
+	priority := generator.DTO{Name: "Priority", Type: "enum", EnumValues: []string{"1", "2", "-3"}, EnumValueType: "integer"}
+	task := generator.DTO{Name: "Task", Type: "object", Properties: []generator.Property{
+		{Name: "priority", Type: generator.ReferenceType{RefName: "Priority"}, Required: true},
+		{Name: "weight", Type: generator.EnumType{Values: []string{"0.5", "1.5"}, UnderlyingType: "number"}, Required: true},
+	}}
+	warnings := &generator.WarningCollector{}
+	config := generator.Config{OutputFolder: testutils.TempDir(t), Warnings: warnings}
+	if err := NewGraphQLGenerator().Generate([]generator.DTO{priority, task}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	schemaFile := filepath.Join(config.OutputFolder, "schema.graphql")
+	testutils.AssertFileContains(t, schemaFile, "type Task {\n  priority: Int!\n  weight: Float!\n}")
+	testutils.AssertFileContains(t, schemaFile, "input TaskInput {\n  priority: Int!\n  weight: Float!\n}")
+	testutils.AssertFileNotContains(t, schemaFile, "enum Priority")
+	// The input type does not report the inline enum again
+	if got := warnings.Warnings(); len(got) != 2 || got[0].Code != "numeric-enum" || got[1].Property != "weight" {
+		t.Errorf("warnings = %v, want numeric-enum warnings for Priority and Task.weight", got)
+	}
+}
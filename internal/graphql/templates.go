@@ -0,0 +1,18 @@
+package graphql
+
+const schemaTemplate = `# Generated by DtoForge - DO NOT EDIT
+{{range .}}
+{{.Description}}
+{{- if eq .Kind "scalar"}}scalar {{.Name}}
+{{else if eq .Kind "union"}}union {{.Name}} = {{join .Members " | "}}
+{{else if eq .Kind "enum"}}enum {{.Name}} {
+{{- range .Values}}
+  {{.}}
+{{- end}}
+}
+{{else}}{{.Kind}} {{.Name}} {
+{{- range .Fields}}
+{{.Description}}  {{.Name}}: {{.Type}}{{if .Deprecated}} @deprecated{{end}}
+{{- end}}
+}
+{{end}}{{end}}`
@@ -0,0 +1,133 @@
+package java
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GenerationConfig defines what to generate
+type GenerationConfig struct {
+	Package  string `yaml:"package"`  // Java package of the generated types, -package overrides it
+	Style    string `yaml:"style"`    // "record" or "class"
+	Builders string `yaml:"builders"` // "auto", "always" or "never"
+	// BuilderThreshold is how many optional properties make a DTO get a
+	// builder in "auto" mode
+	BuilderThreshold int `yaml:"builderThreshold"`
+}
+
+// CustomTypeMapping defines how to map an OpenAPI format to a Java type
+type CustomTypeMapping struct {
+	JavaType string `yaml:"javaType"` // simple type name, e.g. BigDecimal
+	Import   string `yaml:"import"`   // fully qualified class to import, e.g. java.math.BigDecimal
+}
+
+// JavaConfig represents the java section in YAML configuration
+type JavaConfig struct {
+	CustomTypes map[string]CustomTypeMapping `yaml:"customTypes"`
+	Generation  GenerationConfig             `yaml:"generation"`
+}
+
+// FullConfig represents the complete YAML configuration structure
+type FullConfig struct {
+	Java JavaConfig `yaml:"java"`
+}
+
+// CustomTypeRegistry holds all format mappings and config for Java
+type CustomTypeRegistry struct {
+	mappings   map[string]CustomTypeMapping
+	generation GenerationConfig
+}
+
+// NewCustomTypeRegistry creates a new registry with default mappings and config
+func NewCustomTypeRegistry() *CustomTypeRegistry {
+	registry := &CustomTypeRegistry{
+		mappings: make(map[string]CustomTypeMapping),
+		generation: GenerationConfig{
+			Package:          "generated",
+			Style:            "record",
+			Builders:         "auto",
+			BuilderThreshold: 3,
+		},
+	}
+
+	registry.addDefaultMappings()
+	return registry
+}
+
+// GetGenerationConfig returns the generation configuration
+func (r *CustomTypeRegistry) GetGenerationConfig() GenerationConfig {
+	return r.generation
+}
+
+// addDefaultMappings adds the built-in format mappings for Java
+func (r *CustomTypeRegistry) addDefaultMappings() {
+	r.mappings["date-time"] = CustomTypeMapping{JavaType: "OffsetDateTime", Import: "java.time.OffsetDateTime"}
+	r.mappings["date"] = CustomTypeMapping{JavaType: "LocalDate", Import: "java.time.LocalDate"}
+	r.mappings["uuid"] = CustomTypeMapping{JavaType: "UUID", Import: "java.util.UUID"}
+	r.mappings["uri"] = CustomTypeMapping{JavaType: "URI", Import: "java.net.URI"}
+	// Jackson reads base64 text and writes byte arrays as base64
+	r.mappings["byte"] = CustomTypeMapping{JavaType: "byte[]"}
+	r.mappings["binary"] = CustomTypeMapping{JavaType: "byte[]"}
+}
+
+// Register adds or updates a custom type mapping
+func (r *CustomTypeRegistry) Register(format string, mapping CustomTypeMapping) {
+	r.mappings[format] = mapping
+}
+
+// Get retrieves a mapping for a given format
+func (r *CustomTypeRegistry) Get(format string) (CustomTypeMapping, bool) {
+	mapping, exists := r.mappings[format]
+	return mapping, exists
+}
+
+// LoadFromConfig loads custom mappings from a YAML configuration file
+func (r *CustomTypeRegistry) LoadFromConfig(configPath string) error {
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		return nil // Config file is optional
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", configPath, err)
+	}
+
+	var config FullConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", configPath, err)
+	}
+
+	javaConfig := config.Java
+
+	if javaConfig.Generation.Package != "" {
+		r.generation.Package = javaConfig.Generation.Package
+	}
+	if javaConfig.Generation.Style != "" {
+		if javaConfig.Generation.Style != "record" && javaConfig.Generation.Style != "class" {
+			return fmt.Errorf("invalid java style '%s', must be 'record' or 'class'", javaConfig.Generation.Style)
+		}
+		r.generation.Style = javaConfig.Generation.Style
+	}
+	if javaConfig.Generation.Builders != "" {
+		switch javaConfig.Generation.Builders {
+		case "auto", "always", "never":
+			r.generation.Builders = javaConfig.Generation.Builders
+		default:
+			return fmt.Errorf("invalid java builders '%s', must be 'auto', 'always' or 'never'", javaConfig.Generation.Builders)
+		}
+	}
+	if javaConfig.Generation.BuilderThreshold > 0 {
+		r.generation.BuilderThreshold = javaConfig.Generation.BuilderThreshold
+	}
+
+	for format, mapping := range javaConfig.CustomTypes {
+		if mapping.JavaType == "" {
+			return fmt.Errorf("custom type '%s' must have javaType", format)
+		}
+		r.Register(format, mapping)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package java
+
+import (
+	"testing"
+
+	"dtoForge/internal/testutils"
+)
+
+func TestCustomTypeRegistry_LoadFromConfig(t *testing.T) {
+	tempDir := testutils.TempDir(t)
+	configPath := testutils.WriteFile(t, tempDir, "dtoforge.config.yaml", `java:
+  customTypes:
+    decimal:
+      javaType: BigDecimal
+      import: java.math.BigDecimal
+  generation:
+    package: com.example
+    style: class
+    builders: never
+    builderThreshold: 5
+`)
+
+	registry := NewCustomTypeRegistry()
+	if err := registry.LoadFromConfig(configPath); err != nil {
+		t.Fatalf("LoadFromConfig() error = %v", err)
+	}
+
+	mapping, exists := registry.Get("decimal")
+	if !exists || mapping.JavaType != "BigDecimal" || mapping.Import != "java.math.BigDecimal" {
+		t.Errorf("decimal mapping = %+v, exists = %v", mapping, exists)
+	}
+
+	genConfig := registry.GetGenerationConfig()
+	if genConfig.Package != "com.example" || genConfig.Style != "class" || genConfig.Builders != "never" || genConfig.BuilderThreshold != 5 {
+		t.Errorf("generation config = %+v", genConfig)
+	}
+}
+
+func TestCustomTypeRegistry_LoadFromConfig_Defaults(t *testing.T) {
+	tempDir := testutils.TempDir(t)
+	configPath := testutils.WriteFile(t, tempDir, "dtoforge.config.yaml", "output:\n  folder: ./out\n")
+
+	registry := NewCustomTypeRegistry()
+	if err := registry.LoadFromConfig(configPath); err != nil {
+		t.Fatalf("LoadFromConfig() error = %v", err)
+	}
+
+	genConfig := registry.GetGenerationConfig()
+	if genConfig.Package != "generated" || genConfig.Style != "record" || genConfig.Builders != "auto" || genConfig.BuilderThreshold != 3 {
+		t.Errorf("generation config = %+v", genConfig)
+	}
+}
+
+func TestCustomTypeRegistry_LoadFromConfig_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"style":    "java:\n  generation:\n    style: bean\n",
+		"builders": "java:\n  generation:\n    builders: sometimes\n",
+		"javaType": "java:\n  customTypes:\n    money:\n      import: org.acme.Money\n",
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			configPath := testutils.WriteFile(t, testutils.TempDir(t), "dtoforge.config.yaml", content)
+			if err := NewCustomTypeRegistry().LoadFromConfig(configPath); err == nil {
+				t.Error("expected an error for invalid config")
+			}
+		})
+	}
+}
@@ -0,0 +1,687 @@
+package java
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"dtoForge/internal/generator"
+)
+
+// JavaGenerator implements the Generator interface for Java with Jackson
+type JavaGenerator struct {
+	customTypes *CustomTypeRegistry
+	dtos        map[string]generator.DTO
+	warnings    *generator.WarningCollector
+}
+
+// NewJavaGenerator creates a new Java generator
+func NewJavaGenerator() *JavaGenerator {
+	return &JavaGenerator{}
+}
+
+// Language returns the target language name
+func (g *JavaGenerator) Language() string {
+	return "java"
+}
+
+// FileExtension returns the file extension for Java files
+func (g *JavaGenerator) FileExtension() string {
+	return ".java"
+}
+
+// javaDecl is a Java type to render: a top-level DTO or a type nested in one
+type javaDecl struct {
+	Kind       string // "record", "class", "enum" or "union"
+	Name       string
+	Comment    string
+	Deprecated bool
+	Nested     bool // declared inside another type
+	Builder    bool
+	Fields     []javaField
+	Constants  []javaConstant
+	ValueType  string     // Java type of the enum values
+	Types      []javaDecl // inline enums and objects of the fields
+	outer      []string   // names of the types decl is nested in, innermost last
+}
+
+// javaField is a property of a record or class
+type javaField struct {
+	Name       string // Java identifier
+	JSONName   string
+	Type       string
+	Getter     string
+	Required   bool
+	Comment    string
+	Deprecated bool
+}
+
+// Annotation returns the Jackson annotation binding the field to its JSON name
+func (f javaField) Annotation() string {
+	if f.Required {
+		return fmt.Sprintf("@JsonProperty(value = %s, required = true)", stringLiteral(f.JSONName))
+	}
+	return fmt.Sprintf("@JsonProperty(%s)", stringLiteral(f.JSONName))
+}
+
+// javaConstant is an enum constant and the JSON value it stands for
+type javaConstant struct {
+	Name  string
+	Value string
+	Type  string // String, Integer, Long or Double
+}
+
+// Literal returns the JSON value as a Java literal of the constant's type
+func (c javaConstant) Literal() string {
+	switch c.Type {
+	case "Integer":
+		return c.Value
+	case "Long":
+		return c.Value + "L"
+	case "Double":
+		return c.Value + "d"
+	}
+	return stringLiteral(c.Value)
+}
+
+// Generate creates one Java source file per DTO
+func (g *JavaGenerator) Generate(dtos []generator.DTO, config generator.Config) error {
+	// Initialize custom type registry
+	g.customTypes = NewCustomTypeRegistry()
+
+	// Load custom config if specified
+	if config.ConfigFile != "" {
+		if err := g.customTypes.LoadFromConfig(config.ConfigFile); err != nil {
+			return &generator.ConfigError{Path: config.ConfigFile, Err: err}
+		}
+	}
+
+	// Schemas named after Java keywords get a new name
+	dtos, _ = generator.RenameReserved(dtos, isReservedTypeName, "Java", config)
+
+	g.warnings = config.Warnings
+	g.dtos = make(map[string]generator.DTO, len(dtos))
+	for _, dto := range dtos {
+		g.dtos[dto.Name] = dto
+	}
+
+	packageName := g.getPackageName(config)
+	if err := validatePackageName(packageName); err != nil {
+		return err
+	}
+
+	folder := filepath.Join(config.OutputFolder, filepath.FromSlash(strings.ReplaceAll(packageName, ".", "/")))
+	if err := os.MkdirAll(folder, 0755); err != nil {
+		return err
+	}
+
+	// Sort DTOs for consistent output
+	sortedDTOs := generator.SortByDependency(dtos)
+
+	for _, dto := range sortedDTOs {
+		if config.KeepFile(dto.Name, filepath.Join(folder, dto.Name+g.FileExtension())) {
+			continue
+		}
+		if err := g.generateDTOFile(dto, config, folder, packageName); err != nil {
+			return fmt.Errorf("failed to generate file for DTO %s: %w", dto.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// generateDTOFile writes the Java source file of one DTO
+func (g *JavaGenerator) generateDTOFile(dto generator.DTO, config generator.Config, folder, packageName string) error {
+	imports := make(map[string]bool)
+	decl := g.buildDecl(dto, imports)
+
+	var tmpl *template.Template
+	funcs := template.FuncMap{
+		// nested renders a type declared inside another, indented one level
+		"nested": func(decl javaDecl) (string, error) {
+			var b bytes.Buffer
+			if err := tmpl.ExecuteTemplate(&b, "decl", decl); err != nil {
+				return "", err
+			}
+			return indent(b.String(), "    "), nil
+		},
+		"args": func(fields []javaField) string {
+			names := make([]string, len(fields))
+			for i, field := range fields {
+				names[i] = field.Name
+			}
+			return strings.Join(names, ", ")
+		},
+		"quote": stringLiteral,
+	}
+
+	tmpl, err := template.New("file").Funcs(funcs).Parse(fileTemplate + declTemplates)
+	if err != nil {
+		return err
+	}
+
+	file, err := config.CreateFile(filepath.Join(folder, dto.Name+g.FileExtension()), nil)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	data := struct {
+		Package string
+		Imports []string
+		Decl    javaDecl
+	}{
+		Package: packageName,
+		Imports: sortedImports(imports),
+		Decl:    decl,
+	}
+
+	return tmpl.Execute(file, data)
+}
+
+// getPackageName returns the Java package from -package, the config file or the default
+func (g *JavaGenerator) getPackageName(config generator.Config) string {
+	if config.PackageName != "" {
+		return config.PackageName
+	}
+	return g.customTypes.GetGenerationConfig().Package
+}
+
+// validatePackageName checks that name is a dotted list of Java identifiers
+func validatePackageName(name string) error {
+	for _, part := range strings.Split(name, ".") {
+		if !isIdentifier(part) || javaKeywords[part] {
+			return fmt.Errorf("invalid Java package name '%s'", name)
+		}
+	}
+	return nil
+}
+
+// buildDecl turns a DTO into the declaration to render
+func (g *JavaGenerator) buildDecl(dto generator.DTO, imports map[string]bool) javaDecl {
+	genConfig := g.customTypes.GetGenerationConfig()
+
+	decl := javaDecl{
+		Kind:       genConfig.Style,
+		Name:       dto.Name,
+		Deprecated: dto.Deprecated,
+	}
+
+	switch dto.Type {
+	case "enum":
+		decl.Kind = "enum"
+		decl.ValueType = enumValueType(dto.EnumValues, dto.EnumValueType)
+		decl.Constants = enumConstants(dto.EnumValues, decl.ValueType)
+		imports["com.fasterxml.jackson.annotation.JsonCreator"] = true
+		imports["com.fasterxml.jackson.annotation.JsonValue"] = true
+		decl.Comment = generator.DocComment("", dto.Description, docTags(dto.Deprecated)...)
+		return decl
+	case "union":
+		// Java has no union types, so the value is kept as parsed by Jackson
+		decl.Kind = "union"
+		imports["com.fasterxml.jackson.annotation.JsonCreator"] = true
+		imports["com.fasterxml.jackson.annotation.JsonValue"] = true
+		imports["java.util.Objects"] = true
+		decl.Comment = generator.DocComment("", dto.Description, docTags(dto.Deprecated)...)
+		return decl
+	}
+
+	g.addFields(&decl, dto.Name, g.inheritedProperties(dto, make(map[string]bool)), imports)
+
+	var paramTags []string
+	if genConfig.Style == "record" {
+		for _, field := range decl.Fields {
+			if field.Comment != "" {
+				paramTags = append(paramTags, fmt.Sprintf("@param %s %s", field.Name, field.Comment))
+			}
+		}
+	}
+	decl.Comment = generator.DocComment("", dto.Description, append(paramTags, docTags(dto.Deprecated)...)...)
+	return decl
+}
+
+// inheritedProperties returns the properties of the DTOs dto extends followed
+// by its own, since records cannot extend each other
+func (g *JavaGenerator) inheritedProperties(dto generator.DTO, visiting map[string]bool) []generator.Property {
+	if visiting[dto.Name] {
+		return nil
+	}
+	visiting[dto.Name] = true
+
+	var props []generator.Property
+	seen := make(map[string]bool)
+	add := func(prop generator.Property) {
+		if seen[prop.Name] {
+			return
+		}
+		seen[prop.Name] = true
+		props = append(props, prop)
+	}
+
+	for _, base := range dto.Extends {
+		if baseDTO, ok := g.dtos[base]; ok {
+			for _, prop := range g.inheritedProperties(baseDTO, visiting) {
+				add(prop)
+			}
+		}
+	}
+	// Own properties override inherited ones of the same name
+	for _, prop := range dto.Properties {
+		if seen[prop.Name] {
+			for i := range props {
+				if props[i].Name == prop.Name {
+					props[i] = prop
+				}
+			}
+			continue
+		}
+		add(prop)
+	}
+	return props
+}
+
+// addFields adds a field per property to decl, and a nested type for every
+// inline enum or object among them
+func (g *JavaGenerator) addFields(decl *javaDecl, schema string, props []generator.Property, imports map[string]bool) {
+	genConfig := g.customTypes.GetGenerationConfig()
+
+	if len(props) > 0 {
+		imports["com.fasterxml.jackson.annotation.JsonProperty"] = true
+		imports["com.fasterxml.jackson.annotation.JsonInclude"] = true
+	}
+	if genConfig.Style == "class" {
+		imports["com.fasterxml.jackson.annotation.JsonCreator"] = true
+		imports["java.util.Arrays"] = true
+		imports["java.util.Objects"] = true
+	}
+
+	names := make(map[string]bool)
+	optional := 0
+	for _, prop := range props {
+		name := uniqueName(fieldName(prop.Name), names)
+		field := javaField{
+			Name:       name,
+			JSONName:   prop.Name,
+			Type:       g.fieldType(decl, schema, prop, imports),
+			Getter:     getterName(name),
+			Required:   prop.Required,
+			Comment:    strings.Join(generator.DescriptionLines(prop.Description), " "),
+			Deprecated: prop.Deprecated,
+		}
+		if !prop.Required {
+			optional++
+		}
+		decl.Fields = append(decl.Fields, field)
+	}
+
+	switch genConfig.Builders {
+	case "always":
+		decl.Builder = len(decl.Fields) > 0
+	case "auto":
+		decl.Builder = optional >= genConfig.BuilderThreshold
+	}
+}
+
+// nestedName returns name for a type nested in decl, numbered if decl, a type
+// enclosing it or another nested type already has it, which javac rejects
+func (d *javaDecl) nestedName(name string) string {
+	taken := map[string]bool{d.Name: true}
+	for _, outer := range d.outer {
+		taken[outer] = true
+	}
+	for _, nested := range d.Types {
+		taken[nested.Name] = true
+	}
+	return uniqueName(name, taken)
+}
+
+// fieldType returns the Java type of a property, declaring its inline enum or
+// object as a type nested in decl
+func (g *JavaGenerator) fieldType(decl *javaDecl, schema string, prop generator.Property, imports map[string]bool) string {
+	switch t := prop.Type.(type) {
+	case generator.EnumType:
+		name := t.Name
+		if name == "" {
+			name = toPascalCase(prop.Name)
+		}
+		name = decl.nestedName(name)
+		imports["com.fasterxml.jackson.annotation.JsonCreator"] = true
+		imports["com.fasterxml.jackson.annotation.JsonValue"] = true
+		valueType := enumValueType(t.Values, t.UnderlyingType)
+		decl.Types = append(decl.Types, javaDecl{
+			Kind:      "enum",
+			Name:      name,
+			Nested:    true,
+			Constants: enumConstants(t.Values, valueType),
+			ValueType: valueType,
+		})
+		return name
+	case generator.ObjectType:
+		if t.RefName == "" && t.DTORef != nil {
+			name := decl.nestedName(toPascalCase(prop.Name))
+			nested := javaDecl{
+				Kind:    g.customTypes.GetGenerationConfig().Style,
+				Name:    name,
+				Nested:  true,
+				Comment: generator.DocComment("", t.DTORef.Description),
+				outer:   append(slices.Clip(decl.outer), decl.Name),
+			}
+			g.addFields(&nested, schema, t.DTORef.Properties, imports)
+			decl.Types = append(decl.Types, nested)
+			return name
+		}
+	}
+
+	javaType := g.toJavaType(prop.Type, imports)
+	if strings.Contains(javaType, "Object") {
+		switch prop.Type.(type) {
+		case generator.UnionType, generator.TupleType:
+			g.warnings.Add(generator.Warning{
+				Schema:   schema,
+				Property: prop.Name,
+				Code:     "untyped-java",
+				Message:  fmt.Sprintf("Java has no equivalent of %s and it is generated as %s", prop.Type.TypeName(), javaType),
+			})
+		}
+	}
+	return javaType
+}
+
+// toJavaType returns the Java type of an IRType, recording the imports it needs
+func (g *JavaGenerator) toJavaType(irType generator.IRType, imports map[string]bool) string {
+	switch t := irType.(type) {
+	case generator.PrimitiveType:
+		return g.primitiveToJava(t, imports)
+	case generator.ReferenceType:
+		return t.RefName
+	case generator.ObjectType:
+		if t.RefName != "" {
+			return t.RefName
+		}
+		imports["java.util.Map"] = true
+		return "Map<String, Object>"
+	case generator.EnumType:
+		return enumValueType(t.Values, t.UnderlyingType)
+	case generator.ArrayType:
+		element := g.toJavaType(t.ElementType, imports)
+		if t.Constraints != nil && t.Constraints.UniqueItems {
+			imports["java.util.Set"] = true
+			return fmt.Sprintf("Set<%s>", element)
+		}
+		imports["java.util.List"] = true
+		return fmt.Sprintf("List<%s>", element)
+	case generator.TupleType:
+		imports["java.util.List"] = true
+		return "List<Object>"
+	case generator.MapType:
+		imports["java.util.Map"] = true
+		return fmt.Sprintf("Map<String, %s>", g.toJavaType(t.ValueType, imports))
+	case generator.LiteralType:
+		switch t.Kind {
+		case "string":
+			return "String"
+		case "boolean":
+			return "Boolean"
+		case "number":
+			return "Double"
+		}
+		return "Object"
+	case generator.UnionType:
+		// A union with one non-null member is just that member
+		var members []generator.IRType
+		for _, member := range t.Types {
+			if literal, ok := member.(generator.LiteralType); ok && literal.Kind == "null" {
+				continue
+			}
+			members = append(members, member)
+		}
+		if len(members) == 1 {
+			return g.toJavaType(members[0], imports)
+		}
+		return "Object"
+	default:
+		return "Object"
+	}
+}
+
+// primitiveToJava maps a primitive, honoring format mappings first
+func (g *JavaGenerator) primitiveToJava(prim generator.PrimitiveType, imports map[string]bool) string {
+	if prim.Format != "" {
+		if mapping, exists := g.customTypes.Get(prim.Format); exists {
+			if mapping.Import != "" {
+				imports[mapping.Import] = true
+			}
+			return mapping.JavaType
+		}
+	}
+
+	switch prim.Name {
+	case "string":
+		return "String"
+	case "integer":
+		if prim.Format == "int64" {
+			return "Long"
+		}
+		return "Integer"
+	case "number":
+		if prim.Format == "float" {
+			return "Float"
+		}
+		return "Double"
+	case "bigint":
+		imports["java.math.BigInteger"] = true
+		return "BigInteger"
+	case "binary":
+		return "byte[]"
+	case "boolean":
+		return "Boolean"
+	default:
+		return "Object"
+	}
+}
+
+// docTags returns the Javadoc tags of a deprecated or current element
+func docTags(deprecated bool) []string {
+	if deprecated {
+		return []string{"@deprecated"}
+	}
+	return nil
+}
+
+// enumConstants names the constants of an enum after their values, e.g.
+// "in-progress" becomes IN_PROGRESS
+func enumConstants(values []string, valueType string) []javaConstant {
+	names := make(map[string]bool)
+	constants := make([]javaConstant, 0, len(values))
+	for _, value := range values {
+		name := constantName(value)
+		if valueType != "String" && strings.HasPrefix(value, "-") {
+			name = "VALUE_MINUS_" + strings.TrimPrefix(name, "VALUE_")
+		}
+		constants = append(constants, javaConstant{
+			Name:  uniqueName(name, names),
+			Value: value,
+			Type:  valueType,
+		})
+	}
+	return constants
+}
+
+// enumValueType returns the Java type of enum values: String, or for a
+// numeric enum Double, or Integer unless a value needs a Long
+func enumValueType(values []string, enumType string) string {
+	switch enumType {
+	case "number":
+		return "Double"
+	case "integer":
+		if !generator.Int32Values(values) {
+			return "Long"
+		}
+		return "Integer"
+	}
+	return "String"
+}
+
+// words splits a name into its alphanumeric words, also at case changes
+func words(s string) []string {
+	var result []string
+	var current []rune
+	runes := []rune(s)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(current) > 0 {
+				result = append(result, string(current))
+				current = nil
+			}
+			continue
+		}
+		if len(current) > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				result = append(result, string(current))
+				current = nil
+			}
+		}
+		current = append(current, r)
+	}
+	if len(current) > 0 {
+		result = append(result, string(current))
+	}
+	return result
+}
+
+// toPascalCase joins the words of a name, e.g. shipping_address becomes ShippingAddress
+func toPascalCase(s string) string {
+	var b strings.Builder
+	for _, word := range words(s) {
+		runes := []rune(word)
+		b.WriteRune(unicode.ToUpper(runes[0]))
+		b.WriteString(string(runes[1:]))
+	}
+	return b.String()
+}
+
+// fieldName returns a camelCase Java identifier for a JSON property name
+func fieldName(name string) string {
+	parts := words(name)
+	if len(parts) == 0 {
+		return "value"
+	}
+	field := strings.ToLower(parts[0]) + toPascalCase(strings.Join(parts[1:], " "))
+	if unicode.IsDigit([]rune(field)[0]) {
+		field = "_" + field
+	}
+	if javaKeywords[field] {
+		field += "_"
+	}
+	return field
+}
+
+// constantName returns an UPPER_SNAKE_CASE Java identifier for an enum value
+func constantName(value string) string {
+	parts := words(value)
+	if len(parts) == 0 {
+		return "EMPTY"
+	}
+	name := strings.ToUpper(strings.Join(parts, "_"))
+	if unicode.IsDigit([]rune(name)[0]) {
+		name = "VALUE_" + name
+	}
+	return name
+}
+
+// getterName returns the accessor of a class field, e.g. getCreatedAt
+func getterName(field string) string {
+	field = strings.TrimPrefix(field, "_")
+	return "get" + strings.ToUpper(field[:1]) + field[1:]
+}
+
+// uniqueName returns name, or name with a number appended if it is taken
+func uniqueName(name string, taken map[string]bool) string {
+	unique := name
+	for i := 2; taken[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	taken[unique] = true
+	return unique
+}
+
+// isIdentifier reports whether s is a valid Java identifier
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		if r == '_' || r == '$' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r)) {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// stringLiteral quotes s as a Java string literal
+func stringLiteral(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+	return `"` + replacer.Replace(s) + `"`
+}
+
+// indent prefixes every non-empty line of s
+func indent(s, prefix string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// sortedImports returns the imports with Jackson first, then the JDK
+func sortedImports(imports map[string]bool) []string {
+	var result []string
+	for imp := range imports {
+		result = append(result, imp)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		iJDK := strings.HasPrefix(result[i], "java.")
+		jJDK := strings.HasPrefix(result[j], "java.")
+		if iJDK != jJDK {
+			return !iJDK
+		}
+		return result[i] < result[j]
+	})
+	return result
+}
+
+// javaKeywords cannot be used as identifiers
+var javaKeywords = map[string]bool{
+	"abstract": true, "assert": true, "boolean": true, "break": true, "byte": true,
+	"case": true, "catch": true, "char": true, "class": true, "const": true,
+	"continue": true, "default": true, "do": true, "double": true, "else": true,
+	"enum": true, "extends": true, "final": true, "finally": true, "float": true,
+	"for": true, "goto": true, "if": true, "implements": true, "import": true,
+	"instanceof": true, "int": true, "interface": true, "long": true, "native": true,
+	"new": true, "package": true, "private": true, "protected": true, "public": true,
+	"return": true, "short": true, "static": true, "strictfp": true, "super": true,
+	"switch": true, "synchronized": true, "this": true, "throw": true, "throws": true,
+	"transient": true, "try": true, "void": true, "volatile": true, "while": true,
+	"true": true, "false": true, "null": true, "_": true,
+}
+
+// restrictedTypeNames are contextual keywords that cannot name a class
+var restrictedTypeNames = map[string]bool{
+	"var": true, "yield": true, "record": true, "sealed": true, "permits": true,
+}
+
+// isReservedTypeName reports whether name cannot be used as a class name
+func isReservedTypeName(name string) bool {
+	return javaKeywords[name] || restrictedTypeNames[name]
+}
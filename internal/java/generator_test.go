@@ -0,0 +1,290 @@
+package java
+
+import (
+	"path/filepath"
+	"testing"
+
+	"dtoForge/internal/generator"
+	"dtoForge/internal/testutils"
+)
+
+func TestJavaGenerator_Language(t *testing.T) {
+	gen := NewJavaGenerator()
+	if got := gen.Language(); got != "java" {
+		t.Errorf("Language() = %v, want %v", got, "java")
+	}
+	if got := gen.FileExtension(); got != ".java" {
+		t.Errorf("FileExtension() = %v, want %v", got, ".java")
+	}
+}
+
+func TestJavaGenerator_ToJavaType(t *testing.T) {
+	gen := NewJavaGenerator()
+	gen.customTypes = NewCustomTypeRegistry()
+
+	tests := []struct {
+		name     string
+		irType   generator.IRType
+		expected string
+		imports  []string
+	}{
+		{"String", generator.PrimitiveType{Name: "string"}, "String", nil},
+		{"Date-time", generator.PrimitiveType{Name: "string", Format: "date-time"}, "OffsetDateTime", []string{"java.time.OffsetDateTime"}},
+		{"UUID", generator.PrimitiveType{Name: "string", Format: "uuid"}, "UUID", []string{"java.util.UUID"}},
+		{"Integer", generator.PrimitiveType{Name: "integer"}, "Integer", nil},
+		{"Int64", generator.PrimitiveType{Name: "integer", Format: "int64"}, "Long", nil},
+		{"Number", generator.PrimitiveType{Name: "number"}, "Double", nil},
+		{"Float", generator.PrimitiveType{Name: "number", Format: "float"}, "Float", nil},
+		{"BigInt", generator.PrimitiveType{Name: "bigint", Format: "int64"}, "BigInteger", []string{"java.math.BigInteger"}},
+		{"Binary", generator.PrimitiveType{Name: "binary", Format: "byte"}, "byte[]", nil},
+		{"Boolean", generator.PrimitiveType{Name: "boolean"}, "Boolean", nil},
+		{"Unknown", generator.PrimitiveType{Name: "unknown"}, "Object", nil},
+		{"Reference", generator.ReferenceType{RefName: "Pet"}, "Pet", nil},
+		{"List", generator.ArrayType{ElementType: generator.ReferenceType{RefName: "Pet"}}, "List<Pet>", []string{"java.util.List"}},
+		{
+			"Set",
+			generator.ArrayType{ElementType: generator.PrimitiveType{Name: "string"}, Constraints: &generator.Constraints{UniqueItems: true}},
+			"Set<String>",
+			[]string{"java.util.Set"},
+		},
+		{
+			"Map",
+			generator.MapType{KeyType: generator.PrimitiveType{Name: "string"}, ValueType: generator.PrimitiveType{Name: "integer"}},
+			"Map<String, Integer>",
+			[]string{"java.util.Map"},
+		},
+		{
+			"Nullable reference",
+			generator.UnionType{Types: []generator.IRType{generator.ReferenceType{RefName: "Pet"}, generator.NewLiteralType(nil)}},
+			"Pet",
+			nil,
+		},
+		{
+			"Union",
+			generator.UnionType{Types: []generator.IRType{generator.PrimitiveType{Name: "string"}, generator.PrimitiveType{Name: "integer"}}},
+			"Object",
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			imports := make(map[string]bool)
+			if got := gen.toJavaType(tt.irType, imports); got != tt.expected {
+				t.Errorf("toJavaType() = %v, want %v", got, tt.expected)
+			}
+			for _, imp := range tt.imports {
+				if !imports[imp] {
+					t.Errorf("expected import %s, got %v", imp, imports)
+				}
+			}
+		})
+	}
+}
+
+func TestJavaGenerator_Names(t *testing.T) {
+	fields := map[string]string{
+		"id":           "id",
+		"created_at":   "createdAt",
+		"Content-Type": "contentType",
+		"class":        "class_",
+		"2fa":          "_2fa",
+		"HTTPStatus":   "httpStatus",
+	}
+	for name, expected := range fields {
+		if got := fieldName(name); got != expected {
+			t.Errorf("fieldName(%q) = %v, want %v", name, got, expected)
+		}
+	}
+
+	constants := map[string]string{
+		"active":      "ACTIVE",
+		"in-progress": "IN_PROGRESS",
+		"inProgress":  "IN_PROGRESS",
+		"1st":         "VALUE_1ST",
+		"":            "EMPTY",
+	}
+	for value, expected := range constants {
+		if got := constantName(value); got != expected {
+			t.Errorf("constantName(%q) = %v, want %v", value, got, expected)
+		}
+	}
+}
+
+func TestJavaGenerator_GenerateRecords(t *testing.T) {
+	tempDir := testutils.TempDir(t)
+
+	pet := testutils.CreateTestDTO("Pet")
+	pet.Properties = append(pet.Properties,
+		generator.Property{Name: "created_at", Type: generator.PrimitiveType{Name: "string", Format: "date-time"}},
+		generator.Property{Name: "status", Type: generator.EnumType{Name: "PetStatus", Values: []string{"available", "sold"}}},
+		generator.Property{Name: "tags", Type: generator.ArrayType{ElementType: generator.PrimitiveType{Name: "string"}}},
+	)
+	status := generator.DTO{Name: "Status", Type: "enum", EnumValues: []string{"active", "in-progress"}, Description: "Lifecycle state"}
+	small := generator.DTO{
+		Name:       "Tag",
+		Type:       "object",
+		Properties: []generator.Property{{Name: "label", Type: generator.PrimitiveType{Name: "string"}, Required: true}},
+	}
+
+	gen := NewJavaGenerator()
+	config := generator.Config{OutputFolder: tempDir, PackageName: "com.example.dto"}
+	if err := gen.Generate([]generator.DTO{pet, status, small}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	folder := filepath.Join(tempDir, "com", "example", "dto")
+	petFile := filepath.Join(folder, "Pet.java")
+	testutils.AssertFileContains(t, petFile, "package com.example.dto;")
+	testutils.AssertFileContains(t, petFile, "import java.time.OffsetDateTime;")
+	testutils.AssertFileContains(t, petFile, "import java.util.List;")
+	testutils.AssertFileContains(t, petFile, "public record Pet(")
+	testutils.AssertFileContains(t, petFile, `@JsonProperty(value = "id", required = true) String id,`)
+	testutils.AssertFileContains(t, petFile, `@JsonProperty("created_at") OffsetDateTime createdAt,`)
+	testutils.AssertFileContains(t, petFile, " * @param id Identifier")
+	testutils.AssertFileContains(t, petFile, "public enum PetStatus {")
+	testutils.AssertFileContains(t, petFile, `SOLD("sold");`)
+	// Four optional properties reach the default builder threshold
+	testutils.AssertFileContains(t, petFile, "public static Builder builder() {")
+	testutils.AssertFileContains(t, petFile, "return new Pet(id, name, createdAt, status, tags);")
+
+	statusFile := filepath.Join(folder, "Status.java")
+	testutils.AssertFileContains(t, statusFile, "public enum Status {")
+	testutils.AssertFileContains(t, statusFile, `IN_PROGRESS("in-progress");`)
+	testutils.AssertFileContains(t, statusFile, "@JsonValue")
+
+	tagFile := filepath.Join(folder, "Tag.java")
+	testutils.AssertFileContains(t, tagFile, "public record Tag(")
+	testutils.AssertFileNotContains(t, tagFile, "Builder")
+}
+
+func TestJavaGenerator_GenerateClasses(t *testing.T) {
+	tempDir := testutils.TempDir(t)
+	configPath := testutils.WriteFile(t, tempDir, "dtoforge.config.yaml", `java:
+  generation:
+    package: org.acme.model
+    style: class
+    builders: always
+`)
+
+	pet := testutils.CreateTestDTO("Pet")
+	pet.Properties[1].Deprecated = true
+	shape := generator.DTO{
+		Name:         "Shape",
+		Type:         "union",
+		UnionMembers: []generator.IRType{generator.ReferenceType{RefName: "Pet"}, generator.PrimitiveType{Name: "string"}},
+	}
+
+	gen := NewJavaGenerator()
+	config := generator.Config{OutputFolder: tempDir, ConfigFile: configPath}
+	if err := gen.Generate([]generator.DTO{pet, shape}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	folder := filepath.Join(tempDir, "org", "acme", "model")
+	petFile := filepath.Join(folder, "Pet.java")
+	testutils.AssertFileContains(t, petFile, "public final class Pet {")
+	testutils.AssertFileContains(t, petFile, "    private final String id;")
+	testutils.AssertFileContains(t, petFile, "    @JsonCreator\n    public Pet(")
+	testutils.AssertFileContains(t, petFile, "    @Deprecated\n    @JsonProperty(\"name\")\n    public String getName() {")
+	testutils.AssertFileContains(t, petFile, "Objects.deepEquals(id, that.id)")
+	testutils.AssertFileContains(t, petFile, "public static Builder builder() {")
+
+	shapeFile := filepath.Join(folder, "Shape.java")
+	testutils.AssertFileContains(t, shapeFile, "public final class Shape {")
+	testutils.AssertFileContains(t, shapeFile, "public static Shape of(Object value) {")
+}
+
+func TestJavaGenerator_InvalidPackage(t *testing.T) {
+	gen := NewJavaGenerator()
+	config := generator.Config{OutputFolder: testutils.TempDir(t), PackageName: "com.example.class"}
+	if err := gen.Generate([]generator.DTO{testutils.CreateTestDTO("Pet")}, config); err == nil {
+		t.Error("expected an error for a package name containing a keyword")
+	}
+}
+
+func TestJavaGenerator_WarnsUntypedProperties(t *testing.T) {
+	dto := testutils.CreateTestDTO("Pet")
+	dto.Properties = append(dto.Properties, generator.Property{
+		Name: "value",
+		Type: generator.UnionType{Types: []generator.IRType{generator.PrimitiveType{Name: "string"}, generator.PrimitiveType{Name: "integer"}}},
+	})
+
+	warnings := &generator.WarningCollector{}
+	gen := NewJavaGenerator()
+	config := generator.Config{OutputFolder: testutils.TempDir(t), Warnings: warnings}
+	if err := gen.Generate([]generator.DTO{dto}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	got := warnings.Warnings()
+	if len(got) != 1 || got[0].Code != "untyped-java" || got[0].Property != "value" {
+		t.Errorf("warnings = %v, want one untyped-java warning for Pet.value", got)
+	}
+}
+
+func TestJavaGenerator_NestedTypeNames(t *testing.T) {
+	// A nested type named like a type enclosing it does not compile
+	street := generator.DTO{Name: "Street", Type: "object", Properties: []generator.Property{
+		{Name: "address", Type: generator.EnumType{Values: []string{"main", "side"}}},
+	}}
+	address := generator.DTO{Name: "Address", Type: "object", Properties: []generator.Property{
+		{Name: "address", Type: generator.ObjectType{DTORef: &street}},
+	}}
+
+	tempDir := testutils.TempDir(t)
+	config := generator.Config{OutputFolder: tempDir, PackageName: "com.example.dto"}
+	if err := NewJavaGenerator().Generate([]generator.DTO{address}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	addressFile := filepath.Join(tempDir, "com", "example", "dto", "Address.java")
+	testutils.AssertFileContains(t, addressFile, "public record Address(")
+	testutils.AssertFileContains(t, addressFile, "public record Address2(")
+	testutils.AssertFileContains(t, addressFile, "public enum Address3 {")
+	testutils.AssertFileContains(t, addressFile, `@JsonProperty("address") Address2 address`)
+}
+
+func TestJavaGenerator_ReservedNames(t *testing.T) {
+	holder := testutils.CreateTestDTO("Holder")
+	holder.Properties = append(holder.Properties, generator.Property{Name: "entry", Type: generator.ReferenceType{RefName: "record"}})
+	dtos := []generator.DTO{holder, testutils.CreateTestDTO("record"), testutils.CreateTestDTO("number")}
+
+	tempDir := testutils.TempDir(t)
+	warnings := &generator.WarningCollector{}
+	config := generator.Config{OutputFolder: tempDir, PackageName: "com.example.dto", Warnings: warnings}
+	if err := NewJavaGenerator().Generate(dtos, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	folder := filepath.Join(tempDir, "com", "example", "dto")
+	testutils.AssertFileContains(t, filepath.Join(folder, "record_.java"), "public record record_(")
+	testutils.AssertFileContains(t, filepath.Join(folder, "Holder.java"), `@JsonProperty("entry") record_ entry`)
+	// number is only reserved in TypeScript
+	testutils.AssertFileContains(t, filepath.Join(folder, "number.java"), "public record number(")
+	if got := warnings.Warnings(); len(got) != 1 || got[0].Code != "reserved-name" || got[0].Schema != "record" {
+		t.Errorf("warnings = %v, want one reserved-name warning for record", got)
+	}
+}
+
+func TestJavaGenerator_NumericEnums(t *testing.T) {
+	priority := generator.DTO{Name: "Priority", Type: "enum", EnumValues: []string{"1", "2", "-3"}, EnumValueType: "integer"}
+	task := generator.DTO{Name: "Task", Type: "object", Properties: []generator.Property{
+		{Name: "priority", Type: generator.ReferenceType{RefName: "Priority"}, Required: true},
+		{Name: "weight", Type: generator.EnumType{Values: []string{"0.5", "1.5"}, UnderlyingType: "number"}, Required: true},
+	}}
+	tempDir := testutils.TempDir(t)
+	config := generator.Config{OutputFolder: tempDir, PackageName: "com.example.dto"}
+	if err := NewJavaGenerator().Generate([]generator.DTO{priority, task}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	folder := filepath.Join(tempDir, "com", "example", "dto")
+	priorityFile := filepath.Join(folder, "Priority.java")
+	testutils.AssertFileContains(t, priorityFile, "    VALUE_1(1),\n    VALUE_2(2),\n    VALUE_MINUS_3(-3);")
+	testutils.AssertFileContains(t, priorityFile, "    private final Integer value;")
+	testutils.AssertFileContains(t, priorityFile, "public static Priority fromValue(Integer value) {")
+	taskFile := filepath.Join(folder, "Task.java")
+	testutils.AssertFileContains(t, taskFile, "VALUE_0_5(0.5d),")
+	testutils.AssertFileContains(t, taskFile, "private final Double value;")
+}
@@ -0,0 +1,153 @@
+package java
+
+const fileTemplate = `// Generated by DtoForge - DO NOT EDIT
+package {{.Package}};
+{{if .Imports}}
+{{range .Imports}}import {{.}};
+{{end}}{{end}}
+{{template "decl" .Decl}}`
+
+// declTemplates render a javaDecl; nested declarations are rendered through
+// the nested func, which indents them inside their parent
+const declTemplates = `
+{{- define "decl"}}{{.Comment}}{{if .Deprecated}}@Deprecated
+{{end}}{{if eq .Kind "enum"}}{{template "enum" .}}{{else if eq .Kind "union"}}{{template "union" .}}{{else if eq .Kind "class"}}{{template "class" .}}{{else}}{{template "record" .}}{{end}}{{end}}
+
+{{- define "record"}}{{if .Fields}}@JsonInclude(JsonInclude.Include.NON_NULL)
+{{end}}public record {{.Name}}({{range $i, $f := .Fields}}{{if $i}},{{end}}
+    {{if $f.Deprecated}}@Deprecated {{end}}{{$f.Annotation}} {{$f.Type}} {{$f.Name}}{{end}}{{if .Fields}}
+{{end}}) {
+{{- if .Builder}}
+{{template "builder" .}}{{end}}
+{{- range .Types}}
+{{nested .}}{{end}}}
+{{end}}
+
+{{- define "class"}}{{if .Fields}}@JsonInclude(JsonInclude.Include.NON_NULL)
+{{end}}public {{if .Nested}}static {{end}}final class {{.Name}} {
+{{- range .Fields}}
+{{with .Comment}}    /** {{.}} */
+{{end}}    private final {{.Type}} {{.Name}};
+{{- end}}
+
+    @JsonCreator
+    public {{.Name}}({{range $i, $f := .Fields}}{{if $i}},{{end}}
+            {{$f.Annotation}} {{$f.Type}} {{$f.Name}}{{end}}) {
+{{- range .Fields}}
+        this.{{.Name}} = {{.Name}};
+{{- end}}
+    }
+{{range .Fields}}
+{{if .Deprecated}}    @Deprecated
+{{end}}    @JsonProperty({{quote .JSONName}})
+    public {{.Type}} {{.Getter}}() {
+        return {{.Name}};
+    }
+{{end}}
+    @Override
+    public boolean equals(Object o) {
+        if (this == o) {
+            return true;
+        }
+        if (!(o instanceof {{.Name}})) {
+            return false;
+        }
+{{- if .Fields}}
+        {{.Name}} that = ({{.Name}}) o;
+        return {{range $i, $f := .Fields}}{{if $i}}
+                && {{end}}Objects.deepEquals({{$f.Name}}, that.{{$f.Name}}){{end}};
+{{- else}}
+        return true;
+{{- end}}
+    }
+
+    @Override
+    public int hashCode() {
+        return Arrays.deepHashCode(new Object[] {{"{"}}{{args .Fields}}{{"}"}});
+    }
+{{- if .Builder}}
+
+{{template "builder" .}}{{end}}
+{{- range .Types}}
+{{nested .}}{{end}}}
+{{end}}
+
+{{- define "builder"}}    public static Builder builder() {
+        return new Builder();
+    }
+
+    public static final class Builder {
+{{- range .Fields}}
+        private {{.Type}} {{.Name}};
+{{- end}}
+
+        private Builder() {
+        }
+{{range .Fields}}
+        public Builder {{.Name}}({{.Type}} {{.Name}}) {
+            this.{{.Name}} = {{.Name}};
+            return this;
+        }
+{{end}}
+        public {{.Name}} build() {
+            return new {{.Name}}({{args .Fields}});
+        }
+    }
+{{end}}
+
+{{- define "enum"}}public enum {{.Name}} {
+{{- range $i, $c := .Constants}}{{if $i}},{{end}}
+    {{$c.Name}}({{$c.Literal}}){{end}};
+
+    private final {{.ValueType}} value;
+
+    {{.Name}}({{.ValueType}} value) {
+        this.value = value;
+    }
+
+    @JsonValue
+    public {{.ValueType}} getValue() {
+        return value;
+    }
+
+    @JsonCreator
+    public static {{.Name}} fromValue({{.ValueType}} value) {
+        for ({{.Name}} constant : values()) {
+            if (constant.value.equals(value)) {
+                return constant;
+            }
+        }
+        throw new IllegalArgumentException("Unknown {{.Name}} value: " + value);
+    }
+}
+{{end}}
+
+{{- define "union"}}public final class {{.Name}} {
+    private final Object value;
+
+    private {{.Name}}(Object value) {
+        this.value = value;
+    }
+
+    @JsonCreator
+    public static {{.Name}} of(Object value) {
+        return new {{.Name}}(value);
+    }
+
+    /** The value as parsed by Jackson: a Map, List, String, Number, Boolean or null */
+    @JsonValue
+    public Object getValue() {
+        return value;
+    }
+
+    @Override
+    public boolean equals(Object o) {
+        return o instanceof {{.Name}} && Objects.deepEquals(value, (({{.Name}}) o).value);
+    }
+
+    @Override
+    public int hashCode() {
+        return Objects.hashCode(value);
+    }
+}
+{{end}}`
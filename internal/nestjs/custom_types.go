@@ -0,0 +1,132 @@
+package nestjs
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GenerationConfig defines what to generate
+type GenerationConfig struct {
+	GeneratePackageJson *bool  `yaml:"generatePackageJson"` // default true
+	ClassSuffix         string `yaml:"classSuffix"`         // appended to class names, e.g. "Dto"
+}
+
+// CustomTypeMapping defines how to map an OpenAPI format to a TypeScript
+// type and the decorators validating it. Decorators are written as calls,
+// e.g. "IsPhoneNumber('US')", and imported from class-validator unless an
+// import is given.
+type CustomTypeMapping struct {
+	TypeScriptType string   `yaml:"typeScriptType"`
+	Decorators     []string `yaml:"decorators"`
+	Import         string   `yaml:"import"`
+}
+
+// NestJSConfig represents the typescript-nestjs section in YAML configuration
+type NestJSConfig struct {
+	CustomTypes map[string]CustomTypeMapping `yaml:"customTypes"`
+	Generation  GenerationConfig             `yaml:"generation"`
+}
+
+// FullConfig represents the complete YAML configuration structure
+type FullConfig struct {
+	NestJS NestJSConfig `yaml:"typescript-nestjs"`
+}
+
+// CustomTypeRegistry holds all format mappings and config for NestJS
+type CustomTypeRegistry struct {
+	mappings   map[string]CustomTypeMapping
+	generation GenerationConfig
+}
+
+// NewCustomTypeRegistry creates a new registry with default mappings and config
+func NewCustomTypeRegistry() *CustomTypeRegistry {
+	generatePackageJson := true
+	registry := &CustomTypeRegistry{
+		mappings: make(map[string]CustomTypeMapping),
+		generation: GenerationConfig{
+			GeneratePackageJson: &generatePackageJson,
+		},
+	}
+
+	registry.addDefaultMappings()
+	return registry
+}
+
+// GetGenerationConfig returns the generation configuration
+func (r *CustomTypeRegistry) GetGenerationConfig() GenerationConfig {
+	return r.generation
+}
+
+// GeneratesPackageJson returns true if a package.json should be written
+func (r *CustomTypeRegistry) GeneratesPackageJson() bool {
+	return r.generation.GeneratePackageJson == nil || *r.generation.GeneratePackageJson
+}
+
+// addDefaultMappings adds the built-in format mappings for NestJS
+func (r *CustomTypeRegistry) addDefaultMappings() {
+	r.mappings["date-time"] = CustomTypeMapping{TypeScriptType: "string", Decorators: []string{"IsISO8601()"}}
+	r.mappings["date"] = CustomTypeMapping{TypeScriptType: "string", Decorators: []string{"IsISO8601()"}}
+	r.mappings["uuid"] = CustomTypeMapping{TypeScriptType: "string", Decorators: []string{"IsUUID()"}}
+	r.mappings["email"] = CustomTypeMapping{TypeScriptType: "string", Decorators: []string{"IsEmail()"}}
+	r.mappings["uri"] = CustomTypeMapping{TypeScriptType: "string", Decorators: []string{"IsUrl()"}}
+	r.mappings["url"] = CustomTypeMapping{TypeScriptType: "string", Decorators: []string{"IsUrl()"}}
+	r.mappings["byte"] = CustomTypeMapping{TypeScriptType: "string", Decorators: []string{"IsBase64()"}}
+	// Uploads are handled by interceptors, not by class-validator
+	r.mappings["binary"] = CustomTypeMapping{TypeScriptType: "Buffer"}
+}
+
+// Register adds or updates a custom type mapping
+func (r *CustomTypeRegistry) Register(format string, mapping CustomTypeMapping) {
+	r.mappings[format] = mapping
+}
+
+// Get retrieves a mapping for a given format
+func (r *CustomTypeRegistry) Get(format string) (CustomTypeMapping, bool) {
+	mapping, exists := r.mappings[format]
+	return mapping, exists
+}
+
+// LoadFromConfig loads custom mappings from a YAML configuration file
+func (r *CustomTypeRegistry) LoadFromConfig(configPath string) error {
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		return nil // Config file is optional
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", configPath, err)
+	}
+
+	var config FullConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", configPath, err)
+	}
+
+	nestConfig := config.NestJS
+
+	if nestConfig.Generation.GeneratePackageJson != nil {
+		r.generation.GeneratePackageJson = nestConfig.Generation.GeneratePackageJson
+	}
+	if nestConfig.Generation.ClassSuffix != "" {
+		if !isIdentifier(nestConfig.Generation.ClassSuffix) {
+			return fmt.Errorf("invalid classSuffix '%s', must be an identifier", nestConfig.Generation.ClassSuffix)
+		}
+		r.generation.ClassSuffix = nestConfig.Generation.ClassSuffix
+	}
+
+	for format, mapping := range nestConfig.CustomTypes {
+		if mapping.TypeScriptType == "" {
+			return fmt.Errorf("custom type '%s' must have typeScriptType", format)
+		}
+		for _, decorator := range mapping.Decorators {
+			if _, err := parseDecorator(decorator); err != nil {
+				return fmt.Errorf("custom type '%s': %w", format, err)
+			}
+		}
+		r.Register(format, mapping)
+	}
+
+	return nil
+}
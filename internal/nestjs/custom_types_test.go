@@ -0,0 +1,49 @@
+package nestjs
+
+import (
+	"testing"
+
+	"dtoForge/internal/testutils"
+)
+
+func TestCustomTypeRegistry_LoadFromConfig(t *testing.T) {
+	configPath := testutils.WriteFile(t, testutils.TempDir(t), "dtoforge.config.yaml", `typescript-nestjs:
+  customTypes:
+    date-time:
+      typeScriptType: Date
+      decorators: ["IsDate()"]
+  generation:
+    classSuffix: Dto
+`)
+
+	registry := NewCustomTypeRegistry()
+	if err := registry.LoadFromConfig(configPath); err != nil {
+		t.Fatalf("LoadFromConfig() error = %v", err)
+	}
+
+	if mapping, exists := registry.Get("date-time"); !exists || mapping.TypeScriptType != "Date" || len(mapping.Decorators) != 1 {
+		t.Errorf("date-time mapping = %+v, exists = %v", mapping, exists)
+	}
+	if mapping, exists := registry.Get("email"); !exists || mapping.Decorators[0] != "IsEmail()" {
+		t.Errorf("email mapping = %+v, exists = %v", mapping, exists)
+	}
+	if genConfig := registry.GetGenerationConfig(); genConfig.ClassSuffix != "Dto" || !registry.GeneratesPackageJson() {
+		t.Errorf("generation config = %+v", genConfig)
+	}
+}
+
+func TestCustomTypeRegistry_LoadFromConfig_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"classSuffix":    "typescript-nestjs:\n  generation:\n    classSuffix: \"-dto\"\n",
+		"typeScriptType": "typescript-nestjs:\n  customTypes:\n    phone:\n      decorators: [\"IsPhoneNumber()\"]\n",
+		"decorator":      "typescript-nestjs:\n  customTypes:\n    phone:\n      typeScriptType: string\n      decorators: [IsPhoneNumber]\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			configPath := testutils.WriteFile(t, testutils.TempDir(t), "dtoforge.config.yaml", content)
+			if err := NewCustomTypeRegistry().LoadFromConfig(configPath); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,695 @@
+package nestjs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"dtoForge/internal/generator"
+)
+
+// NestJSGenerator implements the Generator interface for NestJS DTO classes
+// validated with class-validator and transformed with class-transformer
+type NestJSGenerator struct {
+	customTypes *CustomTypeRegistry
+	dtos        map[string]generator.DTO
+	warnings    *generator.WarningCollector
+}
+
+// NewNestJSGenerator creates a new NestJS generator
+func NewNestJSGenerator() *NestJSGenerator {
+	return &NestJSGenerator{}
+}
+
+// Language returns the target language name
+func (g *NestJSGenerator) Language() string {
+	return "typescript-nestjs"
+}
+
+// FileExtension returns the file extension for generated files
+func (g *NestJSGenerator) FileExtension() string {
+	return ".ts"
+}
+
+// decorator is a decorator call. Validation options go at argument Options,
+// where { each: true } is passed to validate the elements of an array.
+type decorator struct {
+	Name    string
+	Args    []string
+	Options int
+	Module  string // import module; "" for a custom import statement
+	Import  string // custom import statement
+	Refs    []ref  // symbols of other DTO files used in the arguments
+	NoEach  bool   // applies to the property as is, even for elements
+}
+
+// ref is a symbol exported by the file of a DTO
+type ref struct {
+	DTO    string
+	Symbol string
+}
+
+// String renders the decorator
+func (d decorator) String() string {
+	return fmt.Sprintf("@%s(%s)", d.Name, strings.Join(d.Args, ", "))
+}
+
+// each returns the decorator validating each element instead
+func (d decorator) each() decorator {
+	if d.NoEach {
+		return d
+	}
+	args := append([]string(nil), d.Args...)
+	for len(args) < d.Options {
+		args = append(args, "undefined")
+	}
+	d.Args = append(args, "{ each: true }")
+	return d
+}
+
+// optionsIndex is the position of the validation options of class-validator
+// decorators whose other arguments are optional
+var optionsIndex = map[string]int{
+	"IsNumber":       1,
+	"IsNumberString": 1,
+	"IsUUID":         1,
+	"IsEmail":        1,
+	"IsUrl":          1,
+	"IsISO8601":      1,
+	"IsDateString":   1,
+	"IsBase64":       1,
+	"IsPhoneNumber":  1,
+}
+
+// validator returns a class-validator decorator taking args followed by its
+// validation options
+func validator(name string, args ...string) decorator {
+	return decorator{Name: name, Args: args, Options: max(len(args), optionsIndex[name]), Module: "class-validator"}
+}
+
+// parseDecorator parses a configured decorator call, e.g. IsPhoneNumber('US')
+func parseDecorator(call string) (decorator, error) {
+	call = strings.TrimPrefix(strings.TrimSpace(call), "@")
+	open := strings.Index(call, "(")
+	if open < 0 || !strings.HasSuffix(call, ")") || !isIdentifier(call[:open]) {
+		return decorator{}, fmt.Errorf("invalid decorator '%s', must be a call such as IsPhoneNumber('US')", call)
+	}
+	var args []string
+	if inner := strings.TrimSpace(call[open+1 : len(call)-1]); inner != "" {
+		args = []string{inner}
+	}
+	return validator(call[:open], args...), nil
+}
+
+// nestClass is a class to render
+type nestClass struct {
+	Name       string
+	Extends    string
+	Comment    string
+	Properties []nestProperty
+}
+
+// nestProperty is a decorated class property
+type nestProperty struct {
+	Name       string
+	Type       string
+	Optional   bool
+	Default    string
+	Decorators []string
+	Comment    string
+}
+
+// Declaration renders the property, e.g. name?: string or id!: string
+func (p nestProperty) Declaration() string {
+	switch {
+	case p.Default != "" && p.Optional:
+		return fmt.Sprintf("%s?: %s = %s;", p.Name, p.Type, p.Default)
+	case p.Default != "":
+		return fmt.Sprintf("%s: %s = %s;", p.Name, p.Type, p.Default)
+	case p.Optional:
+		return fmt.Sprintf("%s?: %s;", p.Name, p.Type)
+	default:
+		return fmt.Sprintf("%s!: %s;", p.Name, p.Type)
+	}
+}
+
+// dtoFile collects what one DTO file declares and imports
+type dtoFile struct {
+	gen       *NestJSGenerator
+	dto       string
+	libraries map[string]map[string]bool // module -> imported names
+	imports   map[string]bool            // custom import statements
+	refs      map[string]map[string]bool // DTO -> imported symbols
+}
+
+func (g *NestJSGenerator) newFile(dto string) *dtoFile {
+	return &dtoFile{
+		gen:       g,
+		dto:       dto,
+		libraries: make(map[string]map[string]bool),
+		imports:   make(map[string]bool),
+		refs:      make(map[string]map[string]bool),
+	}
+}
+
+// use records a symbol of another DTO file
+func (f *dtoFile) use(dto, symbol string) {
+	if dto == f.dto {
+		return
+	}
+	if f.refs[dto] == nil {
+		f.refs[dto] = make(map[string]bool)
+	}
+	f.refs[dto][symbol] = true
+}
+
+// apply records the imports of a decorator and renders it
+func (f *dtoFile) apply(d decorator) string {
+	if d.Import != "" {
+		f.imports[d.Import] = true
+	} else {
+		if f.libraries[d.Module] == nil {
+			f.libraries[d.Module] = make(map[string]bool)
+		}
+		f.libraries[d.Module][d.Name] = true
+	}
+	for _, r := range d.Refs {
+		f.use(r.DTO, r.Symbol)
+	}
+	return d.String()
+}
+
+// Imports returns the import statements of the file: libraries, custom
+// imports, then other DTO files
+func (f *dtoFile) Imports() []string {
+	var imports []string
+	for _, module := range sortedKeys(f.libraries) {
+		imports = append(imports, fmt.Sprintf("import { %s } from '%s';", strings.Join(sortedKeys(f.libraries[module]), ", "), module))
+	}
+	imports = append(imports, sortedKeys(f.imports)...)
+	for _, dto := range sortedKeys(f.refs) {
+		imports = append(imports, fmt.Sprintf("import { %s } from './%s';", strings.Join(sortedKeys(f.refs[dto]), ", "), moduleName(dto)))
+	}
+	return imports
+}
+
+// Generate creates a file per DTO, an index file and a package.json
+func (g *NestJSGenerator) Generate(dtos []generator.DTO, config generator.Config) error {
+	// Initialize custom type registry
+	g.customTypes = NewCustomTypeRegistry()
+
+	// Load custom config if specified
+	if config.ConfigFile != "" {
+		if err := g.customTypes.LoadFromConfig(config.ConfigFile); err != nil {
+			return &generator.ConfigError{Path: config.ConfigFile, Err: err}
+		}
+	}
+
+	// Schemas named after reserved TypeScript words get a new name
+	dtos, _ = generator.RenameReserved(dtos, generator.IsReservedWord, "TypeScript", config)
+
+	g.warnings = config.Warnings
+	g.dtos = make(map[string]generator.DTO, len(dtos))
+	for _, dto := range dtos {
+		g.dtos[dto.Name] = dto
+	}
+
+	sortedDTOs := generator.SortByDependency(dtos)
+
+	if err := os.MkdirAll(config.OutputFolder, 0755); err != nil {
+		return err
+	}
+
+	tmpl, err := template.New("nestjs").Funcs(template.FuncMap{"moduleName": moduleName}).Parse(templates)
+	if err != nil {
+		return err
+	}
+
+	for _, dto := range sortedDTOs {
+		if config.KeepFile(dto.Name, filepath.Join(config.OutputFolder, moduleName(dto.Name)+g.FileExtension())) {
+			continue
+		}
+		if err := g.generateDTOFile(tmpl, dto, config); err != nil {
+			return fmt.Errorf("failed to generate file for DTO %s: %w", dto.Name, err)
+		}
+	}
+
+	if err := g.writeFile(tmpl, "index", filepath.Join(config.OutputFolder, "index.ts"), sortedDTOs, config); err != nil {
+		return fmt.Errorf("failed to generate index file: %w", err)
+	}
+
+	if g.customTypes.GeneratesPackageJson() {
+		packagePath := filepath.Join(config.OutputFolder, "package.json")
+		// Don't overwrite existing package.json
+		if _, err := os.Stat(packagePath); os.IsNotExist(err) {
+			if err := g.writeFile(tmpl, "package", packagePath, g.packageName(config), config); err != nil {
+				return fmt.Errorf("failed to generate package.json: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// generateDTOFile writes the class, enum values or union type of a DTO
+func (g *NestJSGenerator) generateDTOFile(tmpl *template.Template, dto generator.DTO, config generator.Config) error {
+	file := g.newFile(dto.Name)
+	data := struct {
+		File    *dtoFile
+		DTO     generator.DTO
+		Comment string
+		Class   nestClass
+		Values  []string
+		Union   string
+	}{
+		File:    file,
+		DTO:     dto,
+		Comment: generator.DocComment("", dto.Description, docTags(dto.Deprecated)...),
+	}
+
+	switch dto.Type {
+	case "enum":
+		data.Values = enumLiterals(dto.EnumValues, dto.NumericEnum())
+	case "union":
+		var members []string
+		for _, member := range dto.UnionMembers {
+			ts, _ := file.typeOf(dto.Name, "", member, false)
+			members = append(members, ts)
+		}
+		data.Union = strings.Join(members, " | ")
+	default:
+		data.Class = file.class(dto)
+	}
+
+	return g.writeFile(tmpl, "dto", filepath.Join(config.OutputFolder, moduleName(dto.Name)+g.FileExtension()), data, config)
+}
+
+// writeFile renders a named template into a file
+func (g *NestJSGenerator) writeFile(tmpl *template.Template, name, path string, data any, config generator.Config) error {
+	file, err := config.CreateFile(path, nil)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return tmpl.ExecuteTemplate(file, name, data)
+}
+
+// class builds the class of an object DTO. A single object base is
+// extended; properties of several bases are copied in, since classes
+// cannot extend more than one class.
+func (f *dtoFile) class(dto generator.DTO) nestClass {
+	class := nestClass{
+		Name:    f.gen.className(dto.Name),
+		Comment: generator.DocComment("", dto.Description, docTags(dto.Deprecated)...),
+	}
+
+	props := dto.Properties
+	if len(dto.Extends) == 1 && f.gen.isClass(dto.Extends[0]) {
+		class.Extends = f.gen.className(dto.Extends[0])
+		f.use(dto.Extends[0], class.Extends)
+	} else if len(dto.Extends) > 0 {
+		props = f.gen.inheritedProperties(dto, make(map[string]bool))
+	}
+
+	for _, prop := range props {
+		class.Properties = append(class.Properties, f.property(dto.Name, prop))
+	}
+	return class
+}
+
+// property builds a decorated property
+func (f *dtoFile) property(dtoName string, prop generator.Property) nestProperty {
+	irType, nullable := nonNull(prop.Type)
+	nullable = nullable || prop.Nullable
+
+	tsType, decorators := f.typeOf(dtoName, prop.Name, irType, false)
+	if nullable {
+		tsType += " | null"
+	}
+
+	property := nestProperty{
+		Name:     propertyName(prop.Name),
+		Type:     tsType,
+		Optional: !prop.Required,
+		Comment:  generator.DocComment("  ", prop.Description, docTags(prop.Deprecated)...),
+	}
+	if prop.Default != nil {
+		property.Default = generator.ValueLiteral(prop.Default)
+	}
+
+	// IsOptional skips validation of null and undefined, ValidateIf of null only
+	var leading []decorator
+	switch {
+	case !prop.Required:
+		leading = append(leading, validator("IsOptional"))
+	case nullable:
+		leading = append(leading, decorator{Name: "ValidateIf", Args: []string{"(_, value) => value !== null"}, Module: "class-validator"})
+	}
+	if len(decorators) == 0 {
+		// Properties without decorators would be stripped by the whitelist option
+		if prop.Required {
+			leading = append(leading, validator("IsDefined"))
+		} else {
+			leading = append(leading, validator("Allow"))
+		}
+	}
+
+	for _, d := range append(leading, decorators...) {
+		property.Decorators = append(property.Decorators, f.apply(d))
+	}
+	return property
+}
+
+// typeOf returns the TypeScript type of an IRType and the decorators
+// validating it, applied to each element if each is set
+func (f *dtoFile) typeOf(dtoName, propName string, irType generator.IRType, each bool) (string, []decorator) {
+	tsType, decorators := f.baseType(dtoName, propName, irType, each)
+	if each {
+		for i, d := range decorators {
+			decorators[i] = d.each()
+		}
+	}
+	return tsType, decorators
+}
+
+func (f *dtoFile) baseType(dtoName, propName string, irType generator.IRType, each bool) (string, []decorator) {
+	switch t := irType.(type) {
+	case generator.PrimitiveType:
+		return f.primitive(t)
+	case generator.ReferenceType:
+		return f.reference(dtoName, propName, t.RefName)
+	case generator.ObjectType:
+		if t.RefName != "" {
+			return f.reference(dtoName, propName, t.RefName)
+		}
+		return "Record<string, unknown>", []decorator{validator("IsObject")}
+	case generator.EnumType:
+		values := enumLiterals(t.Values, t.Numeric())
+		return strings.Join(values, " | "), []decorator{validator("IsIn", "["+strings.Join(values, ", ")+"]")}
+	case generator.LiteralType:
+		return t.Literal(), []decorator{validator("Equals", t.Literal())}
+	case generator.ArrayType:
+		element, elementDecorators := f.typeOf(dtoName, propName, t.ElementType, true)
+		if strings.ContainsAny(element, "| ") {
+			element = "(" + element + ")"
+		}
+		decorators := []decorator{validator("IsArray")}
+		if each {
+			// Only one level of elements can be validated
+			return element + "[]", decorators
+		}
+		return element + "[]", append(append(decorators, arrayConstraints(t.Constraints)...), elementDecorators...)
+	case generator.TupleType:
+		elements := make([]string, len(t.ElementTypes))
+		for i, element := range t.ElementTypes {
+			elements[i], _ = f.typeOf(dtoName, propName, element, false)
+		}
+		if t.Rest != nil {
+			rest, _ := f.typeOf(dtoName, propName, t.Rest, false)
+			if strings.ContainsAny(rest, "| ") {
+				rest = "(" + rest + ")"
+			}
+			elements = append(elements, "..."+rest+"[]")
+		}
+		return "[" + strings.Join(elements, ", ") + "]", []decorator{validator("IsArray")}
+	case generator.MapType:
+		value, _ := f.typeOf(dtoName, propName, t.ValueType, false)
+		return fmt.Sprintf("Record<string, %s>", value), []decorator{validator("IsObject")}
+	case generator.UnionType:
+		members := make([]string, len(t.Types))
+		for i, member := range t.Types {
+			members[i], _ = f.typeOf(dtoName, propName, member, false)
+		}
+		f.gen.warnUnvalidated(dtoName, propName, t)
+		return strings.Join(members, " | "), nil
+	default:
+		return "unknown", nil
+	}
+}
+
+// enumLiterals returns enum values as TypeScript literals. The values of a
+// numeric enum already are number literals.
+func enumLiterals(values []string, numeric bool) []string {
+	literals := make([]string, len(values))
+	for i, value := range values {
+		if numeric {
+			literals[i] = value
+		} else {
+			literals[i] = generator.NewLiteralType(value).Literal()
+		}
+	}
+	return literals
+}
+
+// primitive maps a primitive, honoring format mappings first
+func (f *dtoFile) primitive(prim generator.PrimitiveType) (string, []decorator) {
+	var tsType string
+	var decorators []decorator
+
+	if mapping, exists := f.gen.customTypes.Get(prim.Format); prim.Format != "" && exists {
+		tsType = mapping.TypeScriptType
+		for _, call := range mapping.Decorators {
+			// Calls were validated when the config was loaded
+			d, _ := parseDecorator(call)
+			d.Import = mapping.Import
+			decorators = append(decorators, d)
+		}
+	} else {
+		switch prim.Name {
+		case "string", "binary":
+			tsType, decorators = "string", []decorator{validator("IsString")}
+		case "integer":
+			tsType, decorators = "number", []decorator{validator("IsInt")}
+		case "number":
+			tsType, decorators = "number", []decorator{validator("IsNumber")}
+		case "bigint":
+			// JSON cannot carry bigints, so they travel as digit strings
+			tsType, decorators = "string", []decorator{validator("IsNumberString")}
+		case "boolean":
+			tsType, decorators = "boolean", []decorator{validator("IsBoolean")}
+		default:
+			return "unknown", nil
+		}
+	}
+
+	return tsType, append(decorators, valueConstraints(prim.Constraints)...)
+}
+
+// reference returns the type of a referenced DTO: nested validation for
+// classes, the allowed values for enums, and no validation for unions
+func (f *dtoFile) reference(dtoName, propName, name string) (string, []decorator) {
+	dto, exists := f.gen.dtos[name]
+	if !exists {
+		return "unknown", nil
+	}
+
+	switch dto.Type {
+	case "enum":
+		f.use(name, name)
+		values := name + "Values"
+		isIn := validator("IsIn", values)
+		isIn.Refs = []ref{{DTO: name, Symbol: values}}
+		return name, []decorator{isIn}
+	case "union":
+		f.use(name, name)
+		f.gen.warnUnvalidated(dtoName, propName, dto.Union())
+		return name, nil
+	default:
+		class := f.gen.className(name)
+		f.use(name, class)
+		nestedType := decorator{
+			Name:   "Type",
+			Args:   []string{fmt.Sprintf("() => %s", class)},
+			Module: "class-transformer",
+			Refs:   []ref{{DTO: name, Symbol: class}},
+			NoEach: true,
+		}
+		return class, []decorator{validator("ValidateNested"), nestedType}
+	}
+}
+
+// valueConstraints returns the decorators of string and number constraints
+func valueConstraints(c *generator.Constraints) []decorator {
+	if c == nil {
+		return nil
+	}
+	var decorators []decorator
+	if c.MinLength != nil {
+		decorators = append(decorators, validator("MinLength", fmt.Sprint(*c.MinLength)))
+	}
+	if c.MaxLength != nil {
+		decorators = append(decorators, validator("MaxLength", fmt.Sprint(*c.MaxLength)))
+	}
+	if c.Pattern != "" {
+		decorators = append(decorators, validator("Matches", fmt.Sprintf("new RegExp(%s)", generator.NewLiteralType(c.Pattern).Literal())))
+	}
+	if c.Minimum != nil {
+		decorators = append(decorators, validator("Min", fmt.Sprint(*c.Minimum)))
+	}
+	if c.Maximum != nil {
+		decorators = append(decorators, validator("Max", fmt.Sprint(*c.Maximum)))
+	}
+	if c.MultipleOf != nil {
+		decorators = append(decorators, validator("IsDivisibleBy", fmt.Sprint(*c.MultipleOf)))
+	}
+	return decorators
+}
+
+// arrayConstraints returns the decorators of array constraints
+func arrayConstraints(c *generator.Constraints) []decorator {
+	if c == nil {
+		return nil
+	}
+	var decorators []decorator
+	if c.MinItems != nil {
+		decorators = append(decorators, validator("ArrayMinSize", fmt.Sprint(*c.MinItems)))
+	}
+	if c.MaxItems != nil {
+		decorators = append(decorators, validator("ArrayMaxSize", fmt.Sprint(*c.MaxItems)))
+	}
+	if c.UniqueItems {
+		decorators = append(decorators, decorator{Name: "ArrayUnique", Module: "class-validator", NoEach: true})
+	}
+	return decorators
+}
+
+// warnUnvalidated reports a union, which class-validator cannot validate
+func (g *NestJSGenerator) warnUnvalidated(dtoName, propName string, irType generator.IRType) {
+	g.warnings.Add(generator.Warning{
+		Schema:   dtoName,
+		Property: propName,
+		Code:     "unvalidated-nestjs",
+		Message:  fmt.Sprintf("class-validator cannot validate %s and it is only checked to be present", irType.TypeName()),
+	})
+}
+
+// inheritedProperties returns the properties of the DTOs dto extends followed
+// by its own
+func (g *NestJSGenerator) inheritedProperties(dto generator.DTO, visiting map[string]bool) []generator.Property {
+	if visiting[dto.Name] {
+		return nil
+	}
+	visiting[dto.Name] = true
+
+	var props []generator.Property
+	index := make(map[string]int)
+	add := func(prop generator.Property) {
+		// Own properties override inherited ones of the same name
+		if i, seen := index[prop.Name]; seen {
+			props[i] = prop
+			return
+		}
+		index[prop.Name] = len(props)
+		props = append(props, prop)
+	}
+
+	for _, base := range dto.Extends {
+		if baseDTO, ok := g.dtos[base]; ok {
+			for _, prop := range g.inheritedProperties(baseDTO, visiting) {
+				add(prop)
+			}
+		}
+	}
+	for _, prop := range dto.Properties {
+		add(prop)
+	}
+	return props
+}
+
+// isClass reports whether a DTO is generated as a class
+func (g *NestJSGenerator) isClass(name string) bool {
+	dto, exists := g.dtos[name]
+	return exists && dto.Type != "enum" && dto.Type != "union"
+}
+
+// className returns the class name of an object DTO
+func (g *NestJSGenerator) className(name string) string {
+	return name + g.customTypes.GetGenerationConfig().ClassSuffix
+}
+
+func (g *NestJSGenerator) packageName(config generator.Config) string {
+	if config.PackageName != "" {
+		return config.PackageName
+	}
+	return "generated-nestjs-dtos"
+}
+
+// docTags returns the JSDoc tags implied by deprecation
+func docTags(deprecated bool) []string {
+	if deprecated {
+		return []string{"@deprecated"}
+	}
+	return nil
+}
+
+// nonNull returns the single non-null member of a nullable union and true,
+// or irType and false
+func nonNull(irType generator.IRType) (generator.IRType, bool) {
+	union, ok := irType.(generator.UnionType)
+	if !ok {
+		return irType, false
+	}
+	var members []generator.IRType
+	for _, member := range union.Types {
+		if literal, ok := member.(generator.LiteralType); ok && literal.Kind == "null" {
+			continue
+		}
+		members = append(members, member)
+	}
+	if len(members) == 1 {
+		return members[0], true
+	}
+	return irType, false
+}
+
+// moduleName returns the file name of a DTO without extension, e.g.
+// order-item.dto for OrderItem
+func moduleName(name string) string {
+	var result strings.Builder
+	for i, r := range name {
+		if i > 0 && 'A' <= r && r <= 'Z' {
+			result.WriteRune('-')
+		}
+		result.WriteRune(r)
+	}
+	return strings.ToLower(result.String()) + ".dto"
+}
+
+// propertyName returns the JSON name as a class property, quoted if it is
+// not an identifier
+func propertyName(name string) string {
+	if isIdentifier(name) {
+		return name
+	}
+	return generator.NewLiteralType(name).Literal()
+}
+
+// isIdentifier reports whether s is a valid TypeScript identifier
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case r == '_' || r == '$', 'A' <= r && r <= 'Z', 'a' <= r && r <= 'z':
+		case i > 0 && '0' <= r && r <= '9':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,139 @@
+package nestjs
+
+import (
+	"path/filepath"
+	"testing"
+
+	"dtoForge/internal/generator"
+	"dtoForge/internal/testutils"
+)
+
+func TestNestJSGenerator_Language(t *testing.T) {
+	gen := NewNestJSGenerator()
+	if got := gen.Language(); got != "typescript-nestjs" {
+		t.Errorf("Language() = %v, want %v", got, "typescript-nestjs")
+	}
+	if got := gen.FileExtension(); got != ".ts" {
+		t.Errorf("FileExtension() = %v, want %v", got, ".ts")
+	}
+}
+
+func TestNestJSGenerator_Generate(t *testing.T) {
+	tempDir := testutils.TempDir(t)
+
+	minLength := 3
+	pet := testutils.CreateTestDTO("Pet")
+	pet.Extends = []string{"Base"}
+	pet.Properties = append(pet.Properties,
+		generator.Property{Name: "nickname", Required: true, Type: generator.PrimitiveType{Name: "string", Constraints: &generator.Constraints{MinLength: &minLength}}, Nullable: true},
+		generator.Property{Name: "owner", Type: generator.ReferenceType{RefName: "Owner"}, Required: true},
+		generator.Property{Name: "tags", Type: generator.ArrayType{
+			ElementType: generator.PrimitiveType{Name: "string", Format: "uuid"},
+			Constraints: &generator.Constraints{UniqueItems: true},
+		}},
+		generator.Property{Name: "toys", Type: generator.ArrayType{ElementType: generator.ReferenceType{RefName: "Owner"}}},
+		generator.Property{Name: "status", Type: generator.ReferenceType{RefName: "Status"}, Default: "active", Deprecated: true},
+		generator.Property{Name: "Content-Type", Type: generator.PrimitiveType{Name: "unknown"}, Required: true},
+	)
+	base := generator.DTO{Name: "Base", Type: "object"}
+	owner := generator.DTO{Name: "Owner", Type: "object", Properties: []generator.Property{
+		{Name: "age", Type: generator.PrimitiveType{Name: "integer"}, Required: true},
+	}}
+	status := generator.DTO{Name: "Status", Type: "enum", EnumValues: []string{"active", "in-progress"}, Description: "Lifecycle state"}
+	shape := generator.DTO{Name: "Shape", Type: "union", UnionMembers: []generator.IRType{
+		generator.ReferenceType{RefName: "Pet"},
+		generator.ReferenceType{RefName: "Owner"},
+	}}
+
+	gen := NewNestJSGenerator()
+	config := generator.Config{OutputFolder: tempDir}
+	if err := gen.Generate([]generator.DTO{pet, base, owner, status, shape}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	petFile := filepath.Join(tempDir, "pet.dto.ts")
+	testutils.AssertFileContains(t, petFile, "import { Type } from 'class-transformer';\nimport { ArrayUnique, IsArray, IsDefined, IsIn, IsOptional, IsString, IsUUID, MinLength, ValidateIf, ValidateNested } from 'class-validator';\n")
+	testutils.AssertFileContains(t, petFile, "import { Base } from './base.dto';\nimport { Owner } from './owner.dto';\nimport { Status, StatusValues } from './status.dto';\n")
+	testutils.AssertFileContains(t, petFile, "export class Pet extends Base {\n  /**\n   * Identifier\n   */\n  @IsString()\n  id!: string;\n")
+	testutils.AssertFileContains(t, petFile, "  @ValidateIf((_, value) => value !== null)\n  @IsString()\n  @MinLength(3)\n  nickname!: string | null;\n")
+	testutils.AssertFileContains(t, petFile, "  @ValidateNested()\n  @Type(() => Owner)\n  owner!: Owner;\n")
+	testutils.AssertFileContains(t, petFile, "  @IsOptional()\n  @IsArray()\n  @ArrayUnique()\n  @IsUUID(undefined, { each: true })\n  tags?: string[];\n")
+	testutils.AssertFileContains(t, petFile, "  @ValidateNested({ each: true })\n  @Type(() => Owner)\n  toys?: Owner[];\n")
+	testutils.AssertFileContains(t, petFile, "  /**\n   * @deprecated\n   */\n  @IsOptional()\n  @IsIn(StatusValues)\n  status?: Status = 'active';\n")
+	testutils.AssertFileContains(t, petFile, "  @IsDefined()\n  'Content-Type'!: unknown;\n}")
+
+	statusFile := filepath.Join(tempDir, "status.dto.ts")
+	testutils.AssertFileContains(t, statusFile, "/**\n * Lifecycle state\n */\nexport const StatusValues = ['active', 'in-progress'] as const;\n\nexport type Status = (typeof StatusValues)[number];")
+
+	shapeFile := filepath.Join(tempDir, "shape.dto.ts")
+	testutils.AssertFileContains(t, shapeFile, "import { Owner } from './owner.dto';\nimport { Pet } from './pet.dto';\n")
+	testutils.AssertFileContains(t, shapeFile, "export type Shape = Pet | Owner;")
+
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "index.ts"), "export * from './pet.dto';")
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "package.json"), "\"class-validator\"")
+}
+
+func TestNestJSGenerator_CustomTypes(t *testing.T) {
+	tempDir := testutils.TempDir(t)
+	configPath := testutils.WriteFile(t, tempDir, "dtoforge.config.yaml", `typescript-nestjs:
+  generation:
+    generatePackageJson: false
+    classSuffix: Dto
+  customTypes:
+    phone:
+      typeScriptType: PhoneNumber
+      decorators: ["IsPhoneNumber('US')"]
+      import: "import { IsPhoneNumber, PhoneNumber } from './phone';"
+`)
+
+	contact := generator.DTO{Name: "Contact", Type: "object", Extends: []string{"Person", "Address"}, Properties: []generator.Property{
+		{Name: "phones", Required: true, Type: generator.ArrayType{ElementType: generator.PrimitiveType{Name: "string", Format: "phone"}}},
+		{Name: "value", Type: generator.UnionType{Types: []generator.IRType{generator.PrimitiveType{Name: "string"}, generator.PrimitiveType{Name: "number"}}}},
+	}}
+	person := generator.DTO{Name: "Person", Type: "object", Properties: []generator.Property{
+		{Name: "name", Type: generator.PrimitiveType{Name: "string"}, Required: true},
+	}}
+	address := generator.DTO{Name: "Address", Type: "object", Properties: []generator.Property{
+		{Name: "city", Type: generator.PrimitiveType{Name: "string"}},
+	}}
+
+	warnings := &generator.WarningCollector{}
+	gen := NewNestJSGenerator()
+	config := generator.Config{OutputFolder: tempDir, ConfigFile: configPath, Warnings: warnings}
+	if err := gen.Generate([]generator.DTO{contact, person, address}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	contactFile := filepath.Join(tempDir, "contact.dto.ts")
+	testutils.AssertFileContains(t, contactFile, "import { IsPhoneNumber, PhoneNumber } from './phone';")
+	testutils.AssertFileContains(t, contactFile, "export class ContactDto {\n  @IsString()\n  name!: string;\n\n  @IsOptional()\n  @IsString()\n  city?: string;\n")
+	testutils.AssertFileContains(t, contactFile, "  @IsArray()\n  @IsPhoneNumber('US', { each: true })\n  phones!: PhoneNumber[];\n")
+	testutils.AssertFileContains(t, contactFile, "  @IsOptional()\n  @Allow()\n  value?: string | number;\n")
+	testutils.AssertFileNotContains(t, contactFile, "extends")
+	testutils.AssertFileNotContains(t, contactFile, "from './person.dto'")
+
+	if files, _ := filepath.Glob(filepath.Join(tempDir, "package.json")); len(files) != 0 {
+		t.Error("package.json should not be generated")
+	}
+	if len(warnings.Warnings()) != 1 || warnings.Warnings()[0].Code != "unvalidated-nestjs" {
+		t.Errorf("warnings = %+v, want one unvalidated-nestjs warning", warnings.Warnings())
+	}
+}
+
+func TestNestJSGenerator_NumericEnums(t *testing.T) {
+	priority := generator.DTO{Name: "Priority", Type: "enum", EnumValues: []string{"1", "2", "-3"}, EnumValueType: "integer"}
+	task := generator.DTO{Name: "Task", Type: "object", Properties: []generator.Property{
+		{Name: "priority", Type: generator.ReferenceType{RefName: "Priority"}, Required: true},
+		{Name: "weight", Type: generator.EnumType{Values: []string{"0.5", "1.5"}, UnderlyingType: "number"}, Required: true},
+	}}
+	tempDir := testutils.TempDir(t)
+	config := generator.Config{OutputFolder: tempDir}
+	if err := NewNestJSGenerator().Generate([]generator.DTO{priority, task}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "priority.dto.ts"), "export const PriorityValues = [1, 2, -3] as const;")
+	taskFile := filepath.Join(tempDir, "task.dto.ts")
+	testutils.AssertFileContains(t, taskFile, "  @IsIn(PriorityValues)\n  priority!: Priority;")
+	testutils.AssertFileContains(t, taskFile, "  @IsIn([0.5, 1.5])\n  weight!: 0.5 | 1.5;")
+}
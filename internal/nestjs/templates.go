@@ -0,0 +1,45 @@
+package nestjs
+
+// templates holds the dto, index and package templates
+const templates = `{{define "dto"}}// Generated by DtoForge (NestJS) - DO NOT EDIT
+{{range .File.Imports}}{{.}}
+{{end}}
+{{if eq .DTO.Type "enum"}}{{.Comment}}export const {{.DTO.Name}}Values = [{{range $i, $v := .Values}}{{if $i}}, {{end}}{{$v}}{{end}}] as const;
+
+export type {{.DTO.Name}} = (typeof {{.DTO.Name}}Values)[number];
+{{else if eq .DTO.Type "union"}}{{.Comment}}export type {{.DTO.Name}} = {{.Union}};
+{{else}}{{with .Class}}{{.Comment}}export class {{.Name}}{{with .Extends}} extends {{.}}{{end}} {
+{{- range $i, $p := .Properties}}
+{{if $i}}
+{{end}}{{$p.Comment}}{{range $p.Decorators}}  {{.}}
+{{end}}  {{$p.Declaration}}
+{{- end}}
+}
+{{end}}{{end}}{{end}}
+
+{{- define "index"}}// Generated by DtoForge (NestJS) - DO NOT EDIT
+
+{{range .}}export * from './{{moduleName .Name}}';
+{{end}}{{end}}
+
+{{- define "package"}}{
+  "name": "{{.}}",
+  "version": "1.0.0",
+  "description": "Generated NestJS DTOs with class-validator decorators",
+  "main": "index.js",
+  "types": "index.d.ts",
+  "scripts": {
+    "build": "tsc"
+  },
+  "dependencies": {
+    "class-transformer": "^0.5.1",
+    "class-validator": "^0.14.1",
+    "reflect-metadata": "^0.2.2"
+  },
+  "devDependencies": {
+    "typescript": "^5.0.0"
+  },
+  "keywords": ["typescript", "nestjs", "class-validator", "openapi", "dto"],
+  "license": "MIT"
+}
+{{end}}`
@@ -0,0 +1,142 @@
+package prisma
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GenerationConfig defines what to generate
+type GenerationConfig struct {
+	FileName    string `yaml:"fileName"`    // the schema file holding every model
+	Provider    string `yaml:"provider"`    // datasource provider, e.g. postgresql or mysql
+	IDField     string `yaml:"idField"`     // property used as @id
+	IDType      string `yaml:"idType"`      // "String" or "Int", for ids added to models without idField
+	IDDefault   string `yaml:"idDefault"`   // @default of ids, e.g. cuid(); "none" for no default
+	FieldNaming string `yaml:"fieldNaming"` // "camelCase" (mapped to the JSON name) or "preserve"
+	// Fields overrides single fields, keyed by Model.property
+	Fields map[string]FieldMapping `yaml:"fields"`
+}
+
+// FieldMapping overrides the generated type or attributes of one field
+type FieldMapping struct {
+	Name       string `yaml:"name"`       // field name, mapped to the property name
+	Type       string `yaml:"type"`       // Prisma type, without ? or []
+	Attributes string `yaml:"attributes"` // e.g. @unique @db.VarChar(120)
+}
+
+// CustomTypeMapping defines how to map an OpenAPI format to a Prisma type
+type CustomTypeMapping struct {
+	PrismaType string `yaml:"prismaType"`
+	Attributes string `yaml:"attributes"` // e.g. @db.Uuid
+}
+
+// PrismaConfig represents the prisma section in YAML configuration
+type PrismaConfig struct {
+	CustomTypes map[string]CustomTypeMapping `yaml:"customTypes"`
+	Generation  GenerationConfig             `yaml:"generation"`
+}
+
+// FullConfig represents the complete YAML configuration structure
+type FullConfig struct {
+	Prisma PrismaConfig `yaml:"prisma"`
+}
+
+// CustomTypeRegistry holds all format mappings and config for Prisma
+type CustomTypeRegistry struct {
+	mappings   map[string]CustomTypeMapping
+	generation GenerationConfig
+}
+
+// NewCustomTypeRegistry creates a new registry with default mappings and config
+func NewCustomTypeRegistry() *CustomTypeRegistry {
+	registry := &CustomTypeRegistry{
+		mappings: make(map[string]CustomTypeMapping),
+		generation: GenerationConfig{
+			FileName:    "schema.prisma",
+			Provider:    "postgresql",
+			IDField:     "id",
+			IDType:      "String",
+			FieldNaming: "camelCase",
+		},
+	}
+
+	registry.addDefaultMappings()
+	return registry
+}
+
+// GetGenerationConfig returns the generation configuration
+func (r *CustomTypeRegistry) GetGenerationConfig() GenerationConfig {
+	return r.generation
+}
+
+// addDefaultMappings adds the built-in format mappings for Prisma
+func (r *CustomTypeRegistry) addDefaultMappings() {
+	r.mappings["date-time"] = CustomTypeMapping{PrismaType: "DateTime"}
+	r.mappings["date"] = CustomTypeMapping{PrismaType: "DateTime", Attributes: "@db.Date"}
+	r.mappings["decimal"] = CustomTypeMapping{PrismaType: "Decimal"}
+}
+
+// Register adds or updates a custom type mapping
+func (r *CustomTypeRegistry) Register(format string, mapping CustomTypeMapping) {
+	r.mappings[format] = mapping
+}
+
+// Get retrieves a mapping for a given format
+func (r *CustomTypeRegistry) Get(format string) (CustomTypeMapping, bool) {
+	mapping, exists := r.mappings[format]
+	return mapping, exists
+}
+
+// LoadFromConfig loads custom mappings from a YAML configuration file
+func (r *CustomTypeRegistry) LoadFromConfig(configPath string) error {
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		return nil // Config file is optional
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", configPath, err)
+	}
+
+	var config FullConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", configPath, err)
+	}
+
+	prismaConfig := config.Prisma
+
+	if prismaConfig.Generation.FileName != "" {
+		r.generation.FileName = prismaConfig.Generation.FileName
+	}
+	if prismaConfig.Generation.Provider != "" {
+		r.generation.Provider = prismaConfig.Generation.Provider
+	}
+	if prismaConfig.Generation.IDField != "" {
+		r.generation.IDField = prismaConfig.Generation.IDField
+	}
+	if prismaConfig.Generation.IDType != "" {
+		if prismaConfig.Generation.IDType != "String" && prismaConfig.Generation.IDType != "Int" {
+			return fmt.Errorf("invalid prisma idType '%s', must be 'String' or 'Int'", prismaConfig.Generation.IDType)
+		}
+		r.generation.IDType = prismaConfig.Generation.IDType
+	}
+	r.generation.IDDefault = prismaConfig.Generation.IDDefault
+	if prismaConfig.Generation.FieldNaming != "" {
+		if prismaConfig.Generation.FieldNaming != "camelCase" && prismaConfig.Generation.FieldNaming != "preserve" {
+			return fmt.Errorf("invalid prisma fieldNaming '%s', must be 'camelCase' or 'preserve'", prismaConfig.Generation.FieldNaming)
+		}
+		r.generation.FieldNaming = prismaConfig.Generation.FieldNaming
+	}
+	r.generation.Fields = prismaConfig.Generation.Fields
+
+	for format, mapping := range prismaConfig.CustomTypes {
+		if mapping.PrismaType == "" {
+			return fmt.Errorf("custom type '%s' must have prismaType", format)
+		}
+		r.Register(format, mapping)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package prisma
+
+import (
+	"testing"
+
+	"dtoForge/internal/testutils"
+)
+
+func TestCustomTypeRegistry_LoadFromConfig(t *testing.T) {
+	configPath := testutils.WriteFile(t, testutils.TempDir(t), "dtoforge.config.yaml", `prisma:
+  customTypes:
+    uuid:
+      prismaType: String
+      attributes: "@db.Uuid"
+  generation:
+    fileName: models.prisma
+    provider: sqlite
+    idField: key
+    idDefault: cuid()
+`)
+
+	registry := NewCustomTypeRegistry()
+	if err := registry.LoadFromConfig(configPath); err != nil {
+		t.Fatalf("LoadFromConfig() error = %v", err)
+	}
+
+	if mapping, exists := registry.Get("uuid"); !exists || mapping.PrismaType != "String" || mapping.Attributes != "@db.Uuid" {
+		t.Errorf("uuid mapping = %+v, exists = %v", mapping, exists)
+	}
+	if mapping, exists := registry.Get("date-time"); !exists || mapping.PrismaType != "DateTime" {
+		t.Errorf("date-time mapping = %+v, exists = %v", mapping, exists)
+	}
+	genConfig := registry.GetGenerationConfig()
+	if genConfig.FileName != "models.prisma" || genConfig.Provider != "sqlite" || genConfig.IDField != "key" ||
+		genConfig.IDDefault != "cuid()" || genConfig.IDType != "String" || genConfig.FieldNaming != "camelCase" {
+		t.Errorf("generation config = %+v", genConfig)
+	}
+}
+
+func TestCustomTypeRegistry_LoadFromConfig_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"idType":      "prisma:\n  generation:\n    idType: UUID\n",
+		"fieldNaming": "prisma:\n  generation:\n    fieldNaming: snake_case\n",
+		"prismaType":  "prisma:\n  customTypes:\n    money:\n      attributes: \"@db.Money\"\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			configPath := testutils.WriteFile(t, testutils.TempDir(t), "dtoforge.config.yaml", content)
+			if err := NewCustomTypeRegistry().LoadFromConfig(configPath); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,709 @@
+package prisma
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"dtoForge/internal/generator"
+)
+
+// PrismaGenerator implements the Generator interface for Prisma schemas
+type PrismaGenerator struct {
+	customTypes *CustomTypeRegistry
+	dtos        map[string]generator.DTO
+	models      map[string]*prismaModel
+	enums       map[string]*prismaEnum
+	enumOrder   []string
+	warnings    *generator.WarningCollector
+}
+
+// NewPrismaGenerator creates a new Prisma generator
+func NewPrismaGenerator() *PrismaGenerator {
+	return &PrismaGenerator{}
+}
+
+// Language returns the target language name
+func (g *PrismaGenerator) Language() string {
+	return "prisma"
+}
+
+// FileExtension returns the file extension for Prisma schema files
+func (g *PrismaGenerator) FileExtension() string {
+	return ".prisma"
+}
+
+// prismaModel is a model to render
+type prismaModel struct {
+	Name    string
+	Comment string
+	Fields  []prismaField
+	idName  string // field name of the @id
+	idType  string // scalar type of the @id
+	taken   map[string]bool
+}
+
+// prismaField is a model field; relation fields carry their @relation in Attributes
+type prismaField struct {
+	Name       string
+	Type       string
+	Attributes string
+	Comment    string
+}
+
+// prismaEnum is an enum to render
+type prismaEnum struct {
+	Name    string
+	Comment string
+	Values  []prismaEnumValue
+}
+
+// prismaEnumValue is an enum value, mapped to the JSON value if that is not
+// a valid identifier
+type prismaEnumValue struct {
+	Name  string
+	Value string
+}
+
+// Attributes returns the @map of a renamed enum value
+func (v prismaEnumValue) Attributes() string {
+	if v.Name == v.Value {
+		return ""
+	}
+	return fmt.Sprintf("@map(%s)", strconv.Quote(v.Value))
+}
+
+// scalarListProviders support lists of scalars and enums
+var scalarListProviders = map[string]bool{"postgresql": true, "cockroachdb": true, "mongodb": true}
+
+// Generate writes one schema file with a model per object DTO and an enum per enum
+func (g *PrismaGenerator) Generate(dtos []generator.DTO, config generator.Config) error {
+	// Initialize custom type registry
+	g.customTypes = NewCustomTypeRegistry()
+
+	// Load custom config if specified
+	if config.ConfigFile != "" {
+		if err := g.customTypes.LoadFromConfig(config.ConfigFile); err != nil {
+			return &generator.ConfigError{Path: config.ConfigFile, Err: err}
+		}
+	}
+
+	// Schemas named after scalar types or reserved words get a new name
+	dtos, _ = generator.RenameReserved(dtos, isReservedModelName, "Prisma", config)
+
+	g.warnings = config.Warnings
+	g.dtos = make(map[string]generator.DTO, len(dtos))
+	g.models = make(map[string]*prismaModel)
+	g.enums = make(map[string]*prismaEnum)
+	g.enumOrder = nil
+	for _, dto := range dtos {
+		g.dtos[dto.Name] = dto
+	}
+
+	sortedDTOs := generator.SortByDependency(dtos)
+
+	// Ids first, since relations refer to the id of the other model
+	var modelOrder []string
+	for _, dto := range sortedDTOs {
+		switch dto.Type {
+		case "enum":
+			if dto.NumericEnum() {
+				// Referencing fields use the scalar, see fieldType
+				g.warnNumericEnum(dto.Name, "", numericEnumType(dto.EnumValues, dto.EnumValueType))
+				continue
+			}
+			g.declareEnum(dto.Name, generator.DescriptionLines(dto.Description), dto.EnumValues)
+		case "union":
+		default:
+			g.models[dto.Name] = g.newModel(dto)
+			modelOrder = append(modelOrder, dto.Name)
+		}
+	}
+	for _, name := range modelOrder {
+		dto := g.dtos[name]
+		g.addFields(g.models[name], g.inheritedProperties(dto, make(map[string]bool)))
+	}
+
+	if err := os.MkdirAll(config.OutputFolder, 0755); err != nil {
+		return err
+	}
+
+	file, err := config.CreateFile(filepath.Join(config.OutputFolder, g.customTypes.GetGenerationConfig().FileName), nil)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	tmpl, err := template.New("schema").Funcs(template.FuncMap{"columns": columns}).Parse(schemaTemplate)
+	if err != nil {
+		return err
+	}
+
+	var models []prismaModel
+	for _, name := range modelOrder {
+		models = append(models, *g.models[name])
+	}
+	var enums []prismaEnum
+	for _, name := range g.enumOrder {
+		enums = append(enums, *g.enums[name])
+	}
+
+	data := struct {
+		Provider string
+		Models   []prismaModel
+		Enums    []prismaEnum
+	}{
+		Provider: g.customTypes.GetGenerationConfig().Provider,
+		Models:   models,
+		Enums:    enums,
+	}
+
+	return tmpl.Execute(file, data)
+}
+
+// inheritedProperties returns the properties of the DTOs dto extends followed
+// by its own, since models cannot extend each other
+func (g *PrismaGenerator) inheritedProperties(dto generator.DTO, visiting map[string]bool) []generator.Property {
+	if visiting[dto.Name] {
+		return nil
+	}
+	visiting[dto.Name] = true
+
+	var props []generator.Property
+	index := make(map[string]int)
+	add := func(prop generator.Property) {
+		// Own properties override inherited ones of the same name
+		if i, seen := index[prop.Name]; seen {
+			props[i] = prop
+			return
+		}
+		index[prop.Name] = len(props)
+		props = append(props, prop)
+	}
+
+	for _, base := range dto.Extends {
+		if baseDTO, ok := g.dtos[base]; ok {
+			for _, prop := range g.inheritedProperties(baseDTO, visiting) {
+				add(prop)
+			}
+		}
+	}
+	for _, prop := range dto.Properties {
+		add(prop)
+	}
+	return props
+}
+
+// newModel starts the model of an object DTO, resolving its id: the
+// configured id property, or an added id field if the DTO has none
+func (g *PrismaGenerator) newModel(dto generator.DTO) *prismaModel {
+	genConfig := g.customTypes.GetGenerationConfig()
+	model := &prismaModel{
+		Name:    dto.Name,
+		Comment: docComment("", generator.DescriptionLines(dto.Description)),
+		taken:   make(map[string]bool),
+	}
+
+	for _, prop := range g.inheritedProperties(dto, make(map[string]bool)) {
+		if prop.Name != genConfig.IDField {
+			continue
+		}
+		irType, _ := nonNull(prop.Type)
+		if prim, ok := irType.(generator.PrimitiveType); ok {
+			model.idName = g.fieldName(dto.Name, prop.Name)
+			model.idType, _ = g.primitiveToPrisma(prim)
+			return model
+		}
+	}
+
+	model.idName = "id"
+	model.idType = genConfig.IDType
+	model.taken["id"] = true
+	model.Fields = append(model.Fields, prismaField{
+		Name:       "id",
+		Type:       model.idType,
+		Attributes: strings.TrimSpace("@id " + g.idDefault(model.idType, "")),
+	})
+	return model
+}
+
+// idDefault returns the @default of an id field
+func (g *PrismaGenerator) idDefault(idType, format string) string {
+	switch def := g.customTypes.GetGenerationConfig().IDDefault; def {
+	case "none":
+		return ""
+	case "":
+		if idType == "Int" || idType == "BigInt" {
+			return "@default(autoincrement())"
+		}
+		if idType == "String" {
+			if format == "" || format == "uuid" {
+				return "@default(uuid())"
+			}
+		}
+		return ""
+	default:
+		return fmt.Sprintf("@default(%s)", def)
+	}
+}
+
+// addFields adds a field per property, with foreign keys and back-relations
+// for references to other models
+func (g *PrismaGenerator) addFields(model *prismaModel, props []generator.Property) {
+	genConfig := g.customTypes.GetGenerationConfig()
+
+	for _, prop := range props {
+		name := g.fieldName(model.Name, prop.Name)
+		name = uniqueName(name, model.taken)
+
+		var attrs []string
+		if name != prop.Name {
+			attrs = append(attrs, fmt.Sprintf("@map(%s)", strconv.Quote(prop.Name)))
+		}
+		comment := docComment("  ", generator.DescriptionLines(prop.Description))
+
+		irType, nullable := nonNull(prop.Type)
+		optional := !prop.Required || prop.Nullable || nullable
+		override := genConfig.Fields[model.Name+"."+prop.Name]
+
+		// References to other models become relations
+		if target, ok := g.modelRef(irType); ok && override.Type == "" {
+			g.addManyToOne(model, target, name, optional, comment)
+			continue
+		}
+		if array, ok := irType.(generator.ArrayType); ok && override.Type == "" {
+			if target, ok := g.modelRef(array.ElementType); ok {
+				g.addOneToMany(model, target, name, comment)
+				continue
+			}
+		}
+
+		prismaType, typeAttrs := g.fieldType(model.Name, prop.Name, irType)
+		if override.Type != "" {
+			prismaType, typeAttrs = override.Type, nil
+		}
+		attrs = append(attrs, typeAttrs...)
+
+		if name == model.idName && prop.Name == genConfig.IDField {
+			optional = false
+			attrs = append([]string{"@id"}, attrs...)
+			if prop.Default == nil {
+				format := ""
+				if prim, ok := irType.(generator.PrimitiveType); ok {
+					format = prim.Format
+				}
+				if def := g.idDefault(prismaType, format); def != "" {
+					attrs = append(attrs, def)
+				}
+			}
+		}
+		if def := g.defaultAttribute(prismaType, prop.Default); def != "" {
+			attrs = append(attrs, def)
+		}
+		if override.Attributes != "" {
+			attrs = append(attrs, override.Attributes)
+		}
+
+		if optional && !strings.HasSuffix(prismaType, "[]") {
+			prismaType += "?"
+		}
+		model.Fields = append(model.Fields, prismaField{
+			Name:       name,
+			Type:       prismaType,
+			Attributes: strings.Join(attrs, " "),
+			Comment:    comment,
+		})
+	}
+}
+
+// addManyToOne adds a relation from model to target with a foreign key on
+// model, and the list of models on target
+func (g *PrismaGenerator) addManyToOne(model *prismaModel, target *prismaModel, name string, optional bool, comment string) {
+	relation := model.Name + pascalCase(name)
+	foreignKey := uniqueName(name+"Id", model.taken)
+	suffix := ""
+	if optional {
+		suffix = "?"
+	}
+
+	model.Fields = append(model.Fields,
+		prismaField{
+			Name:       name,
+			Type:       target.Name + suffix,
+			Attributes: fmt.Sprintf("@relation(%s, fields: [%s], references: [%s])", strconv.Quote(relation), foreignKey, target.idName),
+			Comment:    comment,
+		},
+		prismaField{Name: foreignKey, Type: target.idType + suffix},
+	)
+
+	back := uniqueName(lowerFirst(relation), target.taken)
+	target.Fields = append(target.Fields, prismaField{
+		Name:       back,
+		Type:       model.Name + "[]",
+		Attributes: fmt.Sprintf("@relation(%s)", strconv.Quote(relation)),
+	})
+}
+
+// addOneToMany adds a list of target models to model, with the foreign key
+// and the relation back on target
+func (g *PrismaGenerator) addOneToMany(model *prismaModel, target *prismaModel, name string, comment string) {
+	relation := model.Name + pascalCase(name)
+
+	model.Fields = append(model.Fields, prismaField{
+		Name:       name,
+		Type:       target.Name + "[]",
+		Attributes: fmt.Sprintf("@relation(%s)", strconv.Quote(relation)),
+		Comment:    comment,
+	})
+
+	back := uniqueName(lowerFirst(relation), target.taken)
+	foreignKey := uniqueName(back+"Id", target.taken)
+	target.Fields = append(target.Fields,
+		prismaField{
+			Name:       back,
+			Type:       model.Name + "?",
+			Attributes: fmt.Sprintf("@relation(%s, fields: [%s], references: [%s])", strconv.Quote(relation), foreignKey, model.idName),
+		},
+		prismaField{Name: foreignKey, Type: model.idType + "?"},
+	)
+}
+
+// modelRef returns the model an IRType refers to, if any
+func (g *PrismaGenerator) modelRef(irType generator.IRType) (*prismaModel, bool) {
+	var name string
+	switch t := irType.(type) {
+	case generator.ReferenceType:
+		name = t.RefName
+	case generator.ObjectType:
+		name = t.RefName
+	}
+	model, ok := g.models[name]
+	return model, ok
+}
+
+// fieldType returns the type and type attributes of a non-relation field
+func (g *PrismaGenerator) fieldType(model, propName string, irType generator.IRType) (string, []string) {
+	switch t := irType.(type) {
+	case generator.PrimitiveType:
+		return g.primitiveToPrisma(t)
+	case generator.ReferenceType:
+		if _, isEnum := g.enums[t.RefName]; isEnum {
+			return t.RefName, nil
+		}
+		if dto := g.dtos[t.RefName]; dto.NumericEnum() {
+			return numericEnumType(dto.EnumValues, dto.EnumValueType), nil
+		}
+		return "Json", nil
+	case generator.EnumType:
+		if t.Numeric() {
+			scalar := numericEnumType(t.Values, t.UnderlyingType)
+			g.warnNumericEnum(model, propName, scalar)
+			return scalar, nil
+		}
+		name := t.Name
+		if name == "" {
+			name = model + pascalCase(propName)
+		}
+		g.declareEnum(name, nil, t.Values)
+		return name, nil
+	case generator.ArrayType:
+		element, _ := nonNull(t.ElementType)
+		switch element.(type) {
+		case generator.PrimitiveType, generator.EnumType, generator.ReferenceType:
+			elementType, attrs := g.fieldType(model, propName, element)
+			if elementType != "Json" && scalarListProviders[g.customTypes.GetGenerationConfig().Provider] {
+				return elementType + "[]", attrs
+			}
+		}
+		return "Json", nil
+	case generator.UnionType, generator.TupleType:
+		g.warnings.Add(generator.Warning{
+			Schema:   model,
+			Property: propName,
+			Code:     "untyped-prisma",
+			Message:  fmt.Sprintf("Prisma has no equivalent of %s and it is stored as Json", t.TypeName()),
+		})
+		return "Json", nil
+	case generator.LiteralType:
+		switch t.Kind {
+		case "string":
+			return "String", nil
+		case "number":
+			return "Float", nil
+		case "boolean":
+			return "Boolean", nil
+		}
+		return "Json", nil
+	default:
+		// Inline objects and maps are stored as documents
+		return "Json", nil
+	}
+}
+
+// warnNumericEnum reports a numeric enum stored as a scalar, whose values are
+// not enforced
+func (g *PrismaGenerator) warnNumericEnum(schema, property, scalar string) {
+	g.warnings.Add(generator.Warning{
+		Schema:   schema,
+		Property: property,
+		Code:     "numeric-enum",
+		Message:  fmt.Sprintf("Prisma enums cannot hold numbers, the enum is stored as %s without restricting its values", scalar),
+	})
+}
+
+// numericEnumType returns the scalar of a numeric enum
+func numericEnumType(values []string, valueType string) string {
+	if valueType == "number" {
+		return "Float"
+	}
+	if !generator.Int32Values(values) {
+		return "BigInt"
+	}
+	return "Int"
+}
+
+// primitiveToPrisma maps a primitive, honoring format mappings first
+func (g *PrismaGenerator) primitiveToPrisma(prim generator.PrimitiveType) (string, []string) {
+	if prim.Format != "" {
+		if mapping, exists := g.customTypes.Get(prim.Format); exists {
+			if mapping.Attributes != "" {
+				return mapping.PrismaType, []string{mapping.Attributes}
+			}
+			return mapping.PrismaType, nil
+		}
+	}
+
+	switch prim.Name {
+	case "string":
+		return "String", nil
+	case "integer":
+		if prim.Format == "int64" || prim.Format == "uint64" {
+			return "BigInt", nil
+		}
+		return "Int", nil
+	case "bigint":
+		return "BigInt", nil
+	case "number":
+		return "Float", nil
+	case "binary":
+		return "Bytes", nil
+	case "boolean":
+		return "Boolean", nil
+	default:
+		return "Json", nil
+	}
+}
+
+// defaultAttribute returns the @default of a field with a scalar or enum
+// default value, or "" if the value cannot be expressed
+func (g *PrismaGenerator) defaultAttribute(prismaType string, value any) string {
+	if value == nil {
+		return ""
+	}
+	if enum, ok := g.enums[prismaType]; ok {
+		for _, enumValue := range enum.Values {
+			if enumValue.Value == fmt.Sprint(value) {
+				return fmt.Sprintf("@default(%s)", enumValue.Name)
+			}
+		}
+		return ""
+	}
+	switch v := value.(type) {
+	case string:
+		if prismaType == "String" {
+			return fmt.Sprintf("@default(%s)", strconv.Quote(v))
+		}
+	case bool:
+		if prismaType == "Boolean" {
+			return fmt.Sprintf("@default(%t)", v)
+		}
+	case float64, int, int64:
+		switch prismaType {
+		case "Int", "BigInt", "Float", "Decimal":
+			return fmt.Sprintf("@default(%v)", v)
+		}
+	}
+	return ""
+}
+
+// declareEnum adds an enum unless one of the same name exists
+func (g *PrismaGenerator) declareEnum(name string, description []string, values []string) {
+	if _, exists := g.enums[name]; exists {
+		return
+	}
+	enum := &prismaEnum{Name: name, Comment: docComment("", description)}
+	taken := make(map[string]bool)
+	for _, value := range values {
+		valueName := value
+		if !isIdentifier(value) {
+			valueName = strings.ToUpper(strings.Join(words(value), "_"))
+			if valueName == "" {
+				valueName = "EMPTY"
+			} else if unicode.IsDigit([]rune(valueName)[0]) {
+				valueName = "VALUE_" + valueName
+			}
+		}
+		enum.Values = append(enum.Values, prismaEnumValue{Name: uniqueName(valueName, taken), Value: value})
+	}
+	g.enums[name] = enum
+	g.enumOrder = append(g.enumOrder, name)
+}
+
+// fieldName returns the field name of a property: the configured name, the
+// property name camelCased, or the property name as is
+func (g *PrismaGenerator) fieldName(model, propName string) string {
+	genConfig := g.customTypes.GetGenerationConfig()
+	if override := genConfig.Fields[model+"."+propName]; override.Name != "" {
+		return override.Name
+	}
+	if genConfig.FieldNaming == "preserve" && isIdentifier(propName) {
+		return propName
+	}
+
+	name := lowerFirst(pascalCase(propName))
+	if name == "" {
+		return "value"
+	}
+	if unicode.IsDigit([]rune(name)[0]) {
+		name = "_" + name
+	}
+	return name
+}
+
+// columns aligns fields like prisma format: names, types and attributes in columns
+func columns(fields []prismaField) []string {
+	nameWidth, typeWidth := 0, 0
+	for _, field := range fields {
+		nameWidth = max(nameWidth, len(field.Name))
+		typeWidth = max(typeWidth, len(field.Type))
+	}
+
+	lines := make([]string, 0, len(fields))
+	for _, field := range fields {
+		line := fmt.Sprintf("  %-*s %-*s %s", nameWidth, field.Name, typeWidth, field.Type, field.Attributes)
+		lines = append(lines, field.Comment+strings.TrimRight(line, " "))
+	}
+	return lines
+}
+
+// nonNull returns the single non-null member of a nullable union and true,
+// or irType and false
+func nonNull(irType generator.IRType) (generator.IRType, bool) {
+	union, ok := irType.(generator.UnionType)
+	if !ok {
+		return irType, false
+	}
+	var members []generator.IRType
+	for _, member := range union.Types {
+		if literal, ok := member.(generator.LiteralType); ok && literal.Kind == "null" {
+			continue
+		}
+		members = append(members, member)
+	}
+	if len(members) == 1 {
+		return members[0], true
+	}
+	return irType, false
+}
+
+// docComment renders description lines as /// comments, which Prisma keeps
+// in the generated client
+func docComment(indent string, lines []string) string {
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(strings.TrimRight(indent+"/// "+line, " ") + "\n")
+	}
+	return b.String()
+}
+
+// words splits a name into its alphanumeric words, also at case changes
+func words(s string) []string {
+	var result []string
+	var current []rune
+	runes := []rune(s)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(current) > 0 {
+				result = append(result, string(current))
+				current = nil
+			}
+			continue
+		}
+		if len(current) > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				result = append(result, string(current))
+				current = nil
+			}
+		}
+		current = append(current, r)
+	}
+	if len(current) > 0 {
+		result = append(result, string(current))
+	}
+	return result
+}
+
+// scalarTypes are the Prisma field types a model or enum cannot be named after
+var scalarTypes = map[string]bool{
+	"String": true, "Boolean": true, "Int": true, "BigInt": true, "Float": true,
+	"Decimal": true, "DateTime": true, "Json": true, "Bytes": true, "Unsupported": true,
+}
+
+// isReservedModelName reports whether name cannot be used for a model or
+// enum: it is a scalar type, or a word reserved in the generated TypeScript
+// client
+func isReservedModelName(name string) bool {
+	return scalarTypes[name] || generator.IsReservedWord(name)
+}
+
+// pascalCase joins the words of a name, e.g. shipping_address becomes ShippingAddress
+func pascalCase(s string) string {
+	var b strings.Builder
+	for _, word := range words(s) {
+		runes := []rune(word)
+		b.WriteRune(unicode.ToUpper(runes[0]))
+		b.WriteString(string(runes[1:]))
+	}
+	return b.String()
+}
+
+// lowerFirst lowercases the first letter of s
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	runes := []rune(s)
+	return string(unicode.ToLower(runes[0])) + string(runes[1:])
+}
+
+// isIdentifier reports whether s is a valid Prisma name
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case 'A' <= r && r <= 'Z', 'a' <= r && r <= 'z':
+		case i > 0 && (r == '_' || '0' <= r && r <= '9'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// uniqueName returns name, or name with a number appended if it is taken
+func uniqueName(name string, taken map[string]bool) string {
+	unique := name
+	for i := 2; taken[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	taken[unique] = true
+	return unique
+}
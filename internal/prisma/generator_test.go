@@ -0,0 +1,115 @@
+package prisma
+
+import (
+	"path/filepath"
+	"testing"
+
+	"dtoForge/internal/generator"
+	"dtoForge/internal/testutils"
+)
+
+func TestPrismaGenerator_Language(t *testing.T) {
+	gen := NewPrismaGenerator()
+	if got := gen.Language(); got != "prisma" {
+		t.Errorf("Language() = %v, want %v", got, "prisma")
+	}
+	if got := gen.FileExtension(); got != ".prisma" {
+		t.Errorf("FileExtension() = %v, want %v", got, ".prisma")
+	}
+}
+
+func TestPrismaGenerator_Generate(t *testing.T) {
+	tempDir := testutils.TempDir(t)
+
+	pet := testutils.CreateTestDTO("Pet")
+	pet.Properties = append(pet.Properties,
+		generator.Property{Name: "created_at", Type: generator.PrimitiveType{Name: "string", Format: "date-time"}, Required: true},
+		generator.Property{Name: "owner", Type: generator.ReferenceType{RefName: "Owner"}},
+		generator.Property{Name: "status", Type: generator.ReferenceType{RefName: "Status"}, Required: true, Default: "in-progress"},
+		generator.Property{Name: "tags", Type: generator.ArrayType{ElementType: generator.PrimitiveType{Name: "string"}}},
+	)
+	owner := generator.DTO{Name: "Owner", Type: "object", Properties: []generator.Property{
+		{Name: "id", Type: generator.PrimitiveType{Name: "integer"}, Required: true},
+		{Name: "pets", Type: generator.ArrayType{ElementType: generator.ReferenceType{RefName: "Toy"}}},
+	}}
+	toy := generator.DTO{Name: "Toy", Type: "object", Description: "A toy", Properties: []generator.Property{
+		{Name: "label", Type: generator.PrimitiveType{Name: "string"}, Required: true},
+	}}
+	status := generator.DTO{Name: "Status", Type: "enum", EnumValues: []string{"active", "in-progress"}}
+
+	gen := NewPrismaGenerator()
+	config := generator.Config{OutputFolder: tempDir}
+	if err := gen.Generate([]generator.DTO{pet, owner, toy, status}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	schemaFile := filepath.Join(tempDir, "schema.prisma")
+	testutils.AssertFileContains(t, schemaFile, "datasource db {\n  provider = \"postgresql\"")
+	testutils.AssertFileContains(t, schemaFile, "model Pet {\n  /// Identifier\n  id        String   @id @default(uuid())\n")
+	testutils.AssertFileContains(t, schemaFile, "  createdAt DateTime @map(\"created_at\")\n")
+	testutils.AssertFileContains(t, schemaFile, "  owner     Owner?   @relation(\"PetOwner\", fields: [ownerId], references: [id])\n  ownerId   Int?\n")
+	testutils.AssertFileContains(t, schemaFile, "  status    Status   @default(IN_PROGRESS)\n  tags      String[]\n}")
+	testutils.AssertFileContains(t, schemaFile, "  id       Int   @id @default(autoincrement())\n  pets     Toy[] @relation(\"OwnerPets\")\n  petOwner Pet[] @relation(\"PetOwner\")\n}")
+	testutils.AssertFileContains(t, schemaFile, "/// A toy\nmodel Toy {\n  id          String @id @default(uuid())\n  label       String\n  ownerPets   Owner? @relation(\"OwnerPets\", fields: [ownerPetsId], references: [id])\n  ownerPetsId Int?\n}")
+	testutils.AssertFileContains(t, schemaFile, "enum Status {\n  active\n  IN_PROGRESS @map(\"in-progress\")\n}")
+}
+
+func TestPrismaGenerator_ConfiguredFields(t *testing.T) {
+	tempDir := testutils.TempDir(t)
+	configPath := testutils.WriteFile(t, tempDir, "dtoforge.config.yaml", `prisma:
+  generation:
+    provider: mysql
+    idType: Int
+    fieldNaming: preserve
+    fields:
+      Setting.key:
+        attributes: "@unique"
+      Setting.display_name:
+        name: label
+        type: String
+        attributes: "@db.VarChar(120)"
+`)
+
+	setting := generator.DTO{Name: "Setting", Type: "object", Properties: []generator.Property{
+		{Name: "key", Type: generator.PrimitiveType{Name: "string"}, Required: true},
+		{Name: "display_name", Type: generator.PrimitiveType{Name: "number"}},
+		{Name: "values", Type: generator.ArrayType{ElementType: generator.PrimitiveType{Name: "string"}}},
+		{Name: "value", Type: generator.UnionType{Types: []generator.IRType{generator.PrimitiveType{Name: "string"}, generator.PrimitiveType{Name: "number"}}}},
+	}}
+
+	warnings := &generator.WarningCollector{}
+	gen := NewPrismaGenerator()
+	config := generator.Config{OutputFolder: tempDir, ConfigFile: configPath, Warnings: warnings}
+	if err := gen.Generate([]generator.DTO{setting}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	schemaFile := filepath.Join(tempDir, "schema.prisma")
+	testutils.AssertFileContains(t, schemaFile, "provider = \"mysql\"")
+	testutils.AssertFileContains(t, schemaFile, "model Setting {\n  id     Int     @id @default(autoincrement())\n  key    String  @unique\n  label  String? @map(\"display_name\") @db.VarChar(120)\n")
+	testutils.AssertFileContains(t, schemaFile, "  values Json?\n  value  Json?\n}")
+
+	if len(warnings.Warnings()) != 1 || warnings.Warnings()[0].Code != "untyped-prisma" {
+		t.Errorf("warnings = %+v, want one untyped-prisma warning", warnings.Warnings())
+	}
+}
+
+func TestPrismaGenerator_NumericEnums(t *testing.T) {
+	priority := generator.DTO{Name: "Priority", Type: "enum", EnumValues: []string{"1", "2", "-3"}, EnumValueType: "integer"}
+	task := generator.DTO{Name: "Task", Type: "object", Properties: []generator.Property{
+		{Name: "priority", Type: generator.ReferenceType{RefName: "Priority"}, Required: true},
+		{Name: "weight", Type: generator.EnumType{Values: []string{"0.5", "1.5"}, UnderlyingType: "number"}, Required: true},
+	}}
+	warnings := &generator.WarningCollector{}
+	config := generator.Config{OutputFolder: testutils.TempDir(t), Warnings: warnings}
+	if err := NewPrismaGenerator().Generate([]generator.DTO{priority, task}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	schemaFile := filepath.Join(config.OutputFolder, "schema.prisma")
+	testutils.AssertFileContains(t, schemaFile, "  priority Int\n  weight   Float\n}")
+	testutils.AssertFileNotContains(t, schemaFile, "enum Priority")
+	if got := warnings.Warnings(); len(got) != 2 || got[0].Code != "numeric-enum" {
+		t.Errorf("warnings = %v, want numeric-enum warnings for Priority and Task.weight", got)
+	}
+}
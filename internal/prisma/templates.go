@@ -0,0 +1,25 @@
+package prisma
+
+const schemaTemplate = `// Generated by DtoForge - DO NOT EDIT
+
+datasource db {
+  provider = "{{.Provider}}"
+  url      = env("DATABASE_URL")
+}
+
+generator client {
+  provider = "prisma-client-js"
+}
+{{range .Models}}
+{{.Comment}}model {{.Name}} {
+{{- range columns .Fields}}
+{{.}}
+{{- end}}
+}
+{{end}}{{range .Enums}}
+{{.Comment}}enum {{.Name}} {
+{{- range .Values}}
+  {{.Name}}{{with .Attributes}} {{.}}{{end}}
+{{- end}}
+}
+{{end}}`
@@ -0,0 +1,116 @@
+package proto
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GenerationConfig defines what to generate
+type GenerationConfig struct {
+	Package  string            `yaml:"package"`  // proto package, -package overrides it
+	FileName string            `yaml:"fileName"` // the .proto file holding every message
+	Manifest string            `yaml:"manifest"` // field numbering manifest, relative to the output folder
+	Options  map[string]string `yaml:"options"`  // file options, e.g. go_package
+}
+
+// CustomTypeMapping defines how to map an OpenAPI format to a proto type
+type CustomTypeMapping struct {
+	ProtoType string `yaml:"protoType"` // e.g. google.protobuf.Timestamp
+	Import    string `yaml:"import"`    // e.g. google/protobuf/timestamp.proto
+}
+
+// ProtoConfig represents the proto section in YAML configuration
+type ProtoConfig struct {
+	CustomTypes map[string]CustomTypeMapping `yaml:"customTypes"`
+	Generation  GenerationConfig             `yaml:"generation"`
+}
+
+// FullConfig represents the complete YAML configuration structure
+type FullConfig struct {
+	Proto ProtoConfig `yaml:"proto"`
+}
+
+// CustomTypeRegistry holds all format mappings and config for proto
+type CustomTypeRegistry struct {
+	mappings   map[string]CustomTypeMapping
+	generation GenerationConfig
+}
+
+// NewCustomTypeRegistry creates a new registry with default mappings and config
+func NewCustomTypeRegistry() *CustomTypeRegistry {
+	registry := &CustomTypeRegistry{
+		mappings: make(map[string]CustomTypeMapping),
+		generation: GenerationConfig{
+			Package:  "dtoforge",
+			FileName: "schemas.proto",
+			Manifest: "dtoforge.proto.lock.json",
+		},
+	}
+
+	registry.addDefaultMappings()
+	return registry
+}
+
+// GetGenerationConfig returns the generation configuration
+func (r *CustomTypeRegistry) GetGenerationConfig() GenerationConfig {
+	return r.generation
+}
+
+// addDefaultMappings adds the built-in format mappings for proto
+func (r *CustomTypeRegistry) addDefaultMappings() {
+	r.mappings["date-time"] = CustomTypeMapping{ProtoType: "google.protobuf.Timestamp", Import: "google/protobuf/timestamp.proto"}
+	r.mappings["byte"] = CustomTypeMapping{ProtoType: "bytes"}
+	r.mappings["binary"] = CustomTypeMapping{ProtoType: "bytes"}
+}
+
+// Register adds or updates a custom type mapping
+func (r *CustomTypeRegistry) Register(format string, mapping CustomTypeMapping) {
+	r.mappings[format] = mapping
+}
+
+// Get retrieves a mapping for a given format
+func (r *CustomTypeRegistry) Get(format string) (CustomTypeMapping, bool) {
+	mapping, exists := r.mappings[format]
+	return mapping, exists
+}
+
+// LoadFromConfig loads custom mappings from a YAML configuration file
+func (r *CustomTypeRegistry) LoadFromConfig(configPath string) error {
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		return nil // Config file is optional
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", configPath, err)
+	}
+
+	var config FullConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", configPath, err)
+	}
+
+	protoConfig := config.Proto
+
+	if protoConfig.Generation.Package != "" {
+		r.generation.Package = protoConfig.Generation.Package
+	}
+	if protoConfig.Generation.FileName != "" {
+		r.generation.FileName = protoConfig.Generation.FileName
+	}
+	if protoConfig.Generation.Manifest != "" {
+		r.generation.Manifest = protoConfig.Generation.Manifest
+	}
+	r.generation.Options = protoConfig.Generation.Options
+
+	for format, mapping := range protoConfig.CustomTypes {
+		if mapping.ProtoType == "" {
+			return fmt.Errorf("custom type '%s' must have protoType", format)
+		}
+		r.Register(format, mapping)
+	}
+
+	return nil
+}
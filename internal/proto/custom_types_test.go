@@ -0,0 +1,48 @@
+package proto
+
+import (
+	"testing"
+
+	"dtoForge/internal/testutils"
+)
+
+func TestCustomTypeRegistry_LoadFromConfig(t *testing.T) {
+	tempDir := testutils.TempDir(t)
+	configPath := testutils.WriteFile(t, tempDir, "dtoforge.config.yaml", `proto:
+  customTypes:
+    date:
+      protoType: google.type.Date
+      import: google/type/date.proto
+  generation:
+    package: acme.v1
+    fileName: acme.proto
+    manifest: acme.lock.json
+    options:
+      go_package: example.com/acme/v1
+`)
+
+	registry := NewCustomTypeRegistry()
+	if err := registry.LoadFromConfig(configPath); err != nil {
+		t.Fatalf("LoadFromConfig() error = %v", err)
+	}
+
+	mapping, exists := registry.Get("date")
+	if !exists || mapping.ProtoType != "google.type.Date" || mapping.Import != "google/type/date.proto" {
+		t.Errorf("date mapping = %+v, exists = %v", mapping, exists)
+	}
+
+	genConfig := registry.GetGenerationConfig()
+	if genConfig.Package != "acme.v1" || genConfig.FileName != "acme.proto" || genConfig.Manifest != "acme.lock.json" {
+		t.Errorf("generation config = %+v", genConfig)
+	}
+	if genConfig.Options["go_package"] != "example.com/acme/v1" {
+		t.Errorf("options = %v", genConfig.Options)
+	}
+}
+
+func TestCustomTypeRegistry_LoadFromConfig_MissingProtoType(t *testing.T) {
+	configPath := testutils.WriteFile(t, testutils.TempDir(t), "dtoforge.config.yaml", "proto:\n  customTypes:\n    money:\n      import: acme/money.proto\n")
+	if err := NewCustomTypeRegistry().LoadFromConfig(configPath); err == nil {
+		t.Error("expected an error for a mapping without protoType")
+	}
+}
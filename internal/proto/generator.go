@@ -0,0 +1,752 @@
+package proto
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"dtoForge/internal/generator"
+)
+
+// ProtoGenerator implements the Generator interface for Protocol Buffers
+type ProtoGenerator struct {
+	customTypes *CustomTypeRegistry
+	manifest    *Manifest
+	dtos        map[string]generator.DTO
+	imports     map[string]bool
+	warnings    *generator.WarningCollector
+}
+
+// NewProtoGenerator creates a new proto generator
+func NewProtoGenerator() *ProtoGenerator {
+	return &ProtoGenerator{}
+}
+
+// Language returns the target language name
+func (g *ProtoGenerator) Language() string {
+	return "proto"
+}
+
+// FileExtension returns the file extension for proto files
+func (g *ProtoGenerator) FileExtension() string {
+	return ".proto"
+}
+
+// protoMessage is a message to render, top-level or nested in another
+type protoMessage struct {
+	Name          string
+	Comment       string
+	Deprecated    bool
+	Fields        []protoField
+	Oneofs        []protoOneof
+	Reserved      []int
+	ReservedNames []string
+	Messages      []protoMessage // inline objects of the fields
+	Enums         []protoEnum    // inline enums of the fields
+}
+
+// protoField is a message field
+type protoField struct {
+	Label   string // "", "optional" or "repeated"
+	Type    string
+	Name    string
+	Number  int
+	Options []string // e.g. json_name = "createdAt"
+	Comment string
+}
+
+// OptionsText returns the field options in brackets, or "" if there are none
+func (f protoField) OptionsText() string {
+	if len(f.Options) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(f.Options, ", ") + "]"
+}
+
+// protoOneof is a union property: one field per member type
+type protoOneof struct {
+	Name    string
+	Comment string
+	Fields  []protoField
+}
+
+// protoEnum is an enum to render, top-level or nested in a message
+type protoEnum struct {
+	Name          string
+	Comment       string
+	Deprecated    bool
+	Values        []protoEnumValue
+	Reserved      []int
+	ReservedNames []string
+}
+
+// protoEnumValue is a named enum value
+type protoEnumValue struct {
+	Name   string
+	Number int
+}
+
+// Generate writes one .proto file with every DTO and updates the numbering manifest
+func (g *ProtoGenerator) Generate(dtos []generator.DTO, config generator.Config) error {
+	// Initialize custom type registry
+	g.customTypes = NewCustomTypeRegistry()
+
+	// Load custom config if specified
+	if config.ConfigFile != "" {
+		if err := g.customTypes.LoadFromConfig(config.ConfigFile); err != nil {
+			return &generator.ConfigError{Path: config.ConfigFile, Err: err}
+		}
+	}
+
+	// Messages named after scalar types would be shadowed by them
+	dtos, _ = generator.RenameReserved(dtos, isScalar, "protobuf", config)
+
+	genConfig := g.customTypes.GetGenerationConfig()
+	g.warnings = config.Warnings
+	g.imports = make(map[string]bool)
+	g.dtos = make(map[string]generator.DTO, len(dtos))
+	for _, dto := range dtos {
+		g.dtos[dto.Name] = dto
+	}
+
+	if err := os.MkdirAll(config.OutputFolder, 0755); err != nil {
+		return err
+	}
+
+	manifestPath := filepath.Join(config.OutputFolder, genConfig.Manifest)
+	manifest, err := loadManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+	g.manifest = manifest
+
+	var messages []protoMessage
+	var enums []protoEnum
+	for _, dto := range generator.SortByDependency(dtos) {
+		switch dto.Type {
+		case "enum":
+			if dto.NumericEnum() {
+				// Referencing fields use the scalar, see toProtoType
+				g.warnNumericEnum(dto.Name, "", numericEnumType(dto.EnumValues, dto.EnumValueType))
+				continue
+			}
+			enums = append(enums, g.buildEnum(dto.Name, dto.Name, dto.EnumValues, generator.DescriptionLines(dto.Description), dto.Deprecated))
+		case "union":
+			messages = append(messages, g.buildUnion(dto))
+		default:
+			props := g.inheritedProperties(dto, make(map[string]bool))
+			messages = append(messages, g.buildMessage(dto.Name, dto.Name, props, dto.Description, dto.Deprecated))
+		}
+	}
+
+	if err := g.writeProtoFile(filepath.Join(config.OutputFolder, genConfig.FileName), g.getPackageName(config), messages, enums, config); err != nil {
+		return fmt.Errorf("failed to generate proto file: %w", err)
+	}
+
+	if err := g.manifest.save(manifestPath); err != nil {
+		return err
+	}
+	config.Files.Record(manifestPath)
+	return nil
+}
+
+// writeProtoFile renders the messages and enums into one file
+func (g *ProtoGenerator) writeProtoFile(path, packageName string, messages []protoMessage, enums []protoEnum, config generator.Config) error {
+	var tmpl *template.Template
+	funcs := template.FuncMap{
+		// nested renders a declaration inside a message, indented one level
+		"nested": func(name string, data any) (string, error) {
+			var b bytes.Buffer
+			if err := tmpl.ExecuteTemplate(&b, name, data); err != nil {
+				return "", err
+			}
+			return indent(b.String(), "  "), nil
+		},
+		"numbers": func(numbers []int) string {
+			parts := make([]string, len(numbers))
+			for i, number := range numbers {
+				parts[i] = strconv.Itoa(number)
+			}
+			return strings.Join(parts, ", ")
+		},
+		"names": func(names []string) string {
+			parts := make([]string, len(names))
+			for i, name := range names {
+				parts[i] = strconv.Quote(name)
+			}
+			return strings.Join(parts, ", ")
+		},
+	}
+
+	tmpl, err := template.New("file").Funcs(funcs).Parse(fileTemplate + declTemplates)
+	if err != nil {
+		return err
+	}
+
+	file, err := config.CreateFile(path, nil)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	data := struct {
+		Package  string
+		Imports  []string
+		Options  []string
+		Messages []protoMessage
+		Enums    []protoEnum
+	}{
+		Package:  packageName,
+		Imports:  sortedKeys(g.imports),
+		Options:  g.fileOptions(),
+		Messages: messages,
+		Enums:    enums,
+	}
+
+	return tmpl.Execute(file, data)
+}
+
+// getPackageName returns the proto package from -package, the config file or the default
+func (g *ProtoGenerator) getPackageName(config generator.Config) string {
+	if config.PackageName != "" {
+		return config.PackageName
+	}
+	return g.customTypes.GetGenerationConfig().Package
+}
+
+// fileOptions returns the configured file options, sorted by name
+func (g *ProtoGenerator) fileOptions() []string {
+	options := g.customTypes.GetGenerationConfig().Options
+	var result []string
+	for _, name := range sortedKeys(options) {
+		result = append(result, fmt.Sprintf("%s = %s", name, strconv.Quote(options[name])))
+	}
+	return result
+}
+
+// inheritedProperties returns the properties of the DTOs dto extends followed
+// by its own, since messages cannot extend each other
+func (g *ProtoGenerator) inheritedProperties(dto generator.DTO, visiting map[string]bool) []generator.Property {
+	if visiting[dto.Name] {
+		return nil
+	}
+	visiting[dto.Name] = true
+
+	var props []generator.Property
+	index := make(map[string]int)
+	add := func(prop generator.Property) {
+		// Own properties override inherited ones of the same name
+		if i, seen := index[prop.Name]; seen {
+			props[i] = prop
+			return
+		}
+		index[prop.Name] = len(props)
+		props = append(props, prop)
+	}
+
+	for _, base := range dto.Extends {
+		if baseDTO, ok := g.dtos[base]; ok {
+			for _, prop := range g.inheritedProperties(baseDTO, visiting) {
+				add(prop)
+			}
+		}
+	}
+	for _, prop := range dto.Properties {
+		add(prop)
+	}
+	return props
+}
+
+// buildMessage turns the properties of an object into a message. path is the
+// message's key in the manifest, e.g. Pet or Pet.Owner for a nested message.
+func (g *ProtoGenerator) buildMessage(path, name string, props []generator.Property, description string, deprecated bool) protoMessage {
+	msg := protoMessage{
+		Name:       name,
+		Comment:    lineComment("", generator.DescriptionLines(description)),
+		Deprecated: deprecated,
+	}
+	numbers := g.manifest.message(path)
+	used := make(map[string]bool)
+	taken := make(map[string]bool)
+
+	for _, prop := range props {
+		fieldName := uniqueName(snakeCase(prop.Name), taken)
+		comment := lineComment("  ", generator.DescriptionLines(prop.Description))
+
+		var options []string
+		if jsonName := lowerCamel(fieldName); jsonName != prop.Name {
+			options = append(options, fmt.Sprintf("json_name = %s", strconv.Quote(prop.Name)))
+		}
+		if prop.Deprecated {
+			options = append(options, "deprecated = true")
+		}
+
+		if members, ok := g.oneofMembers(prop.Type); ok {
+			oneof := protoOneof{Name: fieldName, Comment: comment}
+			for _, member := range members {
+				memberName := uniqueName(fieldName+"_"+snakeCase(typeWord(member)), taken)
+				used[memberName] = true
+				oneof.Fields = append(oneof.Fields, protoField{
+					Type:   g.toProtoType(&msg, path, prop.Name, member),
+					Name:   memberName,
+					Number: numbers.number(memberName, 1),
+				})
+			}
+			msg.Oneofs = append(msg.Oneofs, oneof)
+			continue
+		}
+
+		label, protoType := g.fieldType(&msg, path, prop)
+		used[fieldName] = true
+		msg.Fields = append(msg.Fields, protoField{
+			Label:   label,
+			Type:    protoType,
+			Name:    fieldName,
+			Number:  numbers.number(fieldName, 1),
+			Options: options,
+			Comment: comment,
+		})
+	}
+
+	numbers.retire(used)
+	msg.Reserved = numbers.Reserved
+	msg.ReservedNames = numbers.ReservedNames
+	return msg
+}
+
+// buildUnion turns a union DTO into a message with a single oneof
+func (g *ProtoGenerator) buildUnion(dto generator.DTO) protoMessage {
+	msg := protoMessage{
+		Name:       dto.Name,
+		Comment:    lineComment("", generator.DescriptionLines(dto.Description)),
+		Deprecated: dto.Deprecated,
+	}
+	numbers := g.manifest.message(dto.Name)
+	used := make(map[string]bool)
+	taken := make(map[string]bool)
+
+	oneof := protoOneof{Name: "value"}
+	for _, member := range dto.UnionMembers {
+		if literal, ok := member.(generator.LiteralType); ok && literal.Kind == "null" {
+			continue
+		}
+		if !oneofAllowed(member) {
+			g.warnUntyped(dto.Name, "", member)
+			member = generator.PrimitiveType{Name: "unknown"}
+		}
+		name := uniqueName(snakeCase(typeWord(member)), taken)
+		used[name] = true
+		oneof.Fields = append(oneof.Fields, protoField{
+			Type:   g.toProtoType(&msg, dto.Name, "", member),
+			Name:   name,
+			Number: numbers.number(name, 1),
+		})
+	}
+	msg.Oneofs = append(msg.Oneofs, oneof)
+
+	numbers.retire(used)
+	msg.Reserved = numbers.Reserved
+	msg.ReservedNames = numbers.ReservedNames
+	return msg
+}
+
+// buildEnum turns enum values into a proto enum. Values are prefixed with the
+// enum name, as proto enum values share their parent's scope, and 0 is the
+// required unspecified value.
+func (g *ProtoGenerator) buildEnum(path, name string, values []string, description []string, deprecated bool) protoEnum {
+	prefix := upperSnake(name)
+	enum := protoEnum{
+		Name:       name,
+		Comment:    lineComment("", description),
+		Deprecated: deprecated,
+		Values:     []protoEnumValue{{Name: prefix + "_UNSPECIFIED", Number: 0}},
+	}
+	numbers := g.manifest.enum(path)
+	used := make(map[string]bool)
+	taken := map[string]bool{prefix + "_UNSPECIFIED": true}
+
+	for _, value := range values {
+		valueName := upperSnake(value)
+		if valueName == "" {
+			valueName = "EMPTY"
+		}
+		valueName = uniqueName(prefix+"_"+valueName, taken)
+		used[valueName] = true
+		enum.Values = append(enum.Values, protoEnumValue{Name: valueName, Number: numbers.number(valueName, 1)})
+	}
+
+	numbers.retire(used)
+	enum.Reserved = numbers.Reserved
+	enum.ReservedNames = numbers.ReservedNames
+	return enum
+}
+
+// fieldType returns the label and type of a property's field
+func (g *ProtoGenerator) fieldType(msg *protoMessage, path string, prop generator.Property) (string, string) {
+	irType, nullable := nonNull(prop.Type)
+
+	switch t := irType.(type) {
+	case generator.ArrayType:
+		element, _ := nonNull(t.ElementType)
+		if !repeatable(element) {
+			g.warnUntyped(path, prop.Name, prop.Type)
+			return "", g.wellKnown("google.protobuf.ListValue", "google/protobuf/struct.proto")
+		}
+		return "repeated", g.toProtoType(msg, path, prop.Name, element)
+	case generator.MapType:
+		value, _ := nonNull(t.ValueType)
+		if !repeatable(value) {
+			g.warnUntyped(path, prop.Name, prop.Type)
+			return "", g.wellKnown("google.protobuf.Struct", "google/protobuf/struct.proto")
+		}
+		return "", fmt.Sprintf("map<string, %s>", g.toProtoType(msg, path, prop.Name, value))
+	case generator.TupleType, generator.UnionType:
+		g.warnUntyped(path, prop.Name, prop.Type)
+	}
+
+	protoType := g.toProtoType(msg, path, prop.Name, irType)
+	// Scalars need explicit presence to tell an absent field from a zero value
+	if (!prop.Required || prop.Nullable || nullable) && isScalar(protoType) {
+		return "optional", protoType
+	}
+	return "", protoType
+}
+
+// toProtoType returns the proto type of an IRType, declaring inline enums and
+// objects as types nested in msg
+func (g *ProtoGenerator) toProtoType(msg *protoMessage, path, propName string, irType generator.IRType) string {
+	switch t := irType.(type) {
+	case generator.PrimitiveType:
+		return g.primitiveToProto(t)
+	case generator.ReferenceType:
+		if dto := g.dtos[t.RefName]; dto.NumericEnum() {
+			return numericEnumType(dto.EnumValues, dto.EnumValueType)
+		}
+		return t.RefName
+	case generator.ObjectType:
+		if t.RefName != "" {
+			return t.RefName
+		}
+		if t.DTORef != nil {
+			name := pascalCase(propName)
+			msg.Messages = append(msg.Messages, g.buildMessage(path+"."+name, name, t.DTORef.Properties, t.DTORef.Description, false))
+			return name
+		}
+		return g.wellKnown("google.protobuf.Struct", "google/protobuf/struct.proto")
+	case generator.EnumType:
+		if t.Numeric() {
+			scalar := numericEnumType(t.Values, t.UnderlyingType)
+			g.warnNumericEnum(path, propName, scalar)
+			return scalar
+		}
+		name := t.Name
+		if name == "" {
+			name = pascalCase(propName)
+		}
+		msg.Enums = append(msg.Enums, g.buildEnum(path+"."+name, name, t.Values, nil, false))
+		return name
+	case generator.LiteralType:
+		switch t.Kind {
+		case "string":
+			return "string"
+		case "boolean":
+			return "bool"
+		case "number":
+			return "double"
+		}
+		return g.wellKnown("google.protobuf.NullValue", "google/protobuf/struct.proto")
+	case generator.ArrayType, generator.TupleType:
+		return g.wellKnown("google.protobuf.ListValue", "google/protobuf/struct.proto")
+	case generator.MapType:
+		return g.wellKnown("google.protobuf.Struct", "google/protobuf/struct.proto")
+	default:
+		return g.wellKnown("google.protobuf.Value", "google/protobuf/struct.proto")
+	}
+}
+
+// primitiveToProto maps a primitive, honoring format mappings first
+func (g *ProtoGenerator) primitiveToProto(prim generator.PrimitiveType) string {
+	if prim.Format != "" {
+		if mapping, exists := g.customTypes.Get(prim.Format); exists {
+			if mapping.Import != "" {
+				g.imports[mapping.Import] = true
+			}
+			return mapping.ProtoType
+		}
+	}
+
+	switch prim.Name {
+	case "string":
+		return "string"
+	case "integer", "bigint":
+		switch prim.Format {
+		case "int64", "uint32", "uint64":
+			return prim.Format
+		}
+		if prim.Name == "bigint" {
+			return "int64"
+		}
+		return "int32"
+	case "number":
+		if prim.Format == "float" {
+			return "float"
+		}
+		return "double"
+	case "binary":
+		return "bytes"
+	case "boolean":
+		return "bool"
+	default:
+		return g.wellKnown("google.protobuf.Value", "google/protobuf/struct.proto")
+	}
+}
+
+// wellKnown returns a well-known type, importing the file that declares it
+func (g *ProtoGenerator) wellKnown(name, file string) string {
+	g.imports[file] = true
+	return name
+}
+
+// oneofMembers returns the non-null members of a union that can all be oneof
+// fields; unions of a single type plus null are plain fields instead
+func (g *ProtoGenerator) oneofMembers(irType generator.IRType) ([]generator.IRType, bool) {
+	union, ok := irType.(generator.UnionType)
+	if !ok {
+		return nil, false
+	}
+	var members []generator.IRType
+	for _, member := range union.Types {
+		if literal, ok := member.(generator.LiteralType); ok && literal.Kind == "null" {
+			continue
+		}
+		if !oneofAllowed(member) {
+			return nil, false
+		}
+		members = append(members, member)
+	}
+	return members, len(members) > 1
+}
+
+// warnUntyped reports a type proto cannot express that falls back to a
+// google.protobuf struct type
+func (g *ProtoGenerator) warnUntyped(schema, property string, irType generator.IRType) {
+	g.warnings.Add(generator.Warning{
+		Schema:   schema,
+		Property: property,
+		Code:     "untyped-proto",
+		Message:  fmt.Sprintf("proto has no equivalent of %s and it is generated as a google.protobuf struct type", irType.TypeName()),
+	})
+}
+
+// warnNumericEnum reports a numeric enum generated as a scalar, whose values
+// are not enforced
+func (g *ProtoGenerator) warnNumericEnum(schema, property, scalar string) {
+	g.warnings.Add(generator.Warning{
+		Schema:   schema,
+		Property: property,
+		Code:     "numeric-enum",
+		Message:  fmt.Sprintf("proto enums cannot hold numbers, the enum is generated as %s without restricting its values", scalar),
+	})
+}
+
+// numericEnumType returns the scalar of a numeric enum
+func numericEnumType(values []string, valueType string) string {
+	if valueType == "number" {
+		return "double"
+	}
+	if !generator.Int32Values(values) {
+		return "int64"
+	}
+	return "int32"
+}
+
+// nonNull returns the single non-null member of a nullable union and true,
+// or irType and false
+func nonNull(irType generator.IRType) (generator.IRType, bool) {
+	union, ok := irType.(generator.UnionType)
+	if !ok {
+		return irType, false
+	}
+	var members []generator.IRType
+	for _, member := range union.Types {
+		if literal, ok := member.(generator.LiteralType); ok && literal.Kind == "null" {
+			continue
+		}
+		members = append(members, member)
+	}
+	if len(members) == 1 {
+		return members[0], true
+	}
+	return irType, false
+}
+
+// repeatable reports whether a type can be the element of a repeated field or
+// the value of a map, which rules out nested lists, maps and unions
+func repeatable(irType generator.IRType) bool {
+	switch irType.(type) {
+	case generator.ArrayType, generator.TupleType, generator.MapType, generator.UnionType:
+		return false
+	}
+	return true
+}
+
+// oneofAllowed reports whether a type can be a oneof field
+func oneofAllowed(irType generator.IRType) bool {
+	switch t := irType.(type) {
+	case generator.ArrayType, generator.TupleType, generator.MapType, generator.UnionType, generator.EnumType:
+		return false
+	case generator.ObjectType:
+		return t.RefName != ""
+	}
+	return true
+}
+
+// isScalar reports whether a proto type is a scalar, which has no presence
+// unless marked optional
+func isScalar(protoType string) bool {
+	switch protoType {
+	case "double", "float", "int32", "int64", "uint32", "uint64", "sint32", "sint64",
+		"fixed32", "fixed64", "sfixed32", "sfixed64", "bool", "string", "bytes":
+		return true
+	}
+	return false
+}
+
+// typeWord names a oneof member after its type, e.g. pet or string
+func typeWord(irType generator.IRType) string {
+	switch t := irType.(type) {
+	case generator.LiteralType:
+		return t.Kind
+	case generator.PrimitiveType:
+		if t.Format != "" {
+			return t.Format
+		}
+		return t.Name
+	}
+	return irType.TypeName()
+}
+
+// lineComment renders description lines as // comments at the given indent
+func lineComment(indent string, lines []string) string {
+	var b strings.Builder
+	for _, line := range lines {
+		if line == "" {
+			b.WriteString(indent + "//\n")
+		} else {
+			b.WriteString(indent + "// " + line + "\n")
+		}
+	}
+	return b.String()
+}
+
+// words splits a name into its alphanumeric words, also at case changes
+func words(s string) []string {
+	var result []string
+	var current []rune
+	runes := []rune(s)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(current) > 0 {
+				result = append(result, string(current))
+				current = nil
+			}
+			continue
+		}
+		if len(current) > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				result = append(result, string(current))
+				current = nil
+			}
+		}
+		current = append(current, r)
+	}
+	if len(current) > 0 {
+		result = append(result, string(current))
+	}
+	return result
+}
+
+// snakeCase returns a lower_snake_case field name, e.g. createdAt becomes created_at
+func snakeCase(s string) string {
+	name := strings.ToLower(strings.Join(words(s), "_"))
+	if name == "" {
+		return "value"
+	}
+	if unicode.IsDigit([]rune(name)[0]) {
+		name = "_" + name
+	}
+	return name
+}
+
+// upperSnake returns an UPPER_SNAKE_CASE enum value name
+func upperSnake(s string) string {
+	return strings.ToUpper(strings.Join(words(s), "_"))
+}
+
+// pascalCase joins the words of a name, e.g. shipping_address becomes ShippingAddress
+func pascalCase(s string) string {
+	var b strings.Builder
+	for _, word := range words(s) {
+		runes := []rune(word)
+		b.WriteRune(unicode.ToUpper(runes[0]))
+		b.WriteString(string(runes[1:]))
+	}
+	return b.String()
+}
+
+// lowerCamel returns the JSON name protoc derives from a field name
+func lowerCamel(field string) string {
+	var b strings.Builder
+	upper := false
+	for _, r := range field {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// uniqueName returns name, or name with a number appended if it is taken
+func uniqueName(name string, taken map[string]bool) string {
+	unique := name
+	for i := 2; taken[unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	taken[unique] = true
+	return unique
+}
+
+// indent prefixes every non-empty line of s
+func indent(s, prefix string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,196 @@
+package proto
+
+import (
+	"path/filepath"
+	"testing"
+
+	"dtoForge/internal/generator"
+	"dtoForge/internal/testutils"
+)
+
+func TestProtoGenerator_Language(t *testing.T) {
+	gen := NewProtoGenerator()
+	if got := gen.Language(); got != "proto" {
+		t.Errorf("Language() = %v, want %v", got, "proto")
+	}
+	if got := gen.FileExtension(); got != ".proto" {
+		t.Errorf("FileExtension() = %v, want %v", got, ".proto")
+	}
+}
+
+func TestProtoGenerator_PrimitiveToProto(t *testing.T) {
+	gen := NewProtoGenerator()
+	gen.customTypes = NewCustomTypeRegistry()
+	gen.imports = make(map[string]bool)
+
+	tests := []struct {
+		prim     generator.PrimitiveType
+		expected string
+	}{
+		{generator.PrimitiveType{Name: "string"}, "string"},
+		{generator.PrimitiveType{Name: "string", Format: "date-time"}, "google.protobuf.Timestamp"},
+		{generator.PrimitiveType{Name: "integer"}, "int32"},
+		{generator.PrimitiveType{Name: "integer", Format: "int64"}, "int64"},
+		{generator.PrimitiveType{Name: "bigint", Format: "uint64"}, "uint64"},
+		{generator.PrimitiveType{Name: "number"}, "double"},
+		{generator.PrimitiveType{Name: "number", Format: "float"}, "float"},
+		{generator.PrimitiveType{Name: "binary", Format: "binary"}, "bytes"},
+		{generator.PrimitiveType{Name: "boolean"}, "bool"},
+		{generator.PrimitiveType{Name: "unknown"}, "google.protobuf.Value"},
+	}
+
+	for _, tt := range tests {
+		if got := gen.primitiveToProto(tt.prim); got != tt.expected {
+			t.Errorf("primitiveToProto(%+v) = %v, want %v", tt.prim, got, tt.expected)
+		}
+	}
+	if !gen.imports["google/protobuf/timestamp.proto"] || !gen.imports["google/protobuf/struct.proto"] {
+		t.Errorf("imports = %v, want timestamp.proto and struct.proto", gen.imports)
+	}
+}
+
+func TestProtoGenerator_Generate(t *testing.T) {
+	tempDir := testutils.TempDir(t)
+
+	pet := testutils.CreateTestDTO("Pet")
+	pet.Properties = append(pet.Properties,
+		generator.Property{Name: "createdAt", Type: generator.PrimitiveType{Name: "string", Format: "date-time"}},
+		generator.Property{Name: "shipping_address", Type: generator.ReferenceType{RefName: "Address"}},
+		generator.Property{Name: "tags", Type: generator.ArrayType{ElementType: generator.PrimitiveType{Name: "string"}}},
+		generator.Property{Name: "kind", Type: generator.EnumType{Name: "PetKind", Values: []string{"cat", "dog"}}},
+		generator.Property{Name: "value", Type: generator.UnionType{Types: []generator.IRType{
+			generator.PrimitiveType{Name: "string"},
+			generator.PrimitiveType{Name: "integer"},
+		}}},
+	)
+	status := generator.DTO{Name: "Status", Type: "enum", EnumValues: []string{"active", "in-progress"}}
+
+	gen := NewProtoGenerator()
+	config := generator.Config{OutputFolder: tempDir, PackageName: "pets.v1"}
+	if err := gen.Generate([]generator.DTO{pet, status}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	protoFile := filepath.Join(tempDir, "schemas.proto")
+	testutils.AssertFileContains(t, protoFile, `syntax = "proto3";`)
+	testutils.AssertFileContains(t, protoFile, "package pets.v1;")
+	testutils.AssertFileContains(t, protoFile, `import "google/protobuf/timestamp.proto";`)
+	testutils.AssertFileContains(t, protoFile, "  // Identifier\n  string id = 1;")
+	testutils.AssertFileContains(t, protoFile, "  optional string name = 2;")
+	testutils.AssertFileContains(t, protoFile, "  google.protobuf.Timestamp created_at = 3;")
+	testutils.AssertFileContains(t, protoFile, `  Address shipping_address = 4 [json_name = "shipping_address"];`)
+	testutils.AssertFileContains(t, protoFile, "  repeated string tags = 5;")
+	testutils.AssertFileContains(t, protoFile, "    PET_KIND_UNSPECIFIED = 0;\n    PET_KIND_CAT = 1;")
+	testutils.AssertFileContains(t, protoFile, "  oneof value {\n    string value_string = 7;\n    int32 value_integer = 8;\n  }")
+	testutils.AssertFileContains(t, protoFile, "enum Status {\n  STATUS_UNSPECIFIED = 0;\n  STATUS_ACTIVE = 1;\n  STATUS_IN_PROGRESS = 2;\n}")
+	testutils.AssertFileExists(t, filepath.Join(tempDir, "dtoforge.proto.lock.json"))
+}
+
+func TestProtoGenerator_StableNumbering(t *testing.T) {
+	tempDir := testutils.TempDir(t)
+	config := generator.Config{OutputFolder: tempDir}
+	gen := NewProtoGenerator()
+
+	pet := generator.DTO{Name: "Pet", Type: "object", Properties: []generator.Property{
+		{Name: "id", Type: generator.PrimitiveType{Name: "string"}, Required: true},
+		{Name: "name", Type: generator.PrimitiveType{Name: "string"}, Required: true},
+		{Name: "age", Type: generator.PrimitiveType{Name: "integer"}, Required: true},
+	}}
+	if err := gen.Generate([]generator.DTO{pet}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// Drop name, add owner in front of the others
+	pet.Properties = []generator.Property{
+		{Name: "owner", Type: generator.PrimitiveType{Name: "string"}, Required: true},
+		{Name: "id", Type: generator.PrimitiveType{Name: "string"}, Required: true},
+		{Name: "age", Type: generator.PrimitiveType{Name: "integer"}, Required: true},
+	}
+	if err := gen.Generate([]generator.DTO{pet}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	protoFile := filepath.Join(tempDir, "schemas.proto")
+	testutils.AssertFileContains(t, protoFile, "  string owner = 4;")
+	testutils.AssertFileContains(t, protoFile, "  string id = 1;")
+	testutils.AssertFileContains(t, protoFile, "  int32 age = 3;")
+	testutils.AssertFileContains(t, protoFile, "  reserved 2;\n  reserved \"name\";")
+
+	// A field that returns gets a new number, its old one stays reserved
+	pet.Properties = append(pet.Properties, generator.Property{Name: "name", Type: generator.PrimitiveType{Name: "string"}, Required: true})
+	if err := gen.Generate([]generator.DTO{pet}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	testutils.AssertFileContains(t, protoFile, "  string name = 5;")
+	testutils.AssertFileContains(t, protoFile, "  reserved 2;\n")
+	testutils.AssertFileNotContains(t, protoFile, `reserved "name";`)
+}
+
+func TestProtoGenerator_WarnsUntypedProperties(t *testing.T) {
+	dto := generator.DTO{Name: "Grid", Type: "object", Properties: []generator.Property{{
+		Name: "cells",
+		Type: generator.ArrayType{ElementType: generator.ArrayType{ElementType: generator.PrimitiveType{Name: "integer"}}},
+	}}}
+
+	warnings := &generator.WarningCollector{}
+	gen := NewProtoGenerator()
+	config := generator.Config{OutputFolder: testutils.TempDir(t), Warnings: warnings}
+	if err := gen.Generate([]generator.DTO{dto}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	testutils.AssertFileContains(t, filepath.Join(config.OutputFolder, "schemas.proto"), "google.protobuf.ListValue cells = 1;")
+	got := warnings.Warnings()
+	if len(got) != 1 || got[0].Code != "untyped-proto" || got[0].Property != "cells" {
+		t.Errorf("warnings = %v, want one untyped-proto warning for Grid.cells", got)
+	}
+}
+
+func TestProtoGenerator_ReservedNames(t *testing.T) {
+	holder := generator.DTO{Name: "Holder", Type: "object", Properties: []generator.Property{
+		{Name: "value", Type: generator.ReferenceType{RefName: "string"}},
+	}}
+	// A message named after a scalar would be shadowed by it
+	dtos := []generator.DTO{holder, {Name: "string", Type: "object"}, {Name: "class", Type: "object"}}
+
+	warnings := &generator.WarningCollector{}
+	config := generator.Config{OutputFolder: testutils.TempDir(t), Warnings: warnings}
+	if err := NewProtoGenerator().Generate(dtos, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	protoFile := filepath.Join(config.OutputFolder, "schemas.proto")
+	testutils.AssertFileContains(t, protoFile, "message string_ {")
+	testutils.AssertFileContains(t, protoFile, "string_ value = 1;")
+	testutils.AssertFileContains(t, protoFile, "message class {")
+	if got := warnings.Warnings(); len(got) != 1 || got[0].Schema != "string" {
+		t.Errorf("warnings = %v, want one reserved-name warning for string", got)
+	}
+}
+
+func TestLoadManifest_Version(t *testing.T) {
+	path := testutils.WriteFile(t, testutils.TempDir(t), "lock.json", `{"version": 99}`)
+	if _, err := loadManifest(path); err == nil {
+		t.Error("expected an error for an unsupported manifest version")
+	}
+}
+
+func TestProtoGenerator_NumericEnums(t *testing.T) {
+	priority := generator.DTO{Name: "Priority", Type: "enum", EnumValues: []string{"1", "2", "-3"}, EnumValueType: "integer"}
+	task := generator.DTO{Name: "Task", Type: "object", Properties: []generator.Property{
+		{Name: "priority", Type: generator.ReferenceType{RefName: "Priority"}, Required: true},
+		{Name: "weight", Type: generator.EnumType{Values: []string{"0.5", "1.5"}, UnderlyingType: "number"}, Required: true},
+	}}
+	warnings := &generator.WarningCollector{}
+	config := generator.Config{OutputFolder: testutils.TempDir(t), Warnings: warnings}
+	if err := NewProtoGenerator().Generate([]generator.DTO{priority, task}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	protoFile := filepath.Join(config.OutputFolder, "schemas.proto")
+	testutils.AssertFileContains(t, protoFile, "  int32 priority = 1;\n  double weight = 2;\n")
+	testutils.AssertFileNotContains(t, protoFile, "enum Priority")
+	if got := warnings.Warnings(); len(got) != 2 || got[0].Code != "numeric-enum" || got[1].Property != "weight" {
+		t.Errorf("warnings = %v, want numeric-enum warnings for Priority and Task.weight", got)
+	}
+}
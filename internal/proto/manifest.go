@@ -0,0 +1,147 @@
+package proto
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// manifestVersion is bumped when the manifest format changes incompatibly
+const manifestVersion = 1
+
+// Manifest records the number of every message field and enum value ever
+// generated, so numbers stay stable across runs and are never reused after
+// a field is removed. It is written next to the .proto file and should be
+// committed with it.
+type Manifest struct {
+	Version  int                       `json:"version"`
+	Messages map[string]*NumberedNames `json:"messages"`
+	Enums    map[string]*NumberedNames `json:"enums"`
+}
+
+// NumberedNames holds the numbers of one message or enum. Numbers of names
+// that disappeared are reserved and never handed out again.
+type NumberedNames struct {
+	Numbers       map[string]int `json:"numbers"`
+	Reserved      []int          `json:"reserved,omitempty"`
+	ReservedNames []string       `json:"reservedNames,omitempty"`
+}
+
+// newManifest returns an empty manifest
+func newManifest() *Manifest {
+	return &Manifest{
+		Version:  manifestVersion,
+		Messages: make(map[string]*NumberedNames),
+		Enums:    make(map[string]*NumberedNames),
+	}
+}
+
+// loadManifest reads the manifest at path, or returns an empty one if the
+// file does not exist yet
+func loadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return newManifest(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read proto manifest %s: %w", path, err)
+	}
+
+	manifest := newManifest()
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse proto manifest %s: %w", path, err)
+	}
+	if manifest.Version != manifestVersion {
+		return nil, fmt.Errorf("unsupported proto manifest version %d in %s, expected %d", manifest.Version, path, manifestVersion)
+	}
+	if manifest.Messages == nil {
+		manifest.Messages = make(map[string]*NumberedNames)
+	}
+	if manifest.Enums == nil {
+		manifest.Enums = make(map[string]*NumberedNames)
+	}
+	return manifest, nil
+}
+
+// save writes the manifest as indented JSON
+func (m *Manifest) save(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// message returns the numbering of a message, creating it if needed
+func (m *Manifest) message(name string) *NumberedNames {
+	return numbered(m.Messages, name)
+}
+
+// enum returns the numbering of an enum, creating it if needed
+func (m *Manifest) enum(name string) *NumberedNames {
+	return numbered(m.Enums, name)
+}
+
+func numbered(all map[string]*NumberedNames, name string) *NumberedNames {
+	entry, ok := all[name]
+	if !ok || entry == nil {
+		entry = &NumberedNames{}
+		all[name] = entry
+	}
+	if entry.Numbers == nil {
+		entry.Numbers = make(map[string]int)
+	}
+	return entry
+}
+
+// number returns the number of name, assigning the next free one above
+// first if it has none yet. A name that comes back after being removed gets a
+// new number, since its type may have changed; only the old number stays
+// reserved.
+func (n *NumberedNames) number(name string, first int) int {
+	if number, ok := n.Numbers[name]; ok {
+		return number
+	}
+	for i, reserved := range n.ReservedNames {
+		if reserved == name {
+			n.ReservedNames = append(n.ReservedNames[:i], n.ReservedNames[i+1:]...)
+			break
+		}
+	}
+
+	next := first
+	for _, number := range n.Numbers {
+		next = max(next, number+1)
+	}
+	for _, number := range n.Reserved {
+		next = max(next, number+1)
+	}
+	// 19000-19999 are reserved for the protobuf implementation
+	if next >= 19000 && next <= 19999 {
+		next = 20000
+	}
+
+	n.Numbers[name] = next
+	return next
+}
+
+// retire reserves the numbers of every name not in used, so a later field
+// of the same name or number cannot be read as the removed one
+func (n *NumberedNames) retire(used map[string]bool) {
+	var names []string
+	for name := range n.Numbers {
+		if !used[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		n.Reserved = append(n.Reserved, n.Numbers[name])
+		n.ReservedNames = append(n.ReservedNames, name)
+		delete(n.Numbers, name)
+	}
+	sort.Ints(n.Reserved)
+	sort.Strings(n.ReservedNames)
+}
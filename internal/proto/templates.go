@@ -0,0 +1,59 @@
+package proto
+
+const fileTemplate = `// Generated by DtoForge - DO NOT EDIT
+syntax = "proto3";
+
+package {{.Package}};
+{{if .Imports}}
+{{range .Imports}}import "{{.}}";
+{{end}}{{end}}{{if .Options}}
+{{range .Options}}option {{.}};
+{{end}}{{end}}{{range .Enums}}
+{{template "enum" .}}{{end}}{{range .Messages}}
+{{template "message" .}}{{end}}`
+
+// declTemplates render messages and enums; nested declarations are rendered
+// through the nested func, which indents them inside their parent
+const declTemplates = `
+{{- define "message"}}{{.Comment}}message {{.Name}} {
+{{- if .Deprecated}}
+  option deprecated = true;
+{{- end}}
+{{- with .Reserved}}
+  reserved {{numbers .}};
+{{- end}}
+{{- with .ReservedNames}}
+  reserved {{names .}};
+{{- end}}
+{{- range .Enums}}
+{{nested "enum" .}}{{end}}
+{{- range .Messages}}
+{{nested "message" .}}{{end}}
+{{- range .Fields}}
+{{.Comment}}  {{with .Label}}{{.}} {{end}}{{.Type}} {{.Name}} = {{.Number}}{{.OptionsText}};
+{{- end}}
+{{- range .Oneofs}}
+{{.Comment}}  oneof {{.Name}} {
+{{- range .Fields}}
+    {{.Type}} {{.Name}} = {{.Number}}{{.OptionsText}};
+{{- end}}
+  }
+{{- end}}
+}
+{{end}}
+
+{{- define "enum"}}{{.Comment}}enum {{.Name}} {
+{{- if .Deprecated}}
+  option deprecated = true;
+{{- end}}
+{{- with .Reserved}}
+  reserved {{numbers .}};
+{{- end}}
+{{- with .ReservedNames}}
+  reserved {{names .}};
+{{- end}}
+{{- range .Values}}
+  {{.Name}} = {{.Number}};
+{{- end}}
+}
+{{end}}`
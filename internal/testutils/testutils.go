@@ -108,6 +108,6 @@ func CreateTestDTO(name string) generator.DTO {
 				Required:    false,
 			},
 		},
-		Metadata: make(map[string]string),
+		Metadata: make(generator.Metadata),
 	}
 }
@@ -6,6 +6,8 @@ import (
 	"sort"
 
 	"gopkg.in/yaml.v3"
+
+	"dtoForge/internal/generator"
 )
 
 // OutputConfig defines output behavior
@@ -13,13 +15,36 @@ type OutputConfig struct {
 	Folder         string `yaml:"folder"`
 	Mode           string `yaml:"mode"`           // "multiple" or "single"
 	SingleFileName string `yaml:"singleFileName"` // for single file mode
+	GroupBy        string `yaml:"groupBy"`        // "" or "tag" (multiple file mode only)
+	// Pragmas are comments prepended to every generated source file, e.g.
+	// "/* eslint-disable */"
+	Pragmas []string `yaml:"pragmas"`
+	// Prune removes the files an earlier run generated that this run no
+	// longer does, in every target language
+	Prune bool `yaml:"prune"`
 }
 
 // GenerationConfig defines what to generate
 type GenerationConfig struct {
-	GeneratePackageJson   bool `yaml:"generatePackageJson"`
-	GeneratePartialCodecs bool `yaml:"generatePartialCodecs"`
-	GenerateHelpers       bool `yaml:"generateHelpers"`
+	GeneratePackageJson   bool   `yaml:"generatePackageJson"`
+	GeneratePartialCodecs *bool  `yaml:"generatePartialCodecs"` // default true in multiple-file mode, false in single-file mode
+	GenerateHelpers       bool   `yaml:"generateHelpers"`
+	BinaryTarget          string `yaml:"binaryTarget"`    // "universal", "browser" or "node"
+	SourceComments        bool   `yaml:"sourceComments"`  // add @see comments pointing into the spec
+	ExactCodecs           bool   `yaml:"exactCodecs"`     // wrap object codecs in t.exact to strip unknown keys
+	BrandedTypes          bool   `yaml:"brandedTypes"`    // map uuid, email and date-time to codecs in branded-types.ts
+	UseIoTsTypes          bool   `yaml:"useIoTsTypes"`    // decode more formats with io-ts-types codecs
+	Readonly              bool   `yaml:"readonly"`        // t.readonly objects and t.readonlyArray arrays
+	Refinements           bool   `yaml:"refinements"`     // enforce string and number constraints with codecs in refinements.ts
+	IoTsVersion           int    `yaml:"ioTsVersion"`     // major io-ts version targeted: 1 or 2
+	FpTsHelpers           bool   `yaml:"fpTsHelpers"`     // generate validation.ts with fp-ts pipeline helpers
+	EnumStyle             string `yaml:"enumStyle"`       // enums as "keyof", "union" or "enum"
+	BrandedIntegers       bool   `yaml:"brandedIntegers"` // integers as the branded t.Int instead of t.number
+	// PartialCodecs overrides GeneratePartialCodecs per schema name
+	PartialCodecs map[string]bool `yaml:"partialCodecs"`
+	// RequestResponseCodecs adds <Name>RequestCodec without the read-only
+	// and <Name>ResponseCodec without the write-only properties
+	RequestResponseCodecs bool `yaml:"requestResponseCodecs"`
 }
 
 // CustomTypeMapping defines how to map OpenAPI formats to TypeScript/io-ts types
@@ -39,6 +64,8 @@ type EnhancedCustomTypeConfig struct {
 // CustomTypeRegistry holds all custom type mappings and config
 type CustomTypeRegistry struct {
 	mappings   map[string]CustomTypeMapping
+	helpers    map[string]bool // formats backed by the generated format-helpers file
+	branded    map[string]bool // formats backed by the generated branded-types file
 	output     OutputConfig
 	generation GenerationConfig
 }
@@ -47,15 +74,19 @@ type CustomTypeRegistry struct {
 func NewCustomTypeRegistry() *CustomTypeRegistry {
 	registry := &CustomTypeRegistry{
 		mappings: make(map[string]CustomTypeMapping),
+		helpers:  make(map[string]bool),
+		branded:  make(map[string]bool),
 		output: OutputConfig{
 			Folder:         "./generated",
 			Mode:           "multiple",
 			SingleFileName: "schemas.ts",
 		},
 		generation: GenerationConfig{
-			GeneratePackageJson:   true,
-			GeneratePartialCodecs: true,
-			GenerateHelpers:       true,
+			GeneratePackageJson: true,
+			GenerateHelpers:     true,
+			BinaryTarget:        "universal",
+			IoTsVersion:         2,
+			EnumStyle:           "keyof",
 		},
 	}
 
@@ -73,11 +104,29 @@ func (r *CustomTypeRegistry) GetGenerationConfig() GenerationConfig {
 	return r.generation
 }
 
+// HasPartialCodec reports whether the config enables a partial codec for a
+// schema: its partialCodecs entry, or generatePartialCodecs. Unset, partial
+// codecs are generated in multiple-file mode only, as they always were.
+func (r *CustomTypeRegistry) HasPartialCodec(name string) bool {
+	if enabled, ok := r.generation.PartialCodecs[name]; ok {
+		return enabled
+	}
+	if r.generation.GeneratePartialCodecs == nil {
+		return !r.IsSingleFileMode()
+	}
+	return *r.generation.GeneratePartialCodecs
+}
+
 // IsSingleFileMode returns true if single file output is configured
 func (r *CustomTypeRegistry) IsSingleFileMode() bool {
 	return r.output.Mode == "single"
 }
 
+// IsTagGrouped returns true if files should be grouped into folders by tag
+func (r *CustomTypeRegistry) IsTagGrouped() bool {
+	return r.output.GroupBy == "tag" && !r.IsSingleFileMode()
+}
+
 // GetSingleFileName returns the filename for single file mode
 func (r *CustomTypeRegistry) GetSingleFileName() string {
 	if r.output.SingleFileName == "" {
@@ -117,11 +166,89 @@ func (r *CustomTypeRegistry) addDefaultMappings() {
 		TypeScriptType:  "string",
 		ImportStatement: "",
 	}
+
+	r.registerHelper("binary", CustomTypeMapping{
+		IoTsType:        "BinaryData",
+		TypeScriptType:  "BinaryData",
+		ImportStatement: "import { BinaryData } from './format-helpers';",
+	})
+
+	r.registerHelper("byte", CustomTypeMapping{
+		IoTsType:        "Base64String",
+		TypeScriptType:  "Base64String",
+		ImportStatement: "import { Base64String } from './format-helpers';",
+	})
 }
 
 // Register adds or updates a custom type mapping
 func (r *CustomTypeRegistry) Register(format string, mapping CustomTypeMapping) {
 	r.mappings[format] = mapping
+	delete(r.helpers, format)
+	delete(r.branded, format)
+}
+
+// registerHelper adds a mapping whose codec is emitted into format-helpers.ts
+func (r *CustomTypeRegistry) registerHelper(format string, mapping CustomTypeMapping) {
+	r.mappings[format] = mapping
+	r.helpers[format] = true
+}
+
+// addIoTsTypesMappings maps formats to io-ts-types codecs: UUIDs, and
+// numbers sent as strings
+func (r *CustomTypeRegistry) addIoTsTypesMappings() {
+	r.Register("uuid", CustomTypeMapping{
+		IoTsType:        "UUID",
+		TypeScriptType:  "UUID",
+		ImportStatement: "import { UUID } from 'io-ts-types';",
+	})
+	r.Register("number", CustomTypeMapping{
+		IoTsType:        "NumberFromString",
+		TypeScriptType:  "number",
+		ImportStatement: "import { NumberFromString } from 'io-ts-types';",
+	})
+	r.Register("integer", CustomTypeMapping{
+		IoTsType:        "IntFromString",
+		TypeScriptType:  "t.Int",
+		ImportStatement: "import { IntFromString } from 'io-ts-types';",
+	})
+}
+
+// addBrandedMappings maps formats to the branded codecs emitted into
+// branded-types.ts
+func (r *CustomTypeRegistry) addBrandedMappings() {
+	for format, name := range map[string]string{"uuid": "UUID", "email": "EmailString", "date-time": "DateTimeString"} {
+		r.Register(format, CustomTypeMapping{
+			IoTsType:        name,
+			TypeScriptType:  name,
+			ImportStatement: fmt.Sprintf("import { %s } from './branded-types';", name),
+		})
+		r.branded[format] = true
+	}
+}
+
+// GetBrandedFormats returns the used formats that need the generated
+// branded-types file
+func (r *CustomTypeRegistry) GetBrandedFormats(usedFormats []string) []string {
+	var formats []string
+	for _, format := range usedFormats {
+		if r.branded[format] {
+			formats = append(formats, format)
+		}
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// GetHelperFormats returns the used formats that need the generated format-helpers file
+func (r *CustomTypeRegistry) GetHelperFormats(usedFormats []string) []string {
+	var formats []string
+	for _, format := range usedFormats {
+		if r.helpers[format] {
+			formats = append(formats, format)
+		}
+	}
+	sort.Strings(formats)
+	return formats
 }
 
 // Get retrieves a mapping for a given format
@@ -173,7 +300,7 @@ func (r *CustomTypeRegistry) LoadFromConfig(configPath string) error {
 	}
 
 	// Load output config if provided
-	if config.Output.Folder != "" || config.Output.Mode != "" || config.Output.SingleFileName != "" {
+	if config.Output.Folder != "" || config.Output.Mode != "" || config.Output.SingleFileName != "" || config.Output.GroupBy != "" {
 		if config.Output.Folder != "" {
 			r.output.Folder = config.Output.Folder
 		}
@@ -186,12 +313,64 @@ func (r *CustomTypeRegistry) LoadFromConfig(configPath string) error {
 		if config.Output.SingleFileName != "" {
 			r.output.SingleFileName = config.Output.SingleFileName
 		}
+		if config.Output.GroupBy != "" {
+			if config.Output.GroupBy != "tag" {
+				return fmt.Errorf("invalid output groupBy '%s', must be 'tag'", config.Output.GroupBy)
+			}
+			r.output.GroupBy = config.Output.GroupBy
+		}
 	}
+	if err := generator.ValidatePragmas(config.Output.Pragmas); err != nil {
+		return err
+	}
+	r.output.Pragmas = config.Output.Pragmas
+	r.output.Prune = config.Output.Prune
 
 	// Load generation config if provided
 	r.generation.GeneratePackageJson = config.Generation.GeneratePackageJson
 	r.generation.GeneratePartialCodecs = config.Generation.GeneratePartialCodecs
 	r.generation.GenerateHelpers = config.Generation.GenerateHelpers
+	r.generation.SourceComments = config.Generation.SourceComments
+	r.generation.ExactCodecs = config.Generation.ExactCodecs
+	r.generation.BrandedTypes = config.Generation.BrandedTypes
+	r.generation.UseIoTsTypes = config.Generation.UseIoTsTypes
+	r.generation.Readonly = config.Generation.Readonly
+	r.generation.Refinements = config.Generation.Refinements
+	r.generation.BrandedIntegers = config.Generation.BrandedIntegers
+	r.generation.FpTsHelpers = config.Generation.FpTsHelpers
+	r.generation.PartialCodecs = config.Generation.PartialCodecs
+	r.generation.RequestResponseCodecs = config.Generation.RequestResponseCodecs
+	if config.Generation.IoTsVersion != 0 {
+		if config.Generation.IoTsVersion != 1 && config.Generation.IoTsVersion != 2 {
+			return fmt.Errorf("invalid io-ts version %d, must be 1 or 2", config.Generation.IoTsVersion)
+		}
+		r.generation.IoTsVersion = config.Generation.IoTsVersion
+	}
+	if config.Generation.EnumStyle != "" {
+		switch config.Generation.EnumStyle {
+		case "keyof", "union", "enum":
+			r.generation.EnumStyle = config.Generation.EnumStyle
+		default:
+			return fmt.Errorf("invalid enum style '%s', must be 'keyof', 'union' or 'enum'", config.Generation.EnumStyle)
+		}
+	}
+	if config.Generation.BinaryTarget != "" {
+		switch config.Generation.BinaryTarget {
+		case "universal", "browser", "node":
+			r.generation.BinaryTarget = config.Generation.BinaryTarget
+		default:
+			return fmt.Errorf("invalid binary target '%s', must be 'universal', 'browser' or 'node'", config.Generation.BinaryTarget)
+		}
+	}
+
+	// io-ts-types and branded codecs replace the plain defaults; customTypes
+	// still win
+	if r.generation.UseIoTsTypes {
+		r.addIoTsTypesMappings()
+	}
+	if r.generation.BrandedTypes {
+		r.addBrandedMappings()
+	}
 
 	// Register all custom types from config
 	for format, mapping := range config.CustomTypes {
@@ -203,6 +382,7 @@ func (r *CustomTypeRegistry) LoadFromConfig(configPath string) error {
 
 // SaveExampleConfig creates an example configuration file
 func (r *CustomTypeRegistry) SaveExampleConfig(configPath string) error {
+	generatePartialCodecs := true
 	exampleConfig := EnhancedCustomTypeConfig{
 		Output: OutputConfig{
 			Folder:         "./generated",
@@ -211,7 +391,7 @@ func (r *CustomTypeRegistry) SaveExampleConfig(configPath string) error {
 		},
 		Generation: GenerationConfig{
 			GeneratePackageJson:   true,
-			GeneratePartialCodecs: true,
+			GeneratePartialCodecs: &generatePartialCodecs,
 			GenerateHelpers:       true,
 		},
 		CustomTypes: map[string]CustomTypeMapping{
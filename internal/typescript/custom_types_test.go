@@ -165,7 +165,7 @@ func TestCustomTypeRegistry_GenerationConfig(t *testing.T) {
 	if !config.GeneratePackageJson {
 		t.Error("Should generate package.json by default")
 	}
-	if !config.GeneratePartialCodecs {
+	if !registry.HasPartialCodec("User") {
 		t.Error("Should generate partial codecs by default")
 	}
 	if !config.GenerateHelpers {
@@ -226,7 +226,7 @@ customTypes:
 	if genConfig.GeneratePackageJson {
 		t.Error("GeneratePackageJson should be false")
 	}
-	if genConfig.GeneratePartialCodecs {
+	if registry.HasPartialCodec("User") {
 		t.Error("GeneratePartialCodecs should be false")
 	}
 	if genConfig.GenerateHelpers {
@@ -283,6 +283,24 @@ func TestCustomTypeRegistry_LoadFromConfig_InvalidMode(t *testing.T) {
 	}
 }
 
+func TestCustomTypeRegistry_LoadFromConfig_GroupBy(t *testing.T) {
+	tempDir := testutils.TempDir(t)
+
+	registry := NewCustomTypeRegistry()
+	if err := registry.LoadFromConfig(testutils.WriteFile(t, tempDir, "tag.yaml", "output:\n  groupBy: tag")); err != nil {
+		t.Fatalf("LoadFromConfig() failed: %v", err)
+	}
+	if !registry.IsTagGrouped() {
+		t.Error("Expected tag grouping to be enabled")
+	}
+
+	registry = NewCustomTypeRegistry()
+	err := registry.LoadFromConfig(testutils.WriteFile(t, tempDir, "invalid.yaml", "output:\n  groupBy: folder"))
+	if err == nil || !contains(err.Error(), "invalid output groupBy") {
+		t.Errorf("Expected invalid groupBy error, got: %v", err)
+	}
+}
+
 func TestCustomTypeRegistry_SaveExampleConfig(t *testing.T) {
 	registry := NewCustomTypeRegistry()
 	tempDir := testutils.TempDir(t)
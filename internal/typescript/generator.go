@@ -2,11 +2,16 @@ package typescript
 
 import (
 	"fmt"
+	"hash/fnv"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"text/template"
+	"unicode"
 
 	"dtoForge/internal/generator"
 )
@@ -14,6 +19,12 @@ import (
 // TypeScriptGenerator implements the Generator interface for TypeScript/io-ts
 type TypeScriptGenerator struct {
 	customTypes *CustomTypeRegistry
+	recursive   map[string]bool   // DTOs that take part in a reference cycle
+	partials    map[string]bool   // DTOs that get a partial codec
+	variants    map[string]bool   // DTOs that get request and response codecs
+	encoders    map[string]bool   // DTOs whose codecs transform values when encoding
+	groups      map[string]string // output folder of each DTO when grouping by tag
+	helpers     map[string]string // name each DTO's helper functions are built from
 }
 
 // NewTypeScriptGenerator creates a new TypeScript generator
@@ -39,38 +50,126 @@ func (g *TypeScriptGenerator) Generate(dtos []generator.DTO, config generator.Co
 	// Load custom config if specified
 	if config.ConfigFile != "" {
 		if err := g.customTypes.LoadFromConfig(config.ConfigFile); err != nil {
-			return fmt.Errorf("failed to load custom types config from %s: %w", config.ConfigFile, err)
+			return &generator.ConfigError{Path: config.ConfigFile, Err: err}
 		}
 	}
+	// Schemas named after reserved words get a new name, their helpers are
+	// named from the PascalCase form (class_ gets isClass)
+	dtos, originals := generator.RenameReserved(dtos, generator.IsReservedWord, "TypeScript", config)
+	g.helpers = generator.HelperNames(dtos, originals)
+
+	// Numbers cannot be enum keys, so numeric enums become literal unions
+	dtos = generator.LiteralEnums(dtos)
+
+	if err := g.validateConfig(dtos); err != nil {
+		return &generator.ConfigError{Path: config.ConfigFile, Err: err}
+	}
+
+	g.warnUnmappedFormats(dtos, config.Warnings)
+	g.warnTupleRest(dtos, config.Warnings)
+	g.warnAdditionalProperties(dtos, config.Warnings)
 
 	// Sort DTOs to ensure consistent output and handle dependencies
 	sortedDTOs := g.sortDTOsByDependency(dtos)
 
+	// Cyclic DTOs need t.recursion and an explicit interface to compile
+	g.recursive = generator.FindCycles(sortedDTOs)
+
+	// Partial codecs of DTOs extending others build on the parents' partials
+	g.partials = g.partialCodecs(sortedDTOs)
+
+	// Request and response codecs strip read-only and write-only properties
+	variants, err := g.codecVariantDTOs(sortedDTOs, config.Warnings)
+	if err != nil {
+		return err
+	}
+	g.variants = variants
+
+	// Codecs like DateFromISOString encode to a different wire format
+	g.encoders = g.encodingDTOs(sortedDTOs)
+
+	// Group DTO files into one folder per tag if configured
+	g.groups = nil
+	if g.customTypes.IsTagGrouped() {
+		g.groups = generator.TagGroups(sortedDTOs)
+	}
+
 	// Get generation settings
 	genConfig := g.customTypes.GetGenerationConfig()
 
+	// Generate the codecs backing built-in format mappings (e.g. binary)
+	helperFormats := g.customTypes.GetHelperFormats(g.getUsedFormats(sortedDTOs))
+	if g.usesPrimitive(sortedDTOs, g.isDefaultBigInt) {
+		helperFormats = append(helperFormats, "bigint")
+	}
+	if len(helperFormats) > 0 {
+		if err := g.generateFormatHelpersFile(helperFormats, config, genConfig); err != nil {
+			return fmt.Errorf("failed to generate format helpers: %w", err)
+		}
+	}
+
+	// Generate the branded codecs of string formats if enabled
+	brandedFormats := g.customTypes.GetBrandedFormats(g.getUsedFormats(sortedDTOs))
+	if len(brandedFormats) > 0 {
+		if err := g.generateBrandedTypesFile(brandedFormats, config); err != nil {
+			return fmt.Errorf("failed to generate branded types: %w", err)
+		}
+	}
+
+	// Generate the refinement codecs enforcing constraints if enabled
+	refinements := g.usedRefinements(sortedDTOs)
+	if len(refinements) > 0 {
+		if err := g.generateRefinementsFile(refinements, config); err != nil {
+			return fmt.Errorf("failed to generate refinements: %w", err)
+		}
+	}
+
+	// Generate the fp-ts validation helpers if enabled
+	if genConfig.FpTsHelpers {
+		if err := g.generateValidationFile(config); err != nil {
+			return fmt.Errorf("failed to generate validation helpers: %w", err)
+		}
+	}
+
 	// Generate based on output mode
 	if g.customTypes.IsSingleFileMode() {
 		if err := g.generateSingleFile(sortedDTOs, config, genConfig); err != nil {
 			return fmt.Errorf("failed to generate single file: %w", err)
 		}
 	} else {
+		// Generate the decode helpers shared by the DTO files if enabled
+		if genConfig.GenerateHelpers {
+			if err := g.generateHelpersFile(config); err != nil {
+				return fmt.Errorf("failed to generate helpers: %w", err)
+			}
+		}
+
 		// Generate index file that exports all schemas
-		if err := g.generateIndexFile(sortedDTOs, config, genConfig); err != nil {
+		if err := g.generateIndexFile(sortedDTOs, config, genConfig, len(helperFormats) > 0, len(brandedFormats) > 0, len(refinements) > 0); err != nil {
 			return fmt.Errorf("failed to generate index file: %w", err)
 		}
 
 		// Generate individual files for each DTO
-		for _, dto := range sortedDTOs {
+		err := generator.ForEachDTO(sortedDTOs, config.Parallel, func(dto generator.DTO) error {
 			if err := g.generateDTOFile(dto, config, genConfig); err != nil {
 				return fmt.Errorf("failed to generate file for DTO %s: %w", dto.Name, err)
 			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if g.groups != nil {
+			if err := g.generateGroupIndexFiles(sortedDTOs, config); err != nil {
+				return fmt.Errorf("failed to generate group index files: %w", err)
+			}
 		}
 	}
 
 	// Generate package.json if needed
 	if genConfig.GeneratePackageJson {
-		if err := g.generatePackageJSON(config); err != nil {
+		if err := g.generatePackageJSON(config, genConfig); err != nil {
 			return fmt.Errorf("failed to generate package.json: %w", err)
 		}
 	}
@@ -83,51 +182,35 @@ func (g *TypeScriptGenerator) generateSingleFile(dtos []generator.DTO, config ge
 	filename := g.customTypes.GetSingleFileName()
 	filepath := filepath.Join(config.OutputFolder, filename)
 
-	file, err := os.Create(filepath)
+	file, err := g.createFile(config, filepath)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
-	tmpl, err := template.New("single-file").Funcs(g.templateFuncs()).Parse(singleFileTemplate)
+	tmpl, err := template.New("single-file").Funcs(g.templateFuncs()).Parse(singleFileTemplate + codecsTemplate + variantCodecsTemplate + enumTemplate + decodeHelpersTemplate)
 	if err != nil {
 		return fmt.Errorf("template parse error: %w", err)
 	}
 
 	// Calculate all imports needed for all DTOs
-	allFormats := []string{}
-	for _, dto := range dtos {
-		dtoFormats := g.getUsedFormatsInDTO(dto)
-		for _, format := range dtoFormats {
-			// Add to allFormats if not already present
-			found := false
-			for _, existing := range allFormats {
-				if existing == format {
-					found = true
-					break
-				}
-			}
-			if !found {
-				allFormats = append(allFormats, format)
-			}
-		}
+	allImports := g.withPrimitiveImports(g.customTypes.GetAllImports(g.getUsedFormats(dtos)), dtos)
+	if genConfig.GenerateHelpers {
+		allImports = append(allImports, g.decodeHelpersImports()...)
 	}
-	allImports := g.customTypes.GetAllImports(allFormats)
 
 	data := struct {
-		DTOs                  []generator.DTO
-		Config                generator.Config
-		Imports               []string
-		PackageName           string
-		GeneratePartialCodecs bool
-		GenerateHelpers       bool
+		DTOs            []generator.DTO
+		Config          generator.Config
+		Imports         []string
+		PackageName     string
+		GenerateHelpers bool
 	}{
-		DTOs:                  dtos,
-		Config:                config,
-		Imports:               allImports,
-		PackageName:           g.getPackageName(config),
-		GeneratePartialCodecs: genConfig.GeneratePartialCodecs,
-		GenerateHelpers:       genConfig.GenerateHelpers,
+		DTOs:            dtos,
+		Config:          config,
+		Imports:         allImports,
+		PackageName:     g.getPackageName(config),
+		GenerateHelpers: genConfig.GenerateHelpers,
 	}
 
 	err = tmpl.Execute(file, data)
@@ -139,41 +222,54 @@ func (g *TypeScriptGenerator) generateSingleFile(dtos []generator.DTO, config ge
 }
 
 func (g *TypeScriptGenerator) generateDTOFile(dto generator.DTO, config generator.Config, genConfig GenerationConfig) error {
+	folder := filepath.Join(config.OutputFolder, g.groups[dto.Name])
+	if err := os.MkdirAll(folder, 0755); err != nil {
+		return err
+	}
+
 	filename := fmt.Sprintf("%s%s", g.toKebabCase(dto.Name), g.FileExtension())
-	filepath := filepath.Join(config.OutputFolder, filename)
+	filepath := filepath.Join(folder, filename)
+	if config.KeepFile(dto.Name, filepath) {
+		return nil
+	}
 
-	file, err := os.Create(filepath)
+	file, err := g.createFile(config, filepath)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
-	tmpl, err := template.New("dto").Funcs(g.templateFuncs()).Parse(dtoTemplate)
+	tmpl, err := template.New("dto").Funcs(g.templateFuncs()).Parse(dtoTemplate + codecsTemplate + variantCodecsTemplate + enumTemplate)
 	if err != nil {
 		return err
 	}
 
+	imports := g.calculateImports(dto)
+	if genConfig.GenerateHelpers {
+		imports = append(imports, generator.RebaseImport(decodeOrThrowImport, g.groups[dto.Name]))
+	}
+
 	data := struct {
-		DTO                   generator.DTO
-		Config                generator.Config
-		Imports               []string
-		PackageName           string
-		GeneratePartialCodecs bool
+		DTO             generator.DTO
+		Config          generator.Config
+		Imports         []string
+		PackageName     string
+		GenerateHelpers bool
 	}{
-		DTO:                   dto,
-		Config:                config,
-		Imports:               g.calculateImports(dto),
-		PackageName:           g.getPackageName(config),
-		GeneratePartialCodecs: genConfig.GeneratePartialCodecs,
+		DTO:             dto,
+		Config:          config,
+		Imports:         imports,
+		PackageName:     g.getPackageName(config),
+		GenerateHelpers: genConfig.GenerateHelpers,
 	}
 	return tmpl.Execute(file, data)
 }
 
 // Updated generateIndexFile to accept genConfig
-func (g *TypeScriptGenerator) generateIndexFile(dtos []generator.DTO, config generator.Config, genConfig GenerationConfig) error {
+func (g *TypeScriptGenerator) generateIndexFile(dtos []generator.DTO, config generator.Config, genConfig GenerationConfig, hasFormatHelpers, hasBrandedTypes, hasRefinements bool) error {
 	filepath := filepath.Join(config.OutputFolder, "index.ts")
 
-	file, err := os.Create(filepath)
+	file, err := g.createFile(config, filepath)
 	if err != nil {
 		return err
 	}
@@ -185,22 +281,187 @@ func (g *TypeScriptGenerator) generateIndexFile(dtos []generator.DTO, config gen
 	}
 
 	data := struct {
-		DTOs            []generator.DTO
-		Config          generator.Config
-		PackageName     string
-		GenerateHelpers bool
+		DTOs             []generator.DTO
+		Config           generator.Config
+		PackageName      string
+		GenerateHelpers  bool
+		HasFormatHelpers bool
+		HasBrandedTypes  bool
+		HasRefinements   bool
+		HasValidation    bool
 	}{
-		DTOs:            dtos,
-		Config:          config,
-		PackageName:     g.getPackageName(config),
-		GenerateHelpers: genConfig.GenerateHelpers,
+		DTOs:             dtos,
+		Config:           config,
+		PackageName:      g.getPackageName(config),
+		GenerateHelpers:  genConfig.GenerateHelpers,
+		HasFormatHelpers: hasFormatHelpers,
+		HasBrandedTypes:  hasBrandedTypes,
+		HasRefinements:   hasRefinements,
+		HasValidation:    genConfig.FpTsHelpers,
 	}
 
 	return tmpl.Execute(file, data)
 }
 
+// generateGroupIndexFiles writes an index file into every tag folder that
+// exports the DTOs in it
+func (g *TypeScriptGenerator) generateGroupIndexFiles(dtos []generator.DTO, config generator.Config) error {
+	tmpl, err := template.New("group-index").Funcs(g.templateFuncs()).Parse(groupIndexTemplate)
+	if err != nil {
+		return err
+	}
+
+	byGroup := make(map[string][]generator.DTO)
+	var groups []string
+	for _, dto := range dtos {
+		group := g.groups[dto.Name]
+		if _, seen := byGroup[group]; !seen {
+			groups = append(groups, group)
+		}
+		byGroup[group] = append(byGroup[group], dto)
+	}
+	sort.Strings(groups)
+
+	for _, group := range groups {
+		file, err := g.createFile(config, filepath.Join(config.OutputFolder, group, "index.ts"))
+		if err != nil {
+			return err
+		}
+
+		data := struct {
+			Group       string
+			DTOs        []generator.DTO
+			PackageName string
+		}{
+			Group:       group,
+			DTOs:        byGroup[group],
+			PackageName: g.getPackageName(config),
+		}
+
+		err = tmpl.Execute(file, data)
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// generateHelpersFile writes helpers.ts with the decode helpers shared by
+// the DTO files
+func (g *TypeScriptGenerator) generateHelpersFile(config generator.Config) error {
+	file, err := g.createFile(config, filepath.Join(config.OutputFolder, "helpers.ts"))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	tmpl, err := template.New("helpers").Funcs(g.templateFuncs()).Parse(helpersTemplate + decodeHelpersTemplate)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(file, nil)
+}
+
+// generateValidationFile writes validation.ts with the fp-ts helpers
+func (g *TypeScriptGenerator) generateValidationFile(config generator.Config) error {
+	file, err := g.createFile(config, filepath.Join(config.OutputFolder, "validation.ts"))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = file.WriteString(validationTemplate)
+	return err
+}
+
+// generateRefinementsFile writes refinements.ts with the branded codecs
+// enforcing constraints
+func (g *TypeScriptGenerator) generateRefinementsFile(refinements []refinement, config generator.Config) error {
+	file, err := g.createFile(config, filepath.Join(config.OutputFolder, "refinements.ts"))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	tmpl, err := template.New("refinements").Parse(refinementsTemplate)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(file, refinements)
+}
+
+// generateFormatHelpersFile writes format-helpers.ts with the codecs for the given formats
+func (g *TypeScriptGenerator) generateFormatHelpersFile(formats []string, config generator.Config, genConfig GenerationConfig) error {
+	filepath := filepath.Join(config.OutputFolder, "format-helpers.ts")
+
+	file, err := g.createFile(config, filepath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	tmpl, err := template.New("format-helpers").Funcs(g.templateFuncs()).Parse(formatHelpersTemplate)
+	if err != nil {
+		return err
+	}
+
+	used := make(map[string]bool)
+	for _, format := range formats {
+		used[format] = true
+	}
+
+	binaryType, binaryCheck := binaryTargetTypes(genConfig.BinaryTarget)
+
+	data := struct {
+		Formats     map[string]bool
+		BinaryType  string
+		BinaryCheck string
+	}{
+		Formats:     used,
+		BinaryType:  binaryType,
+		BinaryCheck: binaryCheck,
+	}
+
+	return tmpl.Execute(file, data)
+}
+
+// generateBrandedTypesFile writes branded-types.ts with the branded codecs
+// for the given formats
+func (g *TypeScriptGenerator) generateBrandedTypesFile(formats []string, config generator.Config) error {
+	file, err := g.createFile(config, filepath.Join(config.OutputFolder, "branded-types.ts"))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	tmpl, err := template.New("branded-types").Parse(brandedTypesTemplate)
+	if err != nil {
+		return err
+	}
+
+	used := make(map[string]bool)
+	for _, format := range formats {
+		used[format] = true
+	}
+	return tmpl.Execute(file, used)
+}
+
+// binaryTargetTypes returns the TypeScript type and instanceof check for binary payloads
+func binaryTargetTypes(target string) (string, string) {
+	switch target {
+	case "browser":
+		return "Blob", "u instanceof Blob"
+	case "node":
+		return "Buffer", "u instanceof Buffer"
+	default:
+		// Guard each global so the codec loads in both environments
+		return "Blob | Buffer", "(typeof Blob !== 'undefined' && u instanceof Blob) ||\n  (typeof Buffer !== 'undefined' && u instanceof Buffer)"
+	}
+}
+
 // generatePackageJSON creates a package.json for the generated code
-func (g *TypeScriptGenerator) generatePackageJSON(config generator.Config) error {
+func (g *TypeScriptGenerator) generatePackageJSON(config generator.Config, genConfig GenerationConfig) error {
 	filepath := filepath.Join(config.OutputFolder, "package.json")
 
 	// Don't overwrite existing package.json
@@ -208,7 +469,7 @@ func (g *TypeScriptGenerator) generatePackageJSON(config generator.Config) error
 		return nil
 	}
 
-	file, err := os.Create(filepath)
+	file, err := config.CreateFile(filepath, nil)
 	if err != nil {
 		return err
 	}
@@ -220,9 +481,11 @@ func (g *TypeScriptGenerator) generatePackageJSON(config generator.Config) error
 	}
 
 	data := struct {
-		PackageName string
+		PackageName     string
+		GenerateHelpers bool
 	}{
-		PackageName: g.getPackageName(config),
+		PackageName:     g.getPackageName(config),
+		GenerateHelpers: genConfig.GenerateHelpers,
 	}
 
 	return tmpl.Execute(file, data)
@@ -231,17 +494,39 @@ func (g *TypeScriptGenerator) generatePackageJSON(config generator.Config) error
 // Helper functions for templates
 func (g *TypeScriptGenerator) templateFuncs() template.FuncMap {
 	return template.FuncMap{
+		"helperName":     g.helperName,
 		"toIoTsType":     g.toIoTsType,
 		"toTSType":       g.toTSType,
 		"toCamelCase":    g.toCamelCase,
 		"toPascalCase":   g.toPascalCase,
 		"toKebabCase":    g.toKebabCase,
+		"modulePath":     g.modulePath,
 		"isRequired":     g.isRequired,
 		"hasDescription": g.hasDescription,
+		"dtoComment":     g.dtoComment,
+		"propComment":    g.propComment,
+		"getExt":         g.getExt,
+		"hasPartial":     g.hasPartialCodec,
+		"isRecursive":    g.isRecursive,
+		"codecGroups":    g.codecGroups,
+		"additionalType": g.additionalType,
+		"exact":          func() bool { return g.customTypes.GetGenerationConfig().ExactCodecs },
+		"codecOpen":      g.codecOpen,
+		"readonly":       g.readonlyModifier,
+		"codecClose":     g.codecClose,
 		"join":           strings.Join,
 		"quote":          g.quote,
 		"len":            func(slice []string) int { return len(slice) },
 		"add":            func(a, b int) int { return a + b },
+		"ioTs1":          g.isIoTs1,
+		"eitherModule":   g.eitherModule,
+		"helperImports":  g.decodeHelpersImports,
+		"enumStyle":      g.enumStyle,
+		"enumMembers":    g.enumMembers,
+		"literals":       g.literalUnion,
+		"literal":        func(value string) string { return generator.NewLiteralType(value).Literal() },
+		"variants":       g.codecVariants,
+		"encodes":        func(name string) bool { return g.encoders[name] },
 	}
 }
 
@@ -251,8 +536,12 @@ func (g *TypeScriptGenerator) toIoTsType(irType generator.IRType, nullable bool)
 
 	switch t := irType.(type) {
 	case generator.PrimitiveType:
+		if refined, ok := g.refinement(t); ok {
+			baseType = refined.Name
+			break
+		}
 		switch t.Name {
-		case "string":
+		case "string", "binary":
 			// Check for custom format mapping
 			if t.Format != "" {
 				if mapping, exists := g.customTypes.Get(t.Format); exists {
@@ -260,11 +549,25 @@ func (g *TypeScriptGenerator) toIoTsType(irType generator.IRType, nullable bool)
 				} else {
 					baseType = "t.string"
 				}
+			} else if g.isNonEmptyString(t) {
+				baseType = "NonEmptyString"
 			} else {
 				baseType = "t.string"
 			}
 		case "number", "integer":
-			baseType = "t.number"
+			if mapping, exists := g.customTypes.Get(t.Format); t.Format != "" && exists {
+				baseType = mapping.IoTsType
+			} else if t.Name == "integer" && g.customTypes.GetGenerationConfig().BrandedIntegers {
+				baseType = "t.Int"
+			} else {
+				baseType = "t.number"
+			}
+		case "bigint":
+			if mapping, exists := g.customTypes.Get(t.Format); t.Format != "" && exists {
+				baseType = mapping.IoTsType
+			} else {
+				baseType = "BigIntFromNumberOrString"
+			}
 		case "boolean":
 			baseType = "t.boolean"
 		default:
@@ -272,10 +575,42 @@ func (g *TypeScriptGenerator) toIoTsType(irType generator.IRType, nullable bool)
 		}
 	case generator.ArrayType:
 		elementType := g.toIoTsType(t.ElementType, false)
-		baseType = fmt.Sprintf("t.array(%s)", elementType)
+		if g.isReadonly() {
+			baseType = fmt.Sprintf("t.readonlyArray(%s)", elementType)
+		} else {
+			baseType = fmt.Sprintf("t.array(%s)", elementType)
+		}
+	case generator.TupleType:
+		elementTypes := make([]string, len(t.ElementTypes))
+		for i, elem := range t.ElementTypes {
+			elementTypes[i] = g.toIoTsType(elem, false)
+		}
+		// io-ts has no rest element codec, so rest elements go unchecked
+		// (reported by warnTupleRest)
+		baseType = fmt.Sprintf("t.tuple([%s])", strings.Join(elementTypes, ", "))
+	case generator.UnionType:
+		members := make([]string, len(t.Types))
+		for i, member := range t.Types {
+			members[i] = g.toIoTsType(member, false)
+		}
+		baseType = fmt.Sprintf("t.union([%s])", strings.Join(members, ", "))
+	case generator.MapType:
+		baseType = fmt.Sprintf("t.record(%s, %s)", g.toIoTsType(t.KeyType, false), g.toIoTsType(t.ValueType, false))
+	case generator.LiteralType:
+		if t.Kind == "null" {
+			baseType = "t.null"
+		} else {
+			baseType = fmt.Sprintf("t.literal(%s)", t.Literal())
+		}
 	case generator.ReferenceType:
 		baseType = fmt.Sprintf("%sCodec", t.RefName)
 	case generator.EnumType:
+		if g.enumStyle() != "keyof" {
+			// Inline enums cannot be declared as TS enums, so both other
+			// styles validate them as literals
+			baseType = g.literalUnion(t.Values)
+			break
+		}
 		values := make([]string, len(t.Values))
 		for i, v := range t.Values {
 			values[i] = fmt.Sprintf("'%s': null", v)
@@ -284,8 +619,11 @@ func (g *TypeScriptGenerator) toIoTsType(irType generator.IRType, nullable bool)
 	case generator.ObjectType:
 		if t.RefName != "" {
 			baseType = fmt.Sprintf("%sCodec", t.RefName)
+		} else if isFreeForm(t) {
+			// A free-form object accepts any keys
+			baseType = g.toIoTsType(dictionary(generator.PrimitiveType{Name: "unknown"}), false)
 		} else {
-			baseType = "t.unknown" // inline objects need special handling
+			baseType = g.inlineCodec(*t.DTORef)
 		}
 	default:
 		baseType = "t.unknown"
@@ -304,8 +642,12 @@ func (g *TypeScriptGenerator) toTSType(irType generator.IRType, nullable bool) s
 
 	switch t := irType.(type) {
 	case generator.PrimitiveType:
+		if refined, ok := g.refinement(t); ok {
+			baseType = refined.Name
+			break
+		}
 		switch t.Name {
-		case "string":
+		case "string", "binary":
 			// Check for custom format mapping
 			if t.Format != "" {
 				if mapping, exists := g.customTypes.Get(t.Format); exists {
@@ -313,11 +655,25 @@ func (g *TypeScriptGenerator) toTSType(irType generator.IRType, nullable bool) s
 				} else {
 					baseType = "string"
 				}
+			} else if g.isNonEmptyString(t) {
+				baseType = "NonEmptyString"
 			} else {
 				baseType = "string"
 			}
 		case "number", "integer":
-			baseType = "number"
+			if mapping, exists := g.customTypes.Get(t.Format); t.Format != "" && exists {
+				baseType = mapping.TypeScriptType
+			} else if t.Name == "integer" && g.customTypes.GetGenerationConfig().BrandedIntegers {
+				baseType = "t.Int"
+			} else {
+				baseType = "number"
+			}
+		case "bigint":
+			if mapping, exists := g.customTypes.Get(t.Format); t.Format != "" && exists {
+				baseType = mapping.TypeScriptType
+			} else {
+				baseType = "bigint"
+			}
 		case "boolean":
 			baseType = "boolean"
 		default:
@@ -325,7 +681,26 @@ func (g *TypeScriptGenerator) toTSType(irType generator.IRType, nullable bool) s
 		}
 	case generator.ArrayType:
 		elementType := g.toTSType(t.ElementType, false)
-		baseType = fmt.Sprintf("%s[]", elementType)
+		if strings.Contains(elementType, "|") {
+			elementType = fmt.Sprintf("(%s)", elementType)
+		}
+		baseType = fmt.Sprintf("%s%s[]", g.readonlyModifier(), elementType)
+	case generator.TupleType:
+		elementTypes := make([]string, len(t.ElementTypes))
+		for i, elem := range t.ElementTypes {
+			elementTypes[i] = g.toTSType(elem, false)
+		}
+		baseType = fmt.Sprintf("[%s]", strings.Join(elementTypes, ", "))
+	case generator.UnionType:
+		members := make([]string, len(t.Types))
+		for i, member := range t.Types {
+			members[i] = g.toTSType(member, false)
+		}
+		baseType = strings.Join(members, " | ")
+	case generator.MapType:
+		baseType = fmt.Sprintf("Record<%s, %s>", g.toTSType(t.KeyType, false), g.toTSType(t.ValueType, false))
+	case generator.LiteralType:
+		baseType = t.Literal()
 	case generator.ReferenceType:
 		baseType = t.RefName
 	case generator.EnumType:
@@ -337,8 +712,10 @@ func (g *TypeScriptGenerator) toTSType(irType generator.IRType, nullable bool) s
 	case generator.ObjectType:
 		if t.RefName != "" {
 			baseType = t.RefName
-		} else {
+		} else if isFreeForm(t) {
 			baseType = "Record<string, unknown>"
+		} else {
+			baseType = g.inlineType(*t.DTORef)
 		}
 	default:
 		baseType = "unknown"
@@ -351,6 +728,14 @@ func (g *TypeScriptGenerator) toTSType(irType generator.IRType, nullable bool) s
 	return baseType
 }
 
+// helperName returns the name the is/decode helpers of a DTO are built from
+func (g *TypeScriptGenerator) helperName(name string) string {
+	if helper, ok := g.helpers[name]; ok {
+		return helper
+	}
+	return name
+}
+
 // Utility functions (same as before)
 func (g *TypeScriptGenerator) toCamelCase(s string) string {
 	if len(s) == 0 {
@@ -390,10 +775,520 @@ func (g *TypeScriptGenerator) hasDescription(desc string) bool {
 	return strings.TrimSpace(desc) != ""
 }
 
+// isRecursive reports whether a DTO is part of a reference cycle
+func (g *TypeScriptGenerator) isRecursive(name string) bool {
+	return g.recursive[name]
+}
+
+// hasPartialCodec reports whether a DTO gets a partial codec
+func (g *TypeScriptGenerator) hasPartialCodec(name string) bool {
+	return g.partials[name]
+}
+
+// partialCodecs returns the object DTOs that get a partial codec: those the
+// config enables it for, and the parents their partial codecs extend
+func (g *TypeScriptGenerator) partialCodecs(dtos []generator.DTO) map[string]bool {
+	byName := make(map[string]generator.DTO, len(dtos))
+	for _, dto := range dtos {
+		byName[dto.Name] = dto
+	}
+
+	partials := make(map[string]bool)
+	var mark func(name string)
+	mark = func(name string) {
+		if partials[name] {
+			return
+		}
+		partials[name] = true
+		for _, base := range byName[name].Extends {
+			mark(base)
+		}
+	}
+	for _, dto := range dtos {
+		if dto.Type != "enum" && dto.Type != "union" && g.customTypes.HasPartialCodec(dto.Name) {
+			mark(dto.Name)
+		}
+	}
+	return partials
+}
+
+// codecVariant is the request or response codec of a DTO: the DTO renamed,
+// without the properties the other side owns
+type codecVariant struct {
+	DTO     generator.DTO
+	Comment string
+}
+
+// codecVariantDTOs returns the object DTOs that get request and response
+// codecs if enabled: those with read-only or write-only properties, and
+// those extending or referencing one, whose variants use the variants of
+// their dependencies. Recursive DTOs keep their single codec, with a warning
+// if they would need variants.
+func (g *TypeScriptGenerator) codecVariantDTOs(dtos []generator.DTO, warnings *generator.WarningCollector) (map[string]bool, error) {
+	if !g.customTypes.GetGenerationConfig().RequestResponseCodecs {
+		return nil, nil
+	}
+
+	names := make(map[string]bool, len(dtos))
+	for _, dto := range dtos {
+		names[dto.Name] = true
+	}
+
+	variants := make(map[string]bool)
+	for changed := true; changed; {
+		changed = false
+		for _, dto := range dtos {
+			if variants[dto.Name] || dto.Type == "enum" || dto.Type == "union" || g.recursive[dto.Name] {
+				continue
+			}
+			if g.needsVariants(dto, variants) {
+				variants[dto.Name] = true
+				changed = true
+			}
+		}
+	}
+
+	// t.recursion codecs cannot be split into variants yet
+	for _, dto := range dtos {
+		if g.recursive[dto.Name] && dto.Type != "enum" && dto.Type != "union" && g.needsVariants(dto, variants) {
+			warnings.Add(generator.Warning{
+				Schema:  dto.Name,
+				Code:    "variant-skipped",
+				Message: "recursive schemas get no request and response codecs; read-only and write-only properties are kept in both directions",
+			})
+		}
+	}
+
+	for name := range variants {
+		for _, suffix := range []string{"Request", "Response"} {
+			if names[name+suffix] {
+				return nil, fmt.Errorf("%s codec of '%s' conflicts with the schema '%s'", strings.ToLower(suffix), name, name+suffix)
+			}
+		}
+	}
+	return variants, nil
+}
+
+// needsVariants reports whether a DTO has read-only or write-only properties
+// or depends on a DTO with request and response codecs
+func (g *TypeScriptGenerator) needsVariants(dto generator.DTO, variants map[string]bool) bool {
+	if hasAccessModes(dto.Properties) {
+		return true
+	}
+	return slices.ContainsFunc(generator.Dependencies(dto), func(dep string) bool { return variants[dep] })
+}
+
+// hasAccessModes reports whether any of props, or of the properties of
+// inline objects within them, is read-only or write-only
+func hasAccessModes(props []generator.Property) bool {
+	for _, prop := range props {
+		if prop.ReadOnly || prop.WriteOnly {
+			return true
+		}
+		var inline func(irType generator.IRType) bool
+		inline = func(irType generator.IRType) bool {
+			switch t := irType.(type) {
+			case generator.ObjectType:
+				return t.DTORef != nil && hasAccessModes(t.DTORef.Properties)
+			case generator.ArrayType:
+				return inline(t.ElementType)
+			case generator.MapType:
+				return inline(t.ValueType)
+			case generator.UnionType:
+				return slices.ContainsFunc(t.Types, inline)
+			case generator.TupleType:
+				return slices.ContainsFunc(t.ElementTypes, inline) || (t.Rest != nil && inline(t.Rest))
+			}
+			return false
+		}
+		if inline(prop.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// codecVariants returns the request and response codecs of a DTO, or nil
+// if it has none
+func (g *TypeScriptGenerator) codecVariants(dto generator.DTO) []codecVariant {
+	if !g.variants[dto.Name] {
+		return nil
+	}
+	return []codecVariant{
+		{
+			DTO:     g.variantDTO(dto, "Request", func(prop generator.Property) bool { return !prop.ReadOnly }),
+			Comment: "Request codec for bodies sent to the server (read-only fields stripped)",
+		},
+		{
+			DTO:     g.variantDTO(dto, "Response", func(prop generator.Property) bool { return !prop.WriteOnly }),
+			Comment: "Response codec for bodies returned by the server (write-only fields stripped)",
+		},
+	}
+}
+
+// variantDTO returns a DTO's variant named with suffix, keeping the
+// properties keep accepts and using the variants of its dependencies
+func (g *TypeScriptGenerator) variantDTO(dto generator.DTO, suffix string, keep func(generator.Property) bool) generator.DTO {
+	variant := dto
+	variant.Name = dto.Name + suffix
+	variant.Properties = nil
+	for _, prop := range dto.Properties {
+		if keep(prop) {
+			prop.Type = g.variantType(prop.Type, suffix, keep)
+			variant.Properties = append(variant.Properties, prop)
+		}
+	}
+	variant.Extends = make([]string, len(dto.Extends))
+	for i, base := range dto.Extends {
+		variant.Extends[i] = g.variantName(base, suffix)
+	}
+	if dto.AdditionalProperties != nil {
+		variant.AdditionalProperties = g.variantType(dto.AdditionalProperties, suffix, keep)
+	}
+	return variant
+}
+
+// variantType rewrites the references within irType to the variants named
+// with suffix, and strips inline objects like variantDTO
+func (g *TypeScriptGenerator) variantType(irType generator.IRType, suffix string, keep func(generator.Property) bool) generator.IRType {
+	switch t := irType.(type) {
+	case generator.ReferenceType:
+		t.RefName = g.variantName(t.RefName, suffix)
+		return t
+	case generator.ObjectType:
+		if t.RefName != "" {
+			t.RefName = g.variantName(t.RefName, suffix)
+		} else if t.DTORef != nil {
+			inline := g.variantDTO(*t.DTORef, suffix, keep)
+			inline.Name = t.DTORef.Name
+			t.DTORef = &inline
+		}
+		return t
+	case generator.ArrayType:
+		t.ElementType = g.variantType(t.ElementType, suffix, keep)
+		return t
+	case generator.MapType:
+		t.ValueType = g.variantType(t.ValueType, suffix, keep)
+		return t
+	case generator.UnionType:
+		types := make([]generator.IRType, len(t.Types))
+		for i, member := range t.Types {
+			types[i] = g.variantType(member, suffix, keep)
+		}
+		t.Types = types
+		return t
+	case generator.TupleType:
+		elems := make([]generator.IRType, len(t.ElementTypes))
+		for i, elem := range t.ElementTypes {
+			elems[i] = g.variantType(elem, suffix, keep)
+		}
+		t.ElementTypes = elems
+		if t.Rest != nil {
+			t.Rest = g.variantType(t.Rest, suffix, keep)
+		}
+		return t
+	}
+	return irType
+}
+
+// variantName returns the name of a DTO's variant, or the DTO's own name if
+// it has no variants
+func (g *TypeScriptGenerator) variantName(name, suffix string) string {
+	if g.variants[name] {
+		return name + suffix
+	}
+	return name
+}
+
+// codecGroup is one codec of a DTO's intersection: an object codec of its
+// properties, or the record codec of its additional properties
+type codecGroup struct {
+	Constructor string // t.type or t.partial
+	Properties  []generator.Property
+	Record      string // the t.record codec, set instead of Constructor
+}
+
+// codecGroups splits a DTO's properties into the object codecs encoding them.
+// t.type requires every key to be present, so optional properties go to a
+// t.partial intersected with it; a DTO without properties or additional
+// properties keeps an empty t.type. Additional properties add a t.record.
+func (g *TypeScriptGenerator) codecGroups(dto generator.DTO, partial bool) []codecGroup {
+	if partial {
+		return []codecGroup{{Constructor: "t.partial", Properties: dto.Properties}}
+	}
+
+	var required, optional []generator.Property
+	for _, prop := range dto.Properties {
+		if prop.Required {
+			required = append(required, prop)
+		} else {
+			optional = append(optional, prop)
+		}
+	}
+
+	var groups []codecGroup
+	if len(required) > 0 || (len(optional) == 0 && dto.AdditionalProperties == nil) {
+		groups = append(groups, codecGroup{Constructor: "t.type", Properties: required})
+	}
+	if len(optional) > 0 {
+		groups = append(groups, codecGroup{Constructor: "t.partial", Properties: optional})
+	}
+	if additional := g.additionalType(dto); additional != nil {
+		groups = append(groups, codecGroup{Record: g.toIoTsType(dictionary(additional), false)})
+	}
+	return groups
+}
+
+// additionalType returns the value type of a DTO's additional properties, or
+// nil if it allows none. t.record checks every key, declared properties too,
+// so the type is widened to unknown unless every property has it as well.
+func (g *TypeScriptGenerator) additionalType(dto generator.DTO) generator.IRType {
+	if dto.AdditionalProperties == nil || g.recordCoversProperties(dto) {
+		return dto.AdditionalProperties
+	}
+	return generator.PrimitiveType{Name: "unknown"}
+}
+
+// recordCoversProperties reports whether the codec of a DTO's additional
+// properties also accepts every declared property
+func (g *TypeScriptGenerator) recordCoversProperties(dto generator.DTO) bool {
+	if prim, ok := dto.AdditionalProperties.(generator.PrimitiveType); ok && prim.Name == "unknown" {
+		return true
+	}
+	value := g.toIoTsType(dto.AdditionalProperties, false)
+	for _, prop := range dto.Properties {
+		if g.toIoTsType(prop.Type, prop.Nullable) != value {
+			return false
+		}
+	}
+	return true
+}
+
+// isFreeForm reports whether an inline object declares nothing about its
+// keys, accepting any
+func isFreeForm(object generator.ObjectType) bool {
+	return object.DTORef == nil || (len(object.DTORef.Properties) == 0 && object.DTORef.AdditionalProperties == nil)
+}
+
+// inlineCodec renders the codec of an inline object on one line, split into
+// object codecs like a DTO's
+func (g *TypeScriptGenerator) inlineCodec(dto generator.DTO) string {
+	groups := g.codecGroups(dto, false)
+	codecs := make([]string, len(groups))
+	for i, group := range groups {
+		if group.Record != "" {
+			codecs[i] = group.Record
+			continue
+		}
+		fields := make([]string, len(group.Properties))
+		for j, prop := range group.Properties {
+			fields[j] = fmt.Sprintf("%s: %s", g.toCamelCase(prop.Name), g.toIoTsType(prop.Type, prop.Nullable))
+		}
+		codecs[i] = fmt.Sprintf("%s({ %s })", group.Constructor, strings.Join(fields, ", "))
+		if g.customTypes.GetGenerationConfig().ExactCodecs {
+			codecs[i] = fmt.Sprintf("t.exact(%s)", codecs[i])
+		}
+	}
+	return g.codecOpen(nil, groups, false) + strings.Join(codecs, ", ") + g.codecClose(nil, groups)
+}
+
+// inlineType renders the TypeScript type of an inline object on one line
+func (g *TypeScriptGenerator) inlineType(dto generator.DTO) string {
+	var fields []string
+	for _, prop := range dto.Properties {
+		optional := "?"
+		if prop.Required {
+			optional = ""
+		}
+		fields = append(fields, fmt.Sprintf("%s%s%s: %s", g.readonlyModifier(), g.toCamelCase(prop.Name), optional, g.toTSType(prop.Type, prop.Nullable)))
+	}
+	if additional := g.additionalType(dto); additional != nil {
+		fields = append(fields, fmt.Sprintf("%s[key: string]: %s", g.readonlyModifier(), g.toTSType(additional, false)))
+	}
+	return "{ " + strings.Join(fields, "; ") + " }"
+}
+
+// dictionary returns the type of an object with string keys of valueType
+func dictionary(valueType generator.IRType) generator.MapType {
+	return generator.MapType{KeyType: generator.PrimitiveType{Name: "string"}, ValueType: valueType}
+}
+
+// codecOpen opens the intersection of a DTO's object codecs with the
+// parents' codecs it extends, and t.readonly if enabled. It returns "" for a
+// single mutable object codec. A nil groups stands for the one t.partial of a
+// partial codec.
+func (g *TypeScriptGenerator) codecOpen(bases []string, groups []codecGroup, partial bool) string {
+	open := ""
+	if g.isReadonly() {
+		open = "t.readonly("
+	}
+	if !intersects(bases, groups) {
+		return open
+	}
+
+	suffix := "Codec"
+	if partial {
+		suffix = "PartialCodec"
+	}
+	open += "t.intersection(["
+	for _, base := range bases {
+		open += base + suffix + ", "
+	}
+	return open
+}
+
+// codecClose closes what codecOpen opened
+func (g *TypeScriptGenerator) codecClose(bases []string, groups []codecGroup) string {
+	close := ""
+	if intersects(bases, groups) {
+		close = "])"
+	}
+	if g.isReadonly() {
+		close += ")"
+	}
+	return close
+}
+
+// isReadonly reports whether object and array codecs are made readonly
+func (g *TypeScriptGenerator) isReadonly() bool {
+	return g.customTypes.GetGenerationConfig().Readonly
+}
+
+// readonlyModifier returns the modifier of properties and arrays in explicit
+// types
+func (g *TypeScriptGenerator) readonlyModifier() string {
+	if g.isReadonly() {
+		return "readonly "
+	}
+	return ""
+}
+
+// intersects reports whether a DTO's codec is an intersection
+func intersects(bases []string, groups []codecGroup) bool {
+	return len(bases)+max(len(groups), 1) > 1
+}
+
+// dtoComment renders the JSDoc block above a DTO declaration, pointing back
+// into the spec when source comments are enabled
+func (g *TypeScriptGenerator) dtoComment(dto generator.DTO) string {
+	tags := g.docTags(dto.Metadata, dto.Deprecated)
+	if dto.Source != nil && g.customTypes.GetGenerationConfig().SourceComments {
+		tags = append(tags, "@see "+dto.Source.String())
+	}
+	return generator.DocComment("", dto.Description, tags...)
+}
+
+// propComment renders a property's comment: a line comment for a one-line
+// description, a JSDoc block for anything longer or carrying tags. io-ts
+// codecs cannot apply defaults, so a default is documented with @default.
+func (g *TypeScriptGenerator) propComment(prop generator.Property) string {
+	tags := g.docTags(prop.Metadata, prop.Deprecated)
+	if prop.Default != nil {
+		tags = append(tags, "@default "+generator.ValueLiteral(prop.Default))
+	}
+	if lines := generator.DescriptionLines(prop.Description); len(lines) == 1 && len(tags) == 0 {
+		return "  // " + strings.TrimSpace(lines[0]) + "\n"
+	}
+	return generator.DocComment("  ", prop.Description, tags...)
+}
+
+// docTags returns the JSDoc tags implied by metadata and deprecation
+func (g *TypeScriptGenerator) docTags(metadata generator.Metadata, deprecated bool) []string {
+	var tags []string
+	if parents := metadata.String("extends"); parents != "" {
+		for _, parent := range strings.Split(parents, ", ") {
+			tags = append(tags, "@extends "+parent)
+		}
+	}
+	if deprecated {
+		tags = append(tags, "@deprecated")
+	}
+	return tags
+}
+
+// getExt returns a vendor extension value from metadata as text, or "" if
+// absent. The name may be given with or without its x- prefix.
+func (g *TypeScriptGenerator) getExt(metadata generator.Metadata, name string) string {
+	if !strings.HasPrefix(name, "x-") {
+		name = "x-" + name
+	}
+	return metadata.String(name)
+}
+
 func (g *TypeScriptGenerator) quote(s string) string {
 	return fmt.Sprintf("'%s'", s)
 }
 
+// EnumMember is a member of an enum declared as a TypeScript enum
+type EnumMember struct {
+	Name  string // member name, e.g. InProgress
+	Value string // the enum value, e.g. in-progress
+}
+
+// enumStyle returns how enum DTOs are declared: keyof, union or enum
+func (g *TypeScriptGenerator) enumStyle() string {
+	if g.customTypes == nil || g.customTypes.GetGenerationConfig().EnumStyle == "" {
+		return "keyof"
+	}
+	return g.customTypes.GetGenerationConfig().EnumStyle
+}
+
+// enumMembers names the members of an enum after their values, e.g.
+// "in-progress" becomes InProgress
+func (g *TypeScriptGenerator) enumMembers(values []string) []EnumMember {
+	taken := make(map[string]bool)
+	members := make([]EnumMember, len(values))
+	for i, value := range values {
+		var name strings.Builder
+		for _, word := range strings.FieldsFunc(value, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			name.WriteString(g.toPascalCase(word))
+		}
+		base := name.String()
+		if base == "" {
+			base = "Empty"
+		} else if unicode.IsDigit([]rune(base)[0]) {
+			base = "_" + base
+		}
+		unique := base
+		for n := 2; taken[unique]; n++ {
+			unique = fmt.Sprintf("%s%d", base, n)
+		}
+		taken[unique] = true
+		members[i] = EnumMember{Name: unique, Value: value}
+	}
+	return members
+}
+
+// literalUnion validates enum values as a union of literals. t.union needs
+// at least two members, so a single value is a plain literal.
+func (g *TypeScriptGenerator) literalUnion(values []string) string {
+	literals := make([]string, len(values))
+	for i, value := range values {
+		literals[i] = fmt.Sprintf("t.literal(%s)", generator.NewLiteralType(value).Literal())
+	}
+	switch len(literals) {
+	case 0:
+		return "t.never"
+	case 1:
+		return literals[0]
+	}
+	return fmt.Sprintf("t.union([%s])", strings.Join(literals, ", "))
+}
+
+// modulePath returns the import path of a DTO's file relative to the output
+// root, e.g. './users/user' when grouping by tag
+func (g *TypeScriptGenerator) modulePath(name string) string {
+	return generator.RelativeModule("", g.groups[name], g.toKebabCase(name))
+}
+
+// createFile creates a generated source file, starting with the configured
+// pragmas
+func (g *TypeScriptGenerator) createFile(config generator.Config, path string) (*os.File, error) {
+	return config.CreateFile(path, g.customTypes.GetOutputConfig().Pragmas)
+}
+
 func (g *TypeScriptGenerator) getPackageName(config generator.Config) string {
 	if config.PackageName != "" {
 		return config.PackageName
@@ -407,35 +1302,438 @@ func (g *TypeScriptGenerator) calculateImports(dto generator.DTO) []string {
 	usedFormats := g.getUsedFormatsInDTO(dto)
 
 	// Use the custom type registry to get the appropriate imports
-	return g.customTypes.GetAllImports(usedFormats)
+	imports := g.withPrimitiveImports(g.customTypes.GetAllImports(usedFormats), []generator.DTO{dto})
+	for i, statement := range imports {
+		imports[i] = generator.RebaseImport(statement, g.groups[dto.Name])
+	}
+
+	// The request and response codecs use the variants of the DTOs they
+	// still refer to, which live in those DTOs' files
+	variantDeps := make(map[string][]string)
+	for _, variant := range g.codecVariants(dto) {
+		suffix := strings.TrimPrefix(variant.DTO.Name, dto.Name)
+		for _, dep := range generator.Dependencies(variant.DTO) {
+			if name, ok := strings.CutSuffix(dep, suffix); ok && g.variants[name] {
+				variantDeps[name] = append(variantDeps[name], dep)
+			}
+		}
+	}
+
+	// Import the codecs of referenced DTOs from their own files; recursive
+	// DTOs also need the types for their explicit interface
+	for _, dep := range generator.Dependencies(dto) {
+		if dep == dto.Name {
+			continue
+		}
+		names := fmt.Sprintf("%sCodec", dep)
+		if slices.Contains(dto.Extends, dep) && g.partials[dto.Name] {
+			names = fmt.Sprintf("%sCodec, %sPartialCodec", dep, dep)
+		}
+		if g.recursive[dto.Name] {
+			names = fmt.Sprintf("%s, %s", dep, names)
+		}
+		for _, variant := range variantDeps[dep] {
+			names = fmt.Sprintf("%s, %sCodec", names, variant)
+		}
+		path := generator.RelativeModule(g.groups[dto.Name], g.groups[dep], g.toKebabCase(dep))
+		imports = append(imports, fmt.Sprintf("import { %s } from '%s';", names, path))
+	}
+
+	return imports
+}
+
+// decodeOrThrowImport provides the throwing decoder of the DTO files' helpers
+const decodeOrThrowImport = "import { decodeOrThrow } from './helpers';"
+
+// decodeHelpersImports returns the imports of the decode helpers besides
+// io-ts itself
+func (g *TypeScriptGenerator) decodeHelpersImports() []string {
+	if g.isIoTs1() {
+		return []string{
+			"import { isLeft, isRight, left } from 'fp-ts/lib/Either';",
+			"import { PathReporter } from 'io-ts/lib/PathReporter';",
+		}
+	}
+	return []string{
+		"import { isLeft, isRight } from 'fp-ts/Either';",
+		"import { formatValidationErrors } from 'io-ts-reporters';",
+	}
+}
+
+// validateConfig rejects settings that cannot be generated together
+func (g *TypeScriptGenerator) validateConfig(dtos []generator.DTO) error {
+	if !g.isIoTs1() {
+		return nil
+	}
+	genConfig := g.customTypes.GetGenerationConfig()
+	if genConfig.FpTsHelpers {
+		return fmt.Errorf("generation.fpTsHelpers requires fp-ts 2, use ioTsVersion 2")
+	}
+	// The io-ts-types 0.4 releases that work with io-ts 1.x have none of
+	// NonEmptyString or IntFromString
+	if genConfig.UseIoTsTypes {
+		return fmt.Errorf("generation.useIoTsTypes requires io-ts-types 0.5, use ioTsVersion 2")
+	}
+	return nil
+}
+
+// isIoTs1 reports whether the io-ts 1.x API, with fp-ts 1.x, is targeted
+func (g *TypeScriptGenerator) isIoTs1() bool {
+	return g.customTypes.GetGenerationConfig().IoTsVersion == 1
+}
+
+// eitherModule returns the fp-ts module of Either: fp-ts 1.x only has the
+// lib path
+func (g *TypeScriptGenerator) eitherModule() string {
+	if g.isIoTs1() {
+		return "fp-ts/lib/Either"
+	}
+	return "fp-ts/Either"
+}
+
+// bigIntImport provides the codec for bigint primitives without a custom
+// format mapping
+const bigIntImport = "import { BigIntFromNumberOrString } from './format-helpers';"
+
+// nonEmptyStringImport provides the codec for strings with a minimum length
+// when io-ts-types are used
+const nonEmptyStringImport = "import { NonEmptyString } from 'io-ts-types';"
+
+// withPrimitiveImports adds the imports of the codecs that unmapped
+// primitives of the DTOs are decoded with
+func (g *TypeScriptGenerator) withPrimitiveImports(imports []string, dtos []generator.DTO) []string {
+	add := func(statement string, uses func(generator.PrimitiveType) bool) {
+		if g.usesPrimitive(dtos, uses) && !slices.Contains(imports, statement) {
+			imports = append(imports, statement)
+		}
+	}
+	add(bigIntImport, g.isDefaultBigInt)
+	add(nonEmptyStringImport, g.isNonEmptyString)
+
+	if refinements := g.usedRefinements(dtos); len(refinements) > 0 {
+		names := make([]string, len(refinements))
+		for i, refined := range refinements {
+			names[i] = refined.Name
+		}
+		imports = append(imports, fmt.Sprintf("import { %s } from './refinements';", strings.Join(names, ", ")))
+	}
+	return imports
+}
+
+// isDefaultBigInt reports whether a primitive is decoded with the default
+// bigint codec, BigIntFromNumberOrString: a bigint without a custom format
+// mapping
+func (g *TypeScriptGenerator) isDefaultBigInt(prim generator.PrimitiveType) bool {
+	_, mapped := g.customTypes.Get(prim.Format)
+	return prim.Name == "bigint" && (prim.Format == "" || !mapped)
+}
+
+// isNonEmptyString reports whether a string primitive is decoded with
+// NonEmptyString: a plain string with a minimum length, when io-ts-types
+// are used
+func (g *TypeScriptGenerator) isNonEmptyString(prim generator.PrimitiveType) bool {
+	if _, refined := g.refinement(prim); refined {
+		return false
+	}
+	return g.customTypes.GetGenerationConfig().UseIoTsTypes && prim.Name == "string" && prim.Format == "" &&
+		prim.Constraints != nil && prim.Constraints.MinLength != nil && *prim.Constraints.MinLength > 0
+}
+
+// refinement is a branded codec enforcing the constraints of a primitive
+type refinement struct {
+	Name        string // codec, brand and type name, e.g. StringMinLength3
+	Codec       string // the refined codec: t.string, t.number or t.Int
+	Type        string // TypeScript type of the refined codec
+	Predicate   string // check of a value named v
+	Constraints string // the enforced constraints, for the doc comment
+}
+
+// refinement returns the refinement codec of a string, number or integer
+// primitive with constraints, when refinements are enabled. Formats mapped
+// to codecs of their own are not refined.
+func (g *TypeScriptGenerator) refinement(prim generator.PrimitiveType) (refinement, bool) {
+	c := prim.Constraints
+	if !g.customTypes.GetGenerationConfig().Refinements || c == nil {
+		return refinement{}, false
+	}
+
+	var r refinement
+	var names, checks, descriptions []string
+	add := func(name, check, description string) {
+		names = append(names, name)
+		checks = append(checks, check)
+		descriptions = append(descriptions, description)
+	}
+	switch prim.Name {
+	case "string":
+		r = refinement{Name: "String", Codec: "t.string", Type: "string"}
+		if c.MinLength != nil {
+			add(fmt.Sprintf("MinLength%d", *c.MinLength), fmt.Sprintf("v.length >= %d", *c.MinLength), fmt.Sprintf("minLength %d", *c.MinLength))
+		}
+		if c.MaxLength != nil {
+			add(fmt.Sprintf("MaxLength%d", *c.MaxLength), fmt.Sprintf("v.length <= %d", *c.MaxLength), fmt.Sprintf("maxLength %d", *c.MaxLength))
+		}
+		if c.Pattern != "" {
+			// Patterns can't be spelled in a name, a hash keeps it stable
+			hash := fnv.New32a()
+			hash.Write([]byte(c.Pattern))
+			pattern := generator.NewLiteralType(c.Pattern).Literal()
+			add(fmt.Sprintf("Pattern%08x", hash.Sum32()), fmt.Sprintf("new RegExp(%s).test(v)", pattern), "pattern "+pattern)
+		}
+	case "number", "integer":
+		r = refinement{Name: "Number", Codec: "t.number", Type: "number"}
+		if prim.Name == "integer" {
+			r = refinement{Name: "Int", Codec: "t.Int", Type: "t.Int"}
+		}
+		bound := func(name, operator, keyword string, value *float64) {
+			if value != nil {
+				literal := strconv.FormatFloat(*value, 'f', -1, 64)
+				spelled := strings.NewReplacer("-", "Minus", ".", "_").Replace(literal)
+				add(name+spelled, fmt.Sprintf("v %s %s", operator, literal), keyword+" "+literal)
+			}
+		}
+		bound("Min", ">=", "minimum", c.Minimum)
+		bound("Gt", ">", "exclusiveMinimum", c.ExclusiveMinimum)
+		bound("Max", "<=", "maximum", c.Maximum)
+		bound("Lt", "<", "exclusiveMaximum", c.ExclusiveMaximum)
+		if c.MultipleOf != nil {
+			literal := strconv.FormatFloat(*c.MultipleOf, 'f', -1, 64)
+			spelled := strings.NewReplacer("-", "Minus", ".", "_").Replace(literal)
+			add("MultipleOf"+spelled, fmt.Sprintf("v %% %s === 0", literal), "multipleOf "+literal)
+		}
+	}
+	if len(names) == 0 {
+		return refinement{}, false
+	}
+	if mapping, mapped := g.customTypes.Get(prim.Format); prim.Format != "" && mapped && mapping.IoTsType != r.Codec {
+		return refinement{}, false
+	}
+
+	r.Name += strings.Join(names, "")
+	r.Predicate = strings.Join(checks, " && ")
+	r.Constraints = strings.Join(descriptions, ", ")
+	return r, true
+}
+
+// usedRefinements returns the refinement codecs the DTOs use, sorted by name
+func (g *TypeScriptGenerator) usedRefinements(dtos []generator.DTO) []refinement {
+	byName := make(map[string]refinement)
+	g.usesPrimitive(dtos, func(prim generator.PrimitiveType) bool {
+		if refined, ok := g.refinement(prim); ok {
+			byName[refined.Name] = refined
+		}
+		return false
+	})
+
+	refinements := make([]refinement, 0, len(byName))
+	for _, refined := range byName {
+		refinements = append(refinements, refined)
+	}
+	sort.Slice(refinements, func(i, j int) bool { return refinements[i].Name < refinements[j].Name })
+	return refinements
+}
+
+// usesPrimitive reports whether any of the DTOs has a primitive matching uses
+func (g *TypeScriptGenerator) usesPrimitive(dtos []generator.DTO, uses func(generator.PrimitiveType) bool) bool {
+	var visit func(irType generator.IRType) bool
+	visit = func(irType generator.IRType) bool {
+		switch t := irType.(type) {
+		case generator.PrimitiveType:
+			return uses(t)
+		case generator.ArrayType:
+			return visit(t.ElementType)
+		case generator.TupleType:
+			return slices.ContainsFunc(t.ElementTypes, visit) || (t.Rest != nil && visit(t.Rest))
+		case generator.UnionType:
+			return slices.ContainsFunc(t.Types, visit)
+		case generator.MapType:
+			return visit(t.ValueType)
+		case generator.ObjectType:
+			return t.DTORef != nil && slices.ContainsFunc(t.DTORef.IRTypes(), visit)
+		}
+		return false
+	}
+
+	for _, dto := range dtos {
+		if slices.ContainsFunc(dto.IRTypes(), visit) {
+			return true
+		}
+	}
+	return false
+}
+
+// transformingCodec matches codecs named after io-ts-types' transforming
+// codecs, e.g. DateFromISOString, which decode to a different type than
+// they encode to
+var transformingCodec = regexp.MustCompile(`From[A-Z]`)
+
+// transforms reports whether a primitive's codec encodes values to a
+// different wire format
+func (g *TypeScriptGenerator) transforms(prim generator.PrimitiveType) bool {
+	if g.isDefaultBigInt(prim) {
+		return true
+	}
+	if prim.Format == "" {
+		return false
+	}
+	if _, refined := g.refinement(prim); refined {
+		return false
+	}
+	mapping, exists := g.customTypes.Get(prim.Format)
+	return exists && transformingCodec.MatchString(mapping.IoTsType)
+}
+
+// encodingDTOs returns the DTOs whose codecs transform values, directly or
+// through the DTOs they extend or reference, and so get an encode helper
+func (g *TypeScriptGenerator) encodingDTOs(dtos []generator.DTO) map[string]bool {
+	encoders := make(map[string]bool)
+	for changed := true; changed; {
+		changed = false
+		for _, dto := range dtos {
+			if encoders[dto.Name] {
+				continue
+			}
+			encodes := g.usesPrimitive([]generator.DTO{dto}, g.transforms)
+			for _, dep := range generator.Dependencies(dto) {
+				encodes = encodes || encoders[dep]
+			}
+			if encodes {
+				encoders[dto.Name] = true
+				changed = true
+			}
+		}
+	}
+	return encoders
+}
+
+// warnUnmappedFormats reports string formats that have no mapping and are
+// generated as plain strings
+func (g *TypeScriptGenerator) warnUnmappedFormats(dtos []generator.DTO, warnings *generator.WarningCollector) {
+	for _, dto := range dtos {
+		for _, prop := range dto.Properties {
+			for _, format := range generator.StringFormats(prop.Type) {
+				if _, exists := g.customTypes.Get(format); exists || format == "password" {
+					continue
+				}
+				warnings.Add(generator.Warning{
+					Schema:   dto.Name,
+					Property: prop.Name,
+					Code:     "unknown-format",
+					Message:  fmt.Sprintf("format '%s' has no mapping and is generated as a plain string", format),
+				})
+			}
+		}
+	}
+}
+
+// warnAdditionalProperties reports typed additional properties widened to
+// unknown because declared properties do not share their type
+func (g *TypeScriptGenerator) warnAdditionalProperties(dtos []generator.DTO, warnings *generator.WarningCollector) {
+	var visit func(schema string, dto generator.DTO)
+	visit = func(schema string, dto generator.DTO) {
+		if dto.AdditionalProperties != nil && !g.recordCoversProperties(dto) {
+			warnings.Add(generator.Warning{
+				Schema:  schema,
+				Code:    "untyped-additional-properties",
+				Message: fmt.Sprintf("io-ts records check declared properties too, so additional properties of type %s are generated as unknown", dto.AdditionalProperties.TypeName()),
+			})
+		}
+		for _, prop := range dto.Properties {
+			if object, ok := prop.Type.(generator.ObjectType); ok && object.DTORef != nil {
+				visit(schema, *object.DTORef)
+			}
+		}
+	}
+	for _, dto := range dtos {
+		visit(dto.Name, dto)
+	}
+}
+
+// warnTupleRest reports tuples with rest elements, which io-ts codecs cannot
+// validate and are generated as fixed-length tuples
+func (g *TypeScriptGenerator) warnTupleRest(dtos []generator.DTO, warnings *generator.WarningCollector) {
+	var hasRest func(irType generator.IRType) bool
+	hasRest = func(irType generator.IRType) bool {
+		switch t := irType.(type) {
+		case generator.TupleType:
+			return t.Rest != nil || slices.ContainsFunc(t.ElementTypes, hasRest)
+		case generator.ArrayType:
+			return hasRest(t.ElementType)
+		case generator.UnionType:
+			return slices.ContainsFunc(t.Types, hasRest)
+		case generator.MapType:
+			return hasRest(t.ValueType)
+		}
+		return false
+	}
+
+	for _, dto := range dtos {
+		for _, prop := range dto.Properties {
+			if hasRest(prop.Type) {
+				warnings.Add(generator.Warning{
+					Schema:   dto.Name,
+					Property: prop.Name,
+					Code:     "tuple-rest",
+					Message:  "io-ts tuples cannot validate rest elements; generated as a fixed-length tuple",
+				})
+			}
+		}
+	}
 }
 
 // getUsedFormatsInDTO finds all formats used in a single DTO
 func (g *TypeScriptGenerator) getUsedFormatsInDTO(dto generator.DTO) []string {
+	return g.getUsedFormats([]generator.DTO{dto})
+}
+
+// getUsedFormats finds all formats used across DTOs, including array and tuple elements
+func (g *TypeScriptGenerator) getUsedFormats(dtos []generator.DTO) []string {
 	formatSet := make(map[string]bool)
 	var formats []string
 
-	for _, prop := range dto.Properties {
-		if prim, ok := prop.Type.(generator.PrimitiveType); ok {
-			if prim.Format != "" && !formatSet[prim.Format] {
-				formats = append(formats, prim.Format)
-				formatSet[prim.Format] = true
+	var visit func(irType generator.IRType)
+	visit = func(irType generator.IRType) {
+		switch t := irType.(type) {
+		case generator.PrimitiveType:
+			if t.Format != "" && !formatSet[t.Format] {
+				formats = append(formats, t.Format)
+				formatSet[t.Format] = true
+			}
+		case generator.ArrayType:
+			visit(t.ElementType)
+		case generator.TupleType:
+			for _, elem := range t.ElementTypes {
+				visit(elem)
+			}
+			if t.Rest != nil {
+				visit(t.Rest)
+			}
+		case generator.UnionType:
+			for _, member := range t.Types {
+				visit(member)
+			}
+		case generator.MapType:
+			visit(t.KeyType)
+			visit(t.ValueType)
+		case generator.ObjectType:
+			if t.DTORef != nil {
+				for _, irType := range t.DTORef.IRTypes() {
+					visit(irType)
+				}
 			}
 		}
 	}
 
+	for _, dto := range dtos {
+		for _, irType := range dto.IRTypes() {
+			visit(irType)
+		}
+	}
+
 	return formats
 }
 
-// sortDTOsByDependency sorts DTOs to handle dependencies correctly
+// sortDTOsByDependency sorts DTOs so referenced schemas are declared before their users
 func (g *TypeScriptGenerator) sortDTOsByDependency(dtos []generator.DTO) []generator.DTO {
-	// Simple alphabetical sort for now - could be enhanced with proper dependency resolution
-	sorted := make([]generator.DTO, len(dtos))
-	copy(sorted, dtos)
-
-	sort.Slice(sorted, func(i, j int) bool {
-		return sorted[i].Name < sorted[j].Name
-	})
-
-	return sorted
+	return generator.SortByDependency(dtos)
 }
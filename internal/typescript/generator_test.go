@@ -1,10 +1,13 @@
 package typescript
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"text/template"
 
 	"dtoForge/internal/generator"
 	"dtoForge/internal/testutils"
@@ -70,6 +73,18 @@ func TestTypeScriptGenerator_ToIoTsType(t *testing.T) {
 			nullable: false,
 			expected: "t.number",
 		},
+		{
+			name:     "Integer with unmapped format",
+			irType:   generator.PrimitiveType{Name: "integer", Format: "int64"},
+			nullable: false,
+			expected: "t.number",
+		},
+		{
+			name:     "BigInt type",
+			irType:   generator.PrimitiveType{Name: "bigint", Format: "int64"},
+			nullable: false,
+			expected: "BigIntFromNumberOrString",
+		},
 		{
 			name:     "Boolean type",
 			irType:   generator.PrimitiveType{Name: "boolean"},
@@ -82,12 +97,54 @@ func TestTypeScriptGenerator_ToIoTsType(t *testing.T) {
 			nullable: false,
 			expected: "t.array(t.string)",
 		},
+		{
+			name:     "Nested array of numbers",
+			irType:   generator.ArrayType{ElementType: generator.ArrayType{ElementType: generator.PrimitiveType{Name: "number"}}},
+			nullable: true,
+			expected: "t.union([t.array(t.array(t.number)), t.null])",
+		},
+		{
+			name:     "Tuple type",
+			irType:   generator.TupleType{ElementTypes: []generator.IRType{generator.PrimitiveType{Name: "string"}, generator.ReferenceType{RefName: "User"}}},
+			nullable: false,
+			expected: "t.tuple([t.string, UserCodec])",
+		},
+		{
+			name:     "Map type",
+			irType:   generator.MapType{KeyType: generator.PrimitiveType{Name: "string"}, ValueType: generator.ReferenceType{RefName: "Tag"}},
+			nullable: true,
+			expected: "t.union([t.record(t.string, TagCodec), t.null])",
+		},
+		{
+			name:     "Literal type",
+			irType:   generator.NewLiteralType("card"),
+			nullable: false,
+			expected: "t.literal('card')",
+		},
+		{
+			name:     "Multi-line literal type",
+			irType:   generator.NewLiteralType("first line\nsecond line"),
+			nullable: false,
+			expected: `t.literal('first line\nsecond line')`,
+		},
+		{
+			name:     "Union type",
+			irType:   generator.UnionType{Types: []generator.IRType{generator.ReferenceType{RefName: "Cat"}, generator.PrimitiveType{Name: "string"}}},
+			nullable: false,
+			expected: "t.union([CatCodec, t.string])",
+		},
 		{
 			name:     "Reference type",
 			irType:   generator.ReferenceType{RefName: "User"},
 			nullable: false,
 			expected: "UserCodec",
 		},
+		{
+			name:     "Nullable reference type",
+			irType:   generator.ReferenceType{RefName: "User"},
+			nullable: true,
+			expected: "t.union([UserCodec, t.null])",
+		},
 		{
 			name:     "Enum type",
 			irType:   generator.EnumType{Values: []string{"active", "inactive"}},
@@ -100,6 +157,25 @@ func TestTypeScriptGenerator_ToIoTsType(t *testing.T) {
 			nullable: false,
 			expected: "ProductCodec",
 		},
+		{
+			name:     "Free-form object type",
+			irType:   generator.ObjectType{},
+			nullable: false,
+			expected: "t.record(t.string, t.unknown)",
+		},
+		{
+			name: "Inline object type",
+			irType: generator.ObjectType{Inline: true, DTORef: &generator.DTO{
+				Name: "shipping",
+				Type: "object",
+				Properties: []generator.Property{
+					{Name: "city", Type: generator.PrimitiveType{Name: "string"}, Required: true},
+					{Name: "zip", Type: generator.PrimitiveType{Name: "string"}, Nullable: true},
+				},
+			}},
+			nullable: false,
+			expected: "t.intersection([t.type({ city: t.string }), t.partial({ zip: t.union([t.string, t.null]) })])",
+		},
 	}
 
 	for _, tt := range tests {
@@ -152,6 +228,62 @@ func TestTypeScriptGenerator_ToTSType(t *testing.T) {
 			nullable: false,
 			expected: "string[]",
 		},
+		{
+			name:     "Nested array of numbers",
+			irType:   generator.ArrayType{ElementType: generator.ArrayType{ElementType: generator.PrimitiveType{Name: "number"}}},
+			nullable: false,
+			expected: "number[][]",
+		},
+		{
+			name:     "Array of enum",
+			irType:   generator.ArrayType{ElementType: generator.EnumType{Values: []string{"a", "b"}}},
+			nullable: false,
+			expected: "('a' | 'b')[]",
+		},
+		{
+			name:     "Tuple type",
+			irType:   generator.TupleType{ElementTypes: []generator.IRType{generator.PrimitiveType{Name: "string"}, generator.ReferenceType{RefName: "User"}}},
+			nullable: true,
+			expected: "[string, User] | null",
+		},
+		{
+			name:     "Map type",
+			irType:   generator.MapType{KeyType: generator.PrimitiveType{Name: "string"}, ValueType: generator.ReferenceType{RefName: "Tag"}},
+			nullable: false,
+			expected: "Record<string, Tag>",
+		},
+		{
+			name:     "Literal type",
+			irType:   generator.NewLiteralType(2),
+			nullable: true,
+			expected: "2 | null",
+		},
+		{
+			name: "Inline object type",
+			irType: generator.ObjectType{Inline: true, DTORef: &generator.DTO{
+				Name: "shipping",
+				Type: "object",
+				Properties: []generator.Property{
+					{Name: "city", Type: generator.PrimitiveType{Name: "string"}, Required: true},
+					{Name: "zip", Type: generator.PrimitiveType{Name: "string"}},
+				},
+				AdditionalProperties: generator.PrimitiveType{Name: "string"},
+			}},
+			nullable: false,
+			expected: "{ city: string; zip?: string; [key: string]: string }",
+		},
+		{
+			name:     "Inline object type without properties",
+			irType:   generator.ObjectType{Inline: true, DTORef: &generator.DTO{Name: "meta", Type: "object"}},
+			nullable: false,
+			expected: "Record<string, unknown>",
+		},
+		{
+			name:     "Union array",
+			irType:   generator.ArrayType{ElementType: generator.UnionType{Types: []generator.IRType{generator.ReferenceType{RefName: "Cat"}, generator.PrimitiveType{Name: "string"}}}},
+			nullable: false,
+			expected: "(Cat | string)[]",
+		},
 		{
 			name:     "Reference type",
 			irType:   generator.ReferenceType{RefName: "User"},
@@ -203,6 +335,38 @@ func TestTypeScriptGenerator_UtilityFunctions(t *testing.T) {
 	}
 }
 
+func TestTypeScriptGenerator_GetExt(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	metadata := generator.Metadata{"x-internal": true, "x-owner": map[string]interface{}{"team": "billing"}}
+
+	tests := []struct {
+		name     string
+		ext      string
+		expected string
+	}{
+		{"Full name", "x-internal", "true"},
+		{"Without prefix", "owner", `{"team":"billing"}`},
+		{"Missing", "x-sensitive", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gen.getExt(metadata, tt.ext); got != tt.expected {
+				t.Errorf("getExt() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+
+	tmpl := template.Must(template.New("ext").Funcs(gen.templateFuncs()).Parse(`{{if eq (getExt . "internal") "true"}}internal{{end}}`))
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, metadata); err != nil {
+		t.Fatalf("Execute() failed: %v", err)
+	}
+	if buf.String() != "internal" {
+		t.Errorf("template output = %q, want %q", buf.String(), "internal")
+	}
+}
+
 func TestTypeScriptGenerator_HasDescription(t *testing.T) {
 	gen := NewTypeScriptGenerator()
 
@@ -287,7 +451,7 @@ func TestTypeScriptGenerator_Generate_MultipleFiles(t *testing.T) {
 
 	// Check content of user.ts
 	userFile := filepath.Join(tempDir, "user.ts")
-	testutils.AssertFileContains(t, userFile, "export const UserCodec = t.type({")
+	testutils.AssertFileContains(t, userFile, "export const UserCodec = t.intersection([t.type({\n  // Identifier\n  id: t.string,\n}), t.partial({\n  // Name\n  name: t.string,\n})]);")
 	testutils.AssertFileContains(t, userFile, "export type User = t.TypeOf<typeof UserCodec>;")
 	testutils.AssertFileContains(t, userFile, "import * as t from 'io-ts';")
 
@@ -310,6 +474,47 @@ func TestTypeScriptGenerator_Generate_MultipleFiles(t *testing.T) {
 	testutils.AssertFileContains(t, packageFile, `"name": "test-typescript"`)
 }
 
+func TestTypeScriptGenerator_Generate_GroupByTag(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+
+	configPath := testutils.WriteFile(t, tempDir, "config.yaml", `output:
+  groupBy: tag
+customTypes:
+  uuid:
+    ioTsType: "UUID"
+    typeScriptType: "UUID"
+    import: "import { UUID } from './branded-types';"`)
+
+	address := testutils.CreateTestDTO("Address")
+	address.Tags = []string{"Billing", "Users"}
+	user := generator.DTO{
+		Name: "User",
+		Tags: []string{"Users"},
+		Properties: []generator.Property{
+			{Name: "id", Type: generator.PrimitiveType{Name: "string", Format: "uuid"}, Required: true},
+			{Name: "address", Type: generator.ReferenceType{RefName: "Address"}, Required: true},
+		},
+	}
+
+	config := generator.Config{
+		OutputFolder:   tempDir,
+		TargetLanguage: "typescript",
+		ConfigFile:     configPath,
+	}
+	if err := gen.Generate([]generator.DTO{user, address}, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	userFile := filepath.Join(tempDir, "users", "user.ts")
+	testutils.AssertFileContains(t, userFile, "import { UUID } from '../branded-types';")
+	testutils.AssertFileContains(t, userFile, "import { AddressCodec } from '../shared/address';")
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "users", "index.ts"), "export * from './user';")
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "shared", "index.ts"), "export * from './address';")
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "index.ts"), "export * from './users/user';")
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "index.ts"), "export * from './shared/address';")
+}
+
 func TestTypeScriptGenerator_Generate_SingleFile(t *testing.T) {
 	gen := NewTypeScriptGenerator()
 	tempDir := testutils.TempDir(t)
@@ -452,3 +657,1310 @@ func TestTypeScriptGenerator_CustomTypes(t *testing.T) {
 		t.Errorf("Should have EmailString import, got content:\n%s", content)
 	}
 }
+
+func TestTypeScriptGenerator_Deprecated(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+
+	dto := testutils.CreateTestDTO("LegacyUser")
+	dto.Deprecated = true
+	dto.Properties[1].Deprecated = true
+
+	config := generator.Config{
+		OutputFolder:   tempDir,
+		PackageName:    "deprecated-test",
+		TargetLanguage: "typescript",
+	}
+
+	if err := gen.Generate([]generator.DTO{dto}, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	userFile := filepath.Join(tempDir, "legacy-user.ts")
+	testutils.AssertFileContains(t, userFile, "/**\n * Test DTO\n * @deprecated\n */")
+	testutils.AssertFileContains(t, userFile, "  /**\n   * Name\n   * @deprecated\n   */\n  name:")
+	testutils.AssertFileContains(t, userFile, "  // Identifier\n  id:")
+}
+
+func TestTypeScriptGenerator_Defaults(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+
+	dto := testutils.CreateTestDTO("Settings")
+	dto.Properties[1].Default = "anonymous"
+	dto.Properties = append(dto.Properties, generator.Property{
+		Name:    "limits",
+		Type:    generator.ArrayType{ElementType: generator.PrimitiveType{Name: "number"}},
+		Default: []interface{}{1, 2},
+	})
+
+	config := generator.Config{
+		OutputFolder:   tempDir,
+		TargetLanguage: "typescript",
+	}
+	if err := gen.Generate([]generator.DTO{dto}, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	settingsFile := filepath.Join(tempDir, "settings.ts")
+	testutils.AssertFileContains(t, settingsFile, "  /**\n   * Name\n   * @default 'anonymous'\n   */\n  name: t.string,")
+	testutils.AssertFileContains(t, settingsFile, "  /**\n   * @default [1,2]\n   */\n  limits:")
+}
+
+func TestTypeScriptGenerator_UnionDTO(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+
+	dtos := []generator.DTO{
+		testutils.CreateTestDTO("Cat"),
+		{
+			Name:         "Pet",
+			Type:         "union",
+			UnionMembers: []generator.IRType{generator.ReferenceType{RefName: "Cat"}, generator.PrimitiveType{Name: "string"}},
+		},
+	}
+
+	config := generator.Config{
+		OutputFolder:   tempDir,
+		TargetLanguage: "typescript",
+	}
+	if err := gen.Generate(dtos, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	petFile := filepath.Join(tempDir, "pet.ts")
+	testutils.AssertFileContains(t, petFile, "import { CatCodec } from './cat';")
+	testutils.AssertFileContains(t, petFile, "export const PetCodec = t.union([CatCodec, t.string]);")
+	testutils.AssertFileContains(t, petFile, "export type Pet = t.TypeOf<typeof PetCodec>;")
+	testutils.AssertFileNotContains(t, petFile, "PetPartialCodec")
+}
+
+func TestTypeScriptGenerator_UnionProperty(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+
+	dtos := []generator.DTO{
+		testutils.CreateTestDTO("Cat"),
+		testutils.CreateTestDTO("Dog"),
+		{
+			Name: "Owner",
+			Type: "object",
+			Properties: []generator.Property{
+				{
+					Name:     "pet",
+					Type:     generator.UnionType{Types: []generator.IRType{generator.ReferenceType{RefName: "Cat"}, generator.ReferenceType{RefName: "Dog"}}},
+					Nullable: true,
+				},
+			},
+			Required: []string{"pet"},
+		},
+	}
+
+	config := generator.Config{
+		OutputFolder:   tempDir,
+		TargetLanguage: "typescript",
+	}
+	if err := gen.Generate(dtos, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	ownerFile := filepath.Join(tempDir, "owner.ts")
+	testutils.AssertFileContains(t, ownerFile, "import { CatCodec } from './cat';")
+	testutils.AssertFileContains(t, ownerFile, "import { DogCodec } from './dog';")
+	testutils.AssertFileContains(t, ownerFile, "pet: t.union([t.union([CatCodec, DogCodec]), t.null]),")
+}
+
+func TestTypeScriptGenerator_AdditionalProperties(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+
+	dtos := []generator.DTO{
+		testutils.CreateTestDTO("Tag"),
+		{
+			Name:                 "Labels",
+			Type:                 "object",
+			AdditionalProperties: generator.PrimitiveType{Name: "string"},
+		},
+		{
+			Name: "Item",
+			Type: "object",
+			Properties: []generator.Property{
+				{Name: "id", Type: generator.PrimitiveType{Name: "string"}, Required: true},
+			},
+			Required:             []string{"id"},
+			AdditionalProperties: generator.ReferenceType{RefName: "Tag"},
+		},
+		{
+			Name: "Tree",
+			Type: "object",
+			Properties: []generator.Property{
+				{Name: "children", Type: generator.ArrayType{ElementType: generator.ReferenceType{RefName: "Tree"}}},
+			},
+			AdditionalProperties: generator.PrimitiveType{Name: "unknown"},
+		},
+		{
+			Name: "Scores",
+			Type: "object",
+			Properties: []generator.Property{
+				{Name: "total", Type: generator.PrimitiveType{Name: "number"}, Required: true},
+			},
+			AdditionalProperties: generator.PrimitiveType{Name: "number"},
+		},
+	}
+
+	warnings := &generator.WarningCollector{}
+	config := generator.Config{
+		OutputFolder:   tempDir,
+		TargetLanguage: "typescript",
+		Warnings:       warnings,
+	}
+	if err := gen.Generate(dtos, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "labels.ts"), "export const LabelsCodec = t.record(t.string, t.string);")
+
+	itemFile := filepath.Join(tempDir, "item.ts")
+	// t.record would check id against TagCodec too, so the record accepts
+	// any value
+	testutils.AssertFileContains(t, itemFile, "export const ItemCodec = t.intersection([t.type({\n  id: t.string,\n}), t.record(t.string, t.unknown)]);")
+	if got := warnings.Warnings(); len(got) != 1 || got[0].Code != "untyped-additional-properties" || got[0].Schema != "Item" {
+		t.Errorf("warnings = %v, want an untyped-additional-properties warning for Item", got)
+	}
+
+	// Properties of the record's type keep it typed
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "scores.ts"), "}), t.record(t.string, t.number)]);")
+
+	treeFile := filepath.Join(tempDir, "tree.ts")
+	testutils.AssertFileContains(t, treeFile, "  [key: string]: unknown;\n}")
+	testutils.AssertFileContains(t, treeFile, "}), t.record(t.string, t.unknown)]));")
+}
+
+func TestTypeScriptGenerator_InlineObjects(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+
+	shipping := generator.DTO{
+		Name: "shipping",
+		Type: "object",
+		Properties: []generator.Property{
+			{Name: "placed_at", Type: generator.PrimitiveType{Name: "string", Format: "date-time"}, Required: true},
+			{Name: "tag", Type: generator.ReferenceType{RefName: "Tag"}},
+		},
+	}
+	dtos := []generator.DTO{
+		testutils.CreateTestDTO("Tag"),
+		{
+			Name:       "Order",
+			Type:       "object",
+			Properties: []generator.Property{{Name: "shipping", Type: generator.ObjectType{DTORef: &shipping, Inline: true}, Required: true}},
+			Required:   []string{"shipping"},
+		},
+	}
+
+	config := generator.Config{
+		OutputFolder:   tempDir,
+		TargetLanguage: "typescript",
+	}
+	if err := gen.Generate(dtos, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	// Codecs used inside the inline object are imported like any other
+	orderFile := filepath.Join(tempDir, "order.ts")
+	testutils.AssertFileContains(t, orderFile, "import { DateFromISOString } from 'io-ts-types';")
+	testutils.AssertFileContains(t, orderFile, "import { TagCodec } from './tag';")
+	testutils.AssertFileContains(t, orderFile, "  shipping: t.intersection([t.type({ placed_at: DateFromISOString }), t.partial({ tag: TagCodec })]),")
+}
+
+func TestTypeScriptGenerator_LiteralProperties(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+
+	dtos := []generator.DTO{
+		{
+			Name: "Card",
+			Type: "object",
+			Properties: []generator.Property{
+				{Name: "kind", Type: generator.NewLiteralType("card"), Required: true},
+				{Name: "version", Type: generator.NewLiteralType(2), Required: true},
+				{Name: "active", Type: generator.NewLiteralType(true)},
+			},
+			Required: []string{"kind", "version"},
+		},
+		{
+			Name: "Folder",
+			Type: "object",
+			Properties: []generator.Property{
+				{Name: "kind", Type: generator.NewLiteralType("folder"), Required: true},
+				{Name: "children", Type: generator.ArrayType{ElementType: generator.ReferenceType{RefName: "Folder"}}},
+			},
+			Required: []string{"kind"},
+		},
+	}
+
+	config := generator.Config{
+		OutputFolder:   tempDir,
+		TargetLanguage: "typescript",
+	}
+	if err := gen.Generate(dtos, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	cardFile := filepath.Join(tempDir, "card.ts")
+	testutils.AssertFileContains(t, cardFile, "  kind: t.literal('card'),")
+	testutils.AssertFileContains(t, cardFile, "  version: t.literal(2),")
+	testutils.AssertFileContains(t, cardFile, "  active: t.literal(true),")
+
+	folderFile := filepath.Join(tempDir, "folder.ts")
+	testutils.AssertFileContains(t, folderFile, "  kind: 'folder';")
+	testutils.AssertFileContains(t, folderFile, "  kind: t.literal('folder'),")
+}
+
+func TestTypeScriptGenerator_DecodeHelpers(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+
+	dtos := []generator.DTO{testutils.CreateTestDTO("User")}
+	config := generator.Config{
+		OutputFolder:   tempDir,
+		TargetLanguage: "typescript",
+	}
+	if err := gen.Generate(dtos, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	helpersFile := filepath.Join(tempDir, "helpers.ts")
+	testutils.AssertFileContains(t, helpersFile, "import { formatValidationErrors } from 'io-ts-reporters';")
+	testutils.AssertFileContains(t, helpersFile, "export class DecodeError extends Error {")
+	testutils.AssertFileContains(t, helpersFile, "export const decodeOrThrow = <A, O>(codec: t.Type<A, O, unknown>) =>")
+
+	userFile := filepath.Join(tempDir, "user.ts")
+	testutils.AssertFileContains(t, userFile, "import { decodeOrThrow } from './helpers';")
+	testutils.AssertFileContains(t, userFile, "export const decodeUserOrThrow = decodeOrThrow(UserCodec);")
+
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "index.ts"), "export * from './helpers';")
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "package.json"), `"io-ts-reporters": "^2.0.1",`)
+
+	// Without helpers, DTO files keep only the Either-returning decoder
+	noHelpersDir := testutils.TempDir(t)
+	configFile := testutils.WriteFile(t, noHelpersDir, "config.yaml", "generation:\n  generatePackageJson: true\n  generateHelpers: false\n")
+	config = generator.Config{OutputFolder: noHelpersDir, ConfigFile: configFile}
+	if err := gen.Generate(dtos, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	userFile = filepath.Join(noHelpersDir, "user.ts")
+	testutils.AssertFileContains(t, userFile, "export const decodeUser = (value: unknown) =>")
+	testutils.AssertFileNotContains(t, userFile, "decodeOrThrow")
+	testutils.AssertFileNotContains(t, filepath.Join(noHelpersDir, "package.json"), "io-ts-reporters")
+	if _, err := os.Stat(filepath.Join(noHelpersDir, "helpers.ts")); !os.IsNotExist(err) {
+		t.Errorf("helpers.ts generated without generateHelpers")
+	}
+	// validateData stays exported from the index either way
+	testutils.AssertFileContains(t, filepath.Join(noHelpersDir, "index.ts"), "export const validateData = <T>(")
+	testutils.AssertFileNotContains(t, filepath.Join(noHelpersDir, "index.ts"), "io-ts-reporters")
+}
+
+func TestTypeScriptGenerator_SingleFileDecodeHelpers(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+
+	configFile := testutils.WriteFile(t, tempDir, "config.yaml", "output:\n  mode: single\ngeneration:\n  generateHelpers: true\n")
+	config := generator.Config{OutputFolder: tempDir, ConfigFile: configFile}
+	if err := gen.Generate([]generator.DTO{testutils.CreateTestDTO("User")}, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	content := testutils.ReadFile(t, filepath.Join(tempDir, "schemas.ts"))
+	for _, expected := range []string{
+		"import { isLeft, isRight } from 'fp-ts/Either';",
+		"import { formatValidationErrors } from 'io-ts-reporters';",
+		"export const decodeUserOrThrow = decodeOrThrow(UserCodec);",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("schemas.ts missing %q", expected)
+		}
+	}
+	// The helpers are declared before the codecs using them
+	if strings.Index(content, "export const decodeOrThrow") > strings.Index(content, "decodeOrThrow(UserCodec)") {
+		t.Errorf("decodeOrThrow is declared after its first use")
+	}
+}
+
+func TestTypeScriptGenerator_ReservedNames(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+
+	holder := testutils.CreateTestDTO("Holder")
+	holder.Properties = append(holder.Properties, generator.Property{Name: "default", Type: generator.ReferenceType{RefName: "default"}, Required: true})
+	dtos := []generator.DTO{holder, testutils.CreateTestDTO("default")}
+
+	warnings := &generator.WarningCollector{}
+	config := generator.Config{OutputFolder: tempDir, Warnings: warnings}
+	if err := gen.Generate(dtos, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	defaultFile := filepath.Join(tempDir, "default_.ts")
+	testutils.AssertFileContains(t, defaultFile, "export type default_ = t.TypeOf<typeof default_Codec>;")
+	// Helpers are named from the PascalCase form of the spec name
+	testutils.AssertFileContains(t, defaultFile, "export const isDefault = (value: unknown): value is default_ =>")
+	testutils.AssertFileContains(t, defaultFile, "export const decodeDefaultOrThrow = decodeOrThrow(default_Codec);")
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "holder.ts"), "default: default_Codec,")
+	if got := warnings.Warnings(); len(got) != 1 || got[0].Code != "reserved-name" || got[0].Schema != "default" {
+		t.Errorf("warnings = %v, want one reserved-name warning for default", got)
+	}
+}
+
+func TestTypeScriptGenerator_BigInt(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+
+	dto := testutils.CreateTestDTO("Account")
+	dto.Properties = append(dto.Properties, generator.Property{
+		Name:     "balance",
+		Type:     generator.PrimitiveType{Name: "bigint", Format: "int64"},
+		Required: true,
+	})
+
+	config := generator.Config{
+		OutputFolder:   tempDir,
+		TargetLanguage: "typescript",
+	}
+	if err := gen.Generate([]generator.DTO{dto}, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	accountFile := filepath.Join(tempDir, "account.ts")
+	testutils.AssertFileContains(t, accountFile, "import { BigIntFromNumberOrString } from './format-helpers';")
+	testutils.AssertFileContains(t, accountFile, "  balance: BigIntFromNumberOrString,")
+	// bigints encode to strings, so the DTO gets an encode helper
+	testutils.AssertFileContains(t, accountFile, "export const encodeAccount")
+
+	// IDs sent as JSON numbers decode as well as numeric strings
+	helpersFile := filepath.Join(tempDir, "format-helpers.ts")
+	testutils.AssertFileContains(t, helpersFile, "export const BigIntFromNumberOrString = t.union([t.string, t.number]).pipe(")
+	testutils.AssertFileContains(t, helpersFile, "(typeof u === 'number' ? Number.isSafeInteger(u) : /^-?\\d+$/.test(u))")
+	testutils.AssertFileContains(t, helpersFile, "(a) => a.toString()")
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "index.ts"), "export * from './format-helpers';")
+}
+
+func TestTypeScriptGenerator_MultiLineDescriptions(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+
+	dto := testutils.CreateTestDTO("Invoice")
+	dto.Description = "An invoice.\n\nStatuses:\n- draft\n- paid"
+	dto.Properties[0].Description = "Opaque id, never ends with */"
+	dto.Properties[1].Description = "Display name.\nShown on the PDF."
+
+	config := generator.Config{
+		OutputFolder:   tempDir,
+		PackageName:    "description-test",
+		TargetLanguage: "typescript",
+	}
+
+	if err := gen.Generate([]generator.DTO{dto}, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	invoiceFile := filepath.Join(tempDir, "invoice.ts")
+	testutils.AssertFileContains(t, invoiceFile, "/**\n * An invoice.\n *\n * Statuses:\n * - draft\n * - paid\n */")
+	testutils.AssertFileContains(t, invoiceFile, "  // Opaque id, never ends with */\n  id:")
+	testutils.AssertFileContains(t, invoiceFile, "  /**\n   * Display name.\n   * Shown on the PDF.\n   */\n  name:")
+}
+
+func TestTypeScriptGenerator_Inheritance(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+
+	entity := testutils.CreateTestDTO("Entity")
+	pet := generator.DTO{
+		Name:    "Pet",
+		Type:    "object",
+		Extends: []string{"Entity"},
+		Properties: []generator.Property{
+			{Name: "tag", Type: generator.PrimitiveType{Name: "string"}, Required: true},
+		},
+	}
+	flattened := testutils.CreateTestDTO("Cat")
+	flattened.Metadata["extends"] = "Entity"
+
+	config := generator.Config{
+		OutputFolder:   tempDir,
+		PackageName:    "inheritance-test",
+		TargetLanguage: "typescript",
+	}
+
+	if err := gen.Generate([]generator.DTO{entity, pet, flattened}, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	petFile := filepath.Join(tempDir, "pet.ts")
+	testutils.AssertFileContains(t, petFile, "export const PetCodec = t.intersection([EntityCodec, t.type({")
+	testutils.AssertFileContains(t, petFile, "export const PetPartialCodec = t.intersection([EntityPartialCodec, t.partial({")
+	testutils.AssertFileContains(t, petFile, "import { EntityCodec, EntityPartialCodec } from './entity';")
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "cat.ts"), " * @extends Entity\n")
+}
+
+func TestTypeScriptGenerator_PartialCodecOverrides(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+
+	entity := testutils.CreateTestDTO("Entity")
+	pet := generator.DTO{
+		Name:    "Pet",
+		Type:    "object",
+		Extends: []string{"Entity"},
+		Properties: []generator.Property{
+			{Name: "tag", Type: generator.PrimitiveType{Name: "string"}, Required: true},
+		},
+	}
+	owner := testutils.CreateTestDTO("Owner")
+	order := testutils.CreateTestDTO("Order")
+
+	configFile := testutils.WriteFile(t, tempDir, "config.yaml", `generation:
+  generatePartialCodecs: false
+  partialCodecs:
+    Pet: true
+    Order: true
+`)
+	config := generator.Config{OutputFolder: tempDir, ConfigFile: configFile}
+	if err := gen.Generate([]generator.DTO{entity, pet, owner, order}, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "order.ts"), "export const OrderPartialCodec = t.partial({")
+	testutils.AssertFileNotContains(t, filepath.Join(tempDir, "owner.ts"), "OwnerPartialCodec")
+
+	// The parents of a partial codec get one too, for it to extend
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "pet.ts"), "export const PetPartialCodec = t.intersection([EntityPartialCodec, t.partial({")
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "entity.ts"), "export const EntityPartialCodec = t.partial({")
+
+	// Opting out keeps the parent's partial codec out of the child's imports
+	optOutDir := testutils.TempDir(t)
+	configFile = testutils.WriteFile(t, optOutDir, "config.yaml", `generation:
+  generatePartialCodecs: true
+  partialCodecs:
+    Pet: false
+`)
+	config = generator.Config{OutputFolder: optOutDir, ConfigFile: configFile}
+	if err := gen.Generate([]generator.DTO{entity, pet}, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	petFile := filepath.Join(optOutDir, "pet.ts")
+	testutils.AssertFileContains(t, petFile, "import { EntityCodec } from './entity';")
+	testutils.AssertFileNotContains(t, petFile, "PartialCodec")
+	testutils.AssertFileContains(t, filepath.Join(optOutDir, "entity.ts"), "export const EntityPartialCodec = t.partial({")
+
+	// Unset, DTO files get partial codecs and a single file does not
+	unsetDir := testutils.TempDir(t)
+	configFile = testutils.WriteFile(t, unsetDir, "config.yaml", "generation:\n  generateHelpers: false\n")
+	if err := gen.Generate([]generator.DTO{owner}, generator.Config{OutputFolder: unsetDir, ConfigFile: configFile}); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	testutils.AssertFileContains(t, filepath.Join(unsetDir, "owner.ts"), "export const OwnerPartialCodec = t.partial({")
+	configFile = testutils.WriteFile(t, unsetDir, "single.yaml", "output:\n  mode: single\n")
+	if err := gen.Generate([]generator.DTO{owner}, generator.Config{OutputFolder: unsetDir, ConfigFile: configFile}); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	testutils.AssertFileNotContains(t, filepath.Join(unsetDir, "schemas.ts"), "OwnerPartialCodec")
+}
+
+func TestTypeScriptGenerator_ExactCodecs(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+	configPath := testutils.WriteFile(t, tempDir, "config.yaml", "generation:\n  generatePartialCodecs: true\n  exactCodecs: true\n")
+
+	pet := generator.DTO{
+		Name:    "Pet",
+		Type:    "object",
+		Extends: []string{"Entity"},
+		Properties: []generator.Property{
+			{Name: "tag", Type: generator.PrimitiveType{Name: "string"}, Required: true},
+		},
+	}
+	config := generator.Config{OutputFolder: tempDir, ConfigFile: configPath}
+	if err := gen.Generate([]generator.DTO{testutils.CreateTestDTO("Entity"), pet}, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	entityFile := filepath.Join(tempDir, "entity.ts")
+	testutils.AssertFileContains(t, entityFile, "export const EntityCodec = t.intersection([t.exact(t.type({")
+	testutils.AssertFileContains(t, entityFile, "})), t.exact(t.partial({")
+	testutils.AssertFileContains(t, entityFile, "export const EntityPartialCodec = t.exact(t.partial({")
+
+	// Parents are exact already; only the DTO's own object codecs are wrapped
+	petFile := filepath.Join(tempDir, "pet.ts")
+	testutils.AssertFileContains(t, petFile, "export const PetCodec = t.intersection([EntityCodec, t.exact(t.type({\n  tag: t.string,\n}))]);")
+}
+
+func TestTypeScriptGenerator_TupleRestWarnings(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+
+	dto := testutils.CreateTestDTO("Row")
+	dto.Properties = append(dto.Properties, generator.Property{
+		Name: "cells",
+		Type: generator.ArrayType{ElementType: generator.TupleType{
+			ElementTypes: []generator.IRType{generator.PrimitiveType{Name: "string"}},
+			Rest:         generator.PrimitiveType{Name: "number"},
+		}},
+	})
+
+	warnings := &generator.WarningCollector{}
+	config := generator.Config{
+		OutputFolder:   tempDir,
+		TargetLanguage: "typescript",
+		Warnings:       warnings,
+	}
+	if err := gen.Generate([]generator.DTO{dto}, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	got := warnings.Warnings()
+	if len(got) != 1 || got[0].Code != "tuple-rest" || got[0].Location() != "Row.cells" {
+		t.Errorf("Unexpected warnings: %v", got)
+	}
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "row.ts"), "cells: t.array(t.tuple([t.string])),")
+}
+
+func TestTypeScriptGenerator_UnknownFormatWarnings(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+
+	dto := testutils.CreateTestDTO("Host")
+	dto.Properties[1].Type = generator.PrimitiveType{Name: "string", Format: "hostname"}
+	dto.Properties = append(dto.Properties,
+		generator.Property{Name: "secret", Type: generator.PrimitiveType{Name: "string", Format: "password"}},
+		generator.Property{Name: "size", Type: generator.PrimitiveType{Name: "integer", Format: "int32"}},
+	)
+
+	warnings := &generator.WarningCollector{}
+	config := generator.Config{
+		OutputFolder:   tempDir,
+		PackageName:    "warnings-test",
+		TargetLanguage: "typescript",
+		Warnings:       warnings,
+	}
+
+	if err := gen.Generate([]generator.DTO{dto}, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	got := warnings.Warnings()
+	if len(got) != 1 || got[0].Code != "unknown-format" || got[0].Location() != "Host.name" {
+		t.Errorf("Unexpected warnings: %v", got)
+	}
+}
+
+func TestTypeScriptGenerator_BinaryFormat(t *testing.T) {
+	dto := generator.DTO{
+		Name:     "Upload",
+		Type:     "object",
+		Required: []string{"file"},
+		Properties: []generator.Property{
+			{
+				Name:     "file",
+				Type:     generator.PrimitiveType{Name: "binary", Format: "binary"},
+				Required: true,
+			},
+			{
+				Name: "attachments",
+				Type: generator.ArrayType{ElementType: generator.PrimitiveType{Name: "binary", Format: "binary"}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		config       string
+		expectedType string
+		expectedTest string
+	}{
+		{
+			name:         "Universal by default",
+			expectedType: "export type BinaryData = Blob | Buffer;",
+			expectedTest: "(typeof Blob !== 'undefined' && u instanceof Blob) ||",
+		},
+		{
+			name:         "Browser target",
+			config:       "generation:\n  binaryTarget: browser",
+			expectedType: "export type BinaryData = Blob;",
+			expectedTest: "  u instanceof Blob;",
+		},
+		{
+			name:         "Node target",
+			config:       "generation:\n  binaryTarget: node",
+			expectedType: "export type BinaryData = Buffer;",
+			expectedTest: "  u instanceof Buffer;",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := NewTypeScriptGenerator()
+			tempDir := testutils.TempDir(t)
+
+			var configPath string
+			if tt.config != "" {
+				configPath = testutils.WriteFile(t, tempDir, "config.yaml", tt.config)
+			}
+
+			config := generator.Config{
+				OutputFolder:   tempDir,
+				PackageName:    "binary-test",
+				TargetLanguage: "typescript",
+				ConfigFile:     configPath,
+			}
+
+			if err := gen.Generate([]generator.DTO{dto}, config); err != nil {
+				t.Fatalf("Generate() failed: %v", err)
+			}
+
+			helpersFile := filepath.Join(tempDir, "format-helpers.ts")
+			testutils.AssertFileContains(t, helpersFile, tt.expectedType)
+			testutils.AssertFileContains(t, helpersFile, tt.expectedTest)
+			testutils.AssertFileContains(t, helpersFile, "export const BinaryData = new t.Type<BinaryData, BinaryData, unknown>(")
+
+			uploadFile := filepath.Join(tempDir, "upload.ts")
+			testutils.AssertFileContains(t, uploadFile, "import { BinaryData } from './format-helpers';")
+			testutils.AssertFileContains(t, uploadFile, "file: BinaryData,")
+			testutils.AssertFileContains(t, uploadFile, "attachments: t.array(BinaryData),")
+
+			testutils.AssertFileContains(t, filepath.Join(tempDir, "index.ts"), "export * from './format-helpers';")
+		})
+	}
+}
+
+func TestTypeScriptGenerator_ByteFormat(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+
+	dto := generator.DTO{
+		Name:     "Attachment",
+		Type:     "object",
+		Required: []string{"content"},
+		Properties: []generator.Property{
+			{
+				Name:     "content",
+				Type:     generator.PrimitiveType{Name: "binary", Format: "byte"},
+				Required: true,
+			},
+		},
+	}
+
+	config := generator.Config{
+		OutputFolder:   tempDir,
+		PackageName:    "byte-test",
+		TargetLanguage: "typescript",
+	}
+
+	if err := gen.Generate([]generator.DTO{dto}, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	helpersFile := filepath.Join(tempDir, "format-helpers.ts")
+	testutils.AssertFileContains(t, helpersFile, "export const Base64String = t.brand(")
+	testutils.AssertFileContains(t, helpersFile, "export type Base64String = t.TypeOf<typeof Base64String>;")
+	testutils.AssertFileNotContains(t, helpersFile, "BinaryData")
+
+	attachmentFile := filepath.Join(tempDir, "attachment.ts")
+	testutils.AssertFileContains(t, attachmentFile, "import { Base64String } from './format-helpers';")
+	testutils.AssertFileContains(t, attachmentFile, "content: Base64String,")
+}
+
+func TestTypeScriptGenerator_BrandedTypes(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+	configPath := testutils.WriteFile(t, tempDir, "config.yaml", "generation:\n  brandedTypes: true\ncustomTypes:\n  email:\n    ioTsType: t.string\n    typeScriptType: string\n")
+
+	dto := generator.DTO{
+		Name: "Account",
+		Type: "object",
+		Properties: []generator.Property{
+			{Name: "email", Type: generator.PrimitiveType{Name: "string", Format: "email"}, Required: true},
+			{Name: "id", Type: generator.PrimitiveType{Name: "string", Format: "uuid"}, Required: true},
+		},
+	}
+	config := generator.Config{OutputFolder: tempDir, ConfigFile: configPath}
+	if err := gen.Generate([]generator.DTO{dto}, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	// Only the used branded codecs are emitted; customTypes override them
+	brandedFile := filepath.Join(tempDir, "branded-types.ts")
+	testutils.AssertFileContains(t, brandedFile, "export const UUID = t.brand(")
+	testutils.AssertFileNotContains(t, brandedFile, "EmailString")
+	testutils.AssertFileNotContains(t, brandedFile, "DateTimeString")
+
+	accountFile := filepath.Join(tempDir, "account.ts")
+	testutils.AssertFileContains(t, accountFile, "import { UUID } from './branded-types';")
+	testutils.AssertFileContains(t, accountFile, "  email: t.string,\n  id: UUID,")
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "index.ts"), "export * from './branded-types';")
+}
+
+func TestTypeScriptGenerator_IoTsTypes(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+	configPath := testutils.WriteFile(t, tempDir, "config.yaml", "generation:\n  useIoTsTypes: true\n  generatePackageJson: true\n")
+
+	minLength := 1
+	dto := generator.DTO{
+		Name: "Product",
+		Type: "object",
+		Properties: []generator.Property{
+			{Name: "id", Type: generator.PrimitiveType{Name: "string", Format: "uuid"}, Required: true},
+			{Name: "name", Type: generator.PrimitiveType{Name: "string", Constraints: &generator.Constraints{MinLength: &minLength}}, Required: true},
+			{Name: "price", Type: generator.PrimitiveType{Name: "string", Format: "number"}, Required: true},
+		},
+	}
+	config := generator.Config{OutputFolder: tempDir, ConfigFile: configPath}
+	if err := gen.Generate([]generator.DTO{dto}, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	productFile := filepath.Join(tempDir, "product.ts")
+	testutils.AssertFileContains(t, productFile, "import { UUID } from 'io-ts-types';")
+	testutils.AssertFileContains(t, productFile, "import { NonEmptyString } from 'io-ts-types';")
+	testutils.AssertFileContains(t, productFile, "  id: UUID,\n  name: NonEmptyString,\n  price: NumberFromString,")
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "package.json"), `"io-ts-types"`)
+}
+
+func TestTypeScriptGenerator_BrandedIntegers(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	dto := generator.DTO{Name: "User", Type: "object", Properties: []generator.Property{
+		{Name: "age", Type: generator.PrimitiveType{Name: "integer"}, Required: true},
+	}}
+
+	// Integers are plain numbers unless branded integers are asked for
+	tempDir := testutils.TempDir(t)
+	if err := gen.Generate([]generator.DTO{dto}, generator.Config{OutputFolder: tempDir}); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "user.ts"), "  age: t.number,")
+
+	tempDir = testutils.TempDir(t)
+	configFile := testutils.WriteFile(t, tempDir, "config.yaml", "generation:\n  brandedIntegers: true\n")
+	if err := gen.Generate([]generator.DTO{dto}, generator.Config{OutputFolder: tempDir, ConfigFile: configFile}); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "user.ts"), "  age: t.Int,")
+}
+
+func TestTypeScriptGenerator_Readonly(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+	configPath := testutils.WriteFile(t, tempDir, "config.yaml", "generation:\n  readonly: true\n  generatePartialCodecs: true\n")
+
+	dtos := []generator.DTO{
+		{
+			Name:     "Folder",
+			Type:     "object",
+			Required: []string{"children"},
+			Properties: []generator.Property{
+				{Name: "children", Type: generator.ArrayType{ElementType: generator.ReferenceType{RefName: "Folder"}}, Required: true},
+			},
+		},
+		{
+			Name:    "Tagged",
+			Type:    "object",
+			Extends: []string{"Folder"},
+			Properties: []generator.Property{
+				{Name: "tags", Type: generator.ArrayType{ElementType: generator.PrimitiveType{Name: "string"}}, Required: true},
+			},
+		},
+	}
+	config := generator.Config{OutputFolder: tempDir, ConfigFile: configPath}
+	if err := gen.Generate(dtos, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	folderFile := filepath.Join(tempDir, "folder.ts")
+	testutils.AssertFileContains(t, folderFile, "export interface Folder {\n  readonly children: readonly Folder[];\n}")
+	testutils.AssertFileContains(t, folderFile, "t.recursion('Folder', () => t.readonly(t.type({\n  children: t.readonlyArray(FolderCodec),\n})));")
+
+	taggedFile := filepath.Join(tempDir, "tagged.ts")
+	testutils.AssertFileContains(t, taggedFile, "export const TaggedCodec = t.readonly(t.intersection([FolderCodec, t.type({\n  tags: t.readonlyArray(t.string),\n})]));")
+	testutils.AssertFileContains(t, taggedFile, "export const TaggedPartialCodec = t.readonly(t.intersection([FolderPartialCodec, t.partial({")
+}
+
+func TestTypeScriptGenerator_Refinements(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+
+	minLength, maxLength := 3, 50
+	minimum, maximum := 0.0, 120.0
+	dtos := []generator.DTO{
+		{
+			Name:     "User",
+			Type:     "object",
+			Required: []string{"name"},
+			Properties: []generator.Property{
+				{Name: "name", Type: generator.PrimitiveType{Name: "string", Constraints: &generator.Constraints{MinLength: &minLength, MaxLength: &maxLength}}, Required: true},
+				{Name: "age", Type: generator.PrimitiveType{Name: "integer", Constraints: &generator.Constraints{Minimum: &minimum, Maximum: &maximum}}},
+				{Name: "code", Type: generator.PrimitiveType{Name: "string", Constraints: &generator.Constraints{Pattern: "^[A-Z]+$"}}},
+				{Name: "born", Type: generator.PrimitiveType{Name: "string", Format: "date-time", Constraints: &generator.Constraints{MaxLength: &maxLength}}},
+			},
+		},
+	}
+
+	configFile := testutils.WriteFile(t, tempDir, "config.yaml", "generation:\n  refinements: true\n")
+	config := generator.Config{OutputFolder: tempDir, ConfigFile: configFile}
+	if err := gen.Generate(dtos, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	refinementsFile := filepath.Join(tempDir, "refinements.ts")
+	testutils.AssertFileContains(t, refinementsFile, "(v): v is t.Branded<string, StringMinLength3MaxLength50Brand> => v.length >= 3 && v.length <= 50,")
+	testutils.AssertFileContains(t, refinementsFile, "export const IntMin0Max120 = t.brand(\n  t.Int,")
+	testutils.AssertFileContains(t, refinementsFile, "=> new RegExp('^[A-Z]+$').test(v),")
+
+	userFile := filepath.Join(tempDir, "user.ts")
+	testutils.AssertFileContains(t, userFile, "  name: StringMinLength3MaxLength50,")
+	testutils.AssertFileContains(t, userFile, "  age: IntMin0Max120,")
+	// Formats with codecs of their own are not refined
+	testutils.AssertFileContains(t, userFile, "  born: DateFromISOString,")
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "index.ts"), "export * from './refinements';")
+
+	// Without the option constraints are not enforced
+	plainDir := testutils.TempDir(t)
+	if err := gen.Generate(dtos, generator.Config{OutputFolder: plainDir}); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	testutils.AssertFileContains(t, filepath.Join(plainDir, "user.ts"), "  name: t.string,")
+	if _, err := os.Stat(filepath.Join(plainDir, "refinements.ts")); !os.IsNotExist(err) {
+		t.Errorf("refinements.ts generated without refinements")
+	}
+}
+
+func TestTypeScriptGenerator_IoTs1(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+
+	configFile := testutils.WriteFile(t, tempDir, "config.yaml", "generation:\n  ioTsVersion: 1\n  generatePackageJson: true\n  generateHelpers: true\n")
+	config := generator.Config{OutputFolder: tempDir, ConfigFile: configFile}
+	if err := gen.Generate([]generator.DTO{testutils.CreateTestDTO("User")}, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	helpersFile := filepath.Join(tempDir, "helpers.ts")
+	testutils.AssertFileContains(t, helpersFile, "import { isLeft, isRight, left } from 'fp-ts/lib/Either';")
+	testutils.AssertFileContains(t, helpersFile, "import { PathReporter } from 'io-ts/lib/PathReporter';")
+	testutils.AssertFileContains(t, helpersFile, "throw new DecodeError(result.value);")
+	testutils.AssertFileNotContains(t, helpersFile, "io-ts-reporters")
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "index.ts"), "export { isLeft, isRight } from 'fp-ts/lib/Either';")
+
+	packageFile := filepath.Join(tempDir, "package.json")
+	testutils.AssertFileContains(t, packageFile, `"io-ts": "^1.10.4",`)
+	testutils.AssertFileContains(t, packageFile, `"fp-ts": "^1.19.5"`)
+
+	// io-ts-types 0.4, the last release for io-ts 1.x, lacks the codecs of
+	// useIoTsTypes
+	var configErr *generator.ConfigError
+	typesFile := testutils.WriteFile(t, tempDir, "types.yaml", "generation:\n  ioTsVersion: 1\n  useIoTsTypes: true\n")
+	if err := gen.Generate(nil, generator.Config{OutputFolder: tempDir, ConfigFile: typesFile}); !errors.As(err, &configErr) {
+		t.Errorf("Generate() error = %v, want a ConfigError for useIoTsTypes", err)
+	}
+	// The bigint codec is generated, so io-ts 1.x can use it
+	counter := generator.DTO{Name: "Counter", Type: "object", Properties: []generator.Property{
+		{Name: "total", Type: generator.PrimitiveType{Name: "bigint", Format: "int64"}, Required: true},
+	}}
+	if err := gen.Generate([]generator.DTO{counter}, config); err != nil {
+		t.Errorf("Generate() failed for bigint: %v", err)
+	}
+
+	invalidFile := testutils.WriteFile(t, tempDir, "invalid.yaml", "generation:\n  ioTsVersion: 3\n")
+	err := gen.Generate(nil, generator.Config{OutputFolder: tempDir, ConfigFile: invalidFile})
+	if err == nil || !strings.Contains(err.Error(), "invalid io-ts version 3") {
+		t.Errorf("Generate() error = %v, want invalid io-ts version", err)
+	}
+}
+
+func TestTypeScriptGenerator_EnumStyles(t *testing.T) {
+	status := generator.DTO{Name: "Status", Type: "enum", EnumValues: []string{"active", "in-progress", "2fa"}}
+	task := generator.DTO{
+		Name: "Task",
+		Type: "object",
+		Properties: []generator.Property{
+			{Name: "status", Type: generator.ReferenceType{RefName: "Status"}, Required: true},
+			{Name: "kind", Type: generator.EnumType{Values: []string{"a", "b"}}, Required: true},
+		},
+	}
+
+	tests := []struct {
+		style    string
+		expected []string
+		absent   string
+	}{
+		{
+			style:    "union",
+			expected: []string{"export const StatusCodec = t.union([t.literal('active'), t.literal('in-progress'), t.literal('2fa')]);", "export type Status = t.TypeOf<typeof StatusCodec>;"},
+			absent:   "t.keyof",
+		},
+		{
+			style: "enum",
+			expected: []string{
+				"export enum Status {\n  Active = 'active',\n  InProgress = 'in-progress',\n  _2fa = '2fa',\n}",
+				"export const StatusCodec: t.Type<Status, string> = new t.Type<Status, string, unknown>(",
+				"  (u): u is Status => u === Status.Active || u === Status.InProgress || u === Status._2fa,",
+				"  (u, c) => (StatusCodec.is(u) ? t.success(u) : t.failure(u, c)),",
+			},
+			absent: "StatusValues",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.style, func(t *testing.T) {
+			gen := NewTypeScriptGenerator()
+			tempDir := testutils.TempDir(t)
+
+			configFile := testutils.WriteFile(t, tempDir, "config.yaml", "generation:\n  enumStyle: "+tt.style+"\n")
+			config := generator.Config{OutputFolder: tempDir, ConfigFile: configFile}
+			if err := gen.Generate([]generator.DTO{status, task}, config); err != nil {
+				t.Fatalf("Generate() failed: %v", err)
+			}
+
+			statusFile := filepath.Join(tempDir, "status.ts")
+			for _, expected := range tt.expected {
+				testutils.AssertFileContains(t, statusFile, expected)
+			}
+			testutils.AssertFileNotContains(t, statusFile, tt.absent)
+
+			// Inline enums cannot be declared, so they are literal unions
+			testutils.AssertFileContains(t, filepath.Join(tempDir, "task.ts"), "  kind: t.union([t.literal('a'), t.literal('b')]),")
+		})
+	}
+
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+	invalidFile := testutils.WriteFile(t, tempDir, "invalid.yaml", "generation:\n  enumStyle: nativeEnum\n")
+	err := gen.Generate(nil, generator.Config{OutputFolder: tempDir, ConfigFile: invalidFile})
+	if err == nil || !strings.Contains(err.Error(), "invalid enum style 'nativeEnum'") {
+		t.Errorf("Generate() error = %v, want invalid enum style", err)
+	}
+}
+
+func TestTypeScriptGenerator_RequestResponseCodecs(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+
+	dtos := []generator.DTO{
+		{
+			Name:       "Entity",
+			Type:       "object",
+			Properties: []generator.Property{{Name: "id", Type: generator.PrimitiveType{Name: "string"}, Required: true, ReadOnly: true}},
+		},
+		{
+			Name: "Address",
+			Type: "object",
+			Properties: []generator.Property{
+				{Name: "city", Type: generator.PrimitiveType{Name: "string"}},
+				{Name: "verifiedAt", Type: generator.PrimitiveType{Name: "string"}, ReadOnly: true},
+			},
+		},
+		testutils.CreateTestDTO("Tag"),
+		{
+			Name:    "User",
+			Type:    "object",
+			Extends: []string{"Entity"},
+			Properties: []generator.Property{
+				{Name: "name", Type: generator.PrimitiveType{Name: "string"}, Required: true},
+				{Name: "password", Type: generator.PrimitiveType{Name: "string"}, WriteOnly: true},
+				{Name: "address", Type: generator.ReferenceType{RefName: "Address"}},
+				{Name: "tags", Type: generator.ArrayType{ElementType: generator.ReferenceType{RefName: "Tag"}}},
+			},
+		},
+	}
+
+	configFile := testutils.WriteFile(t, tempDir, "config.yaml", "generation:\n  requestResponseCodecs: true\n")
+	config := generator.Config{OutputFolder: tempDir, ConfigFile: configFile}
+	if err := gen.Generate(dtos, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	userFile := filepath.Join(tempDir, "user.ts")
+	testutils.AssertFileContains(t, userFile, "import { AddressCodec, AddressRequestCodec, AddressResponseCodec } from './address';")
+	testutils.AssertFileContains(t, userFile, "import { EntityCodec, EntityPartialCodec, EntityRequestCodec, EntityResponseCodec } from './entity';")
+	testutils.AssertFileContains(t, userFile, "import { TagCodec } from './tag';")
+
+	// Variants strip the other side's properties and use the variants of
+	// their parents and referenced DTOs
+	testutils.AssertFileContains(t, userFile, `export const UserRequestCodec = t.intersection([EntityRequestCodec, t.type({
+  name: t.string,
+}), t.partial({
+  password: t.string,
+  address: AddressRequestCodec,
+  tags: t.array(TagCodec),
+})]);`)
+	testutils.AssertFileContains(t, userFile, `export const UserResponseCodec = t.intersection([EntityResponseCodec, t.type({
+  name: t.string,
+}), t.partial({
+  address: AddressResponseCodec,
+  tags: t.array(TagCodec),
+})]);`)
+	testutils.AssertFileContains(t, userFile, "export type UserRequest = t.TypeOf<typeof UserRequestCodec>;")
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "address.ts"), "export const AddressRequestCodec = t.partial({\n  city: t.string,\n});")
+	testutils.AssertFileNotContains(t, filepath.Join(tempDir, "tag.ts"), "TagRequestCodec")
+
+	conflicting := append(dtos, testutils.CreateTestDTO("UserRequest"))
+	err := gen.Generate(conflicting, config)
+	if err == nil || !strings.Contains(err.Error(), "request codec of 'User' conflicts with the schema 'UserRequest'") {
+		t.Errorf("Generate() error = %v, want a name conflict", err)
+	}
+
+	// Recursive DTOs keep their single codec and say so
+	node := generator.DTO{Name: "Node", Type: "object", Properties: []generator.Property{
+		{Name: "id", Type: generator.PrimitiveType{Name: "string"}, Required: true, ReadOnly: true},
+		{Name: "children", Type: generator.ArrayType{ElementType: generator.ReferenceType{RefName: "Node"}}},
+	}}
+	warnings := &generator.WarningCollector{}
+	recursiveDir := testutils.TempDir(t)
+	if err := gen.Generate([]generator.DTO{node}, generator.Config{OutputFolder: recursiveDir, ConfigFile: configFile, Warnings: warnings}); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	testutils.AssertFileNotContains(t, filepath.Join(recursiveDir, "node.ts"), "NodeRequestCodec")
+	if got := warnings.Warnings(); len(got) != 1 || got[0].Code != "variant-skipped" || got[0].Schema != "Node" {
+		t.Errorf("warnings = %v, want a variant-skipped warning for Node", got)
+	}
+}
+
+func TestTypeScriptGenerator_EncodeHelpers(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+
+	dtos := []generator.DTO{
+		{
+			Name:       "Event",
+			Type:       "object",
+			Properties: []generator.Property{{Name: "at", Type: generator.PrimitiveType{Name: "string", Format: "date-time"}, Required: true}},
+		},
+		{
+			Name:       "Schedule",
+			Type:       "object",
+			Properties: []generator.Property{{Name: "events", Type: generator.ArrayType{ElementType: generator.ReferenceType{RefName: "Event"}}, Required: true}},
+		},
+		testutils.CreateTestDTO("Tag"),
+	}
+
+	config := generator.Config{OutputFolder: tempDir, TargetLanguage: "typescript"}
+	if err := gen.Generate(dtos, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "event.ts"), "export const encodeEvent = (value: Event): t.OutputOf<typeof EventCodec> =>\n  EventCodec.encode(value);")
+
+	// Referencing a transforming codec transforms too
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "schedule.ts"), "export const encodeSchedule = (value: Schedule): t.OutputOf<typeof ScheduleCodec> =>")
+
+	// Codecs that encode to what they decode need no helper
+	testutils.AssertFileNotContains(t, filepath.Join(tempDir, "tag.ts"), "encodeTag")
+}
+
+func TestTypeScriptGenerator_Pragmas(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+
+	configFile := testutils.WriteFile(t, tempDir, "config.yaml", "output:\n  pragmas: ['/* eslint-disable */', '// prettier-ignore']\ngeneration:\n  generateHelpers: true\n  fpTsHelpers: true\n  generatePackageJson: true\n")
+	config := generator.Config{OutputFolder: tempDir, ConfigFile: configFile}
+	if err := gen.Generate([]generator.DTO{testutils.CreateTestDTO("User")}, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	for _, name := range []string{"user.ts", "index.ts", "helpers.ts", "validation.ts"} {
+		content := testutils.ReadFile(t, filepath.Join(tempDir, name))
+		if !strings.HasPrefix(content, "/* eslint-disable */\n// prettier-ignore\n// Generated by DtoForge") {
+			t.Errorf("%s does not start with the pragmas:\n%s", name, content)
+		}
+	}
+	testutils.AssertFileNotContains(t, filepath.Join(tempDir, "package.json"), "eslint-disable")
+}
+
+func TestTypeScriptGenerator_Parallel(t *testing.T) {
+	var dtos []generator.DTO
+	for i := range 30 {
+		dto := testutils.CreateTestDTO(fmt.Sprintf("Item%d", i))
+		if i > 0 {
+			dto.Properties = append(dto.Properties, generator.Property{Name: "previous", Type: generator.ReferenceType{RefName: fmt.Sprintf("Item%d", i-1)}})
+		}
+		dtos = append(dtos, dto)
+	}
+
+	sequential := testutils.TempDir(t)
+	parallel := testutils.TempDir(t)
+	if err := NewTypeScriptGenerator().Generate(dtos, generator.Config{OutputFolder: sequential, TargetLanguage: "typescript"}); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	if err := NewTypeScriptGenerator().Generate(dtos, generator.Config{OutputFolder: parallel, TargetLanguage: "typescript", Parallel: 8}); err != nil {
+		t.Fatalf("Generate() with Parallel failed: %v", err)
+	}
+
+	files, err := os.ReadDir(sequential)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		want := testutils.ReadFile(t, filepath.Join(sequential, file.Name()))
+		if got := testutils.ReadFile(t, filepath.Join(parallel, file.Name())); got != want {
+			t.Errorf("%s generated in parallel differs:\n%s\nwant:\n%s", file.Name(), got, want)
+		}
+	}
+}
+
+func TestTypeScriptGenerator_FpTsHelpers(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+
+	configFile := testutils.WriteFile(t, tempDir, "config.yaml", "generation:\n  fpTsHelpers: true\n")
+	config := generator.Config{OutputFolder: tempDir, ConfigFile: configFile}
+	if err := gen.Generate([]generator.DTO{testutils.CreateTestDTO("User")}, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	validationFile := filepath.Join(tempDir, "validation.ts")
+	testutils.AssertFileContains(t, validationFile, "import { pipe } from 'fp-ts/function';")
+	testutils.AssertFileContains(t, validationFile, "export const validateWith = <A, O>(codec: t.Type<A, O, unknown>) =>")
+	testutils.AssertFileContains(t, validationFile, "export const toResult = <A>(validation: t.Validation<A>): Result<A> =>")
+	testutils.AssertFileContains(t, validationFile, "(input: RequestInfo | URL, init?: RequestInit): TE.TaskEither<FetchError, A> =>")
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "index.ts"), "export * from './validation';")
+
+	// fp-ts 1.x has neither pipe nor fp-ts/function
+	legacyFile := testutils.WriteFile(t, tempDir, "legacy.yaml", "generation:\n  fpTsHelpers: true\n  ioTsVersion: 1\n")
+	err := gen.Generate(nil, generator.Config{OutputFolder: tempDir, ConfigFile: legacyFile})
+	var configErr *generator.ConfigError
+	if !errors.As(err, &configErr) || !strings.Contains(err.Error(), "fpTsHelpers requires fp-ts 2") {
+		t.Errorf("Generate() error = %v, want a ConfigError for the fp-ts 2 requirement", err)
+	}
+}
+
+func TestTypeScriptGenerator_RecursiveSchemas(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+
+	dtos := []generator.DTO{
+		{
+			Name:     "Category",
+			Type:     "object",
+			Required: []string{"id"},
+			Properties: []generator.Property{
+				{Name: "children", Type: generator.ArrayType{ElementType: generator.ReferenceType{RefName: "Category"}}},
+				{Name: "id", Type: generator.PrimitiveType{Name: "string"}, Required: true},
+			},
+		},
+		{
+			Name: "Product",
+			Type: "object",
+			Properties: []generator.Property{
+				{Name: "category", Type: generator.ReferenceType{RefName: "Category"}},
+			},
+		},
+	}
+
+	config := generator.Config{
+		OutputFolder:   tempDir,
+		PackageName:    "recursive-test",
+		TargetLanguage: "typescript",
+	}
+
+	if err := gen.Generate(dtos, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	categoryFile := filepath.Join(tempDir, "category.ts")
+	testutils.AssertFileContains(t, categoryFile, "export interface Category {\n  children?: Category[];\n  id: string;\n}")
+	testutils.AssertFileContains(t, categoryFile, "export const CategoryCodec: t.Type<Category, unknown> = t.recursion('Category', () => t.intersection([t.type({")
+	testutils.AssertFileContains(t, categoryFile, "  id: t.string,\n}), t.partial({\n  children: t.array(CategoryCodec),\n})]));")
+	testutils.AssertFileContains(t, categoryFile, "t.recursion('CategoryPartial', () => t.partial({")
+	testutils.AssertFileNotContains(t, categoryFile, "t.TypeOf<typeof CategoryCodec>")
+	testutils.AssertFileNotContains(t, categoryFile, "from './category'")
+
+	// Non-recursive DTOs keep the plain form but import what they reference
+	productFile := filepath.Join(tempDir, "product.ts")
+	testutils.AssertFileContains(t, productFile, "import { CategoryCodec } from './category';")
+	testutils.AssertFileContains(t, productFile, "export const ProductCodec = t.partial({")
+	testutils.AssertFileContains(t, productFile, "export type Product = t.TypeOf<typeof ProductCodec>;")
+}
+
+func TestTypeScriptGenerator_RecursivePropertyComments(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+
+	dtos := []generator.DTO{
+		{
+			Name: "Node",
+			Type: "object",
+			Properties: []generator.Property{
+				{Name: "children", Type: generator.ArrayType{ElementType: generator.ReferenceType{RefName: "Node"}}, Description: "Child nodes", Deprecated: true},
+				{Name: "label", Type: generator.PrimitiveType{Name: "string"}, Description: "Display label"},
+			},
+		},
+	}
+
+	config := generator.Config{
+		OutputFolder:   tempDir,
+		TargetLanguage: "typescript",
+	}
+	if err := gen.Generate(dtos, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	// The explicit interface of a recursive codec documents its properties
+	// like the codec does
+	nodeFile := filepath.Join(tempDir, "node.ts")
+	testutils.AssertFileContains(t, nodeFile, "export interface Node {\n  /**\n   * Child nodes\n   * @deprecated\n   */\n  children?: Node[];\n  // Display label\n  label?: string;\n}")
+	testutils.AssertFileContains(t, nodeFile, "  // Display label\n  label: t.string,")
+}
+
+func TestTypeScriptGenerator_MutuallyRecursiveSchemas(t *testing.T) {
+	gen := NewTypeScriptGenerator()
+	tempDir := testutils.TempDir(t)
+
+	dtos := []generator.DTO{
+		{
+			Name: "Author",
+			Type: "object",
+			Properties: []generator.Property{
+				{Name: "books", Type: generator.ArrayType{ElementType: generator.ReferenceType{RefName: "Book"}}},
+			},
+		},
+		{
+			Name: "Book",
+			Type: "object",
+			Properties: []generator.Property{
+				{Name: "author", Type: generator.ReferenceType{RefName: "Author"}, Required: true},
+			},
+		},
+	}
+
+	config := generator.Config{OutputFolder: tempDir, TargetLanguage: "typescript"}
+	if err := gen.Generate(dtos, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	// Each side of the cycle declares its type and imports the other's
+	authorFile := filepath.Join(tempDir, "author.ts")
+	testutils.AssertFileContains(t, authorFile, "import { Book, BookCodec } from './book';")
+	testutils.AssertFileContains(t, authorFile, "export interface Author {\n  books?: Book[];\n}")
+	testutils.AssertFileContains(t, authorFile, "export const AuthorCodec: t.Type<Author, unknown> = t.recursion('Author', () => t.partial({")
+
+	bookFile := filepath.Join(tempDir, "book.ts")
+	testutils.AssertFileContains(t, bookFile, "import { Author, AuthorCodec } from './author';")
+	testutils.AssertFileContains(t, bookFile, "export const BookCodec: t.Type<Book, unknown> = t.recursion('Book', () => t.type({\n  author: AuthorCodec,\n}));")
+}
+
+func TestTypeScriptGenerator_NumericEnums(t *testing.T) {
+	priority := generator.DTO{Name: "Priority", Type: "enum", EnumValues: []string{"1", "2", "-3"}, EnumValueType: "integer"}
+	task := generator.DTO{Name: "Task", Type: "object", Properties: []generator.Property{
+		{Name: "priority", Type: generator.ReferenceType{RefName: "Priority"}, Required: true},
+		{Name: "weight", Type: generator.EnumType{Values: []string{"0.5", "1.5"}, UnderlyingType: "number"}, Required: true},
+	}}
+	tempDir := testutils.TempDir(t)
+	config := generator.Config{OutputFolder: tempDir}
+	if err := NewTypeScriptGenerator().Generate([]generator.DTO{priority, task}, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	// Numbers cannot be keyof keys, so numeric enums are literal unions
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "priority.ts"), "export const PriorityCodec = t.union([t.literal(1), t.literal(2), t.literal(-3)]);")
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "task.ts"), "weight: t.union([t.literal(0.5), t.literal(1.5)]),")
+}
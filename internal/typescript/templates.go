@@ -4,62 +4,210 @@ package typescript
 const dtoTemplate = `// Generated by DtoForge - DO NOT EDIT
 {{range .Imports}}{{.}}
 {{end}}
-{{if .DTO.Description}}
-/**
- * {{.DTO.Description}}
- */
+{{with dtoComment .DTO}}
+{{.}}{{end}}{{with .DTO.Metadata.String "not"}}// 'not' constraint is not enforced: {{.}}
 {{end}}{{if eq .DTO.Type "enum"}}// Enum: {{.DTO.Name}}
-export const {{.DTO.Name}}Values = {
+{{if ne enumStyle "keyof"}}{{template "enumCodec" .DTO}}{{else}}export const {{.DTO.Name}}Values = {
 {{range $i, $value := .DTO.EnumValues}}  {{quote $value}}: null{{if ne $i (len $.DTO.EnumValues | add -1)}},{{end}}
 {{end}}} as const;
 
 export const {{.DTO.Name}}Codec = t.keyof({{.DTO.Name}}Values);
 
 export type {{.DTO.Name}} = t.TypeOf<typeof {{.DTO.Name}}Codec>;
-
+{{end}}
 // Validation helper
-export const is{{.DTO.Name}} = (value: unknown): value is {{.DTO.Name}} =>
+export const is{{helperName .DTO.Name}} = (value: unknown): value is {{.DTO.Name}} =>
   {{.DTO.Name}}Codec.is(value);
 
 // Decode helper with error handling
-export const decode{{.DTO.Name}} = (value: unknown) =>
+export const decode{{helperName .DTO.Name}} = (value: unknown) =>
   {{.DTO.Name}}Codec.decode(value);
-{{else}}// Schema: {{.DTO.Name}}
-export const {{.DTO.Name}}Codec = t.type({
-{{range .DTO.Properties}}{{if hasDescription .Description}}  // {{.Description}}
-{{end}}  {{toCamelCase .Name}}: {{if .Required}}{{toIoTsType .Type .Nullable}}{{else}}t.union([{{toIoTsType .Type .Nullable}}, t.undefined]){{end}},
-{{end}}});
+{{if $.GenerateHelpers}}
+// Decode helper throwing a DecodeError with readable messages
+export const decode{{helperName .DTO.Name}}OrThrow = decodeOrThrow({{.DTO.Name}}Codec);
+{{end}}{{else if eq .DTO.Type "union"}}// Union: {{.DTO.Name}}
+{{if isRecursive .DTO.Name}}export type {{.DTO.Name}} = {{toTSType .DTO.Union false}};
 
-export type {{.DTO.Name}} = t.TypeOf<typeof {{.DTO.Name}}Codec>;
+export const {{.DTO.Name}}Codec: t.Type<{{.DTO.Name}}, unknown> = t.recursion('{{.DTO.Name}}', () => {{toIoTsType .DTO.Union false}});
+{{else}}export const {{.DTO.Name}}Codec = {{toIoTsType .DTO.Union false}};
 
+export type {{.DTO.Name}} = t.TypeOf<typeof {{.DTO.Name}}Codec>;
+{{end}}
 // Validation helper
-export const is{{.DTO.Name}} = (value: unknown): value is {{.DTO.Name}} =>
+export const is{{helperName .DTO.Name}} = (value: unknown): value is {{.DTO.Name}} =>
   {{.DTO.Name}}Codec.is(value);
 
 // Decode helper with error handling
-export const decode{{.DTO.Name}} = (value: unknown) =>
+export const decode{{helperName .DTO.Name}} = (value: unknown) =>
   {{.DTO.Name}}Codec.decode(value);
+{{if encodes .DTO.Name}}
+// Encode helper producing the wire format, e.g. ISO strings for dates
+export const encode{{.DTO.Name}} = (value: {{.DTO.Name}}): t.OutputOf<typeof {{.DTO.Name}}Codec> =>
+  {{.DTO.Name}}Codec.encode(value);
+{{end}}{{if $.GenerateHelpers}}
+// Decode helper throwing a DecodeError with readable messages
+export const decode{{helperName .DTO.Name}}OrThrow = decodeOrThrow({{.DTO.Name}}Codec);
+{{end}}{{else}}// Schema: {{.DTO.Name}}
+{{$groups := codecGroups .DTO false}}{{if isRecursive .DTO.Name}}export interface {{.DTO.Name}}{{with .DTO.Extends}} extends {{join . ", "}}{{end}} {
+{{range .DTO.Properties}}{{propComment .}}  {{readonly}}{{toCamelCase .Name}}{{if not .Required}}?{{end}}: {{toTSType .Type .Nullable}};
+{{end}}{{with additionalType .DTO}}  {{readonly}}[key: string]: {{toTSType . false}};
+{{end}}}
+
+export const {{.DTO.Name}}Codec: t.Type<{{.DTO.Name}}, unknown> = t.recursion('{{.DTO.Name}}', () => {{else}}export const {{.DTO.Name}}Codec = {{end}}{{codecOpen .DTO.Extends $groups false}}{{template "codecGroups" $groups}}{{codecClose .DTO.Extends $groups}}{{if isRecursive .DTO.Name}}){{end}};
+{{if not (isRecursive .DTO.Name)}}
+export type {{.DTO.Name}} = t.TypeOf<typeof {{.DTO.Name}}Codec>;
+{{end}}
+// Validation helper
+export const is{{helperName .DTO.Name}} = (value: unknown): value is {{.DTO.Name}} =>
+  {{.DTO.Name}}Codec.is(value);
 
+// Decode helper with error handling
+export const decode{{helperName .DTO.Name}} = (value: unknown) =>
+  {{.DTO.Name}}Codec.decode(value);
+{{if encodes .DTO.Name}}
+// Encode helper producing the wire format, e.g. ISO strings for dates
+export const encode{{.DTO.Name}} = (value: {{.DTO.Name}}): t.OutputOf<typeof {{.DTO.Name}}Codec> =>
+  {{.DTO.Name}}Codec.encode(value);
+{{end}}{{if $.GenerateHelpers}}
+// Decode helper throwing a DecodeError with readable messages
+export const decode{{helperName .DTO.Name}}OrThrow = decodeOrThrow({{.DTO.Name}}Codec);
+{{end}}{{if hasPartial .DTO.Name}}
 // Partial codec for updates (all fields optional)
-export const {{.DTO.Name}}PartialCodec = t.partial({
+{{if isRecursive .DTO.Name}}export type {{.DTO.Name}}Partial = Partial<{{.DTO.Name}}>;
+
+export const {{.DTO.Name}}PartialCodec: t.Type<{{.DTO.Name}}Partial, unknown> = t.recursion('{{.DTO.Name}}Partial', () => {{codecOpen .DTO.Extends nil true}}{{if exact}}t.exact({{end}}t.partial({
+{{range .DTO.Properties}}  {{toCamelCase .Name}}: {{toIoTsType .Type .Nullable}},
+{{end}}}){{if exact}}){{end}}{{codecClose .DTO.Extends nil}});
+{{else}}export const {{.DTO.Name}}PartialCodec = {{codecOpen .DTO.Extends nil true}}{{if exact}}t.exact({{end}}t.partial({
 {{range .DTO.Properties}}  {{toCamelCase .Name}}: {{toIoTsType .Type .Nullable}},
-{{end}}});
+{{end}}}){{if exact}}){{end}}{{codecClose .DTO.Extends nil}};
 
 export type {{.DTO.Name}}Partial = t.TypeOf<typeof {{.DTO.Name}}PartialCodec>;
-{{end}}
+{{end}}{{end}}{{template "variantCodecs" .DTO}}{{end}}
 `
 
+// codecsTemplate renders the object codecs of a DTO's properties, separated
+// for an intersection. Exact codecs wrap each one: t.exact only takes
+// object codecs, not the exact codecs of parents in an intersection.
+const codecsTemplate = `{{define "codecGroups"}}{{range $i, $group := .}}{{if $i}}, {{end}}{{if $group.Record}}{{$group.Record}}{{else}}{{if exact}}t.exact({{end}}{{$group.Constructor}}({
+{{range $group.Properties}}{{propComment .}}{{with .Metadata.String "not"}}  // 'not' constraint is not enforced: {{.}}
+{{end}}  {{toCamelCase .Name}}: {{toIoTsType .Type .Nullable}},
+{{end}}}){{if exact}}){{end}}{{end}}{{end}}{{end}}`
+
+// variantCodecsTemplate renders the request and response codecs of a DTO
+const variantCodecsTemplate = `{{define "variantCodecs"}}{{range variants .}}
+// {{.Comment}}
+{{$groups := codecGroups .DTO false}}export const {{.DTO.Name}}Codec = {{codecOpen .DTO.Extends $groups false}}{{template "codecGroups" $groups}}{{codecClose .DTO.Extends $groups}};
+
+export type {{.DTO.Name}} = t.TypeOf<typeof {{.DTO.Name}}Codec>;
+{{end}}{{end}}`
+
+// enumTemplate declares an enum DTO in the union and enum styles. A TS enum
+// gets a hand-written codec, since io-ts has no enum combinator; its type is
+// spelled out because the codec refers to itself.
+const enumTemplate = `{{define "enumCodec"}}{{if eq enumStyle "enum"}}export enum {{.Name}} {
+{{range enumMembers .EnumValues}}  {{.Name}} = {{literal .Value}},
+{{end}}}
+
+export const {{.Name}}Codec: t.Type<{{.Name}}, string> = new t.Type<{{.Name}}, string, unknown>(
+  '{{.Name}}',
+  (u): u is {{.Name}} => {{range $i, $member := enumMembers .EnumValues}}{{if $i}} || {{end}}u === {{$.Name}}.{{$member.Name}}{{else}}false{{end}},
+  (u, c) => ({{.Name}}Codec.is(u) ? t.success(u) : t.failure(u, c)),
+  t.identity
+);
+{{else}}export const {{.Name}}Codec = {{literals .EnumValues}};
+
+export type {{.Name}} = t.TypeOf<typeof {{.Name}}Codec>;
+{{end}}{{end}}`
+
+// helpersTemplate generates helpers.ts with the shared decode helpers
+const helpersTemplate = `// Generated by DtoForge - DO NOT EDIT
+import * as t from 'io-ts';
+{{range helperImports}}{{.}}
+{{end}}
+{{template "decodeHelpers"}}`
+
+// decodeHelpersTemplate renders the decode helpers shared by all codecs,
+// reporting errors with io-ts-reporters, or the PathReporter of io-ts 1.x.
+// fp-ts 1.x keeps both sides of an Either in value.
+const decodeHelpersTemplate = `{{define "decodeHelpers"}}{{if ioTs1}}// Format validation errors with the PathReporter of io-ts
+const formatValidationErrors = (errors: t.Errors): string[] =>
+  PathReporter.report(left(errors));
+
+{{end}}// Utility type for validation results
+export type ValidationResult<T> = {
+  success: boolean;
+  data?: T;
+  errors?: string[];
+};
+
+// Generic validation helper
+export const validateData = <T>(
+  codec: t.Type<T, any, unknown>,
+  data: unknown
+): ValidationResult<T> => {
+  const result = codec.decode(data);
+
+  if (isRight(result)) {
+    return {
+      success: true,
+      data: result.{{if ioTs1}}value{{else}}right{{end}},
+    };
+  }
+
+  return {
+    success: false,
+    errors: [...formatValidationErrors(result.{{if ioTs1}}value{{else}}left{{end}})],
+  };
+};
+
+// Error thrown by the decode...OrThrow helpers, one message per failure
+export class DecodeError extends Error {
+  constructor(readonly errors: t.Errors) {
+    super(formatValidationErrors(errors).join('\n'));
+    this.name = 'DecodeError';
+  }
+}
+
+// Create a decoder returning the decoded value or throwing a DecodeError
+export const decodeOrThrow = <A, O>(codec: t.Type<A, O, unknown>) =>
+  (value: unknown): A => {
+    const result = codec.decode(value);
+    if (isLeft(result)) {
+      throw new DecodeError(result.{{if ioTs1}}value{{else}}left{{end}});
+    }
+    return result.{{if ioTs1}}value{{else}}right{{end}};
+  };
+{{end}}`
+
+// groupIndexTemplate generates the index file of a tag folder
+const groupIndexTemplate = `// Generated by DtoForge - DO NOT EDIT
+// {{.PackageName}} - {{.Group}} schemas
+
+{{range .DTOs}}export * from './{{toKebabCase .Name}}';
+{{end}}`
+
 // indexTemplate generates the main index file that exports everything
 const indexTemplate = `// Generated by DtoForge - DO NOT EDIT
 // {{.PackageName}} - OpenAPI Schema Validators
-
-{{range .DTOs}}export * from './{{toKebabCase .Name}}';
+{{if not .GenerateHelpers}}
+import * as t from 'io-ts';
+import { isRight } from '{{eitherModule}}';
+{{end}}{{if .DTOs}}
+{{range .DTOs}}import { {{.Name}}Codec } from '{{modulePath .Name}}';
+{{end}}{{end}}
+{{range .DTOs}}export * from '{{modulePath .Name}}';
+{{end}}{{if .HasFormatHelpers}}export * from './format-helpers';
+{{end}}{{if .HasBrandedTypes}}export * from './branded-types';
+{{end}}{{if .HasRefinements}}export * from './refinements';
+{{end}}{{if .HasValidation}}export * from './validation';
+{{end}}{{if .GenerateHelpers}}export * from './helpers';
 {{end}}
 
 // Re-export io-ts for convenience
 export * as t from 'io-ts';
-export { isLeft, isRight } from 'fp-ts/Either';
-
+export { isLeft, isRight } from '{{eitherModule}}';
+{{if not .GenerateHelpers}}
 // Utility type for validation results
 export type ValidationResult<T> = {
   success: boolean;
@@ -77,13 +225,13 @@ export const validateData = <T>(
   if (isRight(result)) {
     return {
       success: true,
-      data: result.right,
+      data: result.{{if ioTs1}}value{{else}}right{{end}},
     };
   }
 
   return {
     success: false,
-    errors: formatValidationErrors(result.left),
+    errors: formatValidationErrors(result.{{if ioTs1}}value{{else}}left{{end}}),
   };
 };
 
@@ -99,7 +247,7 @@ const formatValidationErrors = (errors: t.Errors): string[] => {
       : ` + "`" + `Invalid value: expected ${expectedType}, got ${typeof actualValue}` + "`" + `;
   });
 };
-
+{{end}}
 {{if .DTOs}}// All available schemas
 export const schemas = {
 {{range .DTOs}}  {{toCamelCase .Name}}: {{.Name}}Codec,
@@ -114,6 +262,187 @@ export type SchemaName = typeof schemaNames[number];
 {{end}}
 `
 
+// formatHelpersTemplate generates the codecs backing built-in format mappings
+const formatHelpersTemplate = `// Generated by DtoForge - DO NOT EDIT
+import * as t from 'io-ts';
+{{if index .Formats "binary"}}
+// Binary payloads (format: binary)
+export type BinaryData = {{.BinaryType}};
+
+const isBinaryData = (u: unknown): u is BinaryData =>
+  {{.BinaryCheck}};
+
+export const BinaryData = new t.Type<BinaryData, BinaryData, unknown>(
+  'BinaryData',
+  isBinaryData,
+  (u, c) => (isBinaryData(u) ? t.success(u) : t.failure(u, c)),
+  t.identity
+);
+{{end}}{{if index .Formats "byte"}}
+// Base64-encoded strings (format: byte)
+export interface Base64StringBrand {
+  readonly Base64String: unique symbol;
+}
+
+const base64Pattern = /^(?:[A-Za-z0-9+/]{4})*(?:[A-Za-z0-9+/]{2}==|[A-Za-z0-9+/]{3}=)?$/;
+
+export const Base64String = t.brand(
+  t.string,
+  (s): s is t.Branded<string, Base64StringBrand> => base64Pattern.test(s),
+  'Base64String'
+);
+
+export type Base64String = t.TypeOf<typeof Base64String>;
+{{end}}{{if index .Formats "bigint"}}
+// 64-bit integers (bigint), decoded from JSON numbers or strings and
+// encoded as strings
+const BigIntFromInteger = new t.Type<bigint, string, string | number>(
+  'BigIntFromInteger',
+  (u): u is bigint => typeof u === 'bigint',
+  (u, c) =>
+    (typeof u === 'number' ? Number.isSafeInteger(u) : /^-?\d+$/.test(u))
+      ? t.success(BigInt(u))
+      : t.failure(u, c),
+  (a) => a.toString()
+);
+
+export const BigIntFromNumberOrString = t.union([t.string, t.number]).pipe(
+  BigIntFromInteger,
+  'BigIntFromNumberOrString'
+);
+{{end}}`
+
+// brandedTypesTemplate generates the branded codecs of string formats
+const brandedTypesTemplate = `// Generated by DtoForge - DO NOT EDIT
+import * as t from 'io-ts';
+{{if index . "uuid"}}
+// UUIDs (format: uuid)
+export interface UUIDBrand {
+  readonly UUID: unique symbol;
+}
+
+const uuidPattern = /^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$/i;
+
+export const UUID = t.brand(
+  t.string,
+  (s): s is t.Branded<string, UUIDBrand> => uuidPattern.test(s),
+  'UUID'
+);
+
+export type UUID = t.TypeOf<typeof UUID>;
+{{end}}{{if index . "email"}}
+// Email addresses (format: email)
+export interface EmailStringBrand {
+  readonly EmailString: unique symbol;
+}
+
+const emailPattern = /^[^\s@]+@[^\s@]+\.[^\s@]+$/;
+
+export const EmailString = t.brand(
+  t.string,
+  (s): s is t.Branded<string, EmailStringBrand> => emailPattern.test(s),
+  'EmailString'
+);
+
+export type EmailString = t.TypeOf<typeof EmailString>;
+{{end}}{{if index . "date-time"}}
+// RFC 3339 timestamps (format: date-time), kept as strings
+export interface DateTimeStringBrand {
+  readonly DateTimeString: unique symbol;
+}
+
+const dateTimePattern = /^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:Z|[+-]\d{2}:\d{2})$/i;
+
+export const DateTimeString = t.brand(
+  t.string,
+  (s): s is t.Branded<string, DateTimeStringBrand> => dateTimePattern.test(s) && !isNaN(Date.parse(s)),
+  'DateTimeString'
+);
+
+export type DateTimeString = t.TypeOf<typeof DateTimeString>;
+{{end}}`
+
+// validationTemplate generates validation.ts with pipe-friendly fp-ts
+// helpers around the codecs
+const validationTemplate = `// Generated by DtoForge - DO NOT EDIT
+import * as t from 'io-ts';
+import * as E from 'fp-ts/Either';
+import * as TE from 'fp-ts/TaskEither';
+import { pipe } from 'fp-ts/function';
+
+// Decode with a codec inside a pipe, e.g. pipe(data, validateWith(UserCodec))
+export const validateWith = <A, O>(codec: t.Type<A, O, unknown>) =>
+  (value: unknown): t.Validation<A> =>
+    codec.decode(value);
+
+// Outcome of a validation as a plain discriminated union
+export type Result<A> =
+  | { ok: true; value: A }
+  | { ok: false; errors: t.Errors };
+
+// Fold a validation into a Result
+export const toResult = <A>(validation: t.Validation<A>): Result<A> =>
+  pipe(
+    validation,
+    E.fold(
+      (errors): Result<A> => ({ ok: false, errors }),
+      (value): Result<A> => ({ ok: true, value })
+    )
+  );
+
+// Decode straight into a Result
+export const resultWith = <A, O>(codec: t.Type<A, O, unknown>) =>
+  (value: unknown): Result<A> =>
+    toResult(codec.decode(value));
+
+// Failures of fetchWith: the request, a non-2xx status, the JSON body or
+// its decoding
+export type FetchError =
+  | { type: 'network'; error: unknown }
+  | { type: 'status'; response: Response }
+  | { type: 'json'; error: unknown }
+  | { type: 'decode'; errors: t.Errors };
+
+// Fetch JSON and decode it with a codec, as a TaskEither
+export const fetchWith = <A, O>(codec: t.Type<A, O, unknown>) =>
+  (input: RequestInfo | URL, init?: RequestInit): TE.TaskEither<FetchError, A> =>
+    pipe(
+      TE.tryCatch(() => fetch(input, init), (error): FetchError => ({ type: 'network', error })),
+      TE.chain((response) =>
+        response.ok
+          ? TE.tryCatch((): Promise<unknown> => response.json(), (error): FetchError => ({ type: 'json', error }))
+          : TE.left<FetchError, unknown>({ type: 'status', response })
+      ),
+      TE.chain((body) =>
+        TE.fromEither(
+          pipe(
+            codec.decode(body),
+            E.mapLeft((errors): FetchError => ({ type: 'decode', errors }))
+          )
+        )
+      )
+    );
+`
+
+// refinementsTemplate generates the branded codecs enforcing the
+// constraints of string and number properties
+const refinementsTemplate = `// Generated by DtoForge - DO NOT EDIT
+import * as t from 'io-ts';
+{{range .}}
+// {{.Constraints}}
+export interface {{.Name}}Brand {
+  readonly {{.Name}}: unique symbol;
+}
+
+export const {{.Name}} = t.brand(
+  {{.Codec}},
+  (v): v is t.Branded<{{.Type}}, {{.Name}}Brand> => {{.Predicate}},
+  '{{.Name}}'
+);
+
+export type {{.Name}} = t.TypeOf<typeof {{.Name}}>;
+{{end}}`
+
 // packageJSONTemplate generates a package.json for the generated code
 const packageJSONTemplate = `{
   "name": "{{.PackageName}}",
@@ -126,10 +455,14 @@ const packageJSONTemplate = `{
     "test": "jest"
   },
   "dependencies": {
-    "io-ts": "^2.2.20",
+{{if ioTs1}}    "io-ts": "^1.10.4",
+    "io-ts-types": "^0.4.7",
+    "fp-ts": "^1.19.5"
+{{else}}    "io-ts": "^2.2.20",
     "io-ts-types": "^0.5.16",
-    "fp-ts": "^2.16.1"
-  },
+{{if .GenerateHelpers}}    "io-ts-reporters": "^2.0.1",
+{{end}}    "fp-ts": "^2.16.1"
+{{end}}  },
   "devDependencies": {
     "@types/node": "^20.0.0",
     "typescript": "^5.0.0",
@@ -149,82 +482,61 @@ const singleFileTemplate = `// Generated by DtoForge (TypeScript) - DO NOT EDIT
 
 {{range .Imports}}{{.}}
 {{end}}
-
+{{if .GenerateHelpers}}
+{{template "decodeHelpers"}}{{end}}
 {{range .DTOs}}
-{{if .Description}}/**
- * {{.Description}}
- */
-{{end}}
-{{if eq .Type "enum"}}// Enum: {{.Name}}
-export const {{.Name}}Values = {
+{{dtoComment .}}
+{{with .Metadata.String "not"}}// 'not' constraint is not enforced: {{.}}
+{{end}}{{if eq .Type "enum"}}// Enum: {{.Name}}
+{{if ne enumStyle "keyof"}}{{template "enumCodec" .}}{{else}}export const {{.Name}}Values = {
 {{range .EnumValues}}  '{{.}}': null,
 {{end}}} as const;
 
 export const {{.Name}}Codec = t.keyof({{.Name}}Values);
 
 export type {{.Name}} = t.TypeOf<typeof {{.Name}}Codec>;
+{{end}}
+{{else if eq .Type "union"}}// Union: {{.Name}}
+{{if isRecursive .Name}}export type {{.Name}} = {{toTSType .Union false}};
 
-{{else}}// Schema: {{.Name}}
-export const {{.Name}}Codec = t.type({
-{{range .Properties}}{{if hasDescription .Description}}  // {{.Description}}
-{{end}}  {{toCamelCase .Name}}: {{if .Required}}{{toIoTsType .Type .Nullable}}{{else}}t.union([{{toIoTsType .Type .Nullable}}, t.undefined]){{end}},
-{{end}}});
+export const {{.Name}}Codec: t.Type<{{.Name}}, unknown> = t.recursion('{{.Name}}', () => {{toIoTsType .Union false}});
+{{else}}export const {{.Name}}Codec = {{toIoTsType .Union false}};
 
 export type {{.Name}} = t.TypeOf<typeof {{.Name}}Codec>;
-
-{{if $.GeneratePartialCodecs}}// Partial codec for updates (all fields optional)
-export const {{.Name}}PartialCodec = t.partial({
-{{range .Properties}}  {{toCamelCase .Name}}: {{toIoTsType .Type .Nullable}},
-{{end}}});
-
-export type {{.Name}}Partial = t.TypeOf<typeof {{.Name}}PartialCodec>;
-
-{{end}}{{end}}
 {{end}}
+{{else}}// Schema: {{.Name}}
+{{$groups := codecGroups . false}}{{if isRecursive .Name}}export interface {{.Name}}{{with .Extends}} extends {{join . ", "}}{{end}} {
+{{range .Properties}}{{propComment .}}  {{readonly}}{{toCamelCase .Name}}{{if not .Required}}?{{end}}: {{toTSType .Type .Nullable}};
+{{end}}{{with additionalType .}}  {{readonly}}[key: string]: {{toTSType . false}};
+{{end}}}
 
-{{if .GenerateHelpers}}// Re-export io-ts for convenience
-export * as t from 'io-ts';
-export { isLeft, isRight } from 'fp-ts/Either';
-
-// Utility type for validation results
-export type ValidationResult<T> = {
-  success: boolean;
-  data?: T;
-  errors?: string[];
-};
+export const {{.Name}}Codec: t.Type<{{.Name}}, unknown> = t.recursion('{{.Name}}', () => {{else}}export const {{.Name}}Codec = {{end}}{{codecOpen .Extends $groups false}}{{template "codecGroups" $groups}}{{codecClose .Extends $groups}}{{if isRecursive .Name}}){{end}};
 
-// Generic validation helper
-export const validateData = <T>(
-  codec: t.Type<T, any, unknown>,
-  data: unknown
-): ValidationResult<T> => {
-  const result = codec.decode(data);
+{{if not (isRecursive .Name)}}export type {{.Name}} = t.TypeOf<typeof {{.Name}}Codec>;
 
-  if (isRight(result)) {
-    return {
-      success: true,
-      data: result.right,
-    };
-  }
+{{end}}{{if hasPartial .Name}}// Partial codec for updates (all fields optional)
+{{if isRecursive .Name}}export type {{.Name}}Partial = Partial<{{.Name}}>;
 
-  return {
-    success: false,
-    errors: formatValidationErrors(result.left),
-  };
-};
+export const {{.Name}}PartialCodec: t.Type<{{.Name}}Partial, unknown> = t.recursion('{{.Name}}Partial', () => {{codecOpen .Extends nil true}}{{if exact}}t.exact({{end}}t.partial({
+{{range .Properties}}  {{toCamelCase .Name}}: {{toIoTsType .Type .Nullable}},
+{{end}}}){{if exact}}){{end}}{{codecClose .Extends nil}});
+{{else}}export const {{.Name}}PartialCodec = {{codecOpen .Extends nil true}}{{if exact}}t.exact({{end}}t.partial({
+{{range .Properties}}  {{toCamelCase .Name}}: {{toIoTsType .Type .Nullable}},
+{{end}}}){{if exact}}){{end}}{{codecClose .Extends nil}};
 
-// Format io-ts validation errors into readable messages
-const formatValidationErrors = (errors: t.Errors): string[] => {
-  return errors.map(error => {
-    const path = error.context.map(c => c.key).filter(key => key !== '').join('.');
-    const expectedType = error.context[error.context.length - 1]?.type?.name || 'unknown';
-    const actualValue = error.value;
+export type {{.Name}}Partial = t.TypeOf<typeof {{.Name}}PartialCodec>;
+{{end}}
+{{end}}{{template "variantCodecs" .}}{{end}}
+{{end}}
 
-    return path
-      ? ` + "`" + `Invalid value at '${path}': expected ${expectedType}, got ${typeof actualValue}` + "`" + `
-      : ` + "`" + `Invalid value: expected ${expectedType}, got ${typeof actualValue}` + "`" + `;
-  });
-};
+{{if .GenerateHelpers}}// Decode helpers
+{{range .DTOs}}export const decode{{helperName .Name}} = (value: unknown) => {{.Name}}Codec.decode(value);
+export const decode{{helperName .Name}}OrThrow = decodeOrThrow({{.Name}}Codec);
+{{if encodes .Name}}export const encode{{.Name}} = (value: {{.Name}}): t.OutputOf<typeof {{.Name}}Codec> => {{.Name}}Codec.encode(value);
+{{end}}{{end}}
+// Re-export io-ts for convenience
+export * as t from 'io-ts';
+export { isLeft, isRight } from '{{eitherModule}}';
 
 {{end}}// All available schemas
 export const schemas = {
@@ -5,8 +5,11 @@ import (
 	"io/ioutil"
 	"os"
 	"sort"
+	"strings"
 
 	"gopkg.in/yaml.v3"
+
+	"dtoForge/internal/generator"
 )
 
 // OutputConfig defines output behavior
@@ -14,19 +17,83 @@ type OutputConfig struct {
 	Folder         string `yaml:"folder"`
 	Mode           string `yaml:"mode"`           // "multiple" or "single"
 	SingleFileName string `yaml:"singleFileName"` // for single file mode
+	GroupBy        string `yaml:"groupBy"`        // "" or "tag" (multiple file mode only)
+	// Pragmas are comments prepended to every generated source file, e.g.
+	// "/* eslint-disable */"
+	Pragmas []string `yaml:"pragmas"`
 }
 
 // GenerationConfig defines what to generate
 type GenerationConfig struct {
-	GeneratePackageJson bool `yaml:"generatePackageJson"`
-	GenerateHelpers     bool `yaml:"generateHelpers"`
+	GeneratePackageJson    bool   `yaml:"generatePackageJson"`
+	GenerateHelpers        bool   `yaml:"generateHelpers"`
+	BinaryTarget           string `yaml:"binaryTarget"`           // "universal", "browser" or "node"
+	SourceComments         bool   `yaml:"sourceComments"`         // add @see comments pointing into the spec
+	Describe               bool   `yaml:"describe"`               // add .describe() with schema and property descriptions
+	ObjectMode             string `yaml:"objectMode"`             // unknown keys: "strip", "strict" or "passthrough"
+	BrandedFormats         bool   `yaml:"brandedFormats"`         // brand the schemas of built-in formats, declared in brands.ts
+	CoerceDates            bool   `yaml:"coerceDates"`            // parse date-time strings into Date objects
+	LenientIntegers        bool   `yaml:"lenientIntegers"`        // accept fractional numbers for integer schemas
+	GeneratePartialSchemas bool   `yaml:"generatePartialSchemas"` // add <Name>PartialSchema = <Name>Schema.partial()
+	Readonly               bool   `yaml:"readonly"`               // append .readonly() to object and array schemas
+	EnumStyle              string `yaml:"enumStyle"`              // enums as "zodEnum", "nativeEnum" or "constObject"
+	Nullish                bool   `yaml:"nullish"`                // .nullish() instead of .nullable().optional()
+	GenerateJsonSchema     bool   `yaml:"generateJsonSchema"`     // export JSON Schemas from json-schema.ts
+	Mini                   bool   `yaml:"mini"`                   // functional zod/mini API (Zod 4 only)
+	OpenAPIMetadata        bool   `yaml:"openapiMetadata"`        // .openapi()/.meta() metadata for zod-openapi
+	StrictTypes            bool   `yaml:"strictTypes"`            // explicit types compile under exactOptionalPropertyTypes
+}
+
+// Brand is a branded schema declared in brands.ts, e.g. UUIDSchema
+type Brand struct {
+	Name    string // brand and TypeScript type name, e.g. UUID
+	ZodType string // the branded schema
+}
+
+// brandedFormats lists the built-in formats branded by brandedFormats, in
+// declaration order. Names avoid globals such as Date and URL.
+var brandedFormats = []struct {
+	format string
+	name   string
+}{
+	{"uuid", "UUID"},
+	{"email", "Email"},
+	{"uri", "Uri"},
+	{"url", "Url"},
+	{"date-time", "DateTime"},
+	{"date", "DateString"},
+	{"byte", "Base64"},
+}
+
+// DerivedSchema defines a schema derived from a generated object schema by
+// picking or omitting properties, e.g. a create request without the id
+type DerivedSchema struct {
+	From string   `yaml:"from"`
+	Pick []string `yaml:"pick"`
+	Omit []string `yaml:"omit"`
 }
 
-// CustomTypeMapping defines how to map OpenAPI formats to Zod types
+// CustomTypeMapping defines how to map OpenAPI formats to Zod types.
+// Preprocess and Transform are optional function expressions, e.g.
+// "(value) => value.toLowerCase()", run before and after validation.
 type CustomTypeMapping struct {
 	ZodType        string `yaml:"zodType"`
 	TypeScriptType string `yaml:"typeScriptType"`
 	Import         string `yaml:"import"`
+	Preprocess     string `yaml:"preprocess"`
+	Transform      string `yaml:"transform"`
+}
+
+// Schema returns the Zod schema of the mapping, wrapped in its hooks
+func (m CustomTypeMapping) Schema() string {
+	schema := m.ZodType
+	if m.Transform != "" {
+		schema = fmt.Sprintf("%s.transform(%s)", schema, m.Transform)
+	}
+	if m.Preprocess != "" {
+		schema = fmt.Sprintf("z.preprocess(%s, %s)", m.Preprocess, schema)
+	}
+	return schema
 }
 
 // ZodCustomTypeConfig represents the typescript-zod section in YAML configuration
@@ -34,6 +101,7 @@ type ZodCustomTypeConfig struct {
 	Output      OutputConfig                 `yaml:"output"`
 	CustomTypes map[string]CustomTypeMapping `yaml:"customTypes"`
 	Generation  GenerationConfig             `yaml:"generation"`
+	Derive      map[string]DerivedSchema     `yaml:"derive"`
 }
 
 // FullConfig represents the complete YAML configuration structure
@@ -44,14 +112,26 @@ type FullConfig struct {
 // CustomTypeRegistry holds all custom type mappings and config for Zod
 type CustomTypeRegistry struct {
 	mappings   map[string]CustomTypeMapping
+	builtIn    map[string]CustomTypeMapping // the default mappings, to tell overrides apart
 	output     OutputConfig
 	generation GenerationConfig
+	zodVersion int     // major version of the Zod API the defaults target
+	brands     []Brand // branded format schemas, if enabled
+	derived    map[string]DerivedSchema
 }
 
 // NewCustomTypeRegistry creates a new registry with default mappings and config
+// for Zod 3
 func NewCustomTypeRegistry() *CustomTypeRegistry {
+	return NewCustomTypeRegistryForVersion(3)
+}
+
+// NewCustomTypeRegistryForVersion creates a new registry whose default
+// mappings use the API of the given Zod major version
+func NewCustomTypeRegistryForVersion(zodVersion int) *CustomTypeRegistry {
 	registry := &CustomTypeRegistry{
-		mappings: make(map[string]CustomTypeMapping),
+		zodVersion: zodVersion,
+		mappings:   make(map[string]CustomTypeMapping),
 		output: OutputConfig{
 			Folder:         "./generated",
 			Mode:           "multiple",
@@ -60,10 +140,17 @@ func NewCustomTypeRegistry() *CustomTypeRegistry {
 		generation: GenerationConfig{
 			GeneratePackageJson: true,
 			GenerateHelpers:     true,
+			BinaryTarget:        "universal",
+			ObjectMode:          "strip",
+			EnumStyle:           "zodEnum",
 		},
 	}
 
 	registry.addDefaultMappings()
+	registry.builtIn = make(map[string]CustomTypeMapping, len(registry.mappings))
+	for format, mapping := range registry.mappings {
+		registry.builtIn[format] = mapping
+	}
 	return registry
 }
 
@@ -82,6 +169,11 @@ func (r *CustomTypeRegistry) IsSingleFileMode() bool {
 	return r.output.Mode == "single"
 }
 
+// IsTagGrouped returns true if files should be grouped into folders by tag
+func (r *CustomTypeRegistry) IsTagGrouped() bool {
+	return r.output.GroupBy == "tag" && !r.IsSingleFileMode()
+}
+
 // GetSingleFileName returns the filename for single file mode
 func (r *CustomTypeRegistry) GetSingleFileName() string {
 	if r.output.SingleFileName == "" {
@@ -92,6 +184,11 @@ func (r *CustomTypeRegistry) GetSingleFileName() string {
 
 // addDefaultMappings adds the built-in format mappings for Zod
 func (r *CustomTypeRegistry) addDefaultMappings() {
+	if r.zodVersion >= 4 {
+		r.addZod4Mappings()
+		return
+	}
+
 	r.mappings["date-time"] = CustomTypeMapping{
 		ZodType:        "z.string().datetime()",
 		TypeScriptType: "string",
@@ -127,6 +224,114 @@ func (r *CustomTypeRegistry) addDefaultMappings() {
 		TypeScriptType: "string",
 		Import:         "",
 	}
+
+	r.mappings["byte"] = CustomTypeMapping{
+		ZodType:        "z.string().regex(/^(?:[A-Za-z0-9+/]{4})*(?:[A-Za-z0-9+/]{2}==|[A-Za-z0-9+/]{3}=)?$/, 'Invalid base64 string')",
+		TypeScriptType: "string",
+		Import:         "",
+	}
+
+	r.addBinaryMapping()
+}
+
+// addZod4Mappings adds the built-in format mappings using the top-level
+// string formats of Zod 4
+func (r *CustomTypeRegistry) addZod4Mappings() {
+	r.mappings["date-time"] = CustomTypeMapping{
+		ZodType:        "z.iso.datetime()",
+		TypeScriptType: "string",
+		Import:         "",
+	}
+
+	r.mappings["uuid"] = CustomTypeMapping{
+		ZodType:        "z.uuid()",
+		TypeScriptType: "string",
+		Import:         "",
+	}
+
+	r.mappings["email"] = CustomTypeMapping{
+		ZodType:        "z.email()",
+		TypeScriptType: "string",
+		Import:         "",
+	}
+
+	r.mappings["uri"] = CustomTypeMapping{
+		ZodType:        "z.url()",
+		TypeScriptType: "string",
+		Import:         "",
+	}
+
+	r.mappings["url"] = CustomTypeMapping{
+		ZodType:        "z.url()",
+		TypeScriptType: "string",
+		Import:         "",
+	}
+
+	r.mappings["date"] = CustomTypeMapping{
+		ZodType:        "z.iso.date()",
+		TypeScriptType: "string",
+		Import:         "",
+	}
+
+	r.mappings["byte"] = CustomTypeMapping{
+		ZodType:        "z.base64()",
+		TypeScriptType: "string",
+		Import:         "",
+	}
+
+	r.addBinaryMapping()
+}
+
+// addBinaryMapping maps format: binary to an instanceof check for the configured target
+func (r *CustomTypeRegistry) addBinaryMapping() {
+	switch r.generation.BinaryTarget {
+	case "browser":
+		r.mappings["binary"] = CustomTypeMapping{
+			ZodType:        "z.instanceof(Blob)",
+			TypeScriptType: "Blob",
+		}
+	case "node":
+		r.mappings["binary"] = CustomTypeMapping{
+			ZodType:        "z.instanceof(Buffer)",
+			TypeScriptType: "Buffer",
+		}
+	default:
+		// Guard each global so the schema loads in both environments
+		r.mappings["binary"] = CustomTypeMapping{
+			ZodType:        "z.custom<Blob | Buffer>((value) => (typeof Blob !== 'undefined' && value instanceof Blob) || (typeof Buffer !== 'undefined' && value instanceof Buffer))",
+			TypeScriptType: "Blob | Buffer",
+		}
+	}
+}
+
+// brandFormats replaces the mappings of the built-in formats with branded
+// schemas imported from brands.ts, except formats mapped in customTypes
+func (r *CustomTypeRegistry) brandFormats(customTypes map[string]CustomTypeMapping) {
+	for _, branded := range brandedFormats {
+		mapping, exists := r.mappings[branded.format]
+		if _, custom := customTypes[branded.format]; !exists || custom {
+			continue
+		}
+		r.brands = append(r.brands, Brand{
+			Name:    branded.name,
+			ZodType: fmt.Sprintf("%s.brand<'%s'>()", mapping.ZodType, branded.name),
+		})
+		r.mappings[branded.format] = CustomTypeMapping{
+			ZodType:        branded.name + "Schema",
+			TypeScriptType: branded.name,
+			Import:         fmt.Sprintf("import { %s, %sSchema } from './brands';", branded.name, branded.name),
+		}
+	}
+}
+
+// Brands returns the branded format schemas to declare, in order
+func (r *CustomTypeRegistry) Brands() []Brand {
+	return r.brands
+}
+
+// DerivedSchemas returns the configured derived schemas by name
+func (r *CustomTypeRegistry) DerivedSchemas() map[string]DerivedSchema {
+	return r.derived
 }
 
 // Register adds or updates a custom type mapping
@@ -140,13 +345,52 @@ func (r *CustomTypeRegistry) Get(format string) (CustomTypeMapping, bool) {
 	return mapping, exists
 }
 
+// IsBuiltIn reports whether a format still has its default mapping, i.e. it
+// is neither customized, branded nor coerced
+func (r *CustomTypeRegistry) IsBuiltIn(format string) bool {
+	mapping, exists := r.mappings[format]
+	return exists && mapping == r.builtIn[format]
+}
+
+// transformMarkers are the Zod calls that make a schema's output differ from
+// its input
+var transformMarkers = []string{"z.coerce.", "z.preprocess(", ".transform(", ".pipe("}
+
+// Transforms reports whether the schema of a format coerces or transforms
+// its input, looking through branded schemas to the schema they brand
+func (r *CustomTypeRegistry) Transforms(format string) bool {
+	mapping, exists := r.mappings[format]
+	if !exists {
+		return false
+	}
+	zodType := mapping.Schema()
+	for _, brand := range r.brands {
+		if zodType == brand.Name+"Schema" {
+			zodType = brand.ZodType
+		}
+	}
+	for _, marker := range transformMarkers {
+		if strings.Contains(zodType, marker) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetAllImports returns all unique import statements needed for used formats
 func (r *CustomTypeRegistry) GetAllImports(usedFormats []string) []string {
 	importSet := make(map[string]bool)
 	var imports []string
 
 	// Always include Zod first
-	imports = append(imports, "import { z } from 'zod';")
+	if r.generation.Mini {
+		imports = append(imports, "import * as z from 'zod/mini';")
+	} else {
+		imports = append(imports, "import { z } from 'zod';")
+	}
+	if openapi := r.OpenAPIImport(); openapi != "" {
+		imports = append(imports, openapi)
+	}
 
 	// Collect all custom type imports
 	var customImports []string
@@ -166,6 +410,15 @@ func (r *CustomTypeRegistry) GetAllImports(usedFormats []string) []string {
 	return imports
 }
 
+// OpenAPIImport returns the import adding .openapi() to Zod 3 schemas when
+// OpenAPI metadata is enabled, or "". Zod 4 schemas carry it with .meta().
+func (r *CustomTypeRegistry) OpenAPIImport() string {
+	if !r.generation.OpenAPIMetadata || r.zodVersion >= 4 {
+		return ""
+	}
+	return "import 'zod-openapi/extend';"
+}
+
 // LoadFromConfig loads custom mappings from a YAML configuration file
 func (r *CustomTypeRegistry) LoadFromConfig(configPath string) error {
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
@@ -185,7 +438,7 @@ func (r *CustomTypeRegistry) LoadFromConfig(configPath string) error {
 	zodConfig := config.TypeScriptZod
 
 	// Load output config if provided
-	if zodConfig.Output.Folder != "" || zodConfig.Output.Mode != "" || zodConfig.Output.SingleFileName != "" {
+	if zodConfig.Output.Folder != "" || zodConfig.Output.Mode != "" || zodConfig.Output.SingleFileName != "" || zodConfig.Output.GroupBy != "" {
 		if zodConfig.Output.Folder != "" {
 			r.output.Folder = zodConfig.Output.Folder
 		}
@@ -198,20 +451,126 @@ func (r *CustomTypeRegistry) LoadFromConfig(configPath string) error {
 		if zodConfig.Output.SingleFileName != "" {
 			r.output.SingleFileName = zodConfig.Output.SingleFileName
 		}
+		if zodConfig.Output.GroupBy != "" {
+			if zodConfig.Output.GroupBy != "tag" {
+				return fmt.Errorf("invalid output groupBy '%s', must be 'tag'", zodConfig.Output.GroupBy)
+			}
+			r.output.GroupBy = zodConfig.Output.GroupBy
+		}
 	}
+	if err := generator.ValidatePragmas(zodConfig.Output.Pragmas); err != nil {
+		return err
+	}
+	r.output.Pragmas = zodConfig.Output.Pragmas
 
 	// Load generation config if provided
 	r.generation.GeneratePackageJson = zodConfig.Generation.GeneratePackageJson
 	r.generation.GenerateHelpers = zodConfig.Generation.GenerateHelpers
+	r.generation.SourceComments = zodConfig.Generation.SourceComments
+	r.generation.Describe = zodConfig.Generation.Describe
+	r.generation.GeneratePartialSchemas = zodConfig.Generation.GeneratePartialSchemas
+	r.generation.Readonly = zodConfig.Generation.Readonly
+	r.generation.Nullish = zodConfig.Generation.Nullish
+	r.generation.GenerateJsonSchema = zodConfig.Generation.GenerateJsonSchema
+	r.generation.Mini = zodConfig.Generation.Mini
+	r.generation.OpenAPIMetadata = zodConfig.Generation.OpenAPIMetadata
+	r.generation.StrictTypes = zodConfig.Generation.StrictTypes
+	if zodConfig.Generation.ObjectMode != "" {
+		switch zodConfig.Generation.ObjectMode {
+		case "strip", "strict", "passthrough":
+			r.generation.ObjectMode = zodConfig.Generation.ObjectMode
+		default:
+			return fmt.Errorf("invalid object mode '%s', must be 'strip', 'strict' or 'passthrough'", zodConfig.Generation.ObjectMode)
+		}
+	}
+	if zodConfig.Generation.EnumStyle != "" {
+		switch zodConfig.Generation.EnumStyle {
+		case "zodEnum", "nativeEnum", "constObject":
+			r.generation.EnumStyle = zodConfig.Generation.EnumStyle
+		default:
+			return fmt.Errorf("invalid enum style '%s', must be 'zodEnum', 'nativeEnum' or 'constObject'", zodConfig.Generation.EnumStyle)
+		}
+	}
+	if zodConfig.Generation.BinaryTarget != "" {
+		switch zodConfig.Generation.BinaryTarget {
+		case "universal", "browser", "node":
+			r.generation.BinaryTarget = zodConfig.Generation.BinaryTarget
+			r.addBinaryMapping()
+		default:
+			return fmt.Errorf("invalid binary target '%s', must be 'universal', 'browser' or 'node'", zodConfig.Generation.BinaryTarget)
+		}
+	}
+
+	// Most clients want Date objects after parsing rather than ISO strings;
+	// an explicit date-time entry in customTypes still wins
+	r.generation.LenientIntegers = zodConfig.Generation.LenientIntegers
+	r.generation.CoerceDates = zodConfig.Generation.CoerceDates
+	if r.generation.CoerceDates {
+		r.Register("date-time", CustomTypeMapping{
+			ZodType:        "z.coerce.date()",
+			TypeScriptType: "Date",
+			Import:         "",
+		})
+	}
+
+	// Brand the built-in formats that customTypes does not map
+	r.brands = nil
+	r.generation.BrandedFormats = zodConfig.Generation.BrandedFormats
+	if r.generation.BrandedFormats {
+		r.brandFormats(zodConfig.CustomTypes)
+	}
 
-	// Register all custom types from config
+	// Register all custom types from config. Entries with only hooks wrap
+	// the schema the format already maps to.
 	for format, mapping := range zodConfig.CustomTypes {
+		if mapping.ZodType == "" {
+			existing, exists := r.mappings[format]
+			if !exists || (mapping.Preprocess == "" && mapping.Transform == "") {
+				return fmt.Errorf("custom type '%s' must have zodType", format)
+			}
+			mapping.ZodType = existing.ZodType
+			if mapping.TypeScriptType == "" {
+				mapping.TypeScriptType = existing.TypeScriptType
+			}
+			if mapping.Import == "" {
+				mapping.Import = existing.Import
+			}
+		}
 		r.Register(format, mapping)
 	}
 
+	for name, derived := range zodConfig.Derive {
+		if !isIdentifier(name) {
+			return fmt.Errorf("invalid derived schema name '%s', must be an identifier", name)
+		}
+		if derived.From == "" {
+			return fmt.Errorf("derived schema '%s' must have from", name)
+		}
+		if (len(derived.Pick) == 0) == (len(derived.Omit) == 0) {
+			return fmt.Errorf("derived schema '%s' must have either pick or omit", name)
+		}
+	}
+	r.derived = zodConfig.Derive
+
 	return nil
 }
 
+// isIdentifier reports whether s is a valid TypeScript identifier
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case r == '_' || r == '$', 'A' <= r && r <= 'Z', 'a' <= r && r <= 'z':
+		case i > 0 && '0' <= r && r <= '9':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 // SaveExampleConfig creates an example configuration file
 func (r *CustomTypeRegistry) SaveExampleConfig(configPath string) error {
 	exampleConfig := FullConfig{
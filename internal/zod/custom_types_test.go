@@ -318,3 +318,161 @@ func contains(s, substr string) bool {
 		return false
 	}())
 }
+
+func TestCustomTypeRegistry_LoadFromConfig_InvalidBinaryTarget(t *testing.T) {
+	registry := NewCustomTypeRegistry()
+	tempDir := testutils.TempDir(t)
+
+	configPath := testutils.WriteFile(t, tempDir, "config.yaml", `typescript-zod:
+  generation:
+    binaryTarget: "deno"`)
+
+	if err := registry.LoadFromConfig(configPath); err == nil {
+		t.Error("LoadFromConfig should fail with invalid binary target")
+	}
+}
+
+func TestCustomTypeRegistry_LoadFromConfig_InvalidObjectMode(t *testing.T) {
+	tempDir := testutils.TempDir(t)
+	registry := NewCustomTypeRegistry()
+
+	configPath := testutils.WriteFile(t, tempDir, "config.yaml", `typescript-zod:
+  generation:
+    objectMode: "loose"`)
+
+	if err := registry.LoadFromConfig(configPath); err == nil {
+		t.Error("LoadFromConfig should fail with invalid object mode")
+	}
+}
+
+func TestCustomTypeRegistry_CoerceDates(t *testing.T) {
+	registry := NewCustomTypeRegistry()
+	tempDir := testutils.TempDir(t)
+
+	configPath := testutils.WriteFile(t, tempDir, "config.yaml", `typescript-zod:
+  generation:
+    coerceDates: true
+    brandedFormats: true`)
+
+	if err := registry.LoadFromConfig(configPath); err != nil {
+		t.Fatalf("LoadFromConfig failed: %v", err)
+	}
+
+	// Branding builds on the coerced schema
+	for _, brand := range registry.Brands() {
+		if brand.Name == "DateTime" && brand.ZodType != "z.coerce.date().brand<'DateTime'>()" {
+			t.Errorf("DateTime brand = %v", brand.ZodType)
+		}
+	}
+
+	registry = NewCustomTypeRegistry()
+	configPath = testutils.WriteFile(t, tempDir, "config.yaml", "typescript-zod:\n  generation:\n    coerceDates: true\n")
+	if err := registry.LoadFromConfig(configPath); err != nil {
+		t.Fatalf("LoadFromConfig failed: %v", err)
+	}
+	mapping, exists := registry.Get("date-time")
+	if !exists || mapping.ZodType != "z.coerce.date()" || mapping.TypeScriptType != "Date" {
+		t.Errorf("Unexpected date-time mapping: %+v", mapping)
+	}
+}
+
+func TestCustomTypeRegistry_IsBuiltIn(t *testing.T) {
+	registry := NewCustomTypeRegistry()
+	if !registry.IsBuiltIn("email") {
+		t.Error("Expected the default email mapping to be built in")
+	}
+	if registry.IsBuiltIn("phone") {
+		t.Error("Expected an unmapped format not to be built in")
+	}
+
+	registry.Register("email", CustomTypeMapping{ZodType: "EmailSchema", TypeScriptType: "Email"})
+	if registry.IsBuiltIn("email") {
+		t.Error("Expected an overridden mapping not to be built in")
+	}
+}
+
+func TestCustomTypeRegistry_Transforms(t *testing.T) {
+	registry := NewCustomTypeRegistry()
+	registry.Register("money", CustomTypeMapping{ZodType: "z.string().transform(Number)", TypeScriptType: "number"})
+	registry.Register("date-time", CustomTypeMapping{ZodType: "z.coerce.date()", TypeScriptType: "Date"})
+
+	for format, want := range map[string]bool{"money": true, "date-time": true, "email": false, "phone": false} {
+		if got := registry.Transforms(format); got != want {
+			t.Errorf("Transforms(%q) = %v, want %v", format, got, want)
+		}
+	}
+
+	// Branded schemas are looked through
+	registry.brandFormats(nil)
+	if !registry.Transforms("date-time") {
+		t.Error("Expected the branded date-time schema to transform")
+	}
+}
+
+func TestCustomTypeRegistry_LoadFromConfig_InvalidDerive(t *testing.T) {
+	tempDir := testutils.TempDir(t)
+
+	tests := map[string]string{
+		"bad name":     "typescript-zod:\n  derive:\n    User-Create: {from: User, omit: [id]}\n",
+		"missing from": "typescript-zod:\n  derive:\n    UserCreate: {omit: [id]}\n",
+		"pick or omit": "typescript-zod:\n  derive:\n    UserCreate: {from: User, pick: [name], omit: [id]}\n",
+		"neither":      "typescript-zod:\n  derive:\n    UserCreate: {from: User}\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			configPath := testutils.WriteFile(t, tempDir, "config.yaml", content)
+			if err := NewCustomTypeRegistry().LoadFromConfig(configPath); err == nil {
+				t.Error("Expected an error for an invalid derived schema")
+			}
+		})
+	}
+}
+
+func TestCustomTypeRegistry_LoadFromConfig_InvalidEnumStyle(t *testing.T) {
+	registry := NewCustomTypeRegistry()
+	tempDir := testutils.TempDir(t)
+
+	configPath := testutils.WriteFile(t, tempDir, "config.yaml", "typescript-zod:\n  generation:\n    enumStyle: union\n")
+	if err := registry.LoadFromConfig(configPath); err == nil {
+		t.Error("Expected error for invalid enum style")
+	}
+}
+
+func TestCustomTypeRegistry_Hooks(t *testing.T) {
+	registry := NewCustomTypeRegistry()
+	tempDir := testutils.TempDir(t)
+
+	configPath := testutils.WriteFile(t, tempDir, "config.yaml", `typescript-zod:
+  customTypes:
+    email:
+      preprocess: "(value) => String(value).trim()"
+      transform: "(value) => value.toLowerCase()"
+    slug:
+      zodType: "z.string()"
+      transform: "(value) => value.toLowerCase()"`)
+
+	if err := registry.LoadFromConfig(configPath); err != nil {
+		t.Fatalf("LoadFromConfig failed: %v", err)
+	}
+
+	email, _ := registry.Get("email")
+	if got, want := email.Schema(), "z.preprocess((value) => String(value).trim(), z.string().email().transform((value) => value.toLowerCase()))"; got != want {
+		t.Errorf("email Schema() = %v, want %v", got, want)
+	}
+	if email.TypeScriptType != "string" {
+		t.Errorf("Expected email to keep its TypeScript type, got %v", email.TypeScriptType)
+	}
+	slug, _ := registry.Get("slug")
+	if got, want := slug.Schema(), "z.string().transform((value) => value.toLowerCase())"; got != want {
+		t.Errorf("slug Schema() = %v, want %v", got, want)
+	}
+	if !registry.Transforms("email") {
+		t.Error("Expected the hooked email schema to transform")
+	}
+
+	// Hooks need a schema to wrap
+	configPath = testutils.WriteFile(t, tempDir, "config.yaml", "typescript-zod:\n  customTypes:\n    phone:\n      transform: \"(value) => value.trim()\"\n")
+	if err := NewCustomTypeRegistry().LoadFromConfig(configPath); err == nil {
+		t.Error("Expected error for hooks without a schema")
+	}
+}
@@ -2,30 +2,61 @@ package zod
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"text/template"
+	"unicode"
 
 	"dtoForge/internal/generator"
 )
 
+// Derived is a schema derived from a DTO's schema with .pick() or .omit()
+type Derived struct {
+	Name   string
+	From   string
+	Method string   // "pick" or "omit"
+	Keys   []string // property keys as generated
+}
+
 // ZodGenerator implements the Generator interface for TypeScript/Zod
 type ZodGenerator struct {
 	customTypes *CustomTypeRegistry
+	dtos        map[string]generator.DTO
+	recursive   map[string]bool      // DTOs that take part in a reference cycle
+	transforms  map[string]bool      // DTOs whose parsed output differs from their input
+	derived     map[string][]Derived // derived schemas by the DTO they derive from
+	groups      map[string]string    // output folder of each DTO when grouping by tag
+	helpers     map[string]string    // name each DTO's parse helpers are built from
+	zodVersion  int                  // major version of the Zod API to emit
 }
 
-// NewZodGenerator creates a new Zod generator
+// NewZodGenerator creates a new Zod generator targeting the Zod 3 API
 func NewZodGenerator() *ZodGenerator {
-	return &ZodGenerator{}
+	return &ZodGenerator{zodVersion: 3}
+}
+
+// NewZod4Generator creates a new Zod generator targeting the Zod 4 API
+func NewZod4Generator() *ZodGenerator {
+	return &ZodGenerator{zodVersion: 4}
 }
 
 // Language returns the language name
 func (g *ZodGenerator) Language() string {
+	if g.isZod4() {
+		return "typescript-zod4"
+	}
 	return "typescript-zod"
 }
 
+// isZod4 reports whether the Zod 4 API is emitted
+func (g *ZodGenerator) isZod4() bool {
+	return g.zodVersion >= 4
+}
+
 // FileExtension returns the file extension for generated files
 func (g *ZodGenerator) FileExtension() string {
 	return ".ts"
@@ -34,18 +65,53 @@ func (g *ZodGenerator) FileExtension() string {
 // Generate creates TypeScript/Zod files from DTOs
 func (g *ZodGenerator) Generate(dtos []generator.DTO, config generator.Config) error {
 	// Initialize custom type registry
-	g.customTypes = NewCustomTypeRegistry()
+	g.customTypes = NewCustomTypeRegistryForVersion(g.zodVersion)
 
 	// Load custom config if specified
 	if config.ConfigFile != "" {
 		if err := g.customTypes.LoadFromConfig(config.ConfigFile); err != nil {
-			return fmt.Errorf("failed to load custom types config from %s: %w", config.ConfigFile, err)
+			return &generator.ConfigError{Path: config.ConfigFile, Err: err}
 		}
 	}
 
+	// Schemas named after reserved words get a new name, their helpers are
+	// named from the PascalCase form (class_ gets parseClass)
+	dtos, originals := generator.RenameReserved(dtos, generator.IsReservedWord, "TypeScript", config)
+	g.helpers = generator.HelperNames(dtos, originals)
+
+	// Numbers cannot be enum keys, so numeric enums become literal unions
+	dtos = generator.LiteralEnums(dtos)
+
+	if g.customTypes.GetGenerationConfig().Mini && !g.isZod4() {
+		return &generator.ConfigError{Path: config.ConfigFile, Err: fmt.Errorf("generation.mini requires the Zod 4 API, use -lang typescript-zod4")}
+	}
+
+	g.warnUnmappedFormats(dtos, config.Warnings)
+
 	// Sort DTOs for consistent output
 	sortedDTOs := g.sortDTOsByDependency(dtos)
 
+	// Cyclic DTOs need z.lazy references and an explicit type to compile
+	g.recursive = generator.FindCycles(sortedDTOs)
+
+	g.dtos = make(map[string]generator.DTO, len(dtos))
+	for _, dto := range dtos {
+		g.dtos[dto.Name] = dto
+	}
+	g.transforms = g.transformingDTOs(sortedDTOs)
+
+	derived, err := g.resolveDerived()
+	if err != nil {
+		return err
+	}
+	g.derived = derived
+
+	// Group DTO files into one folder per tag if configured
+	g.groups = nil
+	if g.customTypes.IsTagGrouped() {
+		g.groups = generator.TagGroups(sortedDTOs)
+	}
+
 	// Get generation settings
 	genConfig := g.customTypes.GetGenerationConfig()
 
@@ -60,11 +126,42 @@ func (g *ZodGenerator) Generate(dtos []generator.DTO, config generator.Config) e
 			return fmt.Errorf("failed to generate index file: %w", err)
 		}
 
+		// Generate the parse helpers the DTO files import
+		if genConfig.GenerateHelpers {
+			if err := g.generateHelpersFile(config); err != nil {
+				return fmt.Errorf("failed to generate helpers file: %w", err)
+			}
+		}
+
+		// Generate the branded format schemas the DTO files import
+		if brands := g.customTypes.Brands(); len(brands) > 0 {
+			if err := g.generateBrandsFile(brands, config); err != nil {
+				return fmt.Errorf("failed to generate brands file: %w", err)
+			}
+		}
+
 		// Generate individual files for each DTO
-		for _, dto := range sortedDTOs {
+		err := generator.ForEachDTO(sortedDTOs, config.Parallel, func(dto generator.DTO) error {
 			if err := g.generateDTOFile(dto, config, genConfig); err != nil {
 				return fmt.Errorf("failed to generate file for DTO %s: %w", dto.Name, err)
 			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if g.groups != nil {
+			if err := g.generateGroupIndexFiles(sortedDTOs, config); err != nil {
+				return fmt.Errorf("failed to generate group index files: %w", err)
+			}
+		}
+	}
+
+	// Generate the JSON Schema exports if enabled
+	if genConfig.GenerateJsonSchema {
+		if err := g.generateJSONSchemaFile(sortedDTOs, config); err != nil {
+			return fmt.Errorf("failed to generate JSON Schema file: %w", err)
 		}
 	}
 
@@ -80,30 +177,46 @@ func (g *ZodGenerator) Generate(dtos []generator.DTO, config generator.Config) e
 
 // generateDTOFile creates individual DTO files with Zod schemas
 func (g *ZodGenerator) generateDTOFile(dto generator.DTO, config generator.Config, genConfig GenerationConfig) error {
+	folder := filepath.Join(config.OutputFolder, g.groups[dto.Name])
+	if err := os.MkdirAll(folder, 0755); err != nil {
+		return err
+	}
+
 	filename := fmt.Sprintf("%s%s", g.toKebabCase(dto.Name), g.FileExtension())
-	filepath := filepath.Join(config.OutputFolder, filename)
+	filepath := filepath.Join(folder, filename)
+	if config.KeepFile(dto.Name, filepath) {
+		return nil
+	}
 
-	file, err := os.Create(filepath)
+	file, err := g.createFile(config, filepath)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
-	tmpl, err := template.New("dto").Funcs(g.templateFuncs()).Parse(dtoTemplate)
+	tmpl, err := template.New("dto").Funcs(g.templateFuncs()).Parse(dtoTemplate + typesTemplate)
 	if err != nil {
 		return err
 	}
 
+	imports := g.calculateImports(dto)
+	if genConfig.GenerateHelpers {
+		helpers := generator.RelativeModule(g.groups[dto.Name], "", "helpers")
+		imports = append(imports, fmt.Sprintf("import { safeParse, type SafeParseResult } from '%s';", helpers))
+	}
+
 	data := struct {
-		DTO         generator.DTO
-		Config      generator.Config
-		Imports     []string
-		PackageName string
+		DTO             generator.DTO
+		Config          generator.Config
+		Imports         []string
+		PackageName     string
+		GenerateHelpers bool
 	}{
-		DTO:         dto,
-		Config:      config,
-		Imports:     g.calculateImports(dto),
-		PackageName: g.getPackageName(config),
+		DTO:             dto,
+		Config:          config,
+		Imports:         imports,
+		PackageName:     g.getPackageName(config),
+		GenerateHelpers: genConfig.GenerateHelpers,
 	}
 
 	return tmpl.Execute(file, data)
@@ -114,13 +227,13 @@ func (g *ZodGenerator) generateSingleFile(dtos []generator.DTO, config generator
 	filename := g.customTypes.GetSingleFileName()
 	filepath := filepath.Join(config.OutputFolder, filename)
 
-	file, err := os.Create(filepath)
+	file, err := g.createFile(config, filepath)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
-	tmpl, err := template.New("single-file").Funcs(g.templateFuncs()).Parse(singleFileTemplate)
+	tmpl, err := template.New("single-file").Funcs(g.templateFuncs()).Parse(singleFileTemplate + typesTemplate)
 	if err != nil {
 		return fmt.Errorf("template parse error: %w", err)
 	}
@@ -131,12 +244,14 @@ func (g *ZodGenerator) generateSingleFile(dtos []generator.DTO, config generator
 		Imports         []string
 		PackageName     string
 		GenerateHelpers bool
+		Brands          []Brand
 	}{
 		DTOs:            dtos,
 		Config:          config,
 		Imports:         []string{}, // Not using for now since we have import in template
 		PackageName:     g.getPackageName(config),
 		GenerateHelpers: genConfig.GenerateHelpers,
+		Brands:          g.customTypes.Brands(),
 	}
 
 	err = tmpl.Execute(file, data)
@@ -151,7 +266,7 @@ func (g *ZodGenerator) generateSingleFile(dtos []generator.DTO, config generator
 func (g *ZodGenerator) generateIndexFile(dtos []generator.DTO, config generator.Config, genConfig GenerationConfig) error {
 	filepath := filepath.Join(config.OutputFolder, "index.ts")
 
-	file, err := os.Create(filepath)
+	file, err := g.createFile(config, filepath)
 	if err != nil {
 		return err
 	}
@@ -167,11 +282,136 @@ func (g *ZodGenerator) generateIndexFile(dtos []generator.DTO, config generator.
 		Config          generator.Config
 		PackageName     string
 		GenerateHelpers bool
+		Brands          []Brand
 	}{
 		DTOs:            dtos,
 		Config:          config,
 		PackageName:     g.getPackageName(config),
 		GenerateHelpers: genConfig.GenerateHelpers,
+		Brands:          g.customTypes.Brands(),
+	}
+
+	return tmpl.Execute(file, data)
+}
+
+// generateBrandsFile creates brands.ts with the branded format schemas and types
+func (g *ZodGenerator) generateBrandsFile(brands []Brand, config generator.Config) error {
+	file, err := g.createFile(config, filepath.Join(config.OutputFolder, "brands.ts"))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	tmpl, err := template.New("brands").Funcs(g.templateFuncs()).Parse(brandsTemplate)
+	if err != nil {
+		return err
+	}
+
+	return tmpl.Execute(file, brands)
+}
+
+// generateHelpersFile creates helpers.ts with the shared parse helpers
+func (g *ZodGenerator) generateHelpersFile(config generator.Config) error {
+	file, err := g.createFile(config, filepath.Join(config.OutputFolder, "helpers.ts"))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	tmpl, err := template.New("helpers").Funcs(g.templateFuncs()).Parse(helpersTemplate + typesTemplate)
+	if err != nil {
+		return err
+	}
+
+	return tmpl.Execute(file, nil)
+}
+
+// generateGroupIndexFiles writes an index file into every tag folder that
+// exports the DTOs in it
+func (g *ZodGenerator) generateGroupIndexFiles(dtos []generator.DTO, config generator.Config) error {
+	tmpl, err := template.New("group-index").Funcs(g.templateFuncs()).Parse(groupIndexTemplate)
+	if err != nil {
+		return err
+	}
+
+	byGroup := make(map[string][]generator.DTO)
+	var groups []string
+	for _, dto := range dtos {
+		group := g.groups[dto.Name]
+		if _, seen := byGroup[group]; !seen {
+			groups = append(groups, group)
+		}
+		byGroup[group] = append(byGroup[group], dto)
+	}
+	sort.Strings(groups)
+
+	for _, group := range groups {
+		file, err := g.createFile(config, filepath.Join(config.OutputFolder, group, "index.ts"))
+		if err != nil {
+			return err
+		}
+
+		data := struct {
+			Group       string
+			DTOs        []generator.DTO
+			PackageName string
+		}{
+			Group:       group,
+			DTOs:        byGroup[group],
+			PackageName: g.getPackageName(config),
+		}
+
+		err = tmpl.Execute(file, data)
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// generateJSONSchemaFile creates json-schema.ts, exporting the JSON Schema
+// of every generated schema: via zod-to-json-schema for Zod 3, and the
+// built-in z.toJSONSchema for Zod 4
+func (g *ZodGenerator) generateJSONSchemaFile(dtos []generator.DTO, config generator.Config) error {
+	file, err := g.createFile(config, filepath.Join(config.OutputFolder, "json-schema.ts"))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	tmpl, err := template.New("json-schema").Funcs(g.templateFuncs()).Parse(jsonSchemaTemplate)
+	if err != nil {
+		return err
+	}
+
+	// Schemas are imported from their own files, or all from the single file
+	type schemaImport struct {
+		Names  []string
+		Module string
+	}
+	var imports []schemaImport
+	if g.customTypes.IsSingleFileMode() {
+		names := make([]string, len(dtos))
+		for i, dto := range dtos {
+			names[i] = dto.Name + "Schema"
+		}
+		if len(names) > 0 {
+			imports = append(imports, schemaImport{Names: names, Module: "./" + strings.TrimSuffix(g.customTypes.GetSingleFileName(), ".ts")})
+		}
+	} else {
+		for _, dto := range dtos {
+			imports = append(imports, schemaImport{Names: []string{dto.Name + "Schema"}, Module: g.modulePath(dto.Name)})
+		}
+	}
+
+	data := struct {
+		DTOs    []generator.DTO
+		Imports []schemaImport
+	}{
+		DTOs:    dtos,
+		Imports: imports,
 	}
 
 	return tmpl.Execute(file, data)
@@ -186,7 +426,7 @@ func (g *ZodGenerator) generatePackageJSON(config generator.Config) error {
 		return nil
 	}
 
-	file, err := os.Create(filepath)
+	file, err := config.CreateFile(filepath, nil)
 	if err != nil {
 		return err
 	}
@@ -197,10 +437,24 @@ func (g *ZodGenerator) generatePackageJSON(config generator.Config) error {
 		return err
 	}
 
+	zodRange, zodOpenAPI := "^3.22.4", "^4.2.0"
+	if g.isZod4() {
+		zodRange, zodOpenAPI = "^4.0.0", "^5.0.0"
+	}
+	if !g.customTypes.GetGenerationConfig().OpenAPIMetadata {
+		zodOpenAPI = ""
+	}
+
 	data := struct {
-		PackageName string
+		PackageName     string
+		ZodRange        string
+		ZodToJSONSchema bool
+		ZodOpenAPI      string // version range of zod-openapi, "" if unused
 	}{
-		PackageName: g.getPackageName(config),
+		PackageName:     g.getPackageName(config),
+		ZodRange:        zodRange,
+		ZodToJSONSchema: g.customTypes.GetGenerationConfig().GenerateJsonSchema && !g.isZod4(),
+		ZodOpenAPI:      zodOpenAPI,
 	}
 
 	return tmpl.Execute(file, data)
@@ -209,11 +463,41 @@ func (g *ZodGenerator) generatePackageJSON(config generator.Config) error {
 // Helper functions for templates
 func (g *ZodGenerator) templateFuncs() template.FuncMap {
 	return template.FuncMap{
+		"helperName":     g.helperName,
 		"toZodType":      g.toZodType,
+		"toTSType":       g.toTSType,
+		"isRecursive":    g.isRecursive,
+		"transforms":     g.transformsInput,
+		"hasPartial":     g.hasPartial,
+		"derived":        func(name string) []Derived { return g.derived[name] },
+		"zod4":           g.isZod4,
 		"toCamelCase":    g.toCamelCase,
 		"toPascalCase":   g.toPascalCase,
 		"toKebabCase":    g.toKebabCase,
+		"modulePath":     g.modulePath,
 		"hasDescription": g.hasDescription,
+		"dtoComment":     g.dtoComment,
+		"propComment":    g.propComment,
+		"defaultValue":   g.defaultValue,
+		"enumStyle":      g.enumStyle,
+		"enumMembers":    g.enumMembers,
+		"enumSchema":     g.enumSchema,
+		"literal":        func(value string) string { return generator.NewLiteralType(value).Literal() },
+		"describe":       g.describe,
+		"getExt":         g.getExt,
+		"objectOpen":     g.objectOpen,
+		"objectClose":    g.objectClose,
+		"objectRef":      g.objectRef,
+		"partialSchema":  g.partialSchema,
+		"derivedSchema":  g.derivedSchema,
+		"propertySchema": g.propertySchema,
+		"schemaType":     g.schemaType,
+		"mini":           g.isMini,
+		"zodImport":      g.zodImport,
+		"openapi":        g.openapi,
+		"openapiImport":  g.customTypes.OpenAPIImport,
+		"readonly":       g.readonlyModifier,
+		"undefinable":    g.undefinable,
 		"len":            func(slice []string) int { return len(slice) },
 		"add":            func(a, b int) int { return a + b },
 		"sub":            func(a, b int) int { return a - b },
@@ -222,6 +506,18 @@ func (g *ZodGenerator) templateFuncs() template.FuncMap {
 	}
 }
 
+// modulePath returns the import path of a DTO's file relative to the output
+// root, e.g. './users/user' when grouping by tag
+func (g *ZodGenerator) modulePath(name string) string {
+	return generator.RelativeModule("", g.groups[name], g.toKebabCase(name))
+}
+
+// createFile creates a generated source file, starting with the configured
+// pragmas
+func (g *ZodGenerator) createFile(config generator.Config, path string) (*os.File, error) {
+	return config.CreateFile(path, g.customTypes.GetOutputConfig().Pragmas)
+}
+
 func (g *ZodGenerator) getPackageName(config generator.Config) string {
 	if config.PackageName != "" {
 		return config.PackageName
@@ -229,17 +525,9 @@ func (g *ZodGenerator) getPackageName(config generator.Config) string {
 	return "generated-zod-schemas"
 }
 
-// sortDTOsByDependency sorts DTOs to handle dependencies correctly
+// sortDTOsByDependency sorts DTOs so referenced schemas are declared before their users
 func (g *ZodGenerator) sortDTOsByDependency(dtos []generator.DTO) []generator.DTO {
-	// Simple alphabetical sort for now - could be enhanced with proper dependency resolution
-	sorted := make([]generator.DTO, len(dtos))
-	copy(sorted, dtos)
-
-	sort.Slice(sorted, func(i, j int) bool {
-		return sorted[i].Name < sorted[j].Name
-	})
-
-	return sorted
+	return generator.SortByDependency(dtos)
 }
 
 // TYPE CONVERSION FUNCTIONS
@@ -250,12 +538,50 @@ func (g *ZodGenerator) toZodType(irType generator.IRType, nullable bool, optiona
 
 	switch t := irType.(type) {
 	case generator.PrimitiveType:
-		baseType = g.primitiveToZod(t)
+		baseType = g.withChecks(g.primitiveToZod(t), g.valueChecks(t))
 	case generator.ArrayType:
 		elementType := g.toZodType(t.ElementType, false, false)
 		baseType = fmt.Sprintf("z.array(%s)", elementType)
+		if t.Constraints != nil {
+			baseType = g.withChecks(baseType, lengthChecks(t.Constraints.MinItems, t.Constraints.MaxItems))
+		}
+		baseType = g.asReadonly(baseType)
+	case generator.TupleType:
+		elementTypes := make([]string, len(t.ElementTypes))
+		for i, elem := range t.ElementTypes {
+			elementTypes[i] = g.toZodType(elem, false, false)
+		}
+		baseType = fmt.Sprintf("z.tuple([%s])", strings.Join(elementTypes, ", "))
+		if t.Rest != nil && g.isZod4() {
+			// Zod 4 takes the rest element as a second argument
+			baseType = fmt.Sprintf("z.tuple([%s], %s)", strings.Join(elementTypes, ", "), g.toZodType(t.Rest, false, false))
+		} else if t.Rest != nil {
+			baseType += fmt.Sprintf(".rest(%s)", g.toZodType(t.Rest, false, false))
+		}
+	case generator.UnionType:
+		discriminate := g.canDiscriminate(t)
+		members := make([]string, len(t.Types))
+		for i, member := range t.Types {
+			members[i] = g.toZodType(member, false, false)
+			if discriminate && g.isReadonly() {
+				members[i] = g.unwrapReadonly(members[i]) // discriminated unions take objects; readonly is reapplied to the union
+			}
+		}
+		if discriminate {
+			baseType = g.asReadonly(fmt.Sprintf("z.discriminatedUnion('%s', [%s])", g.toCamelCase(t.Discriminator), strings.Join(members, ", ")))
+		} else {
+			baseType = fmt.Sprintf("z.union([%s])", strings.Join(members, ", "))
+		}
+	case generator.MapType:
+		baseType = fmt.Sprintf("z.record(%s, %s)", g.toZodType(t.KeyType, false, false), g.toZodType(t.ValueType, false, false))
+	case generator.LiteralType:
+		if t.Kind == "null" {
+			baseType = "z.null()"
+		} else {
+			baseType = fmt.Sprintf("z.literal(%s)", t.Literal())
+		}
 	case generator.ReferenceType:
-		baseType = fmt.Sprintf("%sSchema", t.RefName)
+		baseType = g.schemaRef(t.RefName)
 	case generator.EnumType:
 		values := make([]string, len(t.Values))
 		for i, v := range t.Values {
@@ -264,21 +590,180 @@ func (g *ZodGenerator) toZodType(irType generator.IRType, nullable bool, optiona
 		baseType = fmt.Sprintf("z.enum([%s])", strings.Join(values, ", "))
 	case generator.ObjectType:
 		if t.RefName != "" {
-			baseType = fmt.Sprintf("%sSchema", t.RefName)
+			baseType = g.schemaRef(t.RefName)
 		} else {
-			baseType = "z.record(z.unknown())" // inline objects
+			// Free-form inline objects are dictionaries of unknown values.
+			// Zod 4 requires the key schema, which Zod 3 accepts too.
+			baseType = "z.record(z.string(), z.unknown())"
 		}
 	default:
 		baseType = "z.unknown()"
 	}
 
 	// Apply modifiers based on nullable and optional
+	if nullable && optional && g.customTypes != nil && g.customTypes.GetGenerationConfig().Nullish {
+		return g.call(baseType, "nullish")
+	}
 	if nullable {
-		baseType = fmt.Sprintf("%s.nullable()", baseType)
+		baseType = g.call(baseType, "nullable")
 	}
 
 	if optional {
-		baseType = fmt.Sprintf("%s.optional()", baseType)
+		baseType = g.call(baseType, "optional")
+	}
+
+	return baseType
+}
+
+// canDiscriminate reports whether a union can be a z.discriminatedUnion:
+// it has a discriminator, and every member is an object schema (not lazy,
+// not intersected with a lazy parent) whose discriminator property is a
+// required literal or enum
+func (g *ZodGenerator) canDiscriminate(union generator.UnionType) bool {
+	if union.Discriminator == "" {
+		return false
+	}
+	for _, member := range union.Types {
+		var name string
+		switch t := member.(type) {
+		case generator.ReferenceType:
+			name = t.RefName
+		case generator.ObjectType:
+			name = t.RefName
+		}
+		if g.recursive[name] {
+			return false
+		}
+		for _, base := range g.dtos[name].Extends {
+			if g.recursive[base] {
+				return false
+			}
+		}
+		if !g.hasDiscriminator(name, union.Discriminator, make(map[string]bool)) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasDiscriminator reports whether the object DTO name, or a DTO it extends,
+// declares property as a required literal or enum
+func (g *ZodGenerator) hasDiscriminator(name, property string, visiting map[string]bool) bool {
+	dto, exists := g.dtos[name]
+	if !exists || dto.Type == "enum" || dto.Type == "union" || visiting[name] {
+		return false
+	}
+	visiting[name] = true
+
+	for _, prop := range dto.Properties {
+		if prop.Name != property {
+			continue
+		}
+		if !prop.Required || prop.Nullable {
+			return false
+		}
+		switch t := prop.Type.(type) {
+		case generator.LiteralType:
+			return t.Kind != "null"
+		case generator.EnumType:
+			return true
+		case generator.ReferenceType:
+			return g.dtos[t.RefName].Type == "enum"
+		}
+		return false
+	}
+	for _, base := range dto.Extends {
+		if g.hasDiscriminator(base, property, visiting) {
+			return true
+		}
+	}
+	return false
+}
+
+// schemaRef references another DTO's schema, deferring recursive ones with z.lazy
+func (g *ZodGenerator) schemaRef(name string) string {
+	if g.recursive[name] {
+		return fmt.Sprintf("z.lazy(() => %sSchema)", name)
+	}
+	return fmt.Sprintf("%sSchema", name)
+}
+
+// toTSType converts an IRType to the TypeScript type its Zod schema infers,
+// used where a type must be declared explicitly (recursive schemas)
+func (g *ZodGenerator) toTSType(irType generator.IRType, nullable bool) string {
+	var baseType string
+
+	switch t := irType.(type) {
+	case generator.PrimitiveType:
+		switch t.Name {
+		case "string", "binary", "number", "integer":
+			if mapping, exists := g.customTypes.Get(t.Format); t.Format != "" && exists && mapping.TypeScriptType != "" {
+				baseType = mapping.TypeScriptType
+			} else if t.Name == "string" || t.Name == "binary" {
+				baseType = "string"
+			} else {
+				baseType = "number"
+			}
+		case "bigint":
+			if mapping, exists := g.customTypes.Get(t.Format); t.Format != "" && exists && mapping.TypeScriptType != "" {
+				baseType = mapping.TypeScriptType
+			} else {
+				baseType = "bigint"
+			}
+		case "boolean":
+			baseType = "boolean"
+		default:
+			baseType = "unknown"
+		}
+	case generator.ArrayType:
+		elementType := g.toTSType(t.ElementType, false)
+		if strings.Contains(elementType, "|") {
+			elementType = fmt.Sprintf("(%s)", elementType)
+		}
+		baseType = fmt.Sprintf("%s%s[]", g.readonlyModifier(), elementType)
+	case generator.TupleType:
+		elementTypes := make([]string, len(t.ElementTypes))
+		for i, elem := range t.ElementTypes {
+			elementTypes[i] = g.toTSType(elem, false)
+		}
+		if t.Rest != nil {
+			rest := g.toTSType(t.Rest, false)
+			if strings.Contains(rest, "|") {
+				rest = fmt.Sprintf("(%s)", rest)
+			}
+			elementTypes = append(elementTypes, fmt.Sprintf("...%s[]", rest))
+		}
+		baseType = fmt.Sprintf("[%s]", strings.Join(elementTypes, ", "))
+	case generator.UnionType:
+		members := make([]string, len(t.Types))
+		for i, member := range t.Types {
+			members[i] = g.toTSType(member, false)
+		}
+		baseType = strings.Join(members, " | ")
+	case generator.MapType:
+		baseType = fmt.Sprintf("Record<%s, %s>", g.toTSType(t.KeyType, false), g.toTSType(t.ValueType, false))
+	case generator.LiteralType:
+		baseType = t.Literal()
+	case generator.ReferenceType:
+		baseType = t.RefName
+	case generator.EnumType:
+		values := make([]string, len(t.Values))
+		for i, v := range t.Values {
+			values[i] = fmt.Sprintf("'%s'", v)
+		}
+		baseType = strings.Join(values, " | ")
+	case generator.ObjectType:
+		if t.RefName != "" {
+			baseType = t.RefName
+		} else {
+			baseType = "Record<string, unknown>"
+		}
+	default:
+		baseType = "unknown"
+	}
+
+	if nullable {
+		return fmt.Sprintf("%s | null", baseType)
 	}
 
 	return baseType
@@ -287,10 +772,30 @@ func (g *ZodGenerator) toZodType(irType generator.IRType, nullable bool, optiona
 // primitiveToZod converts primitive types to Zod equivalents
 func (g *ZodGenerator) primitiveToZod(prim generator.PrimitiveType) string {
 	switch prim.Name {
-	case "string":
+	case "string", "binary":
 		return g.stringWithFormat(prim.Format)
 	case "number", "integer":
+		if g.customTypes != nil && prim.Format != "" {
+			if mapping, exists := g.customTypes.Get(prim.Format); exists {
+				return g.mappingSchema(mapping)
+			}
+		}
+		// Integers reject fractional values unless the API is known to be lenient
+		lenient := g.customTypes != nil && g.customTypes.GetGenerationConfig().LenientIntegers
+		if prim.Name == "integer" && !lenient && g.isZod4() {
+			return "z.int()"
+		}
+		if prim.Name == "integer" && !lenient {
+			return "z.number().int()"
+		}
 		return "z.number()"
+	case "bigint":
+		if g.customTypes != nil && prim.Format != "" {
+			if mapping, exists := g.customTypes.Get(prim.Format); exists {
+				return g.mappingSchema(mapping)
+			}
+		}
+		return "z.coerce.bigint()"
 	case "boolean":
 		return "z.boolean()"
 	default:
@@ -298,16 +803,123 @@ func (g *ZodGenerator) primitiveToZod(prim generator.PrimitiveType) string {
 	}
 }
 
+// zodCheck is a check on a schema: a method of the classic API, or the
+// function passed to .check() on Zod Mini
+type zodCheck struct {
+	Method string // e.g. min
+	Mini   string // e.g. minLength
+	Arg    string
+}
+
+// withChecks applies checks to a schema
+func (g *ZodGenerator) withChecks(schema string, checks []zodCheck) string {
+	if len(checks) == 0 {
+		return schema
+	}
+	calls := make([]string, len(checks))
+	for i, check := range checks {
+		if g.isMini() {
+			calls[i] = fmt.Sprintf("z.%s(%s)", check.Mini, check.Arg)
+		} else {
+			calls[i] = fmt.Sprintf(".%s(%s)", check.Method, check.Arg)
+		}
+	}
+	if g.isMini() {
+		return fmt.Sprintf("%s.check(%s)", schema, strings.Join(calls, ", "))
+	}
+	return schema + strings.Join(calls, "")
+}
+
+// valueChecks returns the checks of a string or number's constraints. Only
+// built-in format schemas get them: custom, branded or coerced schemas need
+// not support the checks.
+func (g *ZodGenerator) valueChecks(prim generator.PrimitiveType) []zodCheck {
+	c := prim.Constraints
+	if c == nil {
+		return nil
+	}
+	if g.customTypes != nil && prim.Format != "" {
+		if _, exists := g.customTypes.Get(prim.Format); exists && !g.customTypes.IsBuiltIn(prim.Format) {
+			return nil
+		}
+	}
+
+	switch prim.Name {
+	case "string":
+		checks := lengthChecks(c.MinLength, c.MaxLength)
+		if c.Pattern != "" {
+			regex := fmt.Sprintf("new RegExp(%s)", generator.NewLiteralType(c.Pattern).Literal())
+			checks = append(checks, zodCheck{Method: "regex", Mini: "regex", Arg: regex})
+		}
+		return checks
+	case "number", "integer", "bigint":
+		var checks []zodCheck
+		bound := func(method, mini string, value *float64) {
+			if value == nil {
+				return
+			}
+			literal := strconv.FormatFloat(*value, 'f', -1, 64)
+			if prim.Name == "bigint" {
+				if *value != math.Trunc(*value) {
+					return // a bigint cannot be compared with a fraction
+				}
+				literal += "n"
+			}
+			checks = append(checks, zodCheck{Method: method, Mini: mini, Arg: literal})
+		}
+		bound("min", "gte", c.Minimum)
+		bound("gt", "gt", c.ExclusiveMinimum)
+		bound("max", "lte", c.Maximum)
+		bound("lt", "lt", c.ExclusiveMaximum)
+		bound("multipleOf", "multipleOf", c.MultipleOf)
+		return checks
+	}
+	return nil
+}
+
+// lengthChecks returns the length bounds of a string or array, using
+// .length() when both bounds are equal
+func lengthChecks(minimum, maximum *int) []zodCheck {
+	if minimum != nil && maximum != nil && *minimum == *maximum {
+		return []zodCheck{{Method: "length", Mini: "length", Arg: strconv.Itoa(*minimum)}}
+	}
+	var checks []zodCheck
+	if minimum != nil {
+		checks = append(checks, zodCheck{Method: "min", Mini: "minLength", Arg: strconv.Itoa(*minimum)})
+	}
+	if maximum != nil {
+		checks = append(checks, zodCheck{Method: "max", Mini: "maxLength", Arg: strconv.Itoa(*maximum)})
+	}
+	return checks
+}
+
 // stringWithFormat applies Zod string validations based on OpenAPI format
 func (g *ZodGenerator) stringWithFormat(format string) string {
 	// Check for custom format mapping first
 	if g.customTypes != nil {
 		if mapping, exists := g.customTypes.Get(format); exists {
-			return mapping.ZodType
+			return g.mappingSchema(mapping)
 		}
 	}
 
 	// Fall back to built-in Zod formats
+	if g.isZod4() {
+		switch format {
+		case "email":
+			return "z.email()"
+		case "uuid":
+			return "z.uuid()"
+		case "uri", "url":
+			return "z.url()"
+		case "date-time":
+			return "z.iso.datetime()"
+		case "date":
+			return "z.iso.date()"
+		default:
+			return "z.string()"
+		}
+	}
+
 	switch format {
 	case "email":
 		return "z.string().email()"
@@ -322,13 +934,21 @@ func (g *ZodGenerator) stringWithFormat(format string) string {
 	case "":
 		return "z.string()"
 	default:
-		// Unknown format, just use string with a comment
-		return fmt.Sprintf("z.string() /* format: %s */", format)
+		// Unknown formats are reported by warnUnmappedFormats
+		return "z.string()"
 	}
 }
 
 // UTILITY FUNCTIONS
 
+// helperName returns the name the parse helpers of a DTO are built from
+func (g *ZodGenerator) helperName(name string) string {
+	if helper, ok := g.helpers[name]; ok {
+		return helper
+	}
+	return name
+}
+
 func (g *ZodGenerator) toCamelCase(s string) string {
 	if len(s) == 0 {
 		return s
@@ -354,32 +974,663 @@ func (g *ZodGenerator) toKebabCase(s string) string {
 	return strings.ToLower(result.String())
 }
 
+// isRecursive reports whether a DTO is part of a reference cycle
+func (g *ZodGenerator) isRecursive(name string) bool {
+	return g.recursive[name]
+}
+
+// transformsInput reports whether parsing a DTO changes its shape, so its
+// z.input and z.output types differ
+func (g *ZodGenerator) transformsInput(name string) bool {
+	return g.transforms[name]
+}
+
+// hasPartial reports whether a partial schema is generated for a DTO
+func (g *ZodGenerator) hasPartial(name string) bool {
+	return g.customTypes.GetGenerationConfig().GeneratePartialSchemas && g.isObjectSchema(name)
+}
+
+// isObjectSchema reports whether a DTO's schema is a Zod object, with
+// .partial(), .pick() and .omit(). Recursive DTOs are typed z.ZodType and
+// DTOs extending recursive ones are intersections.
+func (g *ZodGenerator) isObjectSchema(name string) bool {
+	dto, exists := g.dtos[name]
+	if !exists || dto.Type == "enum" || dto.Type == "union" || g.recursive[name] {
+		return false
+	}
+	for _, base := range dto.Extends {
+		if g.recursive[base] {
+			return false
+		}
+	}
+	return true
+}
+
+// resolveDerived checks the configured derived schemas against the DTOs and
+// groups them by the DTO they derive from
+func (g *ZodGenerator) resolveDerived() (map[string][]Derived, error) {
+	configured := g.customTypes.DerivedSchemas()
+	names := make([]string, 0, len(configured))
+	for name := range configured {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	derived := make(map[string][]Derived)
+	for _, name := range names {
+		config := configured[name]
+		if _, exists := g.dtos[name]; exists {
+			return nil, fmt.Errorf("derived schema '%s' conflicts with a schema of the same name", name)
+		}
+		if _, exists := g.dtos[config.From]; !exists {
+			return nil, fmt.Errorf("derived schema '%s': unknown schema '%s'", name, config.From)
+		}
+		if !g.isObjectSchema(config.From) {
+			return nil, fmt.Errorf("derived schema '%s': '%s' is not a non-recursive object schema", name, config.From)
+		}
+
+		method, properties := "pick", config.Pick
+		if len(config.Omit) > 0 {
+			method, properties = "omit", config.Omit
+		}
+		known := g.propertyNames(config.From, make(map[string]bool))
+		keys := make([]string, len(properties))
+		for i, property := range properties {
+			if !known[property] {
+				return nil, fmt.Errorf("derived schema '%s': '%s' has no property '%s'", name, config.From, property)
+			}
+			keys[i] = g.toCamelCase(property)
+		}
+		derived[config.From] = append(derived[config.From], Derived{Name: name, From: config.From, Method: method, Keys: keys})
+	}
+	return derived, nil
+}
+
+// propertyNames returns the names of a DTO's own and inherited properties
+func (g *ZodGenerator) propertyNames(name string, names map[string]bool) map[string]bool {
+	dto := g.dtos[name]
+	for _, prop := range dto.Properties {
+		names[prop.Name] = true
+	}
+	for _, base := range dto.Extends {
+		g.propertyNames(base, names)
+	}
+	return names
+}
+
+// transformingDTOs finds the DTOs that transform their input themselves and
+// those that embed such a DTO
+func (g *ZodGenerator) transformingDTOs(dtos []generator.DTO) map[string]bool {
+	transforms := make(map[string]bool)
+	for changed := true; changed; {
+		changed = false
+		for _, dto := range dtos {
+			if transforms[dto.Name] {
+				continue
+			}
+			transforming := g.transformsOwnInput(dto)
+			for _, dep := range generator.Dependencies(dto) {
+				transforming = transforming || transforms[dep]
+			}
+			if transforming {
+				transforms[dto.Name] = true
+				changed = true
+			}
+		}
+	}
+	return transforms
+}
+
+// transformsOwnInput reports whether a DTO's own properties fill in defaults
+// or use a format whose schema coerces or transforms its input
+func (g *ZodGenerator) transformsOwnInput(dto generator.DTO) bool {
+	for _, prop := range dto.Properties {
+		if prop.Default != nil {
+			return true
+		}
+	}
+	for _, format := range g.getUsedFormatsInDTO(dto) {
+		if g.customTypes.Transforms(format) {
+			return true
+		}
+	}
+	return false
+}
+
 func (g *ZodGenerator) hasDescription(desc string) bool {
 	return strings.TrimSpace(desc) != ""
 }
 
+// objectOpen opens a DTO's object schema. DTOs extending others build on the
+// parents' schemas: .extend() keeps the result a ZodObject, but recursive
+// parents are lazy and can only be intersected. Zod 4 deprecates .merge(),
+// so further parents are added with .extend(Parent.shape).
+func (g *ZodGenerator) objectOpen(bases []string) string {
+	if g.isMini() {
+		return g.miniObjectOpen(bases)
+	}
+	if len(bases) == 0 && g.isZod4() {
+		switch g.objectMode() {
+		case "strict":
+			return "z.strictObject("
+		case "passthrough":
+			return "z.looseObject("
+		}
+	}
+	if len(bases) == 0 {
+		return "z.object("
+	}
+
+	refs := make([]string, len(bases))
+	intersect := false
+	for _, base := range bases {
+		intersect = intersect || g.recursive[base]
+	}
+	for i, base := range bases {
+		if intersect {
+			refs[i] = g.schemaRef(base)
+		} else {
+			refs[i] = g.objectRef(base)
+		}
+	}
+	if intersect {
+		return strings.Join(refs, ".and(") + strings.Repeat(")", len(refs)-1) + ".and(z.object("
+	}
+	if g.isZod4() {
+		open := refs[0]
+		for _, ref := range refs[1:] {
+			open += ".extend(" + ref + ".shape)"
+		}
+		return open + ".extend("
+	}
+	return strings.Join(refs, ".merge(") + strings.Repeat(")", len(refs)-1) + ".extend("
+}
+
+// objectClose closes what objectOpen opened, applying the object mode and
+// readonly. Intersections stay in strip mode: a strict side would reject the
+// keys of the other.
+func (g *ZodGenerator) objectClose(bases []string) string {
+	if g.isMini() {
+		return g.miniObjectClose(bases)
+	}
+	for _, base := range bases {
+		if g.recursive[base] {
+			return "))" + g.readonlySchema()
+		}
+	}
+	if len(bases) == 0 && g.isZod4() {
+		return ")" + g.readonlySchema()
+	}
+	switch g.objectMode() {
+	case "strict":
+		return ").strict()" + g.readonlySchema()
+	case "passthrough":
+		return ").passthrough()" + g.readonlySchema()
+	}
+	return ")" + g.readonlySchema()
+}
+
+// miniObjectOpen opens an object schema with the functions of Zod Mini:
+// z.extend() and z.intersection() instead of methods, and z.readonly()
+// wrapping the whole schema
+func (g *ZodGenerator) miniObjectOpen(bases []string) string {
+	open := ""
+	if g.isReadonly() {
+		open = "z.readonly("
+	}
+
+	constructor := "z.object("
+	switch g.objectMode() {
+	case "strict":
+		constructor = "z.strictObject("
+	case "passthrough":
+		constructor = "z.looseObject("
+	}
+	if len(bases) == 0 {
+		return open + constructor
+	}
+
+	intersect := false
+	for _, base := range bases {
+		intersect = intersect || g.recursive[base]
+	}
+	if intersect {
+		parents := g.schemaRef(bases[0])
+		for _, base := range bases[1:] {
+			parents = fmt.Sprintf("z.intersection(%s, %s)", parents, g.schemaRef(base))
+		}
+		return open + "z.intersection(" + parents + ", z.object("
+	}
+
+	// Extended objects keep the unknown key handling of their first parent
+	parents := g.objectRef(bases[0])
+	for _, base := range bases[1:] {
+		parents = fmt.Sprintf("z.extend(%s, %s.shape)", parents, g.objectRef(base))
+	}
+	return open + "z.extend(" + parents + ", "
+}
+
+// miniObjectClose closes what miniObjectOpen opened
+func (g *ZodGenerator) miniObjectClose(bases []string) string {
+	close := ")"
+	for _, base := range bases {
+		if g.recursive[base] {
+			close = "))"
+			break
+		}
+	}
+	if g.isReadonly() {
+		close += ")"
+	}
+	return close
+}
+
+// isMini reports whether the functional Zod Mini API is emitted
+func (g *ZodGenerator) isMini() bool {
+	return g.isZod4() && g.customTypes != nil && g.customTypes.GetGenerationConfig().Mini
+}
+
+// call applies a schema method: a method call on the classic API, and a
+// function taking the schema first on Zod Mini, e.g. z.optional(schema)
+func (g *ZodGenerator) call(schema, method string, args ...string) string {
+	if g.isMini() {
+		if method == "default" {
+			method = "_default"
+		}
+		return fmt.Sprintf("z.%s(%s)", method, strings.Join(append([]string{schema}, args...), ", "))
+	}
+	return fmt.Sprintf("%s.%s(%s)", schema, method, strings.Join(args, ", "))
+}
+
+// mappingSchema returns the schema of a format mapping with its hooks, piped
+// through z.transform() on Zod Mini
+func (g *ZodGenerator) mappingSchema(mapping CustomTypeMapping) string {
+	if !g.isMini() {
+		return mapping.Schema()
+	}
+	schema := mapping.ZodType
+	if mapping.Transform != "" {
+		schema = fmt.Sprintf("z.pipe(%s, z.transform(%s))", schema, mapping.Transform)
+	}
+	if mapping.Preprocess != "" {
+		schema = fmt.Sprintf("z.pipe(z.transform(%s), %s)", mapping.Preprocess, schema)
+	}
+	return schema
+}
+
+// zodImport returns the statement importing z
+func (g *ZodGenerator) zodImport() string {
+	if g.isMini() {
+		return "import * as z from 'zod/mini';"
+	}
+	return "import { z } from 'zod';"
+}
+
+// schemaType returns the type of schemas, e.g. in annotations of lazy ones
+func (g *ZodGenerator) schemaType() string {
+	if g.isMini() {
+		return "z.ZodMiniType"
+	}
+	return "z.ZodType"
+}
+
+// isReadonly reports whether object and array schemas are made readonly
+func (g *ZodGenerator) isReadonly() bool {
+	return g.customTypes != nil && g.customTypes.GetGenerationConfig().Readonly
+}
+
+// readonlySchema returns the .readonly() call applied to object and array
+// schemas, or "" if they are mutable
+func (g *ZodGenerator) readonlySchema() string {
+	if g.isReadonly() {
+		return ".readonly()"
+	}
+	return ""
+}
+
+// asReadonly makes an object or array schema readonly if enabled
+func (g *ZodGenerator) asReadonly(schema string) string {
+	if g.isReadonly() {
+		return g.call(schema, "readonly")
+	}
+	return schema
+}
+
+// unwrapReadonly returns the object inside a readonly schema
+func (g *ZodGenerator) unwrapReadonly(schema string) string {
+	if g.isMini() {
+		return schema + ".def.innerType"
+	}
+	return schema + ".unwrap()"
+}
+
+// partialSchema returns the partial schema of an object DTO
+func (g *ZodGenerator) partialSchema(name string) string {
+	return g.asReadonly(g.call(g.objectRef(name), "partial"))
+}
+
+// derivedSchema returns the schema of a derived DTO
+func (g *ZodGenerator) derivedSchema(derived Derived) string {
+	keys := make([]string, len(derived.Keys))
+	for i, key := range derived.Keys {
+		keys[i] = key + ": true"
+	}
+	mask := fmt.Sprintf("{ %s }", strings.Join(keys, ", "))
+	return g.asReadonly(g.call(g.objectRef(derived.From), derived.Method, mask))
+}
+
+// readonlyModifier returns the modifier of properties in explicit types
+func (g *ZodGenerator) readonlyModifier() string {
+	if g.isReadonly() {
+		return "readonly "
+	}
+	return ""
+}
+
+// undefinable returns " | undefined" for an optional or defaulted property
+// in explicit types when strict types are enabled. The schema infers it for
+// .optional(), and for .default() on the input side, and
+// exactOptionalPropertyTypes rejects a type whose optional property leaves
+// it out.
+func (g *ZodGenerator) undefinable(prop generator.Property) string {
+	optional := !prop.Required || g.defaultValue(prop.Type, prop.Default) != ""
+	if g.customTypes.GetGenerationConfig().StrictTypes && optional {
+		return " | undefined"
+	}
+	return ""
+}
+
+// objectRef references a non-recursive object DTO's schema where a Zod
+// object is needed: readonly schemas are unwrapped to their object first
+func (g *ZodGenerator) objectRef(name string) string {
+	if g.isReadonly() {
+		return g.unwrapReadonly(name + "Schema")
+	}
+	return name + "Schema"
+}
+
+// objectMode returns how object schemas treat unknown keys: strip, strict
+// or passthrough
+func (g *ZodGenerator) objectMode() string {
+	if g.customTypes == nil || g.customTypes.GetGenerationConfig().ObjectMode == "" {
+		return "strip"
+	}
+	return g.customTypes.GetGenerationConfig().ObjectMode
+}
+
+// dtoComment renders the JSDoc block above a DTO declaration, pointing back
+// into the spec when source comments are enabled
+func (g *ZodGenerator) dtoComment(dto generator.DTO) string {
+	tags := g.docTags(dto.Metadata, dto.Deprecated)
+	if dto.Source != nil && g.customTypes.GetGenerationConfig().SourceComments {
+		tags = append(tags, "@see "+dto.Source.String())
+	}
+	return generator.DocComment("", dto.Description, tags...)
+}
+
+// propertySchema returns the schema of a property, with its default
+func (g *ZodGenerator) propertySchema(prop generator.Property) string {
+	schema := g.toZodType(prop.Type, prop.Nullable, !prop.Required)
+	if value := g.defaultValue(prop.Type, prop.Default); value != "" {
+		schema = g.call(schema, "default", value)
+	}
+	return schema
+}
+
+// defaultValue renders a property's default as a literal, or "" if it has
+// none. Defaults of native enums must be members, not plain strings.
+func (g *ZodGenerator) defaultValue(irType generator.IRType, value any) string {
+	if value == nil {
+		return ""
+	}
+	if ref, ok := irType.(generator.ReferenceType); ok && g.enumStyle() == "nativeEnum" && g.dtos[ref.RefName].Type == "enum" {
+		for _, member := range g.enumMembers(g.dtos[ref.RefName].EnumValues) {
+			if value == member.Value {
+				return fmt.Sprintf("%s.%s", ref.RefName, member.Name)
+			}
+		}
+	}
+	return generator.ValueLiteral(value)
+}
+
+// defaultsToEnumMember reports whether a property of dto defaults to a
+// member of the native enum name, which must then be imported
+func (g *ZodGenerator) defaultsToEnumMember(dto generator.DTO, name string) bool {
+	for _, prop := range dto.Properties {
+		if ref, ok := prop.Type.(generator.ReferenceType); ok && ref.RefName == name && strings.Contains(g.defaultValue(prop.Type, prop.Default), name+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// EnumMember is a member of an enum declared as a TypeScript enum or object
+type EnumMember struct {
+	Name  string // member name, e.g. InProgress
+	Value string // the enum value, e.g. in-progress
+}
+
+// enumStyle returns how enum DTOs are declared: zodEnum, nativeEnum or
+// constObject
+func (g *ZodGenerator) enumStyle() string {
+	if g.customTypes == nil || g.customTypes.GetGenerationConfig().EnumStyle == "" {
+		return "zodEnum"
+	}
+	return g.customTypes.GetGenerationConfig().EnumStyle
+}
+
+// enumMembers names the members of an enum after their values, e.g.
+// "in-progress" becomes InProgress
+func (g *ZodGenerator) enumMembers(values []string) []EnumMember {
+	taken := make(map[string]bool)
+	members := make([]EnumMember, len(values))
+	for i, value := range values {
+		var name strings.Builder
+		for _, word := range strings.FieldsFunc(value, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			name.WriteString(g.toPascalCase(word))
+		}
+		base := name.String()
+		if base == "" {
+			base = "Empty"
+		} else if unicode.IsDigit([]rune(base)[0]) {
+			base = "_" + base
+		}
+		unique := base
+		for n := 2; taken[unique]; n++ {
+			unique = fmt.Sprintf("%s%d", base, n)
+		}
+		taken[unique] = true
+		members[i] = EnumMember{Name: unique, Value: value}
+	}
+	return members
+}
+
+// enumSchema validates the members of a TypeScript enum or const object
+func (g *ZodGenerator) enumSchema(name string) string {
+	if g.isZod4() {
+		return fmt.Sprintf("z.enum(%s)", name) // z.nativeEnum is deprecated in Zod 4
+	}
+	return fmt.Sprintf("z.nativeEnum(%s)", name)
+}
+
+// describe renders a description as a .describe() call when descriptions
+// are enabled and not already part of the OpenAPI metadata, or "" otherwise
+func (g *ZodGenerator) describe(description string) string {
+	lines := generator.DescriptionLines(description)
+	if !g.customTypes.GetGenerationConfig().Describe || len(lines) == 0 {
+		return ""
+	}
+	if g.customTypes.GetGenerationConfig().OpenAPIMetadata {
+		return "" // the OpenAPI metadata carries the description
+	}
+	literal := descriptionLiteral(lines)
+	if g.isMini() {
+		// Zod Mini has no .describe(); descriptions live in the global registry
+		return fmt.Sprintf(".register(z.globalRegistry, { description: %s })", literal)
+	}
+	return fmt.Sprintf(".describe(%s)", literal)
+}
+
+// openapi renders the OpenAPI metadata of a schema for zod-openapi when
+// enabled: its ref (for DTO schemas, named by ref), description and first
+// example. Zod 3 schemas get .openapi(); Zod 4 ones carry it in the global
+// registry with .meta(), where the ref is called id.
+func (g *ZodGenerator) openapi(ref, description string, examples []any) string {
+	if !g.customTypes.GetGenerationConfig().OpenAPIMetadata {
+		return ""
+	}
+
+	var fields []string
+	if ref != "" {
+		key := "ref"
+		if g.isZod4() {
+			key = "id"
+		}
+		fields = append(fields, fmt.Sprintf("%s: '%s'", key, ref))
+	}
+	if lines := generator.DescriptionLines(description); len(lines) > 0 {
+		fields = append(fields, "description: "+descriptionLiteral(lines))
+	}
+	if len(examples) > 0 {
+		fields = append(fields, "example: "+generator.ValueLiteral(examples[0]))
+	}
+	if len(fields) == 0 {
+		return ""
+	}
+
+	metadata := fmt.Sprintf("{ %s }", strings.Join(fields, ", "))
+	switch {
+	case g.isMini():
+		return fmt.Sprintf(".register(z.globalRegistry, %s)", metadata)
+	case g.isZod4():
+		return fmt.Sprintf(".meta(%s)", metadata)
+	}
+	return fmt.Sprintf(".openapi(%s)", metadata)
+}
+
+// descriptionLiteral renders description lines as one string literal
+func descriptionLiteral(lines []string) string {
+	return generator.NewLiteralType(strings.Join(lines, "\n")).Literal()
+}
+
+// propComment renders a property's comment: a line comment for a one-line
+// description, a JSDoc block for anything longer or carrying tags
+func (g *ZodGenerator) propComment(prop generator.Property) string {
+	tags := g.docTags(prop.Metadata, prop.Deprecated)
+	if lines := generator.DescriptionLines(prop.Description); len(lines) == 1 && len(tags) == 0 {
+		return "  // " + strings.TrimSpace(lines[0]) + "\n"
+	}
+	return generator.DocComment("  ", prop.Description, tags...)
+}
+
+// docTags returns the JSDoc tags implied by metadata and deprecation
+func (g *ZodGenerator) docTags(metadata generator.Metadata, deprecated bool) []string {
+	var tags []string
+	if parents := metadata.String("extends"); parents != "" {
+		for _, parent := range strings.Split(parents, ", ") {
+			tags = append(tags, "@extends "+parent)
+		}
+	}
+	if deprecated {
+		tags = append(tags, "@deprecated")
+	}
+	return tags
+}
+
+// getExt returns a vendor extension value from metadata as text, or "" if
+// absent. The name may be given with or without its x- prefix.
+func (g *ZodGenerator) getExt(metadata generator.Metadata, name string) string {
+	if !strings.HasPrefix(name, "x-") {
+		name = "x-" + name
+	}
+	return metadata.String(name)
+}
+
 // calculateImports determines what needs to be imported for a DTO using custom types
 func (g *ZodGenerator) calculateImports(dto generator.DTO) []string {
 	// Get all formats used in this DTO
 	usedFormats := g.getUsedFormatsInDTO(dto)
 
 	// Use the custom type registry to get the appropriate imports
-	return g.customTypes.GetAllImports(usedFormats)
+	imports := g.customTypes.GetAllImports(usedFormats)
+	for i, statement := range imports {
+		imports[i] = generator.RebaseImport(statement, g.groups[dto.Name])
+	}
+
+	// Import the schemas of referenced DTOs from their own files; recursive
+	// DTOs also need the types for their explicit type declaration
+	for _, dep := range generator.Dependencies(dto) {
+		if dep == dto.Name {
+			continue
+		}
+		names := fmt.Sprintf("%sSchema", dep)
+		if g.recursive[dto.Name] || g.defaultsToEnumMember(dto, dep) {
+			names = fmt.Sprintf("%s, %sSchema", dep, dep)
+		}
+		path := generator.RelativeModule(g.groups[dto.Name], g.groups[dep], g.toKebabCase(dep))
+		imports = append(imports, fmt.Sprintf("import { %s } from '%s';", names, path))
+	}
+
+	return imports
+}
+
+// warnUnmappedFormats reports string formats that have no mapping and are
+// generated as plain strings
+func (g *ZodGenerator) warnUnmappedFormats(dtos []generator.DTO, warnings *generator.WarningCollector) {
+	for _, dto := range dtos {
+		for _, prop := range dto.Properties {
+			for _, format := range generator.StringFormats(prop.Type) {
+				if _, exists := g.customTypes.Get(format); exists || format == "password" {
+					continue
+				}
+				warnings.Add(generator.Warning{
+					Schema:   dto.Name,
+					Property: prop.Name,
+					Code:     "unknown-format",
+					Message:  fmt.Sprintf("format '%s' has no mapping and is generated as a plain string", format),
+				})
+			}
+		}
+	}
 }
 
-// getUsedFormatsInDTO finds all formats used in a single DTO
+// getUsedFormatsInDTO finds all formats used in a single DTO, including array and tuple elements
 func (g *ZodGenerator) getUsedFormatsInDTO(dto generator.DTO) []string {
 	formatSet := make(map[string]bool)
 	var formats []string
 
-	for _, prop := range dto.Properties {
-		if prim, ok := prop.Type.(generator.PrimitiveType); ok {
-			if prim.Format != "" && !formatSet[prim.Format] {
-				formats = append(formats, prim.Format)
-				formatSet[prim.Format] = true
+	var visit func(irType generator.IRType)
+	visit = func(irType generator.IRType) {
+		switch t := irType.(type) {
+		case generator.PrimitiveType:
+			if t.Format != "" && !formatSet[t.Format] {
+				formats = append(formats, t.Format)
+				formatSet[t.Format] = true
+			}
+		case generator.ArrayType:
+			visit(t.ElementType)
+		case generator.TupleType:
+			for _, elem := range t.ElementTypes {
+				visit(elem)
+			}
+			if t.Rest != nil {
+				visit(t.Rest)
 			}
+		case generator.UnionType:
+			for _, member := range t.Types {
+				visit(member)
+			}
+		case generator.MapType:
+			visit(t.KeyType)
+			visit(t.ValueType)
 		}
 	}
 
+	for _, irType := range dto.IRTypes() {
+		visit(irType)
+	}
+
 	return formats
 }
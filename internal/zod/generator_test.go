@@ -1,10 +1,13 @@
 package zod
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"text/template"
 
 	"dtoForge/internal/generator"
 	"dtoForge/internal/testutils"
@@ -98,6 +101,62 @@ func TestZodGenerator_ToZodType(t *testing.T) {
 			optional: false,
 			expected: "z.array(z.string())",
 		},
+		{
+			name:     "Nested array of numbers",
+			irType:   generator.ArrayType{ElementType: generator.ArrayType{ElementType: generator.PrimitiveType{Name: "number"}}},
+			nullable: false,
+			optional: true,
+			expected: "z.array(z.array(z.number())).optional()",
+		},
+		{
+			name:     "Tuple type",
+			irType:   generator.TupleType{ElementTypes: []generator.IRType{generator.PrimitiveType{Name: "string"}, generator.ReferenceType{RefName: "User"}}},
+			nullable: false,
+			optional: true,
+			expected: "z.tuple([z.string(), UserSchema]).optional()",
+		},
+		{
+			name:     "Tuple with rest",
+			irType:   generator.TupleType{ElementTypes: []generator.IRType{generator.PrimitiveType{Name: "string"}}, Rest: generator.ReferenceType{RefName: "User"}},
+			nullable: false,
+			optional: false,
+			expected: "z.tuple([z.string()]).rest(UserSchema)",
+		},
+		{
+			name:     "Literal type",
+			irType:   generator.NewLiteralType(false),
+			nullable: false,
+			optional: false,
+			expected: "z.literal(false)",
+		},
+		{
+			name:     "Map type",
+			irType:   generator.MapType{KeyType: generator.PrimitiveType{Name: "string"}, ValueType: generator.ReferenceType{RefName: "Tag"}},
+			nullable: false,
+			optional: true,
+			expected: "z.record(z.string(), TagSchema).optional()",
+		},
+		{
+			name:     "Inline object type",
+			irType:   generator.ObjectType{Inline: true},
+			nullable: false,
+			optional: false,
+			expected: "z.record(z.string(), z.unknown())",
+		},
+		{
+			name:     "Union type",
+			irType:   generator.UnionType{Types: []generator.IRType{generator.ReferenceType{RefName: "Cat"}, generator.PrimitiveType{Name: "string"}}},
+			nullable: true,
+			optional: false,
+			expected: "z.union([CatSchema, z.string()]).nullable()",
+		},
+		{
+			name:     "Nested union type",
+			irType:   generator.ArrayType{ElementType: generator.UnionType{Types: []generator.IRType{generator.ReferenceType{RefName: "Cat"}, generator.NewLiteralType(nil)}}},
+			nullable: false,
+			optional: true,
+			expected: "z.array(z.union([CatSchema, z.null()])).optional()",
+		},
 		{
 			name:     "Reference type",
 			irType:   generator.ReferenceType{RefName: "User"},
@@ -105,6 +164,13 @@ func TestZodGenerator_ToZodType(t *testing.T) {
 			optional: false,
 			expected: "UserSchema",
 		},
+		{
+			name:     "Nullable reference type",
+			irType:   generator.ReferenceType{RefName: "User"},
+			nullable: true,
+			optional: true,
+			expected: "UserSchema.nullable().optional()",
+		},
 		{
 			name:     "Enum type",
 			irType:   generator.EnumType{Values: []string{"active", "inactive"}},
@@ -124,6 +190,70 @@ func TestZodGenerator_ToZodType(t *testing.T) {
 	}
 }
 
+func TestZodGenerator_Constraints(t *testing.T) {
+	gen := NewZodGenerator()
+	gen.customTypes = NewCustomTypeRegistry()
+	gen.customTypes.Register("phone", CustomTypeMapping{ZodType: "PhoneSchema", TypeScriptType: "Phone"})
+
+	intPtr := func(v int) *int { return &v }
+	floatPtr := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name     string
+		irType   generator.IRType
+		expected string
+	}{
+		{
+			name:     "String length and pattern",
+			irType:   generator.PrimitiveType{Name: "string", Constraints: &generator.Constraints{MinLength: intPtr(1), MaxLength: intPtr(50), Pattern: "^[a-z]+$"}},
+			expected: "z.string().min(1).max(50).regex(new RegExp('^[a-z]+$'))",
+		},
+		{
+			name:     "Exact string length",
+			irType:   generator.PrimitiveType{Name: "string", Constraints: &generator.Constraints{MinLength: intPtr(3), MaxLength: intPtr(3)}},
+			expected: "z.string().length(3)",
+		},
+		{
+			name:     "Built-in format",
+			irType:   generator.PrimitiveType{Name: "string", Format: "email", Constraints: &generator.Constraints{MaxLength: intPtr(100)}},
+			expected: "z.string().email().max(100)",
+		},
+		{
+			name:     "Custom format",
+			irType:   generator.PrimitiveType{Name: "string", Format: "phone", Constraints: &generator.Constraints{MaxLength: intPtr(20)}},
+			expected: "PhoneSchema",
+		},
+		{
+			name:     "Integer bounds",
+			irType:   generator.PrimitiveType{Name: "integer", Constraints: &generator.Constraints{Minimum: floatPtr(0), Maximum: floatPtr(150)}},
+			expected: "z.number().int().min(0).max(150)",
+		},
+		{
+			name:     "Exclusive bounds and multipleOf",
+			irType:   generator.PrimitiveType{Name: "number", Constraints: &generator.Constraints{ExclusiveMinimum: floatPtr(0), ExclusiveMaximum: floatPtr(1), MultipleOf: floatPtr(0.25)}},
+			expected: "z.number().gt(0).lt(1).multipleOf(0.25)",
+		},
+		{
+			name:     "BigInt bounds",
+			irType:   generator.PrimitiveType{Name: "bigint", Constraints: &generator.Constraints{Minimum: floatPtr(1), Maximum: floatPtr(2.5)}},
+			expected: "z.coerce.bigint().min(1n)",
+		},
+		{
+			name:     "Array items",
+			irType:   generator.ArrayType{ElementType: generator.PrimitiveType{Name: "string"}, Constraints: &generator.Constraints{MinItems: intPtr(1), MaxItems: intPtr(10)}},
+			expected: "z.array(z.string()).min(1).max(10)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gen.toZodType(tt.irType, false, false); got != tt.expected {
+				t.Errorf("toZodType() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestZodGenerator_PrimitiveToZod(t *testing.T) {
 	gen := NewZodGenerator()
 	gen.customTypes = NewCustomTypeRegistry()
@@ -138,7 +268,8 @@ func TestZodGenerator_PrimitiveToZod(t *testing.T) {
 		{"String with uuid", generator.PrimitiveType{Name: "string", Format: "uuid"}, "z.string().uuid()"},
 		{"String with date-time", generator.PrimitiveType{Name: "string", Format: "date-time"}, "z.string().datetime()"},
 		{"Number", generator.PrimitiveType{Name: "number"}, "z.number()"},
-		{"Integer", generator.PrimitiveType{Name: "integer"}, "z.number()"},
+		{"Integer", generator.PrimitiveType{Name: "integer"}, "z.number().int()"},
+		{"BigInt", generator.PrimitiveType{Name: "bigint", Format: "int64"}, "z.coerce.bigint()"},
 		{"Boolean", generator.PrimitiveType{Name: "boolean"}, "z.boolean()"},
 		{"Unknown", generator.PrimitiveType{Name: "unknown"}, "z.unknown()"},
 	}
@@ -153,6 +284,202 @@ func TestZodGenerator_PrimitiveToZod(t *testing.T) {
 	}
 }
 
+func TestZodGenerator_Defaults(t *testing.T) {
+	gen := NewZodGenerator()
+	tempDir := testutils.TempDir(t)
+
+	dto := testutils.CreateTestDTO("Settings")
+	dto.Properties[1].Default = "anonymous"
+	dto.Properties = append(dto.Properties, generator.Property{
+		Name:    "limits",
+		Type:    generator.ArrayType{ElementType: generator.PrimitiveType{Name: "number"}},
+		Default: []interface{}{1, 2},
+	})
+
+	config := generator.Config{
+		OutputFolder:   tempDir,
+		TargetLanguage: "typescript-zod",
+	}
+	if err := gen.Generate([]generator.DTO{dto}, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	settingsFile := filepath.Join(tempDir, "settings.ts")
+	testutils.AssertFileContains(t, settingsFile, "  // Name\n  name: z.string().optional().default('anonymous'),")
+	testutils.AssertFileContains(t, settingsFile, "  limits: z.array(z.number()).optional().default([1,2]),")
+	testutils.AssertFileContains(t, settingsFile, "export type SettingsInput = z.input<typeof SettingsSchema>;\nexport type Settings = z.output<typeof SettingsSchema>;")
+}
+
+func TestZodGenerator_InputTypes(t *testing.T) {
+	tempDir := testutils.TempDir(t)
+	configPath := testutils.WriteFile(t, tempDir, "dtoforge.config.yaml", "typescript-zod:\n  output:\n    mode: single\n")
+
+	settings := generator.DTO{Name: "Settings", Type: "object", Properties: []generator.Property{
+		{Name: "theme", Type: generator.PrimitiveType{Name: "string"}, Default: "light"},
+	}}
+	user := generator.DTO{Name: "User", Type: "object", Properties: []generator.Property{
+		{Name: "settings", Type: generator.ReferenceType{RefName: "Settings"}},
+	}}
+	tag := generator.DTO{Name: "Tag", Type: "object", Properties: []generator.Property{
+		{Name: "name", Type: generator.PrimitiveType{Name: "string"}, Required: true},
+	}}
+
+	gen := NewZodGenerator()
+	config := generator.Config{OutputFolder: tempDir, ConfigFile: configPath}
+	if err := gen.Generate([]generator.DTO{user, settings, tag}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	schemasFile := filepath.Join(tempDir, "schemas.ts")
+	testutils.AssertFileContains(t, schemasFile, "export type SettingsInput = z.input<typeof SettingsSchema>;\nexport type Settings = z.output<typeof SettingsSchema>;")
+	testutils.AssertFileContains(t, schemasFile, "export type UserInput = z.input<typeof UserSchema>;\nexport type User = z.output<typeof UserSchema>;")
+	testutils.AssertFileContains(t, schemasFile, "export type Tag = z.infer<typeof TagSchema>;")
+	testutils.AssertFileNotContains(t, schemasFile, "TagInput")
+}
+
+func TestZodGenerator_MultiLineDescriptions(t *testing.T) {
+	gen := NewZodGenerator()
+	tempDir := testutils.TempDir(t)
+
+	dto := testutils.CreateTestDTO("Invoice")
+	dto.Description = "An invoice.\n\nStatuses:\n- draft\n- paid"
+	dto.Properties[0].Description = "Opaque id, never ends with */"
+	dto.Properties[1].Description = "Display name.\nShown on the PDF."
+
+	config := generator.Config{
+		OutputFolder:   tempDir,
+		PackageName:    "description-test",
+		TargetLanguage: "typescript-zod",
+	}
+
+	if err := gen.Generate([]generator.DTO{dto}, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	invoiceFile := filepath.Join(tempDir, "invoice.ts")
+	testutils.AssertFileContains(t, invoiceFile, "/**\n * An invoice.\n *\n * Statuses:\n * - draft\n * - paid\n */")
+	testutils.AssertFileContains(t, invoiceFile, "  // Opaque id, never ends with */\n  id:")
+	testutils.AssertFileContains(t, invoiceFile, "  /**\n   * Display name.\n   * Shown on the PDF.\n   */\n  name:")
+}
+
+func TestZodGenerator_Inheritance(t *testing.T) {
+	gen := NewZodGenerator()
+	tempDir := testutils.TempDir(t)
+
+	entity := testutils.CreateTestDTO("Entity")
+	pet := generator.DTO{
+		Name:    "Pet",
+		Type:    "object",
+		Extends: []string{"Entity"},
+		Properties: []generator.Property{
+			{Name: "tag", Type: generator.PrimitiveType{Name: "string"}, Required: true},
+		},
+	}
+	flattened := testutils.CreateTestDTO("Cat")
+	flattened.Metadata["extends"] = "Entity"
+
+	config := generator.Config{
+		OutputFolder:   tempDir,
+		PackageName:    "inheritance-test",
+		TargetLanguage: "typescript-zod",
+	}
+
+	if err := gen.Generate([]generator.DTO{entity, pet, flattened}, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	petFile := filepath.Join(tempDir, "pet.ts")
+	testutils.AssertFileContains(t, petFile, "export const PetSchema = EntitySchema.extend({")
+	testutils.AssertFileContains(t, petFile, "import { EntitySchema } from './entity';")
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "cat.ts"), " * @extends Entity\n")
+}
+
+func TestZodGenerator_ObjectOpen(t *testing.T) {
+	gen := NewZodGenerator()
+	gen.recursive = map[string]bool{"Node": true}
+
+	tests := []struct {
+		name     string
+		bases    []string
+		expected string
+		closing  string
+	}{
+		{"No parents", nil, "z.object(", ")"},
+		{"Single parent", []string{"Entity"}, "EntitySchema.extend(", ")"},
+		{"Several parents", []string{"Entity", "Audit"}, "EntitySchema.merge(AuditSchema).extend(", ")"},
+		{"Recursive parent", []string{"Node", "Entity"}, "z.lazy(() => NodeSchema).and(EntitySchema).and(z.object(", "))"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gen.objectOpen(tt.bases); got != tt.expected {
+				t.Errorf("objectOpen() = %v, want %v", got, tt.expected)
+			}
+			if got := gen.objectClose(tt.bases); got != tt.closing {
+				t.Errorf("objectClose() = %v, want %v", got, tt.closing)
+			}
+		})
+	}
+}
+
+func TestZodGenerator_UnknownFormatWarnings(t *testing.T) {
+	gen := NewZodGenerator()
+	tempDir := testutils.TempDir(t)
+
+	dto := testutils.CreateTestDTO("Host")
+	dto.Properties[1].Type = generator.PrimitiveType{Name: "string", Format: "hostname"}
+	dto.Properties = append(dto.Properties,
+		generator.Property{Name: "secret", Type: generator.PrimitiveType{Name: "string", Format: "password"}},
+		generator.Property{Name: "size", Type: generator.PrimitiveType{Name: "integer", Format: "int32"}},
+	)
+
+	warnings := &generator.WarningCollector{}
+	config := generator.Config{
+		OutputFolder:   tempDir,
+		PackageName:    "warnings-test",
+		TargetLanguage: "typescript-zod",
+		Warnings:       warnings,
+	}
+
+	if err := gen.Generate([]generator.DTO{dto}, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	got := warnings.Warnings()
+	if len(got) != 1 || got[0].Code != "unknown-format" || got[0].Location() != "Host.name" {
+		t.Errorf("Unexpected warnings: %v", got)
+	}
+}
+
+func TestZodGenerator_BinaryFormat(t *testing.T) {
+	tests := []struct {
+		target   string
+		expected string
+	}{
+		{"browser", "z.instanceof(Blob)"},
+		{"node", "z.instanceof(Buffer)"},
+		{"universal", "z.custom<Blob | Buffer>((value) => (typeof Blob !== 'undefined' && value instanceof Blob) || (typeof Buffer !== 'undefined' && value instanceof Buffer))"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			gen := NewZodGenerator()
+			gen.customTypes = NewCustomTypeRegistry()
+			tempDir := testutils.TempDir(t)
+
+			configPath := testutils.WriteFile(t, tempDir, "config.yaml", "typescript-zod:\n  generation:\n    binaryTarget: "+tt.target)
+			if err := gen.customTypes.LoadFromConfig(configPath); err != nil {
+				t.Fatalf("LoadFromConfig failed: %v", err)
+			}
+
+			got := gen.toZodType(generator.PrimitiveType{Name: "binary", Format: "binary"}, false, false)
+			if got != tt.expected {
+				t.Errorf("toZodType() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestZodGenerator_StringWithFormat(t *testing.T) {
 	gen := NewZodGenerator()
 	gen.customTypes = NewCustomTypeRegistry()
@@ -168,8 +495,9 @@ func TestZodGenerator_StringWithFormat(t *testing.T) {
 		{"URL format", "url", "z.string().url()"},
 		{"Date-time format", "date-time", "z.string().datetime()"},
 		{"Date format", "date", "z.string().date()"},
+		{"Byte format", "byte", "z.string().regex(/^(?:[A-Za-z0-9+/]{4})*(?:[A-Za-z0-9+/]{2}==|[A-Za-z0-9+/]{3}=)?$/, 'Invalid base64 string')"},
 		{"No format", "", "z.string()"},
-		{"Unknown format", "custom-format", "z.string() /* format: custom-format */"},
+		{"Unknown format", "custom-format", "z.string()"},
 	}
 
 	for _, tt := range tests {
@@ -209,6 +537,38 @@ func TestZodGenerator_UtilityFunctions(t *testing.T) {
 	}
 }
 
+func TestZodGenerator_GetExt(t *testing.T) {
+	gen := NewZodGenerator()
+	metadata := generator.Metadata{"x-internal": true, "x-owner": map[string]interface{}{"team": "billing"}}
+
+	tests := []struct {
+		name     string
+		ext      string
+		expected string
+	}{
+		{"Full name", "x-internal", "true"},
+		{"Without prefix", "owner", `{"team":"billing"}`},
+		{"Missing", "x-sensitive", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gen.getExt(metadata, tt.ext); got != tt.expected {
+				t.Errorf("getExt() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+
+	tmpl := template.Must(template.New("ext").Funcs(gen.templateFuncs()).Parse(`{{if eq (getExt . "internal") "true"}}internal{{end}}`))
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, metadata); err != nil {
+		t.Fatalf("Execute() failed: %v", err)
+	}
+	if buf.String() != "internal" {
+		t.Errorf("template output = %q, want %q", buf.String(), "internal")
+	}
+}
+
 func TestZodGenerator_HasDescription(t *testing.T) {
 	gen := NewZodGenerator()
 
@@ -429,3 +789,825 @@ func TestZodGenerator_CustomTypes(t *testing.T) {
 		t.Errorf("Should have EmailSchema import, got content:\n%s", content)
 	}
 }
+
+func TestZodGenerator_Deprecated(t *testing.T) {
+	gen := NewZodGenerator()
+	tempDir := testutils.TempDir(t)
+
+	dto := testutils.CreateTestDTO("LegacyUser")
+	dto.Deprecated = true
+	dto.Properties[1].Deprecated = true
+
+	config := generator.Config{
+		OutputFolder:   tempDir,
+		PackageName:    "deprecated-test",
+		TargetLanguage: "typescript-zod",
+	}
+
+	if err := gen.Generate([]generator.DTO{dto}, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	userFile := filepath.Join(tempDir, "legacy-user.ts")
+	testutils.AssertFileContains(t, userFile, "/**\n * Test DTO\n * @deprecated\n */")
+	testutils.AssertFileContains(t, userFile, "  /**\n   * Name\n   * @deprecated\n   */\n  name:")
+}
+
+func TestZodGenerator_UnionDTO(t *testing.T) {
+	gen := NewZodGenerator()
+	tempDir := testutils.TempDir(t)
+
+	dtos := []generator.DTO{
+		testutils.CreateTestDTO("Cat"),
+		{
+			Name:         "Pet",
+			Type:         "union",
+			UnionMembers: []generator.IRType{generator.ReferenceType{RefName: "Cat"}, generator.PrimitiveType{Name: "string"}},
+		},
+		{
+			Name: "Owner",
+			Type: "object",
+			Properties: []generator.Property{{
+				Name:     "pet",
+				Type:     generator.UnionType{Types: []generator.IRType{generator.ReferenceType{RefName: "Cat"}, generator.PrimitiveType{Name: "string"}}},
+				Nullable: true,
+			}},
+		},
+	}
+
+	config := generator.Config{
+		OutputFolder:   tempDir,
+		TargetLanguage: "typescript-zod",
+	}
+	if err := gen.Generate(dtos, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	petFile := filepath.Join(tempDir, "pet.ts")
+	testutils.AssertFileContains(t, petFile, "import { CatSchema } from './cat';")
+	testutils.AssertFileContains(t, petFile, "export const PetSchema = z.union([CatSchema, z.string()]);")
+	testutils.AssertFileContains(t, petFile, "export type Pet = z.infer<typeof PetSchema>;")
+
+	// Members of union properties are imported like any other reference
+	ownerFile := filepath.Join(tempDir, "owner.ts")
+	testutils.AssertFileContains(t, ownerFile, "import { CatSchema } from './cat';")
+	testutils.AssertFileContains(t, ownerFile, "  pet: z.union([CatSchema, z.string()]).nullable().optional(),")
+}
+
+func TestZodGenerator_SourceComments(t *testing.T) {
+	gen := NewZodGenerator()
+	tempDir := testutils.TempDir(t)
+
+	configPath := testutils.WriteFile(t, tempDir, "config.yaml", `typescript-zod:
+  generation:
+    sourceComments: true`)
+
+	dto := testutils.CreateTestDTO("Pet")
+	dto.Source = &generator.SourceLocation{File: "api.yaml", Line: 12, Column: 5}
+
+	config := generator.Config{
+		OutputFolder:   tempDir,
+		TargetLanguage: "typescript-zod",
+		ConfigFile:     configPath,
+	}
+	if err := gen.Generate([]generator.DTO{dto}, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "pet.ts"), "/**\n * Test DTO\n * @see api.yaml:12:5\n */")
+}
+
+func TestZodGenerator_NotConstraintComment(t *testing.T) {
+	gen := NewZodGenerator()
+	tempDir := testutils.TempDir(t)
+
+	dto := testutils.CreateTestDTO("Pet")
+	dto.Properties[1].Metadata = generator.Metadata{"not": map[string]interface{}{"enum": []interface{}{"unknown"}}}
+
+	config := generator.Config{
+		OutputFolder:   tempDir,
+		PackageName:    "not-test",
+		TargetLanguage: "typescript-zod",
+	}
+
+	if err := gen.Generate([]generator.DTO{dto}, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "pet.ts"),
+		"  // 'not' constraint is not enforced: {\"enum\":[\"unknown\"]}\n  name: z.string().optional(),")
+}
+
+func TestZodGenerator_RecursiveSchemas(t *testing.T) {
+	gen := NewZodGenerator()
+	tempDir := testutils.TempDir(t)
+
+	dtos := []generator.DTO{
+		{
+			Name: "Order",
+			Type: "object",
+			Properties: []generator.Property{
+				{Name: "user", Type: generator.ReferenceType{RefName: "User"}, Nullable: true},
+			},
+		},
+		{
+			Name:     "User",
+			Type:     "object",
+			Required: []string{"orders"},
+			Properties: []generator.Property{
+				{Name: "orders", Type: generator.ArrayType{ElementType: generator.ReferenceType{RefName: "Order"}}, Required: true},
+				{Name: "role", Type: generator.PrimitiveType{Name: "string"}, Required: true, Default: "member"},
+			},
+		},
+	}
+
+	config := generator.Config{
+		OutputFolder:   tempDir,
+		PackageName:    "recursive-test",
+		TargetLanguage: "typescript-zod",
+	}
+
+	if err := gen.Generate(dtos, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	orderFile := filepath.Join(tempDir, "order.ts")
+	testutils.AssertFileContains(t, orderFile, "import { User, UserSchema } from './user';")
+	testutils.AssertFileContains(t, orderFile, "export type Order = {\n  user?: User | null;\n};")
+	testutils.AssertFileContains(t, orderFile, "export const OrderSchema: z.ZodType<Order> = z.object({")
+	testutils.AssertFileContains(t, orderFile, "user: z.lazy(() => UserSchema).nullable().optional(),")
+	testutils.AssertFileNotContains(t, orderFile, "z.infer<typeof OrderSchema>")
+
+	userFile := filepath.Join(tempDir, "user.ts")
+	testutils.AssertFileContains(t, userFile, "orders: z.array(z.lazy(() => OrderSchema)),")
+	// A default makes the property optional on input, which the annotation must allow
+	testutils.AssertFileContains(t, userFile, "export type User = {\n  orders: Order[];\n  role?: string;\n};")
+	testutils.AssertFileContains(t, userFile, "role: z.string().default('member'),")
+}
+
+func TestZodGenerator_Pragmas(t *testing.T) {
+	gen := NewZodGenerator()
+	tempDir := testutils.TempDir(t)
+	configPath := testutils.WriteFile(t, tempDir, "config.yaml", "typescript-zod:\n  output:\n    pragmas: ['/* eslint-disable */', '// @ts-nocheck']\n  generation:\n    generatePackageJson: true\n")
+
+	config := generator.Config{OutputFolder: tempDir, ConfigFile: configPath}
+	if err := gen.Generate([]generator.DTO{testutils.CreateTestDTO("User")}, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	for _, name := range []string{"user.ts", "index.ts"} {
+		content := testutils.ReadFile(t, filepath.Join(tempDir, name))
+		if !strings.HasPrefix(content, "/* eslint-disable */\n// @ts-nocheck\n// Generated by DtoForge (Zod)") {
+			t.Errorf("%s does not start with the pragmas:\n%s", name, content)
+		}
+	}
+	testutils.AssertFileNotContains(t, filepath.Join(tempDir, "package.json"), "eslint-disable")
+
+	invalidPath := testutils.WriteFile(t, tempDir, "invalid.yaml", "typescript-zod:\n  output:\n    pragmas: ['export {};']\n")
+	err := gen.Generate(nil, generator.Config{OutputFolder: tempDir, ConfigFile: invalidPath})
+	if err == nil || !strings.Contains(err.Error(), "invalid pragma 'export {};'") {
+		t.Errorf("Generate() error = %v, want invalid pragma", err)
+	}
+}
+
+func TestZodGenerator_Parallel(t *testing.T) {
+	var dtos []generator.DTO
+	for i := range 30 {
+		dto := testutils.CreateTestDTO(fmt.Sprintf("Item%d", i))
+		if i > 0 {
+			dto.Properties = append(dto.Properties, generator.Property{Name: "previous", Type: generator.ReferenceType{RefName: fmt.Sprintf("Item%d", i-1)}})
+		}
+		dtos = append(dtos, dto)
+	}
+
+	sequential := testutils.TempDir(t)
+	parallel := testutils.TempDir(t)
+	if err := NewZodGenerator().Generate(dtos, generator.Config{OutputFolder: sequential, TargetLanguage: "typescript-zod"}); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	if err := NewZodGenerator().Generate(dtos, generator.Config{OutputFolder: parallel, TargetLanguage: "typescript-zod", Parallel: 8}); err != nil {
+		t.Fatalf("Generate() with Parallel failed: %v", err)
+	}
+
+	files, err := os.ReadDir(sequential)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		want := testutils.ReadFile(t, filepath.Join(sequential, file.Name()))
+		if got := testutils.ReadFile(t, filepath.Join(parallel, file.Name())); got != want {
+			t.Errorf("%s generated in parallel differs:\n%s\nwant:\n%s", file.Name(), got, want)
+		}
+	}
+}
+
+func TestZodGenerator_StrictTypes(t *testing.T) {
+	gen := NewZodGenerator()
+	tempDir := testutils.TempDir(t)
+	configPath := testutils.WriteFile(t, tempDir, "config.yaml", "typescript-zod:\n  generation:\n    strictTypes: true\n")
+
+	node := generator.DTO{Name: "Node", Type: "object", Properties: []generator.Property{
+		{Name: "id", Type: generator.PrimitiveType{Name: "string"}, Required: true},
+		{Name: "label", Type: generator.PrimitiveType{Name: "string"}, Default: "root"},
+		{Name: "parent", Type: generator.ReferenceType{RefName: "Node"}, Nullable: true},
+	}}
+
+	config := generator.Config{OutputFolder: tempDir, ConfigFile: configPath}
+	if err := gen.Generate([]generator.DTO{node}, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	// .optional() infers T | undefined, and .default() accepts it as input,
+	// which exactOptionalPropertyTypes requires the annotation to spell out
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "node.ts"), "export type Node = {\n  id: string;\n  label?: string | undefined;\n  parent?: Node | null | undefined;\n};")
+}
+
+func TestZodGenerator_SingleFileDependencyOrder(t *testing.T) {
+	gen := NewZodGenerator()
+	tempDir := testutils.TempDir(t)
+
+	configPath := testutils.WriteFile(t, tempDir, "config.yaml", `typescript-zod:
+  output:
+    mode: single
+  generation:
+    generatePackageJson: false`)
+
+	dtos := []generator.DTO{
+		{
+			Name: "Order",
+			Type: "object",
+			Properties: []generator.Property{
+				{Name: "user", Type: generator.ReferenceType{RefName: "User"}, Required: true},
+			},
+		},
+		testutils.CreateTestDTO("User"),
+	}
+
+	config := generator.Config{
+		OutputFolder:   tempDir,
+		PackageName:    "order-test",
+		TargetLanguage: "typescript-zod",
+		ConfigFile:     configPath,
+	}
+
+	if err := gen.Generate(dtos, config); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	content := testutils.ReadFile(t, filepath.Join(tempDir, "schemas.ts"))
+	userIndex := strings.Index(content, "export const UserSchema")
+	orderIndex := strings.Index(content, "export const OrderSchema")
+	if userIndex == -1 || orderIndex == -1 || userIndex > orderIndex {
+		t.Errorf("UserSchema must be declared before OrderSchema, got:\n%s", content)
+	}
+}
+
+func TestZodGenerator_Zod4(t *testing.T) {
+	gen := NewZod4Generator()
+	if got := gen.Language(); got != "typescript-zod4" {
+		t.Errorf("Language() = %v, want %v", got, "typescript-zod4")
+	}
+	gen.customTypes = NewCustomTypeRegistryForVersion(4)
+
+	tests := []struct {
+		name     string
+		irType   generator.IRType
+		expected string
+	}{
+		{"Email", generator.PrimitiveType{Name: "string", Format: "email"}, "z.email()"},
+		{"Date-time", generator.PrimitiveType{Name: "string", Format: "date-time"}, "z.iso.datetime()"},
+		{"Base64", generator.PrimitiveType{Name: "binary", Format: "byte"}, "z.base64()"},
+		{"Integer", generator.PrimitiveType{Name: "integer"}, "z.int()"},
+		{"Inline object", generator.ObjectType{Inline: true}, "z.record(z.string(), z.unknown())"},
+		{"Tuple with rest", generator.TupleType{
+			ElementTypes: []generator.IRType{generator.PrimitiveType{Name: "string"}},
+			Rest:         generator.PrimitiveType{Name: "number"},
+		}, "z.tuple([z.string()], z.number())"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gen.toZodType(tt.irType, false, false); got != tt.expected {
+				t.Errorf("toZodType() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+
+	if got := gen.objectOpen([]string{"Entity", "Audit"}); got != "EntitySchema.extend(AuditSchema.shape).extend(" {
+		t.Errorf("objectOpen() = %v, want EntitySchema.extend(AuditSchema.shape).extend(", got)
+	}
+}
+
+func TestZodGenerator_Zod4PackageJSON(t *testing.T) {
+	tempDir := testutils.TempDir(t)
+
+	gen := NewZod4Generator()
+	config := generator.Config{OutputFolder: tempDir}
+	if err := gen.Generate([]generator.DTO{testutils.CreateTestDTO("User")}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "package.json"), `"zod": "^4.0.0"`)
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "index.ts"), "path: PropertyKey[];")
+}
+
+func TestZodGenerator_DiscriminatedUnion(t *testing.T) {
+	gen := NewZodGenerator()
+	tempDir := testutils.TempDir(t)
+
+	kind := func(value string) generator.Property {
+		return generator.Property{Name: "kind", Type: generator.NewLiteralType(value), Required: true}
+	}
+	cat := generator.DTO{Name: "Cat", Type: "object", Properties: []generator.Property{kind("cat")}}
+	dog := generator.DTO{Name: "Dog", Type: "object", Properties: []generator.Property{kind("dog")}}
+	bird := generator.DTO{Name: "Bird", Type: "object", Properties: []generator.Property{
+		{Name: "kind", Type: generator.PrimitiveType{Name: "string"}, Required: true},
+	}}
+	pet := generator.DTO{Name: "Pet", Type: "union", Discriminator: "kind", UnionMembers: []generator.IRType{
+		generator.ReferenceType{RefName: "Cat"},
+		generator.ReferenceType{RefName: "Dog"},
+	}}
+	animal := generator.DTO{Name: "Animal", Type: "union", Discriminator: "kind", UnionMembers: []generator.IRType{
+		generator.ReferenceType{RefName: "Cat"},
+		generator.ReferenceType{RefName: "Bird"},
+	}}
+
+	config := generator.Config{OutputFolder: tempDir}
+	if err := gen.Generate([]generator.DTO{cat, dog, bird, pet, animal}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	petFile := filepath.Join(tempDir, "pet.ts")
+	testutils.AssertFileContains(t, petFile, "import { CatSchema } from './cat';\nimport { DogSchema } from './dog';")
+	testutils.AssertFileContains(t, petFile, "export const PetSchema = z.discriminatedUnion('kind', [CatSchema, DogSchema]);")
+
+	// A member without a literal discriminator falls back to z.union
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "animal.ts"), "export const AnimalSchema = z.union([CatSchema, BirdSchema]);")
+}
+
+func TestZodGenerator_Describe(t *testing.T) {
+	tempDir := testutils.TempDir(t)
+	configPath := testutils.WriteFile(t, tempDir, "dtoforge.config.yaml", "typescript-zod:\n  generation:\n    describe: true\n")
+
+	dto := testutils.CreateTestDTO("User")
+	dto.Description = "A user's account\nwith two lines"
+	status := generator.DTO{Name: "Status", Type: "enum", EnumValues: []string{"active"}, Description: "Account state"}
+
+	gen := NewZodGenerator()
+	config := generator.Config{OutputFolder: tempDir, ConfigFile: configPath}
+	if err := gen.Generate([]generator.DTO{dto, status}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	userFile := filepath.Join(tempDir, "user.ts")
+	testutils.AssertFileContains(t, userFile, "id: z.string().describe('Identifier'),")
+	testutils.AssertFileContains(t, userFile, "name: z.string().optional().describe('Name'),")
+	testutils.AssertFileContains(t, userFile, "}).describe('A user\\'s account\\nwith two lines');")
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "status.ts"), "]).describe('Account state');")
+}
+
+func TestZodGenerator_ObjectMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		gen     *ZodGenerator
+		mode    string
+		bases   []string
+		open    string
+		closing string
+	}{
+		{"Strip", NewZodGenerator(), "strip", nil, "z.object(", ")"},
+		{"Strict", NewZodGenerator(), "strict", nil, "z.object(", ").strict()"},
+		{"Passthrough with parent", NewZodGenerator(), "passthrough", []string{"Entity"}, "EntitySchema.extend(", ").passthrough()"},
+		{"Strict intersection", NewZodGenerator(), "strict", []string{"Node"}, "z.lazy(() => NodeSchema).and(z.object(", "))"},
+		{"Zod 4 strict", NewZod4Generator(), "strict", nil, "z.strictObject(", ")"},
+		{"Zod 4 passthrough", NewZod4Generator(), "passthrough", nil, "z.looseObject(", ")"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.gen.customTypes = NewCustomTypeRegistryForVersion(tt.gen.zodVersion)
+			tt.gen.customTypes.generation.ObjectMode = tt.mode
+			tt.gen.recursive = map[string]bool{"Node": true}
+			if got := tt.gen.objectOpen(tt.bases); got != tt.open {
+				t.Errorf("objectOpen() = %v, want %v", got, tt.open)
+			}
+			if got := tt.gen.objectClose(tt.bases); got != tt.closing {
+				t.Errorf("objectClose() = %v, want %v", got, tt.closing)
+			}
+		})
+	}
+}
+
+func TestZodGenerator_BrandedFormats(t *testing.T) {
+	tempDir := testutils.TempDir(t)
+	configPath := testutils.WriteFile(t, tempDir, "dtoforge.config.yaml", `typescript-zod:
+  generation:
+    brandedFormats: true
+  customTypes:
+    email:
+      zodType: z.string().email().toLowerCase()
+      typeScriptType: string
+`)
+
+	dto := generator.DTO{Name: "User", Type: "object", Properties: []generator.Property{
+		{Name: "id", Type: generator.PrimitiveType{Name: "string", Format: "uuid"}, Required: true},
+		{Name: "email", Type: generator.PrimitiveType{Name: "string", Format: "email"}, Required: true},
+	}}
+
+	gen := NewZodGenerator()
+	config := generator.Config{OutputFolder: tempDir, ConfigFile: configPath}
+	if err := gen.Generate([]generator.DTO{dto}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	brandsFile := filepath.Join(tempDir, "brands.ts")
+	testutils.AssertFileContains(t, brandsFile, "export const UUIDSchema = z.string().uuid().brand<'UUID'>();\nexport type UUID = z.infer<typeof UUIDSchema>;")
+	testutils.AssertFileContains(t, brandsFile, "export const DateTimeSchema = z.string().datetime().brand<'DateTime'>();")
+	testutils.AssertFileNotContains(t, brandsFile, "EmailSchema")
+
+	userFile := filepath.Join(tempDir, "user.ts")
+	testutils.AssertFileContains(t, userFile, "import { UUID, UUIDSchema } from './brands';")
+	testutils.AssertFileContains(t, userFile, "id: UUIDSchema,")
+	testutils.AssertFileContains(t, userFile, "email: z.string().email().toLowerCase(),")
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "index.ts"), "export * from './brands';")
+}
+
+func TestZodGenerator_BrandedFormatsSingleFile(t *testing.T) {
+	tempDir := testutils.TempDir(t)
+	configPath := testutils.WriteFile(t, tempDir, "dtoforge.config.yaml", "typescript-zod:\n  output:\n    mode: single\n  generation:\n    brandedFormats: true\n")
+
+	dto := generator.DTO{Name: "User", Type: "object", Properties: []generator.Property{
+		{Name: "id", Type: generator.PrimitiveType{Name: "string", Format: "uuid"}, Required: true},
+	}}
+
+	gen := NewZodGenerator()
+	config := generator.Config{OutputFolder: tempDir, ConfigFile: configPath}
+	if err := gen.Generate([]generator.DTO{dto}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	schemasFile := filepath.Join(tempDir, "schemas.ts")
+	testutils.AssertFileContains(t, schemasFile, "// Branded formats\nexport const UUIDSchema = z.string().uuid().brand<'UUID'>();")
+	testutils.AssertFileContains(t, schemasFile, "id: UUIDSchema,")
+	testutils.AssertFileNotContains(t, schemasFile, "from './brands'")
+}
+
+func TestZodGenerator_InputTypesForCoercion(t *testing.T) {
+	tempDir := testutils.TempDir(t)
+	configPath := testutils.WriteFile(t, tempDir, "dtoforge.config.yaml", "typescript-zod:\n  generation:\n    coerceDates: true\n")
+
+	event := generator.DTO{Name: "Event", Type: "object", Properties: []generator.Property{
+		{Name: "at", Type: generator.PrimitiveType{Name: "string", Format: "date-time"}, Required: true},
+	}}
+
+	gen := NewZodGenerator()
+	config := generator.Config{OutputFolder: tempDir, ConfigFile: configPath}
+	if err := gen.Generate([]generator.DTO{event}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	eventFile := filepath.Join(tempDir, "event.ts")
+	testutils.AssertFileContains(t, eventFile, "at: z.coerce.date(),")
+	testutils.AssertFileContains(t, eventFile, "export type EventInput = z.input<typeof EventSchema>;\nexport type Event = z.output<typeof EventSchema>;")
+}
+
+func TestZodGenerator_PartialSchemas(t *testing.T) {
+	tempDir := testutils.TempDir(t)
+	configPath := testutils.WriteFile(t, tempDir, "dtoforge.config.yaml", "typescript-zod:\n  generation:\n    generatePartialSchemas: true\n")
+
+	user := testutils.CreateTestDTO("User")
+	status := generator.DTO{Name: "Status", Type: "enum", EnumValues: []string{"active"}}
+	node := generator.DTO{Name: "Node", Type: "object", Properties: []generator.Property{
+		{Name: "children", Type: generator.ArrayType{ElementType: generator.ReferenceType{RefName: "Node"}}},
+	}}
+
+	gen := NewZodGenerator()
+	config := generator.Config{OutputFolder: tempDir, ConfigFile: configPath}
+	if err := gen.Generate([]generator.DTO{user, status, node}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	userFile := filepath.Join(tempDir, "user.ts")
+	testutils.AssertFileContains(t, userFile, "export const UserPartialSchema = UserSchema.partial();\nexport type UserPartial = z.infer<typeof UserPartialSchema>;")
+	testutils.AssertFileNotContains(t, filepath.Join(tempDir, "status.ts"), "Partial")
+	testutils.AssertFileNotContains(t, filepath.Join(tempDir, "node.ts"), "Partial")
+}
+
+func TestZodGenerator_DerivedSchemas(t *testing.T) {
+	tempDir := testutils.TempDir(t)
+	configPath := testutils.WriteFile(t, tempDir, "dtoforge.config.yaml", `typescript-zod:
+  derive:
+    UserCreate: {from: User, omit: [id]}
+    UserSummary: {from: User, pick: [id, name]}
+`)
+
+	gen := NewZodGenerator()
+	config := generator.Config{OutputFolder: tempDir, ConfigFile: configPath}
+	if err := gen.Generate([]generator.DTO{testutils.CreateTestDTO("User")}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	userFile := filepath.Join(tempDir, "user.ts")
+	testutils.AssertFileContains(t, userFile, "export const UserCreateSchema = UserSchema.omit({ id: true });\nexport type UserCreate = z.infer<typeof UserCreateSchema>;")
+	testutils.AssertFileContains(t, userFile, "export const UserSummarySchema = UserSchema.pick({ id: true, name: true });")
+
+	configPath = testutils.WriteFile(t, tempDir, "dtoforge.config.yaml", "typescript-zod:\n  derive:\n    UserCreate: {from: User, omit: [email]}\n")
+	config.ConfigFile = configPath
+	if err := gen.Generate([]generator.DTO{testutils.CreateTestDTO("User")}, config); err == nil {
+		t.Error("Expected an error for an unknown property")
+	}
+
+	configPath = testutils.WriteFile(t, tempDir, "dtoforge.config.yaml", "typescript-zod:\n  derive:\n    UserCreate: {from: Account, omit: [id]}\n")
+	config.ConfigFile = configPath
+	if err := gen.Generate([]generator.DTO{testutils.CreateTestDTO("User")}, config); err == nil {
+		t.Error("Expected an error for an unknown schema")
+	}
+}
+
+func TestZodGenerator_Readonly(t *testing.T) {
+	tempDir := testutils.TempDir(t)
+	configPath := testutils.WriteFile(t, tempDir, "dtoforge.config.yaml", "typescript-zod:\n  generation:\n    readonly: true\n    generatePartialSchemas: true\n")
+
+	kind := func(value string) generator.Property {
+		return generator.Property{Name: "kind", Type: generator.NewLiteralType(value), Required: true}
+	}
+	cat := generator.DTO{Name: "Cat", Type: "object", Properties: []generator.Property{
+		kind("cat"),
+		{Name: "toys", Type: generator.ArrayType{ElementType: generator.PrimitiveType{Name: "string"}}},
+	}}
+	lion := generator.DTO{Name: "Lion", Type: "object", Extends: []string{"Cat"}, Properties: []generator.Property{
+		{Name: "pride", Type: generator.PrimitiveType{Name: "string"}},
+	}}
+	dog := generator.DTO{Name: "Dog", Type: "object", Properties: []generator.Property{kind("dog")}}
+	pet := generator.DTO{Name: "Pet", Type: "union", Discriminator: "kind", UnionMembers: []generator.IRType{
+		generator.ReferenceType{RefName: "Cat"},
+		generator.ReferenceType{RefName: "Dog"},
+	}}
+	node := generator.DTO{Name: "Node", Type: "object", Properties: []generator.Property{
+		{Name: "children", Type: generator.ArrayType{ElementType: generator.ReferenceType{RefName: "Node"}}},
+	}}
+
+	gen := NewZodGenerator()
+	config := generator.Config{OutputFolder: tempDir, ConfigFile: configPath}
+	if err := gen.Generate([]generator.DTO{cat, lion, dog, pet, node}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	catFile := filepath.Join(tempDir, "cat.ts")
+	testutils.AssertFileContains(t, catFile, "  toys: z.array(z.string()).readonly().optional(),\n}).readonly();")
+	testutils.AssertFileContains(t, catFile, "export const CatPartialSchema = CatSchema.unwrap().partial().readonly();")
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "lion.ts"), "export const LionSchema = CatSchema.unwrap().extend({")
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "pet.ts"), "z.discriminatedUnion('kind', [CatSchema.unwrap(), DogSchema.unwrap()]).readonly();")
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "node.ts"), "export type Node = {\n  readonly children?: readonly Node[];\n};")
+}
+
+func TestZodGenerator_EnumStyle(t *testing.T) {
+	status := generator.DTO{Name: "Status", Type: "enum", EnumValues: []string{"active", "in-progress", "2fa"}}
+	user := generator.DTO{Name: "User", Type: "object", Properties: []generator.Property{
+		{Name: "status", Type: generator.ReferenceType{RefName: "Status"}, Default: "in-progress"},
+	}}
+
+	tests := []struct {
+		style    string
+		gen      *ZodGenerator
+		status   []string
+		property string
+	}{
+		{
+			style:    "nativeEnum",
+			gen:      NewZodGenerator(),
+			status:   []string{"export enum Status {\n  Active = 'active',\n  InProgress = 'in-progress',\n  _2fa = '2fa',\n}", "export const StatusSchema = z.nativeEnum(Status);"},
+			property: "status: StatusSchema.optional().default(Status.InProgress),",
+		},
+		{
+			style:    "nativeEnum",
+			gen:      NewZod4Generator(),
+			status:   []string{"export const StatusSchema = z.enum(Status);"},
+			property: "status: StatusSchema.optional().default(Status.InProgress),",
+		},
+		{
+			style:    "constObject",
+			gen:      NewZodGenerator(),
+			status:   []string{"export const Status = {\n  Active: 'active',\n  InProgress: 'in-progress',\n  _2fa: '2fa',\n} as const;", "export type Status = (typeof Status)[keyof typeof Status];"},
+			property: "status: StatusSchema.optional().default('in-progress'),",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.gen.Language()+"/"+tt.style, func(t *testing.T) {
+			tempDir := testutils.TempDir(t)
+			configPath := testutils.WriteFile(t, tempDir, "dtoforge.config.yaml", "typescript-zod:\n  generation:\n    enumStyle: "+tt.style+"\n")
+
+			config := generator.Config{OutputFolder: tempDir, ConfigFile: configPath}
+			if err := tt.gen.Generate([]generator.DTO{status, user}, config); err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+
+			statusFile := filepath.Join(tempDir, "status.ts")
+			for _, expected := range tt.status {
+				testutils.AssertFileContains(t, statusFile, expected)
+			}
+			testutils.AssertFileNotContains(t, statusFile, "z.infer")
+			testutils.AssertFileContains(t, filepath.Join(tempDir, "user.ts"), tt.property)
+			if strings.Contains(tt.property, "Status.") {
+				testutils.AssertFileContains(t, filepath.Join(tempDir, "user.ts"), "import { Status, StatusSchema } from './status';")
+			}
+		})
+	}
+}
+
+func TestZodGenerator_Nullish(t *testing.T) {
+	gen := NewZodGenerator()
+	gen.customTypes = NewCustomTypeRegistry()
+	gen.customTypes.generation.Nullish = true
+
+	str := generator.PrimitiveType{Name: "string"}
+	tests := []struct {
+		nullable bool
+		optional bool
+		expected string
+	}{
+		{true, true, "z.string().nullish()"},
+		{true, false, "z.string().nullable()"},
+		{false, true, "z.string().optional()"},
+	}
+	for _, tt := range tests {
+		if got := gen.toZodType(str, tt.nullable, tt.optional); got != tt.expected {
+			t.Errorf("toZodType(nullable=%v, optional=%v) = %v, want %v", tt.nullable, tt.optional, got, tt.expected)
+		}
+	}
+}
+
+func TestZodGenerator_JSONSchema(t *testing.T) {
+	tempDir := testutils.TempDir(t)
+	configPath := testutils.WriteFile(t, tempDir, "dtoforge.config.yaml", "typescript-zod:\n  generation:\n    generatePackageJson: true\n    generateJsonSchema: true\n")
+
+	gen := NewZodGenerator()
+	config := generator.Config{OutputFolder: tempDir, ConfigFile: configPath}
+	if err := gen.Generate([]generator.DTO{testutils.CreateTestDTO("User")}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	jsonSchemaFile := filepath.Join(tempDir, "json-schema.ts")
+	testutils.AssertFileContains(t, jsonSchemaFile, "import { zodToJsonSchema } from 'zod-to-json-schema';\nimport { UserSchema } from './user';")
+	testutils.AssertFileContains(t, jsonSchemaFile, "  User: zodToJsonSchema(UserSchema, 'User'),")
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "package.json"), `"zod-to-json-schema": "^3.23.0"`)
+
+	// Zod 4 converts schemas itself; single-file schemas come from one module
+	tempDir = testutils.TempDir(t)
+	configPath = testutils.WriteFile(t, tempDir, "dtoforge.config.yaml", "typescript-zod:\n  output:\n    mode: single\n  generation:\n    generatePackageJson: true\n    generateJsonSchema: true\n")
+	config = generator.Config{OutputFolder: tempDir, ConfigFile: configPath}
+	if err := NewZod4Generator().Generate([]generator.DTO{testutils.CreateTestDTO("User")}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	jsonSchemaFile = filepath.Join(tempDir, "json-schema.ts")
+	testutils.AssertFileContains(t, jsonSchemaFile, "import { z } from 'zod';\nimport { UserSchema } from './schemas';")
+	testutils.AssertFileContains(t, jsonSchemaFile, "  User: z.toJSONSchema(UserSchema, { unrepresentable: 'any' }),")
+	testutils.AssertFileNotContains(t, filepath.Join(tempDir, "package.json"), "zod-to-json-schema")
+}
+
+func TestZodGenerator_ParseHelpers(t *testing.T) {
+	tempDir := testutils.TempDir(t)
+
+	gen := NewZodGenerator()
+	config := generator.Config{OutputFolder: tempDir}
+	if err := gen.Generate([]generator.DTO{testutils.CreateTestDTO("User")}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	userFile := filepath.Join(tempDir, "user.ts")
+	testutils.AssertFileContains(t, userFile, "import { safeParse, type SafeParseResult } from './helpers';")
+	testutils.AssertFileContains(t, userFile, "export const parseUser = (data: unknown): User => UserSchema.parse(data);")
+	testutils.AssertFileContains(t, userFile, "export const safeParseUser = (data: unknown): SafeParseResult<User> => safeParse(UserSchema, data);")
+	helpersFile := filepath.Join(tempDir, "helpers.ts")
+	testutils.AssertFileContains(t, helpersFile, "export const safeParse = <T>(schema: z.ZodType<T, any, any>, data: unknown): SafeParseResult<T> => {")
+	testutils.AssertFileContains(t, helpersFile, "fieldErrors: Partial<Record<FieldName<T>, string[]>>;")
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "index.ts"), "export * from './helpers';")
+
+	// Helpers are opt-in once a config file is used
+	tempDir = testutils.TempDir(t)
+	configPath := testutils.WriteFile(t, tempDir, "dtoforge.config.yaml", "typescript-zod:\n  generation:\n    generatePackageJson: true\n")
+	config = generator.Config{OutputFolder: tempDir, ConfigFile: configPath}
+	if err := gen.Generate([]generator.DTO{testutils.CreateTestDTO("User")}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	testutils.AssertFileNotContains(t, filepath.Join(tempDir, "user.ts"), "parseUser")
+	if _, err := os.Stat(filepath.Join(tempDir, "helpers.ts")); !os.IsNotExist(err) {
+		t.Error("Expected no helpers.ts without generateHelpers")
+	}
+}
+
+func TestZodGenerator_ReservedNames(t *testing.T) {
+	tempDir := testutils.TempDir(t)
+
+	gen := NewZodGenerator()
+	warnings := &generator.WarningCollector{}
+	config := generator.Config{OutputFolder: tempDir, Warnings: warnings}
+	if err := gen.Generate([]generator.DTO{testutils.CreateTestDTO("class")}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	file := filepath.Join(tempDir, "class_.ts")
+	testutils.AssertFileContains(t, file, "export type class_ = z.infer<typeof class_Schema>;")
+	testutils.AssertFileContains(t, file, "export const parseClass = (data: unknown): class_ => class_Schema.parse(data);")
+	testutils.AssertFileContains(t, file, "export const safeParseClass = (data: unknown): SafeParseResult<class_> => safeParse(class_Schema, data);")
+	if got := warnings.Warnings(); len(got) != 1 || got[0].Code != "reserved-name" {
+		t.Errorf("warnings = %v, want one reserved-name warning", got)
+	}
+}
+
+func TestZodGenerator_Mini(t *testing.T) {
+	gen := NewZod4Generator()
+	gen.customTypes = NewCustomTypeRegistryForVersion(gen.zodVersion)
+	gen.customTypes.generation.Mini = true
+
+	minLength, maxLength := 1, 20
+	name := generator.PrimitiveType{Name: "string", Constraints: &generator.Constraints{MinLength: &minLength, MaxLength: &maxLength}}
+	if got, want := gen.toZodType(name, true, true), "z.optional(z.nullable(z.string().check(z.minLength(1), z.maxLength(20))))"; got != want {
+		t.Errorf("toZodType() = %v, want %v", got, want)
+	}
+	age := generator.Property{Name: "age", Type: generator.PrimitiveType{Name: "integer"}, Default: 18}
+	if got, want := gen.propertySchema(age), "z._default(z.optional(z.int()), 18)"; got != want {
+		t.Errorf("propertySchema() = %v, want %v", got, want)
+	}
+
+	tempDir := testutils.TempDir(t)
+	configPath := testutils.WriteFile(t, tempDir, "dtoforge.config.yaml", "typescript-zod:\n  generation:\n    mini: true\n    readonly: true\n    generatePartialSchemas: true\n")
+	config := generator.Config{OutputFolder: tempDir, ConfigFile: configPath}
+	if err := NewZod4Generator().Generate([]generator.DTO{testutils.CreateTestDTO("User")}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	userFile := filepath.Join(tempDir, "user.ts")
+	testutils.AssertFileContains(t, userFile, "import * as z from 'zod/mini';")
+	testutils.AssertFileContains(t, userFile, "export const UserSchema = z.readonly(z.object({")
+	testutils.AssertFileContains(t, userFile, "  name: z.optional(z.string()),")
+	testutils.AssertFileContains(t, userFile, "export const UserPartialSchema = z.readonly(z.partial(UserSchema.def.innerType));")
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "index.ts"), "export * as z from 'zod/mini';")
+
+	// The classic API of Zod 3 has no mini counterpart
+	var configErr *generator.ConfigError
+	if err := NewZodGenerator().Generate([]generator.DTO{testutils.CreateTestDTO("User")}, config); !errors.As(err, &configErr) {
+		t.Errorf("Generate() error = %v, want a ConfigError for mini with Zod 3", err)
+	}
+}
+
+func TestZodGenerator_OpenAPIMetadata(t *testing.T) {
+	dto := testutils.CreateTestDTO("User")
+	dto.Examples = []any{map[string]interface{}{"id": "u1"}}
+	dto.Properties[0].Examples = []any{"u1"}
+
+	tempDir := testutils.TempDir(t)
+	configPath := testutils.WriteFile(t, tempDir, "dtoforge.config.yaml", "typescript-zod:\n  generation:\n    generatePackageJson: true\n    openapiMetadata: true\n    describe: true\n")
+	config := generator.Config{OutputFolder: tempDir, ConfigFile: configPath}
+	if err := NewZodGenerator().Generate([]generator.DTO{dto}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	userFile := filepath.Join(tempDir, "user.ts")
+	testutils.AssertFileContains(t, userFile, "import { z } from 'zod';\nimport 'zod-openapi/extend';")
+	testutils.AssertFileContains(t, userFile, "  id: z.string().openapi({ description: 'Identifier', example: 'u1' }),")
+	testutils.AssertFileContains(t, userFile, `}).openapi({ ref: 'User', description: 'Test DTO', example: {"id":"u1"} });`)
+	testutils.AssertFileNotContains(t, userFile, ".describe(")
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "package.json"), `"zod-openapi": "^4.2.0"`)
+
+	// Zod 4 keeps the metadata in its registry, naming the ref id
+	tempDir = testutils.TempDir(t)
+	config.OutputFolder = tempDir
+	if err := NewZod4Generator().Generate([]generator.DTO{dto}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	userFile = filepath.Join(tempDir, "user.ts")
+	testutils.AssertFileNotContains(t, userFile, "zod-openapi/extend")
+	testutils.AssertFileContains(t, userFile, `}).meta({ id: 'User', description: 'Test DTO', example: {"id":"u1"} });`)
+}
+
+func TestZodGenerator_LenientIntegers(t *testing.T) {
+	integer := generator.PrimitiveType{Name: "integer", Format: "int32"}
+
+	for _, gen := range []*ZodGenerator{NewZodGenerator(), NewZod4Generator()} {
+		gen.customTypes = NewCustomTypeRegistryForVersion(gen.zodVersion)
+		gen.customTypes.generation.LenientIntegers = true
+		if got := gen.primitiveToZod(integer); got != "z.number()" {
+			t.Errorf("%s primitiveToZod() = %v, want z.number()", gen.Language(), got)
+		}
+	}
+}
+
+func TestZodGenerator_NumericEnums(t *testing.T) {
+	priority := generator.DTO{Name: "Priority", Type: "enum", EnumValues: []string{"1", "2", "-3"}, EnumValueType: "integer"}
+	task := generator.DTO{Name: "Task", Type: "object", Properties: []generator.Property{
+		{Name: "priority", Type: generator.ReferenceType{RefName: "Priority"}, Required: true},
+		{Name: "weight", Type: generator.EnumType{Values: []string{"0.5", "1.5"}, UnderlyingType: "number"}, Required: true},
+	}}
+	tempDir := testutils.TempDir(t)
+	config := generator.Config{OutputFolder: tempDir}
+	if err := NewZodGenerator().Generate([]generator.DTO{priority, task}, config); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// z.enum only takes strings, so numeric enums are literal unions
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "priority.ts"), "export const PrioritySchema = z.union([z.literal(1), z.literal(2), z.literal(-3)]);")
+	testutils.AssertFileContains(t, filepath.Join(tempDir, "task.ts"), "weight: z.union([z.literal(0.5), z.literal(1.5)]),")
+}
@@ -5,34 +5,135 @@ const dtoTemplate = `// Generated by DtoForge (Zod) - DO NOT EDIT
 {{range .Imports}}{{.}}
 {{end}}
 
-{{if .DTO.Description}}/**
- * {{.DTO.Description}}
- */
+{{dtoComment .DTO}}{{with .DTO.Metadata.String "not"}}// 'not' constraint is not enforced: {{.}}
 {{end}}{{if eq .DTO.Type "enum"}}// Enum: {{.DTO.Name}}
-export const {{.DTO.Name}}Schema = z.enum([
+{{if ne enumStyle "zodEnum"}}{{template "enumObject" .DTO}}{{else}}export const {{.DTO.Name}}Schema = z.enum([
 {{range $i, $value := .DTO.EnumValues}}  '{{$value}}'{{if ne $i (len $.DTO.EnumValues | add -1)}},{{end}}
-{{end}}]);
+{{end}}]){{describe .DTO.Description}}{{openapi .DTO.Name .DTO.Description .DTO.Examples}};
 
 export type {{.DTO.Name}} = z.infer<typeof {{.DTO.Name}}Schema>;
-{{else}}// Schema: {{.DTO.Name}}
-export const {{.DTO.Name}}Schema = z.object({
-{{range .DTO.Properties}}{{if hasDescription .Description}}  // {{.Description}}
-{{end}}  {{toCamelCase .Name}}: {{toZodType .Type .Nullable (not .Required)}},
-{{end}}});
+{{end}}{{else if eq .DTO.Type "union"}}// Union: {{.DTO.Name}}
+{{if isRecursive .DTO.Name}}export type {{.DTO.Name}} = {{toTSType .DTO.Union false}};
 
-export type {{.DTO.Name}} = z.infer<typeof {{.DTO.Name}}Schema>;
-{{end}}
+export const {{.DTO.Name}}Schema: {{schemaType}}<{{.DTO.Name}}> = {{toZodType .DTO.Union false false}}{{describe .DTO.Description}}{{openapi .DTO.Name .DTO.Description .DTO.Examples}};
+{{else}}export const {{.DTO.Name}}Schema = {{toZodType .DTO.Union false false}}{{describe .DTO.Description}}{{openapi .DTO.Name .DTO.Description .DTO.Examples}};
+
+{{template "dtoTypes" .DTO.Name}}{{end}}{{else}}// Schema: {{.DTO.Name}}
+{{if isRecursive .DTO.Name}}export type {{.DTO.Name}} = {{range .DTO.Extends}}{{.}} & {{end}}{
+{{range .DTO.Properties}}  {{readonly}}{{toCamelCase .Name}}{{if or (not .Required) (ne (defaultValue .Type .Default) "")}}?{{end}}: {{toTSType .Type .Nullable}}{{undefinable .}};
+{{end}}};
+
+export const {{.DTO.Name}}Schema: {{schemaType}}<{{.DTO.Name}}> = {{objectOpen .DTO.Extends}}{
+{{else}}export const {{.DTO.Name}}Schema = {{objectOpen .DTO.Extends}}{
+{{end}}{{range .DTO.Properties}}{{propComment .}}{{with .Metadata.String "not"}}  // 'not' constraint is not enforced: {{.}}
+{{end}}  {{toCamelCase .Name}}: {{propertySchema .}}{{describe .Description}}{{openapi "" .Description .Examples}},
+{{end}}}{{objectClose .DTO.Extends}}{{describe .DTO.Description}}{{openapi .DTO.Name .DTO.Description .DTO.Examples}};
+{{if not (isRecursive .DTO.Name)}}
+{{template "dtoTypes" .DTO.Name}}{{end}}{{end}}{{if .GenerateHelpers}}
+// Parse helpers: parse throws, safeParse returns flattened errors
+{{template "parseHelpers" .DTO.Name}}{{end}}
 `
 
+// typesTemplate exports a DTO's inferred types, then its partial schema if
+// enabled and the schemas derived from it. Schemas that fill in defaults,
+// coerce or transform accept a different input than they produce, so both
+// are exported. It also declares enums in the nativeEnum and constObject
+// styles, and the parse helpers.
+const typesTemplate = `{{define "dtoTypes"}}{{if transforms .}}export type {{.}}Input = z.input<typeof {{.}}Schema>;
+export type {{.}} = z.output<typeof {{.}}Schema>;
+{{else}}export type {{.}} = z.infer<typeof {{.}}Schema>;
+{{end}}{{if hasPartial .}}
+// Partial schema for updates (all fields optional)
+export const {{.}}PartialSchema = {{partialSchema .}};
+{{if transforms .}}export type {{.}}PartialInput = z.input<typeof {{.}}PartialSchema>;
+export type {{.}}Partial = z.output<typeof {{.}}PartialSchema>;
+{{else}}export type {{.}}Partial = z.infer<typeof {{.}}PartialSchema>;
+{{end}}{{end}}{{range derived .}}
+// Derived: {{.Name}}
+export const {{.Name}}Schema = {{derivedSchema .}};
+{{if transforms .From}}export type {{.Name}}Input = z.input<typeof {{.Name}}Schema>;
+export type {{.Name}} = z.output<typeof {{.Name}}Schema>;
+{{else}}export type {{.Name}} = z.infer<typeof {{.Name}}Schema>;
+{{end}}{{end}}{{end}}{{define "enumObject"}}{{if eq enumStyle "nativeEnum"}}export enum {{.Name}} {
+{{range enumMembers .EnumValues}}  {{.Name}} = {{literal .Value}},
+{{end}}}
+{{else}}export const {{.Name}} = {
+{{range enumMembers .EnumValues}}  {{.Name}}: {{literal .Value}},
+{{end}}} as const;
+
+export type {{.Name}} = (typeof {{.Name}})[keyof typeof {{.Name}}];
+{{end}}
+export const {{.Name}}Schema = {{enumSchema .Name}}{{describe .Description}}{{openapi .Name .Description .Examples}};
+{{end}}{{define "parseHelpers"}}export const parse{{helperName .}} = (data: unknown): {{.}} => {{.}}Schema.parse(data);
+export const safeParse{{helperName .}} = (data: unknown): SafeParseResult<{{.}}> => safeParse({{.}}Schema, data);
+{{end}}{{define "safeParse"}}// Property names of an object type, or of any member of a union of objects
+type FieldName<T> = T extends readonly unknown[] ? never : T extends object ? Extract<keyof T, string> : never;
+
+// Validation errors, flattened by top-level property
+export type ValidationErrors<T> = {
+  formErrors: string[];
+  fieldErrors: Partial<Record<FieldName<T>, string[]>>;
+};
+
+export type SafeParseResult<T> =
+  | { success: true; data: T }
+  | { success: false; errors: ValidationErrors<T> };
+
+// safeParse validates data, flattening the issues of a failure
+export const safeParse = <T>(schema: {{schemaType}}<T, any, any>, data: unknown): SafeParseResult<T> => {
+  const result = schema.safeParse(data);
+  if (result.success) {
+    return { success: true, data: result.data };
+  }
+
+  const errors: ValidationErrors<T> = { formErrors: [], fieldErrors: {} };
+  for (const issue of result.error.issues) {
+    if (issue.path.length === 0) {
+      errors.formErrors.push(issue.message);
+      continue;
+    }
+    const field = String(issue.path[0]) as FieldName<T>;
+    errors.fieldErrors[field] = [...(errors.fieldErrors[field] ?? []), issue.message];
+  }
+  return { success: false, errors };
+};
+{{end}}`
+
+// helpersTemplate generates helpers.ts with the shared parse helpers
+const helpersTemplate = `// Generated by DtoForge (Zod) - DO NOT EDIT
+{{zodImport}}
+
+{{template "safeParse"}}`
+
+// brandsTemplate generates the branded schemas of built-in formats
+const brandsTemplate = `// Generated by DtoForge (Zod) - DO NOT EDIT
+{{zodImport}}
+{{range .}}
+export const {{.Name}}Schema = {{.ZodType}};
+export type {{.Name}} = z.infer<typeof {{.Name}}Schema>;
+{{end}}`
+
+// groupIndexTemplate generates the index file of a tag folder
+const groupIndexTemplate = `// Generated by DtoForge (Zod) - DO NOT EDIT
+// {{.PackageName}} - {{.Group}} schemas
+
+{{range .DTOs}}export * from './{{toKebabCase .Name}}';
+{{end}}`
+
 // indexTemplate generates the main index file that exports everything
 const indexTemplate = `// Generated by DtoForge (Zod) - DO NOT EDIT
 // {{.PackageName}} - OpenAPI Schema Validators
 
-{{range .DTOs}}export * from './{{toKebabCase .Name}}';
+{{if mini}}import * as z from 'zod/mini';{{else}}import { z } from 'zod';{{end}}
+{{range .DTOs}}import { {{.Name}}Schema } from '{{modulePath .Name}}';
+{{end}}
+{{if .Brands}}export * from './brands';
+{{end}}{{if .GenerateHelpers}}export * from './helpers';
+{{end}}{{range .DTOs}}export * from '{{modulePath .Name}}';
 {{end}}
 
 // Re-export Zod for convenience
-export { z } from 'zod';
+{{if mini}}export * as z from 'zod/mini';{{else}}export { z } from 'zod';{{end}}
 
 // Utility type for validation results (similar to Zod's SafeParseReturnType)
 export type ValidationResult<T> = {
@@ -40,7 +141,7 @@ export type ValidationResult<T> = {
   data?: T;
   error?: {
     issues: Array<{
-      path: (string | number)[];
+      path: {{if zod4}}PropertyKey[]{{else}}(string | number)[]{{end}};
       message: string;
       code: string;
     }>;
@@ -49,7 +150,7 @@ export type ValidationResult<T> = {
 
 // Generic validation helper
 export const validateData = <T>(
-  schema: z.ZodSchema<T>,
+  schema: {{schemaType}}<T>,
   data: unknown
 ): ValidationResult<T> => {
   const result = schema.safeParse(data);
@@ -87,6 +188,21 @@ export type SchemaName = typeof schemaNames[number];
 {{end}}
 `
 
+// jsonSchemaTemplate generates json-schema.ts with the JSON Schema of every
+// generated schema
+const jsonSchemaTemplate = `// Generated by DtoForge (Zod) - DO NOT EDIT
+{{if zod4}}{{zodImport}}
+{{else}}import { zodToJsonSchema } from 'zod-to-json-schema';
+{{end}}{{range .Imports}}import { {{range $i, $name := .Names}}{{if $i}}, {{end}}{{$name}}{{end}} } from '{{.Module}}';
+{{end}}
+// JSON Schema of every generated schema, by schema name
+export const jsonSchemas = {
+{{range .DTOs}}  {{.Name}}: {{if zod4}}z.toJSONSchema({{.Name}}Schema, { unrepresentable: 'any' }){{else}}zodToJsonSchema({{.Name}}Schema, '{{.Name}}'){{end}},
+{{end}}};
+
+export type JsonSchemaName = keyof typeof jsonSchemas;
+`
+
 // packageJSONTemplate generates a package.json for the generated code
 const packageJSONTemplate = `{
   "name": "{{.PackageName}}",
@@ -99,7 +215,9 @@ const packageJSONTemplate = `{
     "test": "jest"
   },
   "dependencies": {
-    "zod": "^3.22.4"
+    "zod": "{{.ZodRange}}"{{if .ZodToJSONSchema}},
+    "zod-to-json-schema": "^3.23.0"{{end}}{{if .ZodOpenAPI}},
+    "zod-openapi": "{{.ZodOpenAPI}}"{{end}}
   },
   "devDependencies": {
     "@types/node": "^20.0.0",
@@ -116,39 +234,56 @@ const packageJSONTemplate = `{
 const singleFileTemplate = `// Generated by DtoForge (Zod) - DO NOT EDIT
 // {{.PackageName}} - OpenAPI Schema Validators
 
-import { z } from 'zod';
-
+{{zodImport}}
+{{with openapiImport}}{{.}}
+{{end}}{{if .Brands}}
+// Branded formats
+{{range .Brands}}export const {{.Name}}Schema = {{.ZodType}};
+export type {{.Name}} = z.infer<typeof {{.Name}}Schema>;
+{{end}}{{end}}
 {{range .DTOs}}
-{{if .Description}}/**
- * {{.Description}}
- */
-{{end}}
-{{if eq .Type "enum"}}// Enum: {{.Name}}
-export const {{.Name}}Schema = z.enum([
+{{dtoComment .}}
+{{with .Metadata.String "not"}}// 'not' constraint is not enforced: {{.}}
+{{end}}{{if eq .Type "enum"}}// Enum: {{.Name}}
+{{if ne enumStyle "zodEnum"}}{{template "enumObject" .}}{{else}}export const {{.Name}}Schema = z.enum([
 {{range .EnumValues}}  '{{.}}',
-{{end}}]);
+{{end}}]){{describe .Description}}{{openapi .Name .Description .Examples}};
 
 export type {{.Name}} = z.infer<typeof {{.Name}}Schema>;
+{{end}}
+{{else if eq .Type "union"}}// Union: {{.Name}}
+{{if isRecursive .Name}}export type {{.Name}} = {{toTSType .Union false}};
+
+export const {{.Name}}Schema: {{schemaType}}<{{.Name}}> = {{toZodType .Union false false}}{{describe .Description}}{{openapi .Name .Description .Examples}};
+{{else}}export const {{.Name}}Schema = {{toZodType .Union false false}}{{describe .Description}}{{openapi .Name .Description .Examples}};
 
+{{template "dtoTypes" .Name}}{{end}}
 {{else}}// Schema: {{.Name}}
-export const {{.Name}}Schema = z.object({
-{{range .Properties}}{{if hasDescription .Description}}  // {{.Description}}
-{{end}}  {{toCamelCase .Name}}: {{toZodType .Type .Nullable (not .Required)}},
-{{end}}});
+{{if isRecursive .Name}}export type {{.Name}} = {{range .Extends}}{{.}} & {{end}}{
+{{range .Properties}}  {{readonly}}{{toCamelCase .Name}}{{if or (not .Required) (ne (defaultValue .Type .Default) "")}}?{{end}}: {{toTSType .Type .Nullable}}{{undefinable .}};
+{{end}}};
 
-export type {{.Name}} = z.infer<typeof {{.Name}}Schema>;
+export const {{.Name}}Schema: {{schemaType}}<{{.Name}}> = {{objectOpen .Extends}}{
+{{else}}export const {{.Name}}Schema = {{objectOpen .Extends}}{
+{{end}}{{range .Properties}}{{propComment .}}{{with .Metadata.String "not"}}  // 'not' constraint is not enforced: {{.}}
+{{end}}  {{toCamelCase .Name}}: {{propertySchema .}}{{describe .Description}}{{openapi "" .Description .Examples}},
+{{end}}}{{objectClose .Extends}}{{describe .Description}}{{openapi .Name .Description .Examples}};
 
-{{end}}
+{{if not (isRecursive .Name)}}{{template "dtoTypes" .Name}}
+{{end}}{{end}}
 {{end}}
 
 {{if .GenerateHelpers}}// Generic validation helper
 export const validateData = <T>(
-  schema: z.ZodSchema<T>,
+  schema: {{schemaType}}<T>,
   data: unknown
 ) => {
   return schema.safeParse(data);
 };
-{{end}}
+
+{{template "safeParse"}}
+// Parse helpers: parse throws, safeParse returns flattened errors
+{{range .DTOs}}{{template "parseHelpers" .Name}}{{end}}{{end}}
 
 // All available schemas
 export const schemas = {
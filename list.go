@@ -0,0 +1,91 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"dtoForge/internal/generator"
+)
+
+// runList implements dtoforge list: it prints the schemas of a spec with
+// their kind and how many schemas they depend on and are used by, without
+// generating anything
+func runList(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("list", flag.ContinueOnError)
+	flags.SetOutput(out)
+	openAPIFile := flags.String("openapi", "", "Path to the OpenAPI spec file (default: openapi in the config file)")
+	configFile := flags.String("config", "", "Path to dtoforge config file (optional)")
+	noConfig := flags.Bool("no-config", false, "Disable automatic config file discovery")
+	useTitles := flags.Bool("use-titles", false, "Name types after schema titles instead of components keys")
+	flags.Usage = func() {
+		fmt.Fprintf(out, "Usage: dtoforge list [options]\n\nOptions:\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return &usageError{err}
+	}
+
+	config := Config{
+		OpenAPIFile: *openAPIFile,
+		ConfigFile:  *configFile,
+		NoConfig:    *noConfig,
+		SetFlags:    make(map[string]bool),
+	}
+	flags.Visit(func(f *flag.Flag) {
+		config.SetFlags[f.Name] = true
+	})
+	applyEnvironment(&config, os.LookupEnv)
+	path := discoverConfigFile(config)
+	if path != "" {
+		if err := applyProjectOptions(&config, path); err != nil {
+			return &generator.ConfigError{Path: path, Err: err}
+		}
+	}
+	if config.OpenAPIFile == "" {
+		return &usageError{fmt.Errorf("OpenAPI spec file is required, use the -openapi flag or set openapi in the config file")}
+	}
+
+	options, err := loadConversionOptions(path)
+	if err != nil {
+		return &generator.ConfigError{Path: path, Err: err}
+	}
+	if *useTitles {
+		options.Naming.UseSchemaTitles = true
+	}
+
+	spec, err := readOpenAPISpec(config.OpenAPIFile)
+	if err != nil {
+		return &specError{err}
+	}
+	if issues := validateSpec(spec); hasErrors(issues) {
+		printSpecIssues(issues)
+		return &specError{fmt.Errorf("the OpenAPI spec has errors")}
+	}
+	dtos, _, err := convertToGeneratorDTOs(spec, options)
+	if err != nil {
+		return &specError{err}
+	}
+
+	writeSchemaList(out, dtos)
+	return nil
+}
+
+// writeSchemaList prints one row per DTO: its name, kind, and the number of
+// DTOs it depends on and that depend on it
+func writeSchemaList(out io.Writer, dtos []generator.DTO) {
+	graph := generator.BuildGraph(dtos)
+	table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "SCHEMA\tKIND\tDEPENDENCIES\tUSED BY")
+	for _, dto := range dtos {
+		kind := dto.Type
+		if kind == "" {
+			kind = "object"
+		}
+		fmt.Fprintf(table, "%s\t%s\t%d\t%d\n", dto.Name, kind, len(graph.Dependencies(dto.Name)), len(graph.Dependents(dto.Name)))
+	}
+	table.Flush()
+	fmt.Fprintf(out, "\n%d schemas\n", len(dtos))
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteSchemaList(t *testing.T) {
+	dtos, _ := convertSpec(t, `
+openapi: 3.0.0
+components:
+  schemas:
+    Status:
+      type: string
+      enum: [active, inactive]
+    Cat:
+      type: object
+      properties:
+        status:
+          $ref: '#/components/schemas/Status'
+    Dog:
+      type: object
+      properties:
+        name:
+          type: string
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Cat'
+        - $ref: '#/components/schemas/Dog'
+`)
+
+	var out strings.Builder
+	writeSchemaList(&out, dtos)
+
+	rows := map[string][]string{}
+	for _, line := range strings.Split(out.String(), "\n") {
+		if fields := strings.Fields(line); len(fields) == 4 {
+			rows[fields[0]] = fields[1:]
+		}
+	}
+	for name, want := range map[string][]string{
+		"Cat":    {"object", "1", "1"},
+		"Dog":    {"object", "0", "1"},
+		"Pet":    {"union", "2", "0"},
+		"Status": {"enum", "0", "1"},
+	} {
+		if got := strings.Join(rows[name], " "); got != strings.Join(want, " ") {
+			t.Errorf("%s row = %q, want %q", name, got, strings.Join(want, " "))
+		}
+	}
+	if !strings.HasSuffix(out.String(), "\n4 schemas\n") {
+		t.Errorf("output does not end with the schema count:\n%s", out.String())
+	}
+}
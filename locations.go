@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"dtoForge/internal/generator"
+)
+
+// indexLocations records the position of every key and sequence item below
+// node, keyed by dotted spec path (components.schemas.Pet.properties.tag,
+// components.schemas.Pet.allOf[0])
+func indexLocations(file string, node *yaml.Node, path string, locations map[string]generator.SourceLocation) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			indexLocations(file, child, path, locations)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			child := key.Value
+			if path != "" {
+				child = path + "." + key.Value
+			}
+			locations[child] = generator.SourceLocation{File: file, Line: key.Line, Column: key.Column}
+			indexLocations(file, value, child, locations)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			child := fmt.Sprintf("%s[%d]", path, i)
+			locations[child] = generator.SourceLocation{File: file, Line: item.Line, Column: item.Column}
+			indexLocations(file, item, child, locations)
+		}
+	}
+}
+
+// location returns where a spec path is defined, or nil if unknown
+func (s *OpenAPISpec) location(path string) *generator.SourceLocation {
+	loc, ok := s.locations[path]
+	if !ok {
+		return nil
+	}
+	return &loc
+}
+
+// locateDTO records where a component DTO and its properties are defined.
+// Properties merged from inline allOf members are found in those members.
+func (s *OpenAPISpec) locateDTO(dto *generator.DTO, path string, schema map[string]interface{}) {
+	dto.Source = s.location(path)
+
+	paths := []string{path}
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		for i := range allOf {
+			paths = append(paths, fmt.Sprintf("%s.allOf[%d]", path, i))
+		}
+	}
+	for i := range dto.Properties {
+		prop := &dto.Properties[i]
+		for _, p := range paths {
+			if prop.Source = s.location(p + ".properties." + prop.Name); prop.Source != nil {
+				break
+			}
+		}
+	}
+}
+
+// locateWarnings points warnings without a source at the DTO or property
+// they are about
+func locateWarnings(warnings []generator.Warning, dtos []generator.DTO) {
+	byName := make(map[string]generator.DTO, len(dtos))
+	for _, dto := range dtos {
+		byName[dto.Name] = dto
+	}
+
+	for i := range warnings {
+		warning := &warnings[i]
+		dto, ok := byName[warning.Schema]
+		if warning.Source != nil || !ok {
+			continue
+		}
+		warning.Source = dto.Source
+		for _, prop := range dto.Properties {
+			if prop.Name == warning.Property && prop.Source != nil {
+				warning.Source = prop.Source
+			}
+		}
+	}
+}
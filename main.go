@@ -1,16 +1,30 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
+	"io/fs"
+	"math"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"gopkg.in/yaml.v3"
 
 	"dtoForge/internal/generator"
+	"dtoForge/internal/graphql"
+	"dtoForge/internal/java"
+	"dtoForge/internal/nestjs"
+	"dtoForge/internal/prisma"
+	"dtoForge/internal/proto"
 	"dtoForge/internal/typescript"
 	"dtoForge/internal/zod"
 )
@@ -22,6 +36,115 @@ type Config struct {
 	PackageName    string
 	ConfigFile     string
 	NoConfig       bool
+	UseTitles      bool
+	ValidateOnly   bool
+	WarningsJSON   string
+	EmitIR         string
+	FromIR         string
+	Report         string
+	ReportFile     string
+	Clean          bool
+	Force          bool
+	Check          bool
+	Parallel       int
+	FailOnWarning  bool
+	SetFlags       map[string]bool // flags given on the command line
+}
+
+// Exit codes, so CI pipelines can react to each class of failure
+const (
+	exitUsage           = 1 // invalid flags or arguments
+	exitSpecError       = 2 // the spec or IR file cannot be read, is invalid or has no schemas
+	exitConfigError     = 3 // the config file cannot be read or is invalid
+	exitGenerationError = 4 // generating or writing the output failed
+	exitWarnings        = 5 // the run had warnings and -fail-on-warning is set
+	exitDiff            = 6 // with -check, the output differs from what generating would write
+)
+
+// usageError is an error in the flags or arguments of a command
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string { return e.err.Error() }
+func (e *usageError) Unwrap() error { return e.err }
+
+// specError is an error reading, validating or converting the spec
+type specError struct {
+	err error
+}
+
+func (e *specError) Error() string { return e.err.Error() }
+func (e *specError) Unwrap() error { return e.err }
+
+// exitCode returns the exit code for an error returned by a generator or a
+// subcommand
+func exitCode(err error) int {
+	var usageErr *usageError
+	var specErr *specError
+	var configErr *generator.ConfigError
+	switch {
+	case errors.As(err, &usageErr):
+		return exitUsage
+	case errors.As(err, &specErr):
+		return exitSpecError
+	case errors.As(err, &configErr):
+		return exitConfigError
+	}
+	return exitGenerationError
+}
+
+// ProjectOptions holds the config file settings that stand in for command
+// line flags, so a project's runs need none
+type ProjectOptions struct {
+	OpenAPI string `yaml:"openapi"` // spec path, relative to the config file
+	Lang    string `yaml:"lang"`    // target language
+}
+
+// ConversionOptions holds the language-independent config file settings that
+// control how spec schemas become DTOs
+type ConversionOptions struct {
+	Naming      NamingOptions      `yaml:"naming"`
+	Inheritance InheritanceOptions `yaml:"inheritance"`
+	Operations  OperationOptions   `yaml:"operations"`
+	Types       TypeOptions        `yaml:"types"`
+	Enums       EnumOptions        `yaml:"enums"`
+	Objects     ObjectOptions      `yaml:"objects"`
+}
+
+// EnumOptions controls how inline property enums are generated
+type EnumOptions struct {
+	Deduplicate bool `yaml:"deduplicate"` // identical inline enums share one named enum type
+}
+
+// ObjectOptions controls how inline object schemas are generated
+type ObjectOptions struct {
+	Deduplicate     bool `yaml:"deduplicate"`     // identical inline objects share one named DTO
+	ReuseComponents bool `yaml:"reuseComponents"` // inline objects equal to a component object reference it
+}
+
+// TypeOptions controls which IR primitive a spec type becomes
+type TypeOptions struct {
+	BigIntFormats []string `yaml:"bigIntFormats"` // integer formats generated as bigint, e.g. int64, uint64
+}
+
+// OperationOptions controls DTOs for the inline request and response schemas
+// of path operations
+type OperationOptions struct {
+	Generate     bool   `yaml:"generate"`     // generate DTOs for inline operation schemas
+	NameTemplate string `yaml:"nameTemplate"` // default "{Operation}{Kind}", see operationDTOName
+}
+
+// InheritanceOptions controls how allOf: [{$ref: Base}, {...}] is generated
+type InheritanceOptions struct {
+	Mode string `yaml:"mode"` // "intersect" (default) reuses the base schema, "flatten" copies its properties
+}
+
+// NamingOptions controls how generated types are named
+type NamingOptions struct {
+	UseSchemaTitles      bool   `yaml:"useSchemaTitles"`      // prefer schema titles over components keys
+	ReservedWordStrategy string `yaml:"reservedWordStrategy"` // "suffix" (default) or "prefix"
+	ReservedWordAffix    string `yaml:"reservedWordAffix"`    // added to reserved names, default "_"
 }
 
 type OpenAPISpec struct {
@@ -29,25 +152,49 @@ type OpenAPISpec struct {
 	Info       map[string]interface{} `yaml:"info"`
 	Paths      map[string]interface{} `yaml:"paths"`
 	Components map[string]interface{} `yaml:"components"`
+
+	locations map[string]generator.SourceLocation // spec path -> position in the file
 }
 
 func parseCLIArgs() Config {
 	openAPIFile := flag.String("openapi", "", "Path to the OpenAPI spec file (JSON or YAML)")
-	outputFolder := flag.String("out", "./generated", "Output folder for generated files")
-	targetLang := flag.String("lang", "typescript", "Target language (typescript, typescript-zod)")
+	outputFolder := flag.String("out", "./generated", "Output folder for generated files, or - to write a single generated file to stdout")
+	targetLang := flag.String("lang", "typescript", "Target language (typescript, typescript-zod, typescript-zod4, typescript-nestjs, java, proto, graphql, prisma)")
 	packageName := flag.String("package", "", "Package/module name (optional)")
 	configFile := flag.String("config", "", "Path to dtoforge config file (optional)")
 	noConfig := flag.Bool("no-config", false, "Disable automatic config file discovery")
+	useTitles := flag.Bool("use-titles", false, "Name generated types after schema titles instead of components keys")
+	validateOnly := flag.Bool("validate-spec", false, "Validate the OpenAPI spec, report all problems and exit")
+	warningsJSON := flag.String("warnings-json", "", "Write all warnings as JSON to this file (optional)")
+	emitIR := flag.String("emit-ir", "", "Write the intermediate representation as JSON to this file (optional)")
+	fromIR := flag.String("from-ir", "", "Generate from an IR file written by -emit-ir instead of an OpenAPI spec")
+	report := flag.String("report", "", "Write a run report in this format (json) (optional)")
+	reportFile := flag.String("report-file", "-", "File for the -report run report, or - for stdout")
+	clean := flag.Bool("clean", false, "Remove files an earlier run generated that this run no longer does")
+	force := flag.Bool("force", false, "Generate even if the spec, config and DtoForge are unchanged since the last run")
+	check := flag.Bool("check", false, "Exit with code 6 if the output folder differs from what generating would write, without changing it")
+	failOnWarning := flag.Bool("fail-on-warning", false, "Exit with code 5 if the run had warnings")
+	parallel := flag.Int("parallel", 1, "Number of files generated at once in multiple-file mode (io-ts, Zod), 0 for one per CPU")
 
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "DtoForge - OpenAPI to TypeScript schema generator\n\n")
-		fmt.Fprintf(os.Stderr, "Usage: %s [options]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Usage: %s [options]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s init [options]   write a dtoforge.config.yaml for a project\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s list [options]   list the schemas of a spec\n\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "Options:\n")
 		flag.PrintDefaults()
 		fmt.Fprintf(os.Stderr, "\nSupported languages:\n")
-		fmt.Fprintf(os.Stderr, "  typescript     - TypeScript with io-ts validation (default)\n")
-		fmt.Fprintf(os.Stderr, "  typescript-zod - TypeScript with Zod validation\n")
-		fmt.Fprintf(os.Stderr, "\nConfig file discovery (if -config not specified and -no-config not set):\n")
+		fmt.Fprintf(os.Stderr, "  typescript        - TypeScript with io-ts validation (default)\n")
+		fmt.Fprintf(os.Stderr, "  typescript-zod    - TypeScript with Zod validation\n")
+		fmt.Fprintf(os.Stderr, "  typescript-zod4   - TypeScript with Zod 4 validation\n")
+		fmt.Fprintf(os.Stderr, "  typescript-nestjs - NestJS DTO classes with class-validator decorators\n")
+		fmt.Fprintf(os.Stderr, "  java              - Java records or classes with Jackson annotations\n")
+		fmt.Fprintf(os.Stderr, "  proto             - Protocol Buffers messages and enums\n")
+		fmt.Fprintf(os.Stderr, "  graphql           - GraphQL SDL types, inputs and enums\n")
+		fmt.Fprintf(os.Stderr, "  prisma            - Prisma schema models and enums\n")
+		fmt.Fprintf(os.Stderr, "\nEnvironment variables (used when the flag is not given, before the config file):\n")
+		fmt.Fprintf(os.Stderr, "  DTOFORGE_OPENAPI, DTOFORGE_OUT, DTOFORGE_LANG, DTOFORGE_CONFIG\n")
+		fmt.Fprintf(os.Stderr, "\nConfig file discovery (if neither -config nor DTOFORGE_CONFIG is set and -no-config is not set):\n")
 		fmt.Fprintf(os.Stderr, "  1. ./dtoforge.config.yaml (current directory)\n")
 		fmt.Fprintf(os.Stderr, "  2. Same directory as OpenAPI file\n")
 		fmt.Fprintf(os.Stderr, "  3. Same directory as binary\n")
@@ -57,31 +204,106 @@ func parseCLIArgs() Config {
 	// Special flag to generate example config
 	exampleConfig := flag.Bool("example-config", false, "Generate example dtoforge.config.yaml and exit")
 
-	flag.Parse()
+	// Bad flags exit with exitUsage rather than the flag package's 2, which
+	// is taken by exitSpecError
+	flag.CommandLine.Init(os.Args[0], flag.ContinueOnError)
+	if err := flag.CommandLine.Parse(os.Args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		os.Exit(exitUsage)
+	}
 
 	// Handle example config generation
 	if *exampleConfig {
 		if err := generateExampleConfig(); err != nil {
 			fmt.Printf("Error generating example config: %v\n", err)
-			os.Exit(1)
+			os.Exit(exitGenerationError)
 		}
 		fmt.Println("✅ Generated dtoforge.config.yaml example file")
 		os.Exit(0)
 	}
 
-	if *openAPIFile == "" {
-		fmt.Println("Error: OpenAPI spec file is required. Use the -openapi flag.")
-		flag.Usage()
-		os.Exit(1)
+	setFlags := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) {
+		setFlags[f.Name] = true
+	})
+
+	if *parallel < 0 {
+		fmt.Printf("Error: -parallel must be 0 or more, got %d\n", *parallel)
+		os.Exit(exitUsage)
+	}
+	if *parallel == 0 {
+		*parallel = runtime.NumCPU()
+	}
+	if *report != "" && *report != "json" {
+		fmt.Printf("Error: unsupported report format '%s', must be 'json'\n", *report)
+		os.Exit(exitUsage)
 	}
 
-	return Config{
+	config := Config{
 		OpenAPIFile:    *openAPIFile,
 		OutputFolder:   *outputFolder,
 		TargetLanguage: *targetLang,
 		PackageName:    *packageName,
 		ConfigFile:     *configFile,
 		NoConfig:       *noConfig,
+		UseTitles:      *useTitles,
+		ValidateOnly:   *validateOnly,
+		WarningsJSON:   *warningsJSON,
+		EmitIR:         *emitIR,
+		FromIR:         *fromIR,
+		Report:         *report,
+		ReportFile:     *reportFile,
+		Clean:          *clean,
+		Force:          *force,
+		Check:          *check,
+		Parallel:       *parallel,
+		FailOnWarning:  *failOnWarning,
+		SetFlags:       setFlags,
+	}
+	applyEnvironment(&config, os.LookupEnv)
+
+	if config.Report != "" && config.ReportFile == "-" && config.OutputFolder == "-" {
+		fmt.Println("Error: -out - and the run report cannot both use stdout, set -report-file")
+		os.Exit(exitUsage)
+	}
+	if config.Check && config.OutputFolder == "-" {
+		fmt.Println("Error: -check compares the output folder, it cannot be used with -out -")
+		os.Exit(exitUsage)
+	}
+	return config
+}
+
+// environmentFlags maps the environment variables that stand in for flags,
+// e.g. in containers and CI, to the flag each one replaces
+var environmentFlags = map[string]string{
+	"DTOFORGE_OPENAPI": "openapi",
+	"DTOFORGE_OUT":     "out",
+	"DTOFORGE_LANG":    "lang",
+	"DTOFORGE_CONFIG":  "config",
+}
+
+// applyEnvironment sets the settings of environment variables whose flag was
+// not given, and marks them as set so they win over the config file: flags
+// come first, then the environment, then the config file, then defaults
+func applyEnvironment(config *Config, lookup func(string) (string, bool)) {
+	if config.SetFlags == nil {
+		config.SetFlags = make(map[string]bool)
+	}
+	settings := map[string]*string{
+		"openapi": &config.OpenAPIFile,
+		"out":     &config.OutputFolder,
+		"lang":    &config.TargetLanguage,
+		"config":  &config.ConfigFile,
+	}
+	for variable, name := range environmentFlags {
+		value, ok := lookup(variable)
+		if !ok || value == "" || config.SetFlags[name] {
+			continue
+		}
+		*settings[name] = value
+		config.SetFlags[name] = true
 	}
 }
 
@@ -130,6 +352,163 @@ func generateExampleConfig() error {
 	return registry.SaveExampleConfig("dtoforge.config.yaml")
 }
 
+// loadConversionOptions reads the language-independent sections of the config file
+func loadConversionOptions(configPath string) (ConversionOptions, error) {
+	var options ConversionOptions
+	if configPath == "" {
+		return options, nil
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return options, fmt.Errorf("failed to read config file %s: %w", configPath, err)
+	}
+	if err := yaml.Unmarshal(data, &options); err != nil {
+		return options, fmt.Errorf("failed to parse config file %s: %w", configPath, err)
+	}
+
+	// int64AsBigInt of the io-ts and Zod settings predates bigIntFormats and
+	// means bigIntFormats: [int64], so there is one source of bigint types
+	var legacy struct {
+		Generation struct {
+			Int64AsBigInt bool `yaml:"int64AsBigInt"`
+		} `yaml:"generation"`
+		Zod struct {
+			Generation struct {
+				Int64AsBigInt bool `yaml:"int64AsBigInt"`
+			} `yaml:"generation"`
+		} `yaml:"typescript-zod"`
+	}
+	if err := yaml.Unmarshal(data, &legacy); err != nil {
+		return options, fmt.Errorf("failed to parse config file %s: %w", configPath, err)
+	}
+	if (legacy.Generation.Int64AsBigInt || legacy.Zod.Generation.Int64AsBigInt) && !slices.Contains(options.Types.BigIntFormats, "int64") {
+		options.Types.BigIntFormats = append(options.Types.BigIntFormats, "int64")
+	}
+
+	switch options.Naming.ReservedWordStrategy {
+	case "", "suffix", "prefix":
+	default:
+		return options, fmt.Errorf("invalid reserved word strategy '%s', must be 'suffix' or 'prefix'", options.Naming.ReservedWordStrategy)
+	}
+	switch options.Inheritance.Mode {
+	case "", "intersect", "flatten":
+	default:
+		return options, fmt.Errorf("invalid inheritance mode '%s', must be 'intersect' or 'flatten'", options.Inheritance.Mode)
+	}
+	if template := options.Operations.NameTemplate; template != "" {
+		if !strings.Contains(strings.ToLower(template), "{operation}") || !strings.Contains(strings.ToLower(template), "{kind}") {
+			return options, fmt.Errorf("invalid operation name template '%s', must contain {Operation} and {Kind}", template)
+		}
+	}
+	return options, nil
+}
+
+// loadProjectOptions reads the openapi and lang settings of the config file
+func loadProjectOptions(configPath string) (ProjectOptions, error) {
+	var options ProjectOptions
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return options, fmt.Errorf("failed to read config file %s: %w", configPath, err)
+	}
+	if err := yaml.Unmarshal(data, &options); err != nil {
+		return options, fmt.Errorf("failed to parse config file %s: %w", configPath, err)
+	}
+	if options.OpenAPI != "" && !filepath.IsAbs(options.OpenAPI) {
+		options.OpenAPI = filepath.Join(filepath.Dir(configPath), options.OpenAPI)
+	}
+	return options, nil
+}
+
+// applyProjectOptions fills in the spec and language from the config file
+// where no flag set them
+func applyProjectOptions(config *Config, configPath string) error {
+	options, err := loadProjectOptions(configPath)
+	if err != nil {
+		return err
+	}
+	if config.OpenAPIFile == "" && config.FromIR == "" {
+		config.OpenAPIFile = options.OpenAPI
+	}
+	if options.Lang != "" && !config.SetFlags["lang"] {
+		config.TargetLanguage = options.Lang
+	}
+	return nil
+}
+
+// writeIR writes DTOs to path in the versioned IR format
+func writeIR(path string, dtos []generator.DTO) error {
+	data, err := generator.MarshalIR(dtos)
+	if err != nil {
+		return fmt.Errorf("failed to encode IR: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// readIR reads DTOs from an IR file written by writeIR
+func readIR(path string) ([]generator.DTO, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	dtos, err := generator.UnmarshalIR(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return dtos, nil
+}
+
+// writeWarningsJSON writes the run's warnings as a JSON array for tooling
+func writeWarningsJSON(path string, warnings []generator.Warning) error {
+	if warnings == nil {
+		warnings = []generator.Warning{}
+	}
+	data, err := json.MarshalIndent(warnings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode warnings: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// streamOutput writes the one file generated into dir to w, for -out -.
+// A package.json is left out; any other file means the output is not a
+// single file and cannot be streamed.
+func streamOutput(dir string, w io.Writer) error {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() && entry.Name() != "package.json" {
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			files = append(files, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(files) != 1 {
+		return fmt.Errorf("-out - needs a single generated file, but %d were generated (%s); set output.mode: single", len(files), strings.Join(files, ", "))
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, files[0]))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
 // ... rest of the functions remain the same (readOpenAPISpec, convertToGeneratorDTOs, etc.)
 
 func readOpenAPISpec(path string) (*OpenAPISpec, error) {
@@ -138,57 +517,663 @@ func readOpenAPISpec(path string) (*OpenAPISpec, error) {
 		return nil, fmt.Errorf("failed to read file %s: %w", path, err)
 	}
 
-	var spec OpenAPISpec
-	if err := yaml.Unmarshal(data, &spec); err != nil {
+	// Decode through a node tree so positions can be reported later
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
 		return nil, fmt.Errorf("failed to parse OpenAPI spec: %w", err)
 	}
+	var spec OpenAPISpec
+	if len(root.Content) > 0 {
+		if err := root.Decode(&spec); err != nil {
+			return nil, fmt.Errorf("failed to parse OpenAPI spec: %w", err)
+		}
+	}
+	spec.locations = make(map[string]generator.SourceLocation)
+	indexLocations(path, &root, "", spec.locations)
 
 	return &spec, nil
 }
 
-func convertToGeneratorDTOs(spec *OpenAPISpec) ([]generator.DTO, error) {
+// specConverter turns OpenAPI schemas into generator DTOs, collecting warnings
+// about constructs that cannot be represented exactly
+type specConverter struct {
+	options       ConversionOptions
+	warnings      []generator.Warning
+	names         map[string]bool   // schema names taken so far
+	refNames      map[string]string // components key -> DTO name, where they differ
+	extraDTOs     []generator.DTO   // DTOs materialized from inline schemas
+	shapes        map[string]string // schema fingerprint -> DTO generated for it, with Objects options
+	repeated      map[string]bool   // fingerprints of inline objects found more than once, with Objects.Deduplicate
+	enums         map[string]string // enum fingerprint -> named enum DTO, with Enums.Deduplicate
+	repeatedEnums map[string]bool   // fingerprints of inline enums found more than once
+
+	operationTags map[string][]string // operation DTO name -> tags of its operation
+}
+
+// warn records a warning for a schema or one of its properties
+func (c *specConverter) warn(schema, property, code, message string) {
+	c.warnings = append(c.warnings, generator.Warning{
+		Schema:   schema,
+		Property: property,
+		Code:     code,
+		Message:  message,
+	})
+}
+
+// skip records that a components.schemas entry produces no DTO, so the run
+// report can list it
+func (c *specConverter) skip(key, reason string) {
+	c.warn(key, "", skippedSchemaCode, fmt.Sprintf("%s, so no type is generated for it", reason))
+}
+
+// warnDroppedEnumValue reports an enum value that cannot be generated
+func (c *specConverter) warnDroppedEnumValue(schema, property string, value interface{}) {
+	c.warn(schema, property, "dropped-enum-value", fmt.Sprintf("enum value %v is not a string and was dropped", value))
+}
+
+// numericEnum returns the values of an enum whose values are all numbers and
+// their type: the schema's "integer" or "number", or "integer" if every value
+// is a whole number when the schema has no numeric type
+func numericEnum(values []interface{}, schemaType interface{}) ([]string, string, bool) {
+	valueType := "integer"
+	var literals []string
+	for _, val := range values {
+		switch v := val.(type) {
+		case int, int64, uint64:
+			literals = append(literals, fmt.Sprint(v))
+		case float64:
+			literals = append(literals, strconv.FormatFloat(v, 'f', -1, 64))
+			if v != math.Trunc(v) {
+				valueType = "number"
+			}
+		default:
+			return nil, "", false
+		}
+	}
+	if typ, ok := schemaType.(string); ok && generator.IsNumericType(typ) {
+		valueType = typ
+	}
+	return literals, valueType, len(literals) > 0
+}
+
+func convertToGeneratorDTOs(spec *OpenAPISpec, options ConversionOptions) ([]generator.DTO, []generator.Warning, error) {
+	converter := &specConverter{
+		options:  options,
+		names:    make(map[string]bool),
+		refNames: make(map[string]string),
+	}
 	var dtos []generator.DTO
 
 	if comp, ok := spec.Components["schemas"]; ok {
 		if schemas, ok := comp.(map[string]interface{}); ok {
-			for name, schemaVal := range schemas {
-				if schema, ok := schemaVal.(map[string]interface{}); ok {
-					dto, err := convertSchemaToGeneratorDTO(name, schema)
-					if err != nil {
-						return nil, fmt.Errorf("failed to convert schema %s: %w", name, err)
-					}
-					dtos = append(dtos, dto)
+			// Sort names so generated names for inline schemas are stable
+			var keys []string
+			for key := range schemas {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+
+			converter.assignSchemaNames(keys, schemas)
+			converter.registerShapes(keys, schemas)
+
+			for _, key := range keys {
+				schema, ok := schemas[key].(map[string]interface{})
+				if !ok {
+					converter.skip(key, "not a schema object")
+					continue
+				}
+				dto, err := converter.convertSchemaToGeneratorDTO(converter.schemaName(key), schema)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to convert schema %s: %w", key, err)
+				}
+				spec.locateDTO(&dto, "components.schemas."+key, schema)
+				dtos = append(dtos, dto)
+			}
+		}
+	}
+
+	if options.Operations.Generate {
+		if err := converter.convertOperations(spec); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	dtos = append(dtos, converter.extraDTOs...)
+
+	if options.Inheritance.Mode == "flatten" {
+		dtos = flattenInheritance(dtos)
+	}
+
+	converter.assignTags(spec, dtos)
+	locateWarnings(converter.warnings, dtos)
+
+	return dtos, converter.warnings, nil
+}
+
+// flattenInheritance copies inherited properties into every DTO that extends
+// others, so each DTO stands alone. The parents are kept in the "extends"
+// metadata for documentation.
+func flattenInheritance(dtos []generator.DTO) []generator.DTO {
+	byName := make(map[string]generator.DTO, len(dtos))
+	for _, dto := range dtos {
+		byName[dto.Name] = dto
+	}
+
+	flattened := make(map[string]generator.DTO, len(dtos))
+	var flatten func(name string, visiting map[string]bool) generator.DTO
+	flatten = func(name string, visiting map[string]bool) generator.DTO {
+		if dto, done := flattened[name]; done {
+			return dto
+		}
+		dto := byName[name]
+		if len(dto.Extends) == 0 || visiting[name] {
+			return dto
+		}
+		visiting[name] = true
+
+		var properties []generator.Property
+		var required []string
+		index := make(map[string]int)
+		add := func(props []generator.Property, req []string) {
+			for _, prop := range props {
+				// Redeclared properties override the inherited ones in place
+				if i, exists := index[prop.Name]; exists {
+					properties[i] = prop
+					continue
 				}
+				index[prop.Name] = len(properties)
+				properties = append(properties, prop)
+			}
+			for _, r := range req {
+				if !slices.Contains(required, r) {
+					required = append(required, r)
+				}
+			}
+		}
+		for _, base := range dto.Extends {
+			if _, known := byName[base]; !known {
+				continue
+			}
+			parent := flatten(base, visiting)
+			add(parent.Properties, parent.Required)
+		}
+		add(dto.Properties, dto.Required)
+
+		metadata := make(generator.Metadata, len(dto.Metadata)+1)
+		for key, value := range dto.Metadata {
+			metadata[key] = value
+		}
+		metadata["extends"] = strings.Join(dto.Extends, ", ")
+
+		dto.Properties = properties
+		dto.Required = required
+		dto.Metadata = metadata
+		dto.Extends = nil
+		flattened[name] = dto
+		return dto
+	}
+
+	result := make([]generator.DTO, len(dtos))
+	for i, dto := range dtos {
+		result[i] = flatten(dto.Name, make(map[string]bool))
+	}
+	return result
+}
+
+// assignSchemaNames decides the DTO name of every component schema. Keys are
+// used as-is unless titles are preferred; a title that would collide with
+// another schema's name falls back to the schema's key. Names reserved in a
+// target language are renamed later by its generator.
+func (c *specConverter) assignSchemaNames(keys []string, schemas map[string]interface{}) {
+	names := make(map[string]string, len(keys))
+	for _, key := range keys {
+		names[key] = key
+		if !c.options.Naming.UseSchemaTitles {
+			continue
+		}
+		schema, _ := schemas[key].(map[string]interface{})
+		if title, ok := schema["title"].(string); ok {
+			if name := typeNameFromTitle(title); name != "" {
+				names[key] = name
+			}
+		}
+	}
+
+	// Falling back to a key can create a new collision, so repeat until stable.
+	// Keys are unique, so this always terminates.
+	for changed := true; changed; {
+		changed = false
+		counts := make(map[string]int, len(names))
+		for _, name := range names {
+			counts[name]++
+		}
+		for _, key := range keys {
+			if name := names[key]; name != key && counts[name] > 1 {
+				c.warn(key, "", "title-collision", fmt.Sprintf("title-derived name '%s' is used by another schema, keeping '%s'", name, key))
+				names[key] = key
+				changed = true
 			}
 		}
 	}
 
-	return dtos, nil
+	for _, key := range keys {
+		c.names[names[key]] = true
+		if names[key] != key {
+			c.refNames[key] = names[key]
+		}
+	}
+}
+
+// schemaName returns the DTO name for a components key
+func (c *specConverter) schemaName(key string) string {
+	if name, ok := c.refNames[key]; ok {
+		return name
+	}
+	return key
+}
+
+// typeNameFromTitle turns a human-friendly title like "User profile" into a
+// type name ("UserProfile"). It returns "" if no valid identifier results.
+func typeNameFromTitle(title string) string {
+	words := strings.FieldsFunc(title, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var name strings.Builder
+	for _, word := range words {
+		name.WriteString(toPascalCase(word))
+	}
+
+	result := name.String()
+	if result == "" || unicode.IsDigit(rune(result[0])) {
+		return ""
+	}
+	return result
+}
+
+// mergeAllOf records the $ref members of allOf as the DTO's parents and
+// returns the object schema made of the inline members' properties and
+// required fields, together with the schema's own
+func (c *specConverter) mergeAllOf(dto *generator.DTO, schema map[string]interface{}, allOf []interface{}) map[string]interface{} {
+	properties := make(map[string]interface{})
+	var required []interface{}
+	merge := func(member map[string]interface{}) {
+		if props, ok := member["properties"].(map[string]interface{}); ok {
+			for name, prop := range props {
+				properties[name] = prop
+			}
+		}
+		if req, ok := member["required"].([]interface{}); ok {
+			required = append(required, req...)
+		}
+	}
+
+	for _, item := range allOf {
+		member, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if ref, ok := member["$ref"].(string); ok {
+			dto.Extends = append(dto.Extends, c.schemaName(extractRefName(ref)))
+			continue
+		}
+		merge(member)
+	}
+	merge(schema)
+
+	merged := make(map[string]interface{}, len(schema))
+	for key, value := range schema {
+		merged[key] = value
+	}
+	merged["type"] = "object"
+	merged["properties"] = properties
+	merged["required"] = required
+	return merged
+}
+
+// uniqueName returns base, or base with a numeric suffix if that name is taken
+func (c *specConverter) uniqueName(base string) string {
+	if c.names == nil {
+		c.names = make(map[string]bool)
+	}
+	name := base
+	for i := 2; c.names[name]; i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+	c.names[name] = true
+	return name
+}
+
+// hoistInlineDTO materializes an inline object schema as a named DTO and
+// returns the name to reference it by
+func (c *specConverter) hoistInlineDTO(baseName string, schema map[string]interface{}) (string, error) {
+	// With Objects.Deduplicate, identical inline schemas, typically YAML
+	// aliases expanded by the parser, share one DTO instead of each getting a
+	// copy; with Objects.ReuseComponents, copies of a component reference it
+	fingerprint, ok := schemaFingerprint(schema)
+	if existing, found := c.shapes[fingerprint]; ok && found {
+		return existing, nil
+	}
+
+	name := c.uniqueName(baseName)
+	if ok && c.options.Objects.Deduplicate {
+		if c.shapes == nil {
+			c.shapes = make(map[string]string)
+		}
+		c.shapes[fingerprint] = name
+	}
+
+	dto, err := c.convertSchemaToGeneratorDTO(name, schema)
+	if err != nil {
+		return "", err
+	}
+	c.extraDTOs = append(c.extraDTOs, dto)
+	return name, nil
+}
+
+// registerShapes records the fingerprints of component object schemas, so
+// inline copies of them reference the component's DTO with
+// Objects.ReuseComponents, and finds the inline objects that occur more than
+// once for Objects.Deduplicate
+func (c *specConverter) registerShapes(keys []string, schemas map[string]interface{}) {
+	if c.shapes == nil {
+		c.shapes = make(map[string]string)
+	}
+	if c.enums == nil {
+		c.enums = make(map[string]string)
+	}
+	counts := make(map[string]int)
+	enumCounts := make(map[string]int)
+	var count func(schema map[string]interface{})
+	count = func(schema map[string]interface{}) {
+		forEachSubschema(schema, func(sub map[string]interface{}) {
+			if isInlineObject(sub) {
+				if fingerprint, ok := schemaFingerprint(sub); ok {
+					counts[fingerprint]++
+				}
+			}
+			if fingerprint, ok := enumFingerprint(sub); ok {
+				enumCounts[fingerprint]++
+			}
+			count(sub)
+		})
+	}
+
+	for _, key := range keys {
+		schema, ok := schemas[key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		count(schema)
+		// Inline enums equal to a component enum can reuse it
+		if fingerprint, ok := enumFingerprint(schema); ok && c.enums[fingerprint] == "" {
+			c.enums[fingerprint] = c.schemaName(key)
+		}
+		if !c.options.Objects.ReuseComponents || !isInlineObject(schema) {
+			continue
+		}
+		fingerprint, ok := schemaFingerprint(schema)
+		if _, taken := c.shapes[fingerprint]; ok && !taken {
+			c.shapes[fingerprint] = c.schemaName(key)
+		}
+	}
+
+	c.repeated = make(map[string]bool)
+	for fingerprint, n := range counts {
+		if n > 1 && c.options.Objects.Deduplicate {
+			c.repeated[fingerprint] = true
+		}
+	}
+	c.repeatedEnums = make(map[string]bool)
+	for fingerprint, n := range enumCounts {
+		if n > 1 {
+			c.repeatedEnums[fingerprint] = true
+		}
+	}
+}
+
+// enumFingerprint identifies an enum schema by its type and values, ignoring
+// descriptions and other annotations. Single-value enums are literals and
+// have no fingerprint.
+func enumFingerprint(schema map[string]interface{}) (string, bool) {
+	values, ok := schema["enum"].([]interface{})
+	if !ok || len(values) < 2 {
+		return "", false
+	}
+	typ, ok := schema["type"].(string)
+	if !ok {
+		typ = "string"
+	}
+	data, err := json.Marshal([]interface{}{typ, values})
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// sharedEnum returns the named enum DTO for an inline enum schema that is
+// repeated in the spec or equal to a component enum, hoisting it on first
+// use. It returns "" when the enum stays inline.
+func (c *specConverter) sharedEnum(name string, schema map[string]interface{}) (string, error) {
+	if !c.options.Enums.Deduplicate {
+		return "", nil
+	}
+	fingerprint, ok := enumFingerprint(schema)
+	if !ok {
+		return "", nil
+	}
+	if existing := c.enums[fingerprint]; existing != "" {
+		return existing, nil
+	}
+	if !c.repeatedEnums[fingerprint] {
+		return "", nil
+	}
+
+	enumName := c.uniqueName(toPascalCase(name))
+	if c.enums == nil {
+		c.enums = make(map[string]string)
+	}
+	c.enums[fingerprint] = enumName
+	dto, err := c.convertSchemaToGeneratorDTO(enumName, map[string]interface{}{"type": schema["type"], "enum": schema["enum"]})
+	if err != nil {
+		return "", err
+	}
+	c.extraDTOs = append(c.extraDTOs, dto)
+	return enumName, nil
+}
+
+// forEachSubschema calls fn for every schema directly nested in schema
+func forEachSubschema(schema map[string]interface{}, fn func(map[string]interface{})) {
+	if props, ok := schema["properties"].(map[string]interface{}); ok {
+		for _, prop := range props {
+			if sub, ok := prop.(map[string]interface{}); ok {
+				fn(sub)
+			}
+		}
+	}
+	for _, keyword := range []string{"items", "additionalProperties", "not"} {
+		if sub, ok := schema[keyword].(map[string]interface{}); ok {
+			fn(sub)
+		}
+	}
+	for _, keyword := range []string{"items", "prefixItems", "allOf", "anyOf", "oneOf"} {
+		if members, ok := schema[keyword].([]interface{}); ok {
+			for _, member := range members {
+				if sub, ok := member.(map[string]interface{}); ok {
+					fn(sub)
+				}
+			}
+		}
+	}
+}
+
+// schemaFingerprint returns a canonical encoding of a schema; structurally
+// identical schemas have the same fingerprint
+func schemaFingerprint(schema map[string]interface{}) (string, bool) {
+	// encoding/json sorts map keys, so equal maps encode identically
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// wrappedRef recognizes the 3.0 allOf: [{$ref}] wrapper, used to put siblings
+// such as nullable: true next to a reference
+func wrappedRef(schema map[string]interface{}) (string, bool) {
+	if members, ok := schema["allOf"].([]interface{}); ok && len(members) == 1 {
+		if member, ok := members[0].(map[string]interface{}); ok {
+			if ref, ok := member["$ref"].(string); ok {
+				return ref, true
+			}
+		}
+	}
+	return "", false
+}
+
+// nullableVariant recognizes a schema that is a single type plus null, either
+// as anyOf/oneOf: [T, {type: 'null'}] or as type: [T, 'null'], and returns T
+// marked nullable with the outer schema's other keywords (description, ...)
+func nullableVariant(schema map[string]interface{}) (map[string]interface{}, bool) {
+	if types, ok := schema["type"].([]interface{}); ok && len(types) == 2 {
+		for i, typ := range types {
+			if typ == "null" {
+				if other, ok := types[1-i].(string); ok && other != "null" {
+					merged := copySchema(schema)
+					merged["type"] = other
+					merged["nullable"] = true
+					return merged, true
+				}
+			}
+		}
+	}
+
+	for _, keyword := range []string{"anyOf", "oneOf"} {
+		members, ok := schema[keyword].([]interface{})
+		if !ok || len(members) != 2 {
+			continue
+		}
+		for i, item := range members {
+			member, _ := item.(map[string]interface{})
+			if member["type"] != "null" {
+				continue
+			}
+			other, ok := members[1-i].(map[string]interface{})
+			if !ok || other["type"] == "null" {
+				break
+			}
+			merged := copySchema(schema)
+			delete(merged, keyword)
+			for key, value := range other {
+				if _, exists := merged[key]; !exists {
+					merged[key] = value
+				}
+			}
+			merged["nullable"] = true
+			return merged, true
+		}
+	}
+
+	return nil, false
+}
+
+// copySchema returns a shallow copy of a schema
+func copySchema(schema map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(schema))
+	for key, value := range schema {
+		copied[key] = value
+	}
+	return copied
+}
+
+// isInlineObject reports whether a schema declares an object structure in place
+func isInlineObject(schema map[string]interface{}) bool {
+	if _, isRef := schema["$ref"]; isRef {
+		return false
+	}
+	_, hasProps := schema["properties"].(map[string]interface{})
+	return schema["type"] == "object" && hasProps
 }
 
-func convertSchemaToGeneratorDTO(name string, schema map[string]interface{}) (generator.DTO, error) {
+func (c *specConverter) convertSchemaToGeneratorDTO(name string, schema map[string]interface{}) (generator.DTO, error) {
 	dto := generator.DTO{
 		Name:       name,
 		Properties: []generator.Property{},
 		Required:   []string{},
-		Metadata:   make(map[string]string),
+		Metadata:   make(generator.Metadata),
 	}
 
 	if desc, ok := schema["description"].(string); ok {
 		dto.Description = desc
 	}
 
+	if deprecated, ok := schema["deprecated"].(bool); ok {
+		dto.Deprecated = deprecated
+	}
+
+	if err := collectExamples(schema, dto.Metadata); err != nil {
+		return dto, err
+	}
+	dto.Examples = exampleValues(schema)
+
+	if err := collectExtensions(schema, dto.Metadata); err != nil {
+		return dto, err
+	}
+
+	if err := c.collectNot(name, "", schema, dto.Metadata); err != nil {
+		return dto, err
+	}
+
 	// Handle enum types
 	if enumVals, ok := schema["enum"].([]interface{}); ok {
 		dto.Type = "enum"
+		if values, valueType, ok := numericEnum(enumVals, schema["type"]); ok {
+			dto.EnumValues = values
+			dto.EnumValueType = valueType
+			return dto, nil
+		}
 		for _, val := range enumVals {
 			if strVal, ok := val.(string); ok {
 				dto.EnumValues = append(dto.EnumValues, strVal)
+			} else {
+				c.warnDroppedEnumValue(name, "", val)
 			}
 		}
 		return dto, nil
 	}
 
+	// A oneOf/anyOf schema without properties of its own is a union of its
+	// members; a null member makes the union accept null
+	if _, hasProps := schema["properties"]; !hasProps {
+		for _, keyword := range []string{"oneOf", "anyOf"} {
+			members, ok := schema[keyword].([]interface{})
+			if !ok {
+				continue
+			}
+			unionType, nullable, err := c.convertUnionMembers(name, "", members)
+			if err != nil {
+				return dto, err
+			}
+			dto.Type = "union"
+			if union, ok := unionType.(generator.UnionType); ok {
+				dto.UnionMembers = union.Types
+				dto.Discriminator = discriminatorProperty(schema)
+			} else {
+				dto.UnionMembers = []generator.IRType{unionType}
+			}
+			if nullable {
+				dto.UnionMembers = append(dto.UnionMembers, generator.NewLiteralType(nil))
+			}
+			return dto, nil
+		}
+	}
+
+	// allOf: [{$ref: Base}, {properties: ...}] extends Base with the inline
+	// members' properties
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		schema = c.mergeAllOf(&dto, schema, allOf)
+	}
+
 	// Capture required fields
 	if req, ok := schema["required"].([]interface{}); ok {
 		for _, r := range req {
@@ -201,6 +1186,11 @@ func convertSchemaToGeneratorDTO(name string, schema map[string]interface{}) (ge
 	// Process object properties
 	if typ, ok := schema["type"].(string); ok && typ == "object" {
 		dto.Type = "object"
+		if additional, ok, err := c.convertAdditionalProperties(name, "", schema); err != nil {
+			return dto, fmt.Errorf("failed to convert additionalProperties: %w", err)
+		} else if ok {
+			dto.AdditionalProperties = additional
+		}
 		if props, ok := schema["properties"].(map[string]interface{}); ok {
 			// IMPORTANT: Sort property names for consistent ordering
 			var propNames []string
@@ -213,7 +1203,7 @@ func convertSchemaToGeneratorDTO(name string, schema map[string]interface{}) (ge
 			for _, propName := range propNames {
 				propVal := props[propName]
 				if propSchema, ok := propVal.(map[string]interface{}); ok {
-					property, err := convertSchemaToGeneratorProperty(propName, propSchema, dto.Required)
+					property, err := c.convertSchemaToGeneratorProperty(name, propName, propSchema, dto.Required)
 					if err != nil {
 						return dto, fmt.Errorf("failed to convert property %s: %w", propName, err)
 					}
@@ -226,10 +1216,10 @@ func convertSchemaToGeneratorDTO(name string, schema map[string]interface{}) (ge
 	return dto, nil
 }
 
-func convertSchemaToGeneratorProperty(name string, schema map[string]interface{}, required []string) (generator.Property, error) {
+func (c *specConverter) convertSchemaToGeneratorProperty(dtoName, name string, schema map[string]interface{}, required []string) (generator.Property, error) {
 	prop := generator.Property{
 		Name:     name,
-		Metadata: make(map[string]string),
+		Metadata: make(generator.Metadata),
 	}
 
 	// Check if property is required
@@ -248,17 +1238,94 @@ func convertSchemaToGeneratorProperty(name string, schema map[string]interface{}
 		prop.Nullable = nullable
 	}
 
+	if defaultValue, ok := schema["default"]; ok {
+		prop.Default = defaultValue
+	}
+
+	if deprecated, ok := schema["deprecated"].(bool); ok {
+		prop.Deprecated = deprecated
+	}
+
+	if readOnly, ok := schema["readOnly"].(bool); ok {
+		prop.ReadOnly = readOnly
+	}
+	if writeOnly, ok := schema["writeOnly"].(bool); ok {
+		prop.WriteOnly = writeOnly
+	}
+
+	if err := collectExamples(schema, prop.Metadata); err != nil {
+		return prop, err
+	}
+	prop.Examples = exampleValues(schema)
+
+	if err := collectExtensions(schema, prop.Metadata); err != nil {
+		return prop, err
+	}
+
+	if err := c.collectNot(dtoName, name, schema, prop.Metadata); err != nil {
+		return prop, err
+	}
+
+	// anyOf/oneOf: [T, {type: 'null'}] and type: [T, 'null'] are just a nullable T
+	if nullableSchema, ok := nullableVariant(schema); ok {
+		return c.convertSchemaToGeneratorProperty(dtoName, name, nullableSchema, required)
+	}
+
+	// A $ref wrapped in allOf (to allow siblings like nullable) keeps its reference type
+	if ref, ok := wrappedRef(schema); ok {
+		prop.Type = generator.ReferenceType{RefName: c.schemaName(extractRefName(ref))}
+		return prop, nil
+	}
+
+	// oneOf/anyOf: [{$ref: A}, {$ref: B}, ...] becomes a union of the members
+	for _, keyword := range []string{"oneOf", "anyOf"} {
+		if members, ok := schema[keyword].([]interface{}); ok {
+			unionType, nullable, err := c.convertUnionMembers(dtoName, name, members)
+			if err != nil {
+				return prop, err
+			}
+			if union, ok := unionType.(generator.UnionType); ok {
+				union.Discriminator = discriminatorProperty(schema)
+				unionType = union
+			}
+			prop.Type = unionType
+			prop.Nullable = prop.Nullable || nullable
+			return prop, nil
+		}
+	}
+
+	// const and single-value enums allow exactly one value. Only scalars have
+	// a literal type, an object or array value keeps the schema's type.
+	value, single := schema["const"]
+	if enumVals, ok := schema["enum"].([]interface{}); ok && !single && len(enumVals) == 1 {
+		value, single = enumVals[0], true
+	}
+	if single {
+		if generator.IsLiteralValue(value) {
+			prop.Type = generator.NewLiteralType(value)
+			return prop, nil
+		}
+		c.warn(dtoName, name, "unsupported-const", fmt.Sprintf("const value %s is not a string, number, boolean or null, generated as the schema's type", generator.ValueLiteral(value)))
+	}
+
 	// Handle enum within property
-	if enumVals, ok := schema["enum"].([]interface{}); ok {
-		var values []string
-		underlyingType := "string"
-		if typ, ok := schema["type"].(string); ok {
-			underlyingType = typ
+	if enumVals, ok := schema["enum"].([]interface{}); ok && !single {
+		if refName, err := c.sharedEnum(name, schema); err != nil {
+			return prop, err
+		} else if refName != "" {
+			prop.Type = generator.ReferenceType{RefName: refName}
+			return prop, nil
 		}
 
-		for _, val := range enumVals {
-			if strVal, ok := val.(string); ok {
-				values = append(values, strVal)
+		values, underlyingType, numeric := numericEnum(enumVals, schema["type"])
+		if !numeric {
+			values, underlyingType = nil, "string"
+			for _, val := range enumVals {
+				if strVal, ok := val.(string); ok {
+					values = append(values, strVal)
+				} else {
+					c.warnDroppedEnumValue(dtoName, name, val)
+				}
 			}
 		}
 
@@ -278,26 +1345,61 @@ func convertSchemaToGeneratorProperty(name string, schema map[string]interface{}
 			if f, ok := schema["format"].(string); ok {
 				format = f
 			}
-			prop.Type = generator.PrimitiveType{Name: "string", Format: format}
+			typ := "string"
+			if format == "binary" || format == "byte" {
+				// File contents, raw or base64 encoded
+				typ = "binary"
+			}
+			prop.Type = generator.PrimitiveType{Name: typ, Format: format, Constraints: collectConstraints(schema)}
 		case "number", "integer":
-			prop.Type = generator.PrimitiveType{Name: typ}
+			format := ""
+			if f, ok := schema["format"].(string); ok {
+				format = f
+			}
+			// Integers too large for a JS number are generated as bigint when configured
+			if typ == "integer" && format != "" && slices.Contains(c.options.Types.BigIntFormats, format) {
+				typ = "bigint"
+			}
+			prop.Type = generator.PrimitiveType{Name: typ, Format: format, Constraints: collectConstraints(schema)}
 		case "boolean":
 			prop.Type = generator.PrimitiveType{Name: "boolean"}
 		case "array":
-			if items, ok := schema["items"].(map[string]interface{}); ok {
-				itemProp, err := convertSchemaToGeneratorProperty(name+"Item", items, []string{})
-				if err != nil {
-					return prop, err
-				}
-				prop.Type = generator.ArrayType{ElementType: itemProp.Type}
+			arrayType, err := c.convertArrayItems(dtoName, name, schema)
+			if err != nil {
+				return prop, err
 			}
+			if array, ok := arrayType.(generator.ArrayType); ok {
+				array.Constraints = collectConstraints(schema)
+				arrayType = array
+			}
+			prop.Type = arrayType
 		case "object":
 			if ref, ok := schema["$ref"].(string); ok {
-				refName := extractRefName(ref)
+				refName := c.schemaName(extractRefName(ref))
 				prop.Type = generator.ReferenceType{RefName: refName}
 			} else {
+				// Objects without properties but with additionalProperties are dictionaries
+				if _, hasProps := schema["properties"]; !hasProps {
+					if valueType, ok, err := c.convertAdditionalProperties(dtoName, name, schema); err != nil {
+						return prop, err
+					} else if ok {
+						prop.Type = generator.MapType{KeyType: generator.PrimitiveType{Name: "string"}, ValueType: valueType}
+						break
+					}
+				}
+
+				// Inline objects repeated in the spec share a named DTO
+				if fingerprint, ok := schemaFingerprint(schema); ok && (c.repeated[fingerprint] || c.shapes[fingerprint] != "") {
+					refName, err := c.hoistInlineDTO(dtoName+toPascalCase(name), schema)
+					if err != nil {
+						return prop, err
+					}
+					prop.Type = generator.ReferenceType{RefName: refName}
+					break
+				}
+
 				// Inline object - create a nested DTO
-				nestedDTO, err := convertSchemaToGeneratorDTO(name, schema)
+				nestedDTO, err := c.convertSchemaToGeneratorDTO(name, schema)
 				if err != nil {
 					return prop, err
 				}
@@ -307,7 +1409,7 @@ func convertSchemaToGeneratorProperty(name string, schema map[string]interface{}
 			prop.Type = generator.PrimitiveType{Name: typ}
 		}
 	} else if ref, ok := schema["$ref"].(string); ok {
-		refName := extractRefName(ref)
+		refName := c.schemaName(extractRefName(ref))
 		prop.Type = generator.ReferenceType{RefName: refName}
 	} else {
 		prop.Type = generator.PrimitiveType{Name: "unknown"}
@@ -316,14 +1418,302 @@ func convertSchemaToGeneratorProperty(name string, schema map[string]interface{}
 	return prop, nil
 }
 
+// collectExamples stores the schema's example/examples values in metadata as
+// JSON so templates can surface them without re-parsing the spec
+func collectExamples(schema map[string]interface{}, metadata generator.Metadata) error {
+	for _, key := range []string{"example", "examples"} {
+		value, ok := schema[key]
+		if !ok {
+			continue
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", key, err)
+		}
+		metadata.Set(key, string(encoded))
+	}
+	return nil
+}
+
+// exampleValues returns the schema's example values: example, the JSON Schema
+// examples list, or the values of an OpenAPI examples map ({name: {value}})
+func exampleValues(schema map[string]interface{}) []any {
+	var examples []any
+	if example, ok := schema["example"]; ok {
+		examples = append(examples, example)
+	}
+
+	switch list := schema["examples"].(type) {
+	case []interface{}:
+		examples = append(examples, list...)
+	case map[string]interface{}:
+		var names []string
+		for name := range list {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if example, ok := list[name].(map[string]interface{}); ok {
+				if value, ok := example["value"]; ok {
+					examples = append(examples, value)
+				}
+			}
+		}
+	}
+	return examples
+}
+
+// collectConstraints reads the validation keywords of a schema, returning nil
+// if it has none
+func collectConstraints(schema map[string]interface{}) *generator.Constraints {
+	var constraints generator.Constraints
+	found := false
+
+	intValue := func(key string) *int {
+		if n, ok := numberValue(schema[key]); ok {
+			found = true
+			i := int(n)
+			return &i
+		}
+		return nil
+	}
+	floatValue := func(key string) *float64 {
+		if n, ok := numberValue(schema[key]); ok {
+			found = true
+			return &n
+		}
+		return nil
+	}
+
+	constraints.MinLength = intValue("minLength")
+	constraints.MaxLength = intValue("maxLength")
+	constraints.Minimum = floatValue("minimum")
+	constraints.Maximum = floatValue("maximum")
+	constraints.ExclusiveMinimum = floatValue("exclusiveMinimum")
+	constraints.ExclusiveMaximum = floatValue("exclusiveMaximum")
+	constraints.MultipleOf = floatValue("multipleOf")
+	constraints.MinItems = intValue("minItems")
+	constraints.MaxItems = intValue("maxItems")
+
+	// OpenAPI 3.0 marks minimum/maximum exclusive with a boolean instead
+	if exclusive, _ := schema["exclusiveMinimum"].(bool); exclusive && constraints.Minimum != nil {
+		constraints.ExclusiveMinimum, constraints.Minimum = constraints.Minimum, nil
+	}
+	if exclusive, _ := schema["exclusiveMaximum"].(bool); exclusive && constraints.Maximum != nil {
+		constraints.ExclusiveMaximum, constraints.Maximum = constraints.Maximum, nil
+	}
+
+	if pattern, ok := schema["pattern"].(string); ok {
+		constraints.Pattern = pattern
+		found = true
+	}
+	if unique, ok := schema["uniqueItems"].(bool); ok && unique {
+		constraints.UniqueItems = true
+		found = true
+	}
+
+	if !found {
+		return nil
+	}
+	return &constraints
+}
+
+// numberValue converts a number decoded from YAML or JSON to float64
+func numberValue(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// collectExtensions copies vendor extensions (x-*) into metadata under their
+// own key. Values are stored as decoded from the spec, so structured
+// extensions stay structured; Metadata.String gives their text form.
+func collectExtensions(schema map[string]interface{}, metadata generator.Metadata) error {
+	for key, value := range schema {
+		if !strings.HasPrefix(key, "x-") {
+			continue
+		}
+		// Values must survive JSON encoding, for the IR and Metadata.String
+		if _, err := json.Marshal(value); err != nil {
+			return fmt.Errorf("failed to encode %s: %w", key, err)
+		}
+		metadata.Set(key, value)
+	}
+	return nil
+}
+
+// convertArrayItems converts an array schema's items, recursing for nested
+// arrays so that e.g. number[][] keeps every dimension
+func (c *specConverter) convertArrayItems(dtoName, name string, schema map[string]interface{}) (generator.IRType, error) {
+	// Tuples: prefixItems (3.1) or the array form of items (older drafts)
+	if prefixItems, ok := schema["prefixItems"].([]interface{}); ok {
+		return c.convertTupleItems(dtoName, name, prefixItems, schema["items"])
+	}
+	if itemList, ok := schema["items"].([]interface{}); ok {
+		return c.convertTupleItems(dtoName, name, itemList, schema["additionalItems"])
+	}
+
+	items, ok := schema["items"].(map[string]interface{})
+	if !ok {
+		return generator.ArrayType{ElementType: generator.PrimitiveType{Name: "unknown"}}, nil
+	}
+
+	elementType, err := c.convertItemSchema(dtoName, name+"Item", items)
+	if err != nil {
+		return nil, err
+	}
+	return generator.ArrayType{ElementType: elementType}, nil
+}
+
+// convertTupleItems converts positional item schemas, and the schema of any
+// further elements, into a TupleType
+func (c *specConverter) convertTupleItems(dtoName, name string, items []interface{}, rest interface{}) (generator.IRType, error) {
+	tuple := generator.TupleType{}
+	for i, item := range items {
+		itemSchema, ok := item.(map[string]interface{})
+		if !ok {
+			tuple.ElementTypes = append(tuple.ElementTypes, generator.PrimitiveType{Name: "unknown"})
+			continue
+		}
+		elementType, err := c.convertItemSchema(dtoName, fmt.Sprintf("%sItem%d", name, i), itemSchema)
+		if err != nil {
+			return nil, err
+		}
+		tuple.ElementTypes = append(tuple.ElementTypes, elementType)
+	}
+
+	// Elements after the positional ones: items (prefixItems) or
+	// additionalItems (array-form items); false or absent means none
+	if restSchema, ok := rest.(map[string]interface{}); ok {
+		restType, err := c.convertItemSchema(dtoName, name+"Rest", restSchema)
+		if err != nil {
+			return nil, err
+		}
+		tuple.Rest = restType
+	}
+	return tuple, nil
+}
+
+// convertUnionMembers converts oneOf/anyOf members into a UnionType. A
+// {type: 'null'} member makes the property nullable instead of joining the
+// union, and a single remaining member is used as-is.
+func (c *specConverter) convertUnionMembers(dtoName, name string, members []interface{}) (generator.IRType, bool, error) {
+	var types []generator.IRType
+	nullable := false
+	for i, item := range members {
+		member, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if member["type"] == "null" {
+			nullable = true
+			continue
+		}
+		memberType, err := c.convertItemSchema(dtoName, fmt.Sprintf("%sOption%d", name, i+1), member)
+		if err != nil {
+			return nil, false, err
+		}
+		types = append(types, memberType)
+	}
+
+	switch len(types) {
+	case 0:
+		return generator.PrimitiveType{Name: "unknown"}, nullable, nil
+	case 1:
+		return types[0], nullable, nil
+	}
+	return generator.UnionType{Types: types}, nullable, nil
+}
+
+// discriminatorProperty returns the discriminator.propertyName of a
+// oneOf/anyOf schema, or "" if it has none
+func discriminatorProperty(schema map[string]interface{}) string {
+	discriminator, ok := schema["discriminator"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	propertyName, _ := discriminator["propertyName"].(string)
+	return propertyName
+}
+
+// convertAdditionalProperties returns the value type of the keys an object
+// allows beyond its fixed properties, and false if it allows none
+func (c *specConverter) convertAdditionalProperties(dtoName, name string, schema map[string]interface{}) (generator.IRType, bool, error) {
+	switch additional := schema["additionalProperties"].(type) {
+	case bool:
+		if additional {
+			return generator.PrimitiveType{Name: "unknown"}, true, nil
+		}
+	case map[string]interface{}:
+		valueType, err := c.convertItemSchema(dtoName, name+"Value", additional)
+		return valueType, err == nil, err
+	}
+	return nil, false, nil
+}
+
+// convertItemSchema converts the schema of a single array or tuple element
+func (c *specConverter) convertItemSchema(dtoName, name string, items map[string]interface{}) (generator.IRType, error) {
+	// Inline item objects become their own DTO, e.g. Order.lines -> OrderLinesItem
+	if isInlineObject(items) {
+		refName, err := c.hoistInlineDTO(dtoName+toPascalCase(name), items)
+		if err != nil {
+			return nil, err
+		}
+		return generator.ReferenceType{RefName: refName}, nil
+	}
+
+	if items["type"] == "array" {
+		return c.convertArrayItems(dtoName, name, items)
+	}
+
+	itemProp, err := c.convertSchemaToGeneratorProperty(dtoName, name, items, []string{})
+	if err != nil {
+		return nil, err
+	}
+	return itemProp.Type, nil
+}
+
+// collectNot records an unenforceable 'not' subschema in metadata and warns about it.
+// The rest of the schema is still converted, giving the closest representable type.
+func (c *specConverter) collectNot(schemaName, propName string, schema map[string]interface{}, metadata generator.Metadata) error {
+	notSchema, ok := schema["not"]
+	if !ok {
+		return nil
+	}
+
+	encoded, err := json.Marshal(notSchema)
+	if err != nil {
+		return fmt.Errorf("failed to encode not: %w", err)
+	}
+	metadata.Set("not", string(encoded))
+
+	c.warn(schemaName, propName, "unsupported-not",
+		fmt.Sprintf("'not' is not supported, generated type does not exclude %s", encoded))
+	return nil
+}
+
+func toPascalCase(s string) string {
+	if len(s) == 0 {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
 func extractRefName(ref string) string {
 	parts := strings.Split(ref, "/")
 	return parts[len(parts)-1]
 }
 
-func main() {
-	config := parseCLIArgs()
-
+// newRegistry returns a registry with the generator of every target language
+func newRegistry() *generator.Registry {
 	registry := generator.NewRegistry()
 
 	tsGen := typescript.NewTypeScriptGenerator()
@@ -332,12 +1722,112 @@ func main() {
 	zodGen := zod.NewZodGenerator()
 	registry.Register(zodGen)
 
+	zod4Gen := zod.NewZod4Generator()
+	registry.Register(zod4Gen)
+
+	nestjsGen := nestjs.NewNestJSGenerator()
+	registry.Register(nestjsGen)
+
+	javaGen := java.NewJavaGenerator()
+	registry.Register(javaGen)
+
+	protoGen := proto.NewProtoGenerator()
+	registry.Register(protoGen)
+
+	graphqlGen := graphql.NewGraphQLGenerator()
+	registry.Register(graphqlGen)
+
+	prismaGen := prisma.NewPrismaGenerator()
+	registry.Register(prismaGen)
+
+	return registry
+}
+
+func main() {
+	if len(os.Args) > 1 && (os.Args[1] == "init" || os.Args[1] == "list") {
+		var err error
+		if os.Args[1] == "init" {
+			err = runInit(os.Args[2:], os.Stdin, os.Stdout, isTerminal(os.Stdin))
+		} else {
+			err = runList(os.Args[2:], os.Stdout)
+		}
+		if err != nil && !errors.Is(err, flag.ErrHelp) {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+		return
+	}
+
+	config := parseCLIArgs()
+	timer := newStageTimer()
+	var durations reportDurations
+
+	// -out - streams the generated file to stdout, so everything else that
+	// is printed goes to stderr. The same goes for a report on stdout.
+	streaming := config.OutputFolder == "-"
+	stdout := os.Stdout
+	if streaming || (config.Report != "" && config.ReportFile == "-") {
+		os.Stdout = os.Stderr
+	}
+
+	// The config file can name the spec and language, so no flags are needed
+	if configFile := discoverConfigFile(config); configFile != "" {
+		if err := applyProjectOptions(&config, configFile); err != nil {
+			fmt.Printf("Error loading config file: %v\n", err)
+			os.Exit(exitConfigError)
+		}
+		config.ConfigFile = configFile
+	}
+	if config.OpenAPIFile == "" && config.FromIR == "" {
+		fmt.Println("Error: OpenAPI spec file is required. Use the -openapi flag or set openapi in the config file.")
+		flag.Usage()
+		os.Exit(exitUsage)
+	}
+
+	registry := newRegistry()
+
 	// Get the appropriate generator
 	gen, err := registry.Get(config.TargetLanguage)
 	if err != nil {
 		fmt.Printf("Error: %v\n", err)
 		fmt.Printf("Available languages: %v\n", registry.Available())
-		os.Exit(1)
+		os.Exit(exitUsage)
+	}
+
+	// Read and parse OpenAPI spec, unless the DTOs come from a saved IR file
+	var spec *OpenAPISpec
+	var issues []specIssue
+	if config.FromIR == "" {
+		spec, err = readOpenAPISpec(config.OpenAPIFile)
+		if err != nil {
+			fmt.Printf("Error reading OpenAPI spec: %v\n", err)
+			os.Exit(exitSpecError)
+		}
+
+		// Report every problem in the spec at once before converting it
+		issues = validateSpec(spec)
+	}
+	durations.Parse = timer.lap()
+	if config.ValidateOnly {
+		printSpecIssues(issues)
+		if hasErrors(issues) {
+			os.Exit(exitSpecError)
+		}
+		if len(issues) == 0 {
+			fmt.Println("✅ OpenAPI spec is valid")
+		}
+		os.Exit(0)
+	}
+	if hasErrors(issues) {
+		printSpecIssues(issues)
+		fmt.Println("Error: the OpenAPI spec has errors, nothing was generated")
+		os.Exit(exitSpecError)
+	}
+
+	// Warnings from every stage are summarized at the end of the run
+	warnings := &generator.WarningCollector{}
+	for _, issue := range issues {
+		warnings.Add(issue.Warning())
 	}
 
 	// Discover config file BEFORE setting up output directory
@@ -352,46 +1842,86 @@ func main() {
 
 	// Load config to get default output folder if CLI didn't specify one
 	finalOutputFolder := config.OutputFolder
+	prune := config.Clean
 	if configFile != "" {
 		// Create a temporary registry just to load the config and get output settings
 		tempRegistry := typescript.NewCustomTypeRegistry()
 		if err := tempRegistry.LoadFromConfig(configFile); err != nil {
-			fmt.Printf("Warning: Failed to load config file %s: %v\n", configFile, err)
-		} else {
-			outputConfig := tempRegistry.GetOutputConfig()
-			// Only use config's output folder if CLI didn't specify one (still using default)
-			if config.OutputFolder == "./generated" && outputConfig.Folder != "" {
-				finalOutputFolder = outputConfig.Folder
-				fmt.Printf("📁 Using output folder from config: %s\n", finalOutputFolder)
-			}
+			fmt.Printf("Error loading config file: %v\n", err)
+			os.Exit(exitConfigError)
+		}
+		outputConfig := tempRegistry.GetOutputConfig()
+		prune = prune || outputConfig.Prune
+		// Only use config's output folder if neither -out nor DTOFORGE_OUT set one
+		if !config.SetFlags["out"] && outputConfig.Folder != "" && !streaming {
+			finalOutputFolder = outputConfig.Folder
+			fmt.Printf("📁 Using output folder from config: %s\n", finalOutputFolder)
 		}
 	}
 
-	if err := os.MkdirAll(finalOutputFolder, 0755); err != nil {
-		fmt.Printf("Error creating output directory: %v\n", err)
-		os.Exit(1)
+	// -check leaves the output folder as it is
+	if !streaming && !config.Check {
+		if err := os.MkdirAll(finalOutputFolder, 0755); err != nil {
+			fmt.Printf("Error creating output directory: %v\n", err)
+			os.Exit(exitGenerationError)
+		}
 	}
 
-	// Read and parse OpenAPI spec
-	spec, err := readOpenAPISpec(config.OpenAPIFile)
+	conversionOptions, err := loadConversionOptions(configFile)
 	if err != nil {
-		fmt.Printf("Error reading OpenAPI spec: %v\n", err)
-		os.Exit(1)
+		fmt.Printf("Error loading config file: %v\n", err)
+		os.Exit(exitConfigError)
+	}
+	if config.UseTitles {
+		conversionOptions.Naming.UseSchemaTitles = true
 	}
 
-	// Convert to generator DTOs
-	dtos, err := convertToGeneratorDTOs(spec)
-	if err != nil {
-		fmt.Printf("Error converting spec to DTOs: %v\n", err)
-		os.Exit(1)
+	var dtos []generator.DTO
+	if config.FromIR != "" {
+		dtos, err = readIR(config.FromIR)
+		if err != nil {
+			fmt.Printf("Error reading IR: %v\n", err)
+			os.Exit(exitSpecError)
+		}
+		fmt.Printf("✅ Loaded %d schemas from %s\n", len(dtos), config.FromIR)
+	} else {
+		// Convert to generator DTOs
+		var conversionWarnings []generator.Warning
+		dtos, conversionWarnings, err = convertToGeneratorDTOs(spec, conversionOptions)
+		if err != nil {
+			fmt.Printf("Error converting spec to DTOs: %v\n", err)
+			os.Exit(exitSpecError)
+		}
+		for _, warning := range conversionWarnings {
+			warnings.Add(warning)
+		}
+
+		if len(dtos) == 0 {
+			fmt.Println("No schemas found in the OpenAPI spec")
+			os.Exit(exitSpecError)
+		}
+
+		fmt.Printf("✅ Successfully parsed %d schemas from OpenAPI spec\n", len(dtos))
 	}
+	durations.Convert = timer.lap()
 
-	if len(dtos) == 0 {
-		fmt.Println("No schemas found in the OpenAPI spec")
-		os.Exit(1)
+	if config.EmitIR != "" {
+		if err := writeIR(config.EmitIR, dtos); err != nil {
+			fmt.Printf("Error writing IR: %v\n", err)
+			os.Exit(exitGenerationError)
+		}
+		fmt.Printf("📦 Wrote IR (version %d) to %s\n", generator.IRVersion, config.EmitIR)
 	}
 
-	fmt.Printf("✅ Successfully parsed %d schemas from OpenAPI spec\n", len(dtos))
+	// A streamed file is generated into a scratch folder first
+	if streaming {
+		finalOutputFolder, err = os.MkdirTemp("", "dtoforge-")
+		if err != nil {
+			fmt.Printf("Error creating output directory: %v\n", err)
+			os.Exit(exitGenerationError)
+		}
+		defer os.RemoveAll(finalOutputFolder)
+	}
 
 	// Generate code
 	genConfig := generator.Config{
@@ -399,12 +1929,156 @@ func main() {
 		PackageName:    config.PackageName,
 		TargetLanguage: config.TargetLanguage,
 		ConfigFile:     configFile, // This will be empty if --no-config is used
+		Warnings:       warnings,
+		Parallel:       config.Parallel,
+		Files:          &generator.FileRecorder{},
+		Naming: generator.Naming{
+			ReservedWordStrategy: conversionOptions.Naming.ReservedWordStrategy,
+			ReservedWordAffix:    conversionOptions.Naming.ReservedWordAffix,
+		},
+	}
+
+	// Output generated from the same inputs as last time is left as it is,
+	// and otherwise the files of unchanged schemas are kept
+	var previous generationManifest
+	var inputs runInputs
+	upToDate := false
+	if !streaming {
+		previous, err = readManifest(finalOutputFolder)
+		if err == nil {
+			inputs, err = hashInputs(config.TargetLanguage, config.PackageName, configFile, dtos)
+		}
+		if err == nil && !config.Force {
+			upToDate = previous.upToDate(finalOutputFolder, inputs.hash)
+			if !upToDate {
+				genConfig.Unchanged, err = previous.unchangedSchemas(finalOutputFolder, inputs.settings, dtos)
+			}
+		}
+		if err != nil {
+			fmt.Printf("Error reading the generation manifest: %v\n", err)
+			os.Exit(exitGenerationError)
+		}
+	}
+
+	var files, differences []string
+	if upToDate {
+		// Generating again would report the same warnings as last time
+		for _, warning := range previous.Warnings {
+			warnings.Add(warning)
+		}
+		files = previous.files()
+		fmt.Printf("✅ %s code in %s is up to date\n", config.TargetLanguage, finalOutputFolder)
+	} else {
+		// -check generates over a copy of the output folder and compares
+		var scratch string
+		if config.Check {
+			scratch, err = os.MkdirTemp("", "dtoforge-check-")
+			if err == nil {
+				err = copyFolder(finalOutputFolder, scratch)
+			}
+			if err != nil {
+				fmt.Printf("Error copying the output folder: %v\n", err)
+				os.RemoveAll(scratch)
+				os.Exit(exitGenerationError)
+			}
+			genConfig.OutputFolder = scratch
+		}
+
+		converted := len(warnings.Warnings())
+		if err := gen.Generate(dtos, genConfig); err != nil {
+			var configErr *generator.ConfigError
+			if errors.As(err, &configErr) && configErr.Path != "" {
+				fmt.Printf("Error in config file %s: %v\n", configErr.Path, err)
+			} else {
+				fmt.Printf("Error generating code: %v\n", err)
+			}
+			if streaming {
+				os.RemoveAll(finalOutputFolder)
+			}
+			os.RemoveAll(scratch)
+			os.Exit(exitCode(err))
+		}
+
+		files = generatedFiles(genConfig.OutputFolder, genConfig.Files.Paths())
+
+		// The generator did not get to report again the warnings of the
+		// schemas whose files it kept
+		for _, warning := range previous.keptWarnings(genConfig.Unchanged, warnings.Warnings()[converted:]) {
+			warnings.Add(warning)
+		}
+
+		if config.Check {
+			differences, err = outputDifferences(finalOutputFolder, scratch, files, previous, prune)
+			os.RemoveAll(scratch)
+			if err != nil {
+				fmt.Printf("Error comparing the output: %v\n", err)
+				os.Exit(exitGenerationError)
+			}
+			if len(differences) == 0 {
+				fmt.Printf("✅ %s code in %s is up to date\n", config.TargetLanguage, finalOutputFolder)
+			} else {
+				fmt.Printf("❌ %d files in %s differ from the generated %s code:\n", len(differences), finalOutputFolder, config.TargetLanguage)
+				for _, file := range differences {
+					fmt.Printf("  %s\n", file)
+				}
+			}
+		} else if streaming {
+			err := streamOutput(finalOutputFolder, stdout)
+			os.RemoveAll(finalOutputFolder)
+			if err != nil {
+				fmt.Printf("Error writing to stdout: %v\n", err)
+				os.Exit(exitGenerationError)
+			}
+			fmt.Printf("🚀 Successfully generated %s code to stdout\n", config.TargetLanguage)
+		} else {
+			fmt.Printf("🚀 Successfully generated %s code in %s\n", config.TargetLanguage, finalOutputFolder)
+			if err := finishOutput(finalOutputFolder, previous, files, inputs, dtos, genConfig.Unchanged, warnings.Warnings()[converted:], prune); err != nil {
+				fmt.Printf("Error updating the generation manifest: %v\n", err)
+				os.Exit(exitGenerationError)
+			}
+		}
+	}
+	durations.Generate = timer.lap()
+
+	fmt.Print(warnings.Summary())
+	if config.WarningsJSON != "" {
+		if err := writeWarningsJSON(config.WarningsJSON, warnings.Warnings()); err != nil {
+			fmt.Printf("Error writing warnings: %v\n", err)
+			os.Exit(exitGenerationError)
+		}
 	}
 
-	if err := gen.Generate(dtos, genConfig); err != nil {
-		fmt.Printf("Error generating code: %v\n", err)
-		os.Exit(1)
+	if config.Report != "" {
+		output := finalOutputFolder
+		if streaming {
+			output = "-"
+		}
+		var schemas []string
+		for _, dto := range dtos {
+			schemas = append(schemas, dto.Name)
+		}
+		durations.Total = timer.total()
+		report := runReport{
+			Language:       config.TargetLanguage,
+			Output:         output,
+			Schemas:        schemas,
+			SkippedSchemas: skippedSchemas(warnings.Warnings()),
+			Files:          files,
+			Warnings:       warnings.Warnings(),
+			Durations:      durations,
+		}
+		if err := saveReport(config.ReportFile, config.Report, report, stdout); err != nil {
+			fmt.Printf("Error writing report: %v\n", err)
+			os.Exit(exitGenerationError)
+		}
 	}
 
-	fmt.Printf("🚀 Successfully generated %s code in %s\n", config.TargetLanguage, finalOutputFolder)
+	if len(differences) > 0 {
+		fmt.Println("Error: the output is out of date and -check is set")
+		os.Exit(exitDiff)
+	}
+	if count := len(warnings.Warnings()); count > 0 && config.FailOnWarning {
+		fmt.Printf("Error: the run had %d warnings and -fail-on-warning is set\n", count)
+		os.Exit(exitWarnings)
+	}
 }
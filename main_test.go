@@ -0,0 +1,1731 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"dtoForge/internal/generator"
+)
+
+// parseSchema decodes an inline YAML schema the same way readOpenAPISpec does
+func parseSchema(t *testing.T, source string) map[string]interface{} {
+	t.Helper()
+
+	var schema map[string]interface{}
+	if err := yaml.Unmarshal([]byte(source), &schema); err != nil {
+		t.Fatalf("Failed to parse schema: %v", err)
+	}
+	return schema
+}
+
+func TestConvertSchema_Examples(t *testing.T) {
+	schema := parseSchema(t, `
+type: object
+example:
+  id: abc
+  tags: [a, b]
+properties:
+  id:
+    type: string
+    example: abc
+  age:
+    type: integer
+    examples: [1, 2]
+`)
+
+	dto, err := (&specConverter{}).convertSchemaToGeneratorDTO("User", schema)
+	if err != nil {
+		t.Fatalf("(&specConverter{}).convertSchemaToGeneratorDTO() failed: %v", err)
+	}
+
+	if got := dto.Metadata.String("example"); got != `{"id":"abc","tags":["a","b"]}` {
+		t.Errorf("DTO example = %v", got)
+	}
+
+	props := map[string]generator.Metadata{}
+	for _, prop := range dto.Properties {
+		props[prop.Name] = prop.Metadata
+	}
+
+	if got := props["id"].String("example"); got != `"abc"` {
+		t.Errorf("id example = %v, want %v", got, `"abc"`)
+	}
+	if got := props["age"].String("examples"); got != `[1,2]` {
+		t.Errorf("age examples = %v, want %v", got, `[1,2]`)
+	}
+	if _, exists := props["age"]["example"]; exists {
+		t.Error("age should not have a single example")
+	}
+
+	if len(dto.Examples) != 1 {
+		t.Errorf("DTO Examples = %v, want one example", dto.Examples)
+	}
+	for _, prop := range dto.Properties {
+		want := map[string][]any{"id": {"abc"}, "age": {1, 2}}[prop.Name]
+		if !reflect.DeepEqual(prop.Examples, want) {
+			t.Errorf("%s Examples = %#v, want %#v", prop.Name, prop.Examples, want)
+		}
+	}
+}
+
+func TestExampleValues(t *testing.T) {
+	schema := parseSchema(t, `
+type: string
+example: first
+examples:
+  short:
+    value: b
+  long:
+    summary: A long one
+    value: aaaa
+`)
+
+	if got := exampleValues(schema); !reflect.DeepEqual(got, []any{"first", "aaaa", "b"}) {
+		t.Errorf("exampleValues() = %#v", got)
+	}
+}
+
+func TestConvertSchema_Deprecated(t *testing.T) {
+	schema := parseSchema(t, `
+type: object
+deprecated: true
+properties:
+  legacyId:
+    type: string
+    deprecated: true
+  id:
+    type: string
+`)
+
+	dto, err := (&specConverter{}).convertSchemaToGeneratorDTO("Account", schema)
+	if err != nil {
+		t.Fatalf("(&specConverter{}).convertSchemaToGeneratorDTO() failed: %v", err)
+	}
+
+	if !dto.Deprecated {
+		t.Error("DTO should be marked deprecated")
+	}
+	for _, prop := range dto.Properties {
+		want := prop.Name == "legacyId"
+		if got := prop.Deprecated; got != want {
+			t.Errorf("property %s deprecated = %v, want %v", prop.Name, got, want)
+		}
+	}
+}
+
+func TestConvertSchema_ReadOnlyWriteOnly(t *testing.T) {
+	schema := parseSchema(t, `
+type: object
+properties:
+  id:
+    type: string
+    readOnly: true
+  password:
+    type: string
+    writeOnly: true
+  name:
+    type: string
+`)
+
+	dto, err := (&specConverter{}).convertSchemaToGeneratorDTO("Account", schema)
+	if err != nil {
+		t.Fatalf("convertSchemaToGeneratorDTO() failed: %v", err)
+	}
+
+	for _, prop := range dto.Properties {
+		if want := prop.Name == "id"; prop.ReadOnly != want {
+			t.Errorf("%s ReadOnly = %v, want %v", prop.Name, prop.ReadOnly, want)
+		}
+		if want := prop.Name == "password"; prop.WriteOnly != want {
+			t.Errorf("%s WriteOnly = %v, want %v", prop.Name, prop.WriteOnly, want)
+		}
+	}
+}
+
+func TestConvertSpec_NotKeyword(t *testing.T) {
+	var spec OpenAPISpec
+	if err := yaml.Unmarshal([]byte(`
+openapi: 3.0.0
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+          not:
+            enum: [unknown]
+        tag:
+          not:
+            type: integer
+`), &spec); err != nil {
+		t.Fatalf("Failed to parse spec: %v", err)
+	}
+
+	dtos, warnings, err := convertToGeneratorDTOs(&spec, ConversionOptions{})
+	if err != nil {
+		t.Fatalf("convertToGeneratorDTOs() failed: %v", err)
+	}
+
+	props := map[string]generator.Property{}
+	for _, prop := range dtos[0].Properties {
+		props[prop.Name] = prop
+	}
+
+	// The closest representable type is the schema without its 'not' clause
+	if got := props["name"].Type.TypeName(); got != "string" {
+		t.Errorf("name type = %v, want string", got)
+	}
+	if got := props["tag"].Type.TypeName(); got != "unknown" {
+		t.Errorf("tag type = %v, want unknown", got)
+	}
+	if got := props["name"].Metadata.String("not"); got != `{"enum":["unknown"]}` {
+		t.Errorf("name not metadata = %v", got)
+	}
+
+	if len(warnings) != 2 {
+		t.Fatalf("Expected 2 warnings, got %d: %v", len(warnings), warnings)
+	}
+	for _, warning := range warnings {
+		if warning.Code != "unsupported-not" || warning.Schema != "Pet" {
+			t.Errorf("Unexpected warning: %+v", warning)
+		}
+	}
+}
+
+// convertSpec parses an inline YAML OpenAPI document and converts it to DTOs
+func convertSpec(t *testing.T, source string) ([]generator.DTO, []generator.Warning) {
+	t.Helper()
+	return convertSpecWithOptions(t, source, ConversionOptions{})
+}
+
+// convertSpecWithOptions is convertSpec with explicit conversion options
+func convertSpecWithOptions(t *testing.T, source string, options ConversionOptions) ([]generator.DTO, []generator.Warning) {
+	t.Helper()
+
+	var spec OpenAPISpec
+	if err := yaml.Unmarshal([]byte(source), &spec); err != nil {
+		t.Fatalf("Failed to parse spec: %v", err)
+	}
+
+	dtos, warnings, err := convertToGeneratorDTOs(&spec, options)
+	if err != nil {
+		t.Fatalf("convertToGeneratorDTOs() failed: %v", err)
+	}
+	return dtos, warnings
+}
+
+// findDTO returns the DTO with the given name or fails the test
+func findDTO(t *testing.T, dtos []generator.DTO, name string) generator.DTO {
+	t.Helper()
+
+	for _, dto := range dtos {
+		if dto.Name == name {
+			return dto
+		}
+	}
+	t.Fatalf("DTO %s not found", name)
+	return generator.DTO{}
+}
+
+func TestConvertSpec_InlineArrayItems(t *testing.T) {
+	dtos, _ := convertSpec(t, `
+openapi: 3.0.0
+components:
+  schemas:
+    OrderLinesItem:
+      type: string
+    Order:
+      type: object
+      properties:
+        lines:
+          type: array
+          items:
+            type: object
+            required: [sku]
+            properties:
+              sku:
+                type: string
+              discounts:
+                type: array
+                items:
+                  type: object
+                  properties:
+                    amount:
+                      type: number
+`)
+
+	if len(dtos) != 4 {
+		t.Fatalf("Expected 4 DTOs, got %d", len(dtos))
+	}
+
+	order := findDTO(t, dtos, "Order")
+	lines, ok := order.Properties[0].Type.(generator.ArrayType)
+	if !ok {
+		t.Fatalf("lines should be an array, got %T", order.Properties[0].Type)
+	}
+	// OrderLinesItem is taken by a component schema, so the hoisted DTO gets a suffix
+	if ref, ok := lines.ElementType.(generator.ReferenceType); !ok || ref.RefName != "OrderLinesItem2" {
+		t.Errorf("lines element = %#v, want reference to OrderLinesItem2", lines.ElementType)
+	}
+
+	item := findDTO(t, dtos, "OrderLinesItem2")
+	if item.Type != "object" || len(item.Properties) != 2 {
+		t.Errorf("Unexpected hoisted DTO: %+v", item)
+	}
+	if got := item.Properties[0].Type.TypeName(); got != "Array<OrderLinesItem2DiscountsItem>" {
+		t.Errorf("discounts type = %v", got)
+	}
+	findDTO(t, dtos, "OrderLinesItem2DiscountsItem")
+}
+
+func TestConvertSchema_NestedArrays(t *testing.T) {
+	schema := parseSchema(t, `
+type: object
+properties:
+  matrix:
+    type: array
+    items:
+      type: array
+      items:
+        type: number
+  cube:
+    type: array
+    items:
+      type: array
+      items:
+        type: array
+        items:
+          type: string
+          format: uuid
+  anything:
+    type: array
+`)
+
+	dto, err := (&specConverter{}).convertSchemaToGeneratorDTO("Grid", schema)
+	if err != nil {
+		t.Fatalf("convertSchemaToGeneratorDTO() failed: %v", err)
+	}
+
+	expected := map[string]string{
+		"anything": "Array<unknown>",
+		"cube":     "Array<Array<Array<string>>>",
+		"matrix":   "Array<Array<number>>",
+	}
+	for _, prop := range dto.Properties {
+		if got := prop.Type.TypeName(); got != expected[prop.Name] {
+			t.Errorf("%s type = %v, want %v", prop.Name, got, expected[prop.Name])
+		}
+	}
+
+	cube := dto.Properties[1].Type.(generator.ArrayType)
+	innermost := cube.ElementType.(generator.ArrayType).ElementType.(generator.ArrayType).ElementType
+	if prim, ok := innermost.(generator.PrimitiveType); !ok || prim.Format != "uuid" {
+		t.Errorf("innermost element = %#v, want uuid string", innermost)
+	}
+}
+
+func TestConvertSchema_Tuples(t *testing.T) {
+	schema := parseSchema(t, `
+type: object
+properties:
+  point:
+    type: array
+    prefixItems:
+      - type: number
+      - type: number
+  entry:
+    type: array
+    items:
+      - type: string
+        format: uuid
+      - type: object
+        properties:
+          label:
+            type: string
+  row:
+    type: array
+    prefixItems:
+      - type: string
+    items:
+      type: number
+  pair:
+    type: array
+    items:
+      - type: string
+      - type: boolean
+    additionalItems:
+      type: integer
+`)
+
+	converter := &specConverter{}
+	dto, err := converter.convertSchemaToGeneratorDTO("Shape", schema)
+	if err != nil {
+		t.Fatalf("convertSchemaToGeneratorDTO() failed: %v", err)
+	}
+
+	expected := map[string]string{
+		"entry": "[string, ShapeEntryItem1]",
+		"pair":  "[string, boolean, ...integer[]]",
+		"point": "[number, number]",
+		"row":   "[string, ...number[]]",
+	}
+	for _, prop := range dto.Properties {
+		if _, ok := prop.Type.(generator.TupleType); !ok {
+			t.Errorf("%s should be a tuple, got %T", prop.Name, prop.Type)
+		}
+		if got := prop.Type.TypeName(); got != expected[prop.Name] {
+			t.Errorf("%s type = %v, want %v", prop.Name, got, expected[prop.Name])
+		}
+	}
+
+	if len(converter.extraDTOs) != 1 || converter.extraDTOs[0].Name != "ShapeEntryItem1" {
+		t.Errorf("Expected hoisted ShapeEntryItem1, got %+v", converter.extraDTOs)
+	}
+}
+
+func TestConvertSchema_Extensions(t *testing.T) {
+	schema := parseSchema(t, `
+type: object
+x-internal: true
+x-owner: billing
+properties:
+  ssn:
+    type: string
+    x-sensitive: true
+    x-mask:
+      keep: 4
+`)
+
+	dto, err := (&specConverter{}).convertSchemaToGeneratorDTO("Customer", schema)
+	if err != nil {
+		t.Fatalf("convertSchemaToGeneratorDTO() failed: %v", err)
+	}
+
+	if got := dto.Metadata.String("x-internal"); got != "true" {
+		t.Errorf("x-internal = %v, want true", got)
+	}
+	if got := dto.Metadata["x-owner"]; got != "billing" {
+		t.Errorf("x-owner = %v, want billing", got)
+	}
+
+	ssn := dto.Properties[0].Metadata
+	if got := ssn.String("x-sensitive"); got != "true" {
+		t.Errorf("x-sensitive = %v, want true", got)
+	}
+	if got := ssn.String("x-mask"); got != `{"keep":4}` {
+		t.Errorf("x-mask = %v, want %v", got, `{"keep":4}`)
+	}
+	if mask, ok := ssn.Extension("mask").(map[string]interface{}); !ok || mask["keep"] != 4 {
+		t.Errorf("x-mask extension = %#v, want the structured value", ssn.Extension("mask"))
+	}
+}
+
+func TestConvertSpec_SchemaTitles(t *testing.T) {
+	source := `
+openapi: 3.0.0
+components:
+  schemas:
+    user_v1_response:
+      title: User profile
+      type: object
+      properties:
+        address:
+          $ref: '#/components/schemas/addr_v1'
+    addr_v1:
+      title: Address
+      type: object
+      properties:
+        street:
+          type: string
+    order_a:
+      title: Order
+      type: object
+    order_b:
+      title: Order
+      type: object
+    misc:
+      title: "123"
+      type: object
+`
+
+	dtos, warnings := convertSpec(t, source)
+	findDTO(t, dtos, "user_v1_response")
+
+	options := ConversionOptions{Naming: NamingOptions{UseSchemaTitles: true}}
+	dtos, warnings = convertSpecWithOptions(t, source, options)
+
+	user := findDTO(t, dtos, "UserProfile")
+	if got := user.Properties[0].Type.TypeName(); got != "Address" {
+		t.Errorf("address reference = %v, want Address", got)
+	}
+	findDTO(t, dtos, "Address")
+	// Colliding titles fall back to their keys, unusable titles are ignored
+	findDTO(t, dtos, "order_a")
+	findDTO(t, dtos, "order_b")
+	findDTO(t, dtos, "misc")
+
+	if len(warnings) != 2 {
+		t.Fatalf("Expected 2 warnings, got %d: %v", len(warnings), warnings)
+	}
+	for _, warning := range warnings {
+		if warning.Code != "title-collision" {
+			t.Errorf("Unexpected warning: %+v", warning)
+		}
+	}
+}
+
+func TestTypeNameFromTitle(t *testing.T) {
+	tests := []struct {
+		title    string
+		expected string
+	}{
+		{"User", "User"},
+		{"User profile", "UserProfile"},
+		{"order-line (v2)", "OrderLineV2"},
+		{"2FA settings", ""},
+		{"  ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			if got := typeNameFromTitle(tt.title); got != tt.expected {
+				t.Errorf("typeNameFromTitle(%q) = %q, want %q", tt.title, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConvertSpec_ReservedWords(t *testing.T) {
+	source := `
+openapi: 3.0.0
+components:
+  schemas:
+    default:
+      type: object
+      properties:
+        class:
+          $ref: '#/components/schemas/class'
+    class:
+      type: string
+      enum: [a, b]
+    class_:
+      type: string
+`
+
+	// Names reserved in a target language are renamed by its generator, the
+	// IR keeps the spec names
+	dtos, warnings := convertSpec(t, source)
+	holder := findDTO(t, dtos, "default")
+	if got := holder.Properties[0].Type.TypeName(); got != "class" {
+		t.Errorf("class reference = %v, want class", got)
+	}
+	findDTO(t, dtos, "class")
+	findDTO(t, dtos, "class_")
+	if len(warnings) != 0 {
+		t.Errorf("Expected no warnings, got %v", warnings)
+	}
+}
+
+func TestLoadConversionOptions(t *testing.T) {
+	tempDir := t.TempDir()
+
+	configPath := filepath.Join(tempDir, "dtoforge.config.yaml")
+	if err := os.WriteFile(configPath, []byte("naming:\n  useSchemaTitles: true\n  reservedWordStrategy: prefix\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	options, err := loadConversionOptions(configPath)
+	if err != nil {
+		t.Fatalf("loadConversionOptions() failed: %v", err)
+	}
+	if !options.Naming.UseSchemaTitles || options.Naming.ReservedWordStrategy != "prefix" {
+		t.Errorf("Unexpected options: %+v", options)
+	}
+
+	if err := os.WriteFile(configPath, []byte("objects:\n  deduplicate: true\n  reuseComponents: true\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if options, err := loadConversionOptions(configPath); err != nil || !options.Objects.Deduplicate || !options.Objects.ReuseComponents {
+		t.Errorf("loadConversionOptions() = %+v, %v, want objects.deduplicate and objects.reuseComponents", options.Objects, err)
+	}
+
+	if err := os.WriteFile(configPath, []byte("naming:\n  reservedWordStrategy: rename\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if _, err := loadConversionOptions(configPath); err == nil {
+		t.Error("Expected an error for an invalid reserved word strategy")
+	}
+
+	if err := os.WriteFile(configPath, []byte("inheritance:\n  mode: merge\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if _, err := loadConversionOptions(configPath); err == nil {
+		t.Error("Expected an error for an invalid inheritance mode")
+	}
+
+	if err := os.WriteFile(configPath, []byte("operations:\n  nameTemplate: \"{Operation}Dto\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if _, err := loadConversionOptions(configPath); err == nil {
+		t.Error("Expected an error for an operation name template without {Kind}")
+	}
+
+	// int64AsBigInt of either TypeScript section adds int64 to bigIntFormats
+	for _, content := range []string{"generation:\n  int64AsBigInt: true\n", "typescript-zod:\n  generation:\n    int64AsBigInt: true\n"} {
+		if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+		options, err := loadConversionOptions(configPath)
+		if err != nil {
+			t.Fatalf("loadConversionOptions() failed: %v", err)
+		}
+		if !slices.Equal(options.Types.BigIntFormats, []string{"int64"}) {
+			t.Errorf("BigIntFormats = %v, want [int64] for %q", options.Types.BigIntFormats, content)
+		}
+	}
+}
+
+const allOfSpec = `
+openapi: 3.0.0
+components:
+  schemas:
+    Entity:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: string
+        name:
+          type: string
+    Pet:
+      allOf:
+        - $ref: '#/components/schemas/Entity'
+        - type: object
+          required: [name]
+          properties:
+            name:
+              type: string
+              description: Overrides the inherited name
+            tag:
+              type: string
+`
+
+func TestConvertSpec_AllOfIntersect(t *testing.T) {
+	dtos, _ := convertSpec(t, allOfSpec)
+
+	pet := findDTO(t, dtos, "Pet")
+	if pet.Type != "object" {
+		t.Errorf("Pet type = %v, want object", pet.Type)
+	}
+	if len(pet.Extends) != 1 || pet.Extends[0] != "Entity" {
+		t.Errorf("Pet extends = %v, want [Entity]", pet.Extends)
+	}
+	if len(pet.Properties) != 2 || pet.Properties[0].Name != "name" || !pet.Properties[0].Required {
+		t.Errorf("Unexpected own properties: %+v", pet.Properties)
+	}
+}
+
+func TestConvertSpec_AllOfFlatten(t *testing.T) {
+	options := ConversionOptions{Inheritance: InheritanceOptions{Mode: "flatten"}}
+	dtos, _ := convertSpecWithOptions(t, allOfSpec, options)
+
+	pet := findDTO(t, dtos, "Pet")
+	if len(pet.Extends) != 0 {
+		t.Errorf("Flattened DTO should not extend anything, got %v", pet.Extends)
+	}
+	if pet.Metadata["extends"] != "Entity" {
+		t.Errorf("extends metadata = %v, want Entity", pet.Metadata["extends"])
+	}
+
+	var names []string
+	for _, prop := range pet.Properties {
+		names = append(names, prop.Name)
+	}
+	if strings.Join(names, ",") != "id,name,tag" {
+		t.Errorf("properties = %v, want [id name tag]", names)
+	}
+	if pet.Properties[1].Description != "Overrides the inherited name" {
+		t.Error("Own property should override the inherited one")
+	}
+	if strings.Join(pet.Required, ",") != "id,name" {
+		t.Errorf("required = %v, want [id name]", pet.Required)
+	}
+}
+
+func TestConvertSpec_DroppedEnumValues(t *testing.T) {
+	dtos, warnings := convertSpec(t, `
+openapi: 3.0.0
+components:
+  schemas:
+    Level:
+      type: string
+      enum: [low, 2, high]
+    Setting:
+      type: object
+      properties:
+        mode:
+          enum: [auto, true]
+`)
+
+	if got := findDTO(t, dtos, "Level").EnumValues; len(got) != 2 {
+		t.Errorf("Level values = %v, want [low high]", got)
+	}
+
+	if len(warnings) != 2 {
+		t.Fatalf("Expected 2 warnings, got %d: %v", len(warnings), warnings)
+	}
+	if warnings[0].Location() != "Level" || warnings[1].Location() != "Setting.mode" {
+		t.Errorf("Unexpected warning locations: %v", warnings)
+	}
+	for _, warning := range warnings {
+		if warning.Code != "dropped-enum-value" {
+			t.Errorf("Unexpected warning: %+v", warning)
+		}
+	}
+}
+
+func TestConvertSpec_NumericEnums(t *testing.T) {
+	dtos, warnings := convertSpec(t, `
+openapi: 3.0.0
+components:
+  schemas:
+    Priority:
+      type: integer
+      enum: [1, 2, 3]
+    Task:
+      type: object
+      properties:
+        weight:
+          type: number
+          enum: [0.5, 1.5]
+`)
+
+	if len(warnings) != 0 {
+		t.Errorf("Unexpected warnings: %v", warnings)
+	}
+
+	// Numeric enums stay enums, each target decides how to generate them
+	priority := findDTO(t, dtos, "Priority")
+	if priority.Type != "enum" || !priority.NumericEnum() || priority.EnumValueType != "integer" || !reflect.DeepEqual(priority.EnumValues, []string{"1", "2", "3"}) {
+		t.Errorf("Priority = %+v, want an integer enum of 1, 2, 3", priority)
+	}
+
+	weight := findDTO(t, dtos, "Task").Properties[0]
+	enum, ok := weight.Type.(generator.EnumType)
+	if !ok || enum.UnderlyingType != "number" || !reflect.DeepEqual(enum.Values, []string{"0.5", "1.5"}) {
+		t.Errorf("weight type = %#v, want a number enum of 0.5, 1.5", weight.Type)
+	}
+}
+
+func TestConvertSpec_NullableRefs(t *testing.T) {
+	dtos, warnings := convertSpec(t, `
+openapi: 3.0.0
+components:
+  schemas:
+    Owner:
+      type: object
+      properties:
+        id:
+          type: string
+    Pet:
+      type: object
+      properties:
+        sibling:
+          $ref: '#/components/schemas/Owner'
+          nullable: true
+        wrapped:
+          allOf:
+            - $ref: '#/components/schemas/Owner'
+          nullable: true
+        plain:
+          allOf:
+            - $ref: '#/components/schemas/Owner'
+        anyOf:
+          anyOf:
+            - $ref: '#/components/schemas/Owner'
+            - type: 'null'
+        oneOf:
+          oneOf:
+            - type: 'null'
+            - $ref: '#/components/schemas/Owner'
+`)
+
+	if len(warnings) != 0 {
+		t.Errorf("Expected no warnings, got %v", warnings)
+	}
+
+	for _, prop := range findDTO(t, dtos, "Pet").Properties {
+		if ref, ok := prop.Type.(generator.ReferenceType); !ok || ref.RefName != "Owner" {
+			t.Errorf("%s type = %#v, want reference to Owner", prop.Name, prop.Type)
+		}
+		if want := prop.Name != "plain"; prop.Nullable != want {
+			t.Errorf("%s nullable = %v, want %v", prop.Name, prop.Nullable, want)
+		}
+	}
+}
+
+func TestConvertSpec_PropertyUnions(t *testing.T) {
+	dtos, warnings := convertSpec(t, `
+openapi: 3.0.0
+components:
+  schemas:
+    Cat:
+      type: object
+      properties:
+        meows:
+          type: boolean
+    Dog:
+      type: object
+      properties:
+        barks:
+          type: boolean
+    Owner:
+      type: object
+      properties:
+        pet:
+          oneOf:
+            - $ref: '#/components/schemas/Cat'
+            - $ref: '#/components/schemas/Dog'
+        backup:
+          anyOf:
+            - $ref: '#/components/schemas/Cat'
+            - type: 'null'
+            - type: object
+              properties:
+                name:
+                  type: string
+`)
+
+	if len(warnings) != 0 {
+		t.Errorf("Expected no warnings, got %v", warnings)
+	}
+
+	owner := findDTO(t, dtos, "Owner")
+	expected := map[string]struct {
+		typeName string
+		nullable bool
+	}{
+		"backup": {"(Cat | OwnerBackupOption3)", true},
+		"pet":    {"(Cat | Dog)", false},
+	}
+	for _, prop := range owner.Properties {
+		want := expected[prop.Name]
+		if _, ok := prop.Type.(generator.UnionType); !ok {
+			t.Errorf("%s type = %#v, want union", prop.Name, prop.Type)
+		}
+		if got := prop.Type.TypeName(); got != want.typeName || prop.Nullable != want.nullable {
+			t.Errorf("%s = %v (nullable %v), want %v (nullable %v)", prop.Name, got, prop.Nullable, want.typeName, want.nullable)
+		}
+	}
+
+	findDTO(t, dtos, "OwnerBackupOption3")
+	if deps := generator.Dependencies(owner); !slices.Equal(deps, []string{"Cat", "Dog", "OwnerBackupOption3"}) {
+		t.Errorf("Dependencies() = %v", deps)
+	}
+}
+
+func TestConvertSpec_OperationTags(t *testing.T) {
+	dtos, _ := convertSpec(t, `
+openapi: 3.0.0
+paths:
+  /users:
+    get:
+      tags: [Users]
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/User'
+  /invoices:
+    post:
+      tags: [Billing]
+      requestBody:
+        $ref: '#/components/requestBodies/NewInvoice'
+      responses:
+        '204':
+          description: created
+components:
+  requestBodies:
+    NewInvoice:
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Invoice'
+  schemas:
+    Address:
+      type: object
+      properties:
+        city:
+          type: string
+    User:
+      type: object
+      properties:
+        address:
+          $ref: '#/components/schemas/Address'
+    Invoice:
+      type: object
+      properties:
+        billing:
+          $ref: '#/components/schemas/Address'
+        lines:
+          type: array
+          items:
+            type: object
+            properties:
+              amount:
+                type: number
+    Unused:
+      type: object
+      properties:
+        id:
+          type: string
+`)
+
+	expected := map[string][]string{
+		"Address":          {"Billing", "Users"},
+		"Invoice":          {"Billing"},
+		"InvoiceLinesItem": {"Billing"},
+		"User":             {"Users"},
+		"Unused":           nil,
+	}
+	for name, want := range expected {
+		if got := findDTO(t, dtos, name).Tags; !slices.Equal(got, want) {
+			t.Errorf("%s tags = %v, want %v", name, got, want)
+		}
+	}
+}
+
+const operationsSpec = `
+openapi: 3.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      tags: [Pets]
+      responses:
+        200:
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  type: object
+                  properties:
+                    name:
+                      type: string
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+      responses:
+        '201':
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        id:
+          type: integer
+        name:
+          type: string
+`
+
+func TestConvertSpec_OperationDTOs(t *testing.T) {
+	dtos, _ := convertSpec(t, operationsSpec)
+	if len(dtos) != 1 {
+		t.Errorf("Expected only component DTOs by default, got %d", len(dtos))
+	}
+
+	dtos, _ = convertSpecWithOptions(t, operationsSpec, ConversionOptions{Operations: OperationOptions{Generate: true}})
+	if len(dtos) != 3 {
+		t.Errorf("Expected 3 DTOs, got %d", len(dtos))
+	}
+	if tags := findDTO(t, dtos, "ListPetsResponseItem").Tags; !slices.Equal(tags, []string{"Pets"}) {
+		t.Errorf("ListPetsResponseItem tags = %v, want [Pets]", tags)
+	}
+	findDTO(t, dtos, "PostPetsRequest")
+
+	dtos, _ = convertSpecWithOptions(t, operationsSpec, ConversionOptions{Operations: OperationOptions{
+		Generate:     true,
+		NameTemplate: "{operation}{Kind}Body",
+	}})
+	findDTO(t, dtos, "listPetsResponseBodyItem")
+	findDTO(t, dtos, "postPetsRequestBody")
+}
+
+func TestOperationName(t *testing.T) {
+	tests := []struct {
+		method, path, operationID, expected string
+	}{
+		{"get", "/pets", "listPets", "ListPets"},
+		{"get", "/pets", "list_pets", "ListPets"},
+		{"get", "/pets/{petId}/toys", "", "GetPetsPetIdToys"},
+		{"delete", "/", "", "Delete"},
+		{"get", "/", "2fa-verify", "Operation2faVerify"},
+	}
+	for _, tt := range tests {
+		if got := operationName(tt.method, tt.path, tt.operationID); got != tt.expected {
+			t.Errorf("operationName(%q, %q, %q) = %q, want %q", tt.method, tt.path, tt.operationID, got, tt.expected)
+		}
+	}
+}
+
+func TestConvertSpec_DeduplicatesAliases(t *testing.T) {
+	source := `
+openapi: 3.0.0
+components:
+  schemas:
+    Address: &address
+      type: object
+      properties:
+        city:
+          type: string
+    User:
+      type: object
+      properties:
+        home: *address
+        contact: &contact
+          type: object
+          properties:
+            email:
+              type: string
+    Order:
+      type: object
+      properties:
+        shipping: *address
+        contact: *contact
+        notes:
+          type: array
+          items: *contact
+`
+	dtos, _ := convertSpecWithOptions(t, source, ConversionOptions{Objects: ObjectOptions{Deduplicate: true, ReuseComponents: true}})
+
+	if len(dtos) != 4 {
+		t.Errorf("Expected 4 DTOs, got %d", len(dtos))
+	}
+
+	expected := map[string]map[string]string{
+		"User":  {"home": "Address", "contact": "OrderContact"},
+		"Order": {"shipping": "Address", "contact": "OrderContact", "notes": "Array<OrderContact>"},
+	}
+	for dtoName, props := range expected {
+		for _, prop := range findDTO(t, dtos, dtoName).Properties {
+			if got := prop.Type.TypeName(); got != props[prop.Name] {
+				t.Errorf("%s.%s = %s, want %s", dtoName, prop.Name, got, props[prop.Name])
+			}
+		}
+	}
+}
+
+func TestConvertSpec_DeduplicateOptions(t *testing.T) {
+	source := `
+openapi: 3.0.0
+components:
+  schemas:
+    Address: &address
+      type: object
+      properties:
+        city:
+          type: string
+    User:
+      type: object
+      properties:
+        home: *address
+        notes:
+          type: array
+          items: &note
+            type: object
+            properties:
+              text:
+                type: string
+    Order:
+      type: object
+      properties:
+        shipping: *address
+        notes:
+          type: array
+          items: *note
+`
+	tests := []struct {
+		name    string
+		objects ObjectOptions
+		want    map[string]map[string]string
+	}{
+		{"off", ObjectOptions{}, map[string]map[string]string{
+			"User":  {"home": "home", "notes": "Array<UserNotesItem>"},
+			"Order": {"shipping": "shipping", "notes": "Array<OrderNotesItem>"},
+		}},
+		{"deduplicate", ObjectOptions{Deduplicate: true}, map[string]map[string]string{
+			"User":  {"home": "OrderShipping", "notes": "Array<OrderNotesItem>"},
+			"Order": {"shipping": "OrderShipping", "notes": "Array<OrderNotesItem>"},
+		}},
+		{"reuse components", ObjectOptions{ReuseComponents: true}, map[string]map[string]string{
+			"User":  {"home": "Address", "notes": "Array<UserNotesItem>"},
+			"Order": {"shipping": "Address", "notes": "Array<OrderNotesItem>"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dtos, _ := convertSpecWithOptions(t, source, ConversionOptions{Objects: tt.objects})
+			for dtoName, props := range tt.want {
+				for _, prop := range findDTO(t, dtos, dtoName).Properties {
+					if got := prop.Type.TypeName(); got != props[prop.Name] {
+						t.Errorf("%s.%s = %s, want %s", dtoName, prop.Name, got, props[prop.Name])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestConvertSpec_AdditionalProperties(t *testing.T) {
+	dtos, _ := convertSpec(t, `
+openapi: 3.0.0
+components:
+  schemas:
+    Tag:
+      type: object
+      properties:
+        label:
+          type: string
+    Inventory:
+      type: object
+      properties:
+        counts:
+          type: object
+          additionalProperties:
+            type: integer
+        tags:
+          type: object
+          additionalProperties:
+            $ref: '#/components/schemas/Tag'
+        labels:
+          type: object
+          additionalProperties:
+            type: object
+            properties:
+              text:
+                type: string
+        extra:
+          type: object
+          additionalProperties: true
+        closed:
+          type: object
+          additionalProperties: false
+    Settings:
+      type: object
+      properties:
+        theme:
+          type: string
+      additionalProperties:
+        type: string
+`)
+
+	expected := map[string]string{
+		"counts": "Record<string, integer>",
+		"tags":   "Record<string, Tag>",
+		"labels": "Record<string, InventoryLabelsValue>",
+		"extra":  "Record<string, unknown>",
+	}
+	for _, prop := range findDTO(t, dtos, "Inventory").Properties {
+		want, isMap := expected[prop.Name]
+		if _, ok := prop.Type.(generator.MapType); ok != isMap {
+			t.Errorf("%s type = %#v, want map: %v", prop.Name, prop.Type, isMap)
+			continue
+		}
+		if isMap && prop.Type.TypeName() != want {
+			t.Errorf("%s = %s, want %s", prop.Name, prop.Type.TypeName(), want)
+		}
+	}
+	findDTO(t, dtos, "InventoryLabelsValue")
+
+	if got := findDTO(t, dtos, "Settings").AdditionalProperties; got != (generator.PrimitiveType{Name: "string"}) {
+		t.Errorf("Settings additional properties = %#v, want string", got)
+	}
+	if got := findDTO(t, dtos, "Inventory").AdditionalProperties; got != nil {
+		t.Errorf("Inventory additional properties = %#v, want nil", got)
+	}
+}
+
+func TestConvertSchema_Literals(t *testing.T) {
+	schema := parseSchema(t, `
+type: object
+properties:
+  kind:
+    type: string
+    const: card
+  version:
+    enum: [2]
+  status:
+    type: string
+    enum: [active, inactive]
+`)
+
+	dto, err := (&specConverter{}).convertSchemaToGeneratorDTO("Payment", schema)
+	if err != nil {
+		t.Fatalf("convertSchemaToGeneratorDTO() failed: %v", err)
+	}
+
+	expected := map[string]generator.IRType{
+		"kind":    generator.LiteralType{Value: "card", Kind: "string"},
+		"version": generator.LiteralType{Value: 2, Kind: "number"},
+	}
+	for _, prop := range dto.Properties {
+		want, isLiteral := expected[prop.Name]
+		if !isLiteral {
+			if _, ok := prop.Type.(generator.EnumType); !ok {
+				t.Errorf("%s type = %#v, want enum", prop.Name, prop.Type)
+			}
+			continue
+		}
+		if prop.Type != want {
+			t.Errorf("%s type = %#v, want %#v", prop.Name, prop.Type, want)
+		}
+	}
+}
+
+func TestConvertSchema_StructuredConst(t *testing.T) {
+	schema := parseSchema(t, `
+type: object
+properties:
+  origin:
+    type: object
+    const: {x: 0, y: 0}
+  tags:
+    type: array
+    items:
+      type: string
+    enum: [[a, b]]
+`)
+
+	converter := &specConverter{}
+	dto, err := converter.convertSchemaToGeneratorDTO("Shape", schema)
+	if err != nil {
+		t.Fatalf("convertSchemaToGeneratorDTO() failed: %v", err)
+	}
+
+	// Objects and arrays have no literal type, so they keep the schema's type
+	for _, prop := range dto.Properties {
+		if _, ok := prop.Type.(generator.LiteralType); ok {
+			t.Errorf("%s type = %#v, want the schema's type", prop.Name, prop.Type)
+		}
+	}
+	if tags := dto.Properties[1]; tags.Name != "tags" {
+		t.Fatalf("second property = %s, want tags", tags.Name)
+	} else if _, ok := tags.Type.(generator.ArrayType); !ok {
+		t.Errorf("tags type = %#v, want an array", tags.Type)
+	}
+	if len(converter.warnings) != 2 || converter.warnings[0].Code != "unsupported-const" {
+		t.Errorf("warnings = %v, want two unsupported-const warnings", converter.warnings)
+	}
+}
+
+func TestConvertSchema_Constraints(t *testing.T) {
+	schema := parseSchema(t, `
+type: object
+properties:
+  code:
+    type: string
+    minLength: 2
+    maxLength: 8
+    pattern: '^[A-Z]+$'
+  price:
+    type: number
+    minimum: 0
+    exclusiveMinimum: true
+    maximum: 99.5
+    multipleOf: 0.5
+  ratio:
+    type: number
+    exclusiveMaximum: 1
+  tags:
+    type: array
+    minItems: 1
+    maxItems: 5
+    uniqueItems: true
+    items:
+      type: string
+      maxLength: 20
+  name:
+    type: string
+`)
+
+	dto, err := (&specConverter{}).convertSchemaToGeneratorDTO("Product", schema)
+	if err != nil {
+		t.Fatalf("convertSchemaToGeneratorDTO() failed: %v", err)
+	}
+
+	intPtr := func(n int) *int { return &n }
+	floatPtr := func(n float64) *float64 { return &n }
+	expected := map[string]*generator.Constraints{
+		"code":  {MinLength: intPtr(2), MaxLength: intPtr(8), Pattern: "^[A-Z]+$"},
+		"price": {ExclusiveMinimum: floatPtr(0), Maximum: floatPtr(99.5), MultipleOf: floatPtr(0.5)},
+		"ratio": {ExclusiveMaximum: floatPtr(1)},
+		"tags":  {MinItems: intPtr(1), MaxItems: intPtr(5), UniqueItems: true},
+		"name":  nil,
+	}
+
+	for _, prop := range dto.Properties {
+		var got *generator.Constraints
+		switch typ := prop.Type.(type) {
+		case generator.PrimitiveType:
+			got = typ.Constraints
+		case generator.ArrayType:
+			got = typ.Constraints
+			if item := typ.ElementType.(generator.PrimitiveType); item.Constraints == nil || *item.Constraints.MaxLength != 20 {
+				t.Errorf("tags item constraints = %+v", item.Constraints)
+			}
+		}
+		if !reflect.DeepEqual(got, expected[prop.Name]) {
+			t.Errorf("%s constraints = %+v, want %+v", prop.Name, got, expected[prop.Name])
+		}
+	}
+}
+
+func TestConvertSchema_Defaults(t *testing.T) {
+	schema := parseSchema(t, `
+type: object
+properties:
+  theme:
+    type: string
+    default: dark
+  pageSize:
+    type: integer
+    default: 20
+  beta:
+    type: boolean
+    default: false
+  name:
+    type: string
+`)
+
+	dto, err := (&specConverter{}).convertSchemaToGeneratorDTO("Settings", schema)
+	if err != nil {
+		t.Fatalf("convertSchemaToGeneratorDTO() failed: %v", err)
+	}
+
+	expected := map[string]any{"theme": "dark", "pageSize": 20, "beta": false, "name": nil}
+	for _, prop := range dto.Properties {
+		if prop.Default != expected[prop.Name] {
+			t.Errorf("%s default = %#v, want %#v", prop.Name, prop.Default, expected[prop.Name])
+		}
+	}
+}
+
+func TestConvertSchema_NullableIdioms(t *testing.T) {
+	schema := parseSchema(t, `
+type: object
+properties:
+  nickname:
+    description: Optional nickname
+    anyOf:
+      - type: string
+        maxLength: 20
+      - type: 'null'
+  scores:
+    oneOf:
+      - type: 'null'
+      - type: array
+        items:
+          type: number
+  age:
+    type: [integer, 'null']
+    format: int32
+  either:
+    anyOf:
+      - type: string
+      - type: number
+`)
+
+	dto, err := (&specConverter{}).convertSchemaToGeneratorDTO("Profile", schema)
+	if err != nil {
+		t.Fatalf("convertSchemaToGeneratorDTO() failed: %v", err)
+	}
+
+	expected := map[string]struct {
+		typeName string
+		nullable bool
+	}{
+		"age":      {"integer", true},
+		"either":   {"(string | number)", false},
+		"nickname": {"string", true},
+		"scores":   {"Array<number>", true},
+	}
+	for _, prop := range dto.Properties {
+		want := expected[prop.Name]
+		if got := prop.Type.TypeName(); got != want.typeName || prop.Nullable != want.nullable {
+			t.Errorf("%s = %v (nullable %v), want %v (nullable %v)", prop.Name, got, prop.Nullable, want.typeName, want.nullable)
+		}
+	}
+
+	if dto.Properties[2].Description != "Optional nickname" {
+		t.Errorf("nickname description = %q", dto.Properties[2].Description)
+	}
+	if age := dto.Properties[0].Type.(generator.PrimitiveType); age.Format != "int32" {
+		t.Errorf("age format = %q, want int32", age.Format)
+	}
+}
+
+func TestReadOpenAPISpec_SourceLocations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api.yaml")
+	source := `openapi: 3.0.0
+components:
+  schemas:
+    Pet:
+      type: object
+      if: {required: [name]}
+      properties:
+        name:
+          type: string
+        tag:
+          not: {type: number}
+`
+	if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	spec, err := readOpenAPISpec(path)
+	if err != nil {
+		t.Fatalf("readOpenAPISpec() failed: %v", err)
+	}
+	dtos, warnings, err := convertToGeneratorDTOs(spec, ConversionOptions{})
+	if err != nil {
+		t.Fatalf("convertToGeneratorDTOs() failed: %v", err)
+	}
+
+	pet := dtos[0]
+	if pet.Source == nil || *pet.Source != (generator.SourceLocation{File: path, Line: 4, Column: 5}) {
+		t.Errorf("Pet source = %v, want %s:4:5", pet.Source, path)
+	}
+	for _, prop := range pet.Properties {
+		want := map[string]int{"name": 8, "tag": 10}[prop.Name]
+		if prop.Source == nil || prop.Source.Line != want || prop.Source.Column != 9 {
+			t.Errorf("%s source = %v, want line %d column 9", prop.Name, prop.Source, want)
+		}
+	}
+
+	if len(warnings) == 0 || warnings[0].Source == nil || warnings[0].Source.Line != 10 {
+		t.Errorf("expected the 'not' warning to point at line 10, got %v", warnings)
+	}
+
+	issues := validateSpec(spec)
+	if len(issues) != 1 || issues[0].Source == nil || issues[0].Source.Line != 4 {
+		t.Errorf("expected one issue located at line 4, got %v", issues)
+	}
+}
+
+func TestConvertSchema_BigIntFormats(t *testing.T) {
+	schema := parseSchema(t, `
+type: object
+properties:
+  id:
+    type: integer
+    format: int64
+  count:
+    type: integer
+    format: int32
+  total:
+    type: number
+    format: int64
+`)
+
+	converter := &specConverter{options: ConversionOptions{Types: TypeOptions{BigIntFormats: []string{"int64", "uint64"}}}}
+	dto, err := converter.convertSchemaToGeneratorDTO("Account", schema)
+	if err != nil {
+		t.Fatalf("convertSchemaToGeneratorDTO() failed: %v", err)
+	}
+
+	expected := map[string]string{"id": "bigint", "count": "integer", "total": "number"}
+	for _, prop := range dto.Properties {
+		if got := prop.Type.(generator.PrimitiveType).Name; got != expected[prop.Name] {
+			t.Errorf("%s type = %v, want %v", prop.Name, got, expected[prop.Name])
+		}
+	}
+}
+
+func TestConvertSchema_BinaryFormats(t *testing.T) {
+	schema := parseSchema(t, `
+type: object
+properties:
+  file:
+    type: string
+    format: binary
+  thumbnail:
+    type: string
+    format: byte
+  name:
+    type: string
+`)
+
+	dto, err := (&specConverter{}).convertSchemaToGeneratorDTO("Upload", schema)
+	if err != nil {
+		t.Fatalf("convertSchemaToGeneratorDTO() failed: %v", err)
+	}
+
+	expected := map[string]generator.PrimitiveType{
+		"file":      {Name: "binary", Format: "binary"},
+		"thumbnail": {Name: "binary", Format: "byte"},
+		"name":      {Name: "string"},
+	}
+	for _, prop := range dto.Properties {
+		if !reflect.DeepEqual(prop.Type, expected[prop.Name]) {
+			t.Errorf("%s type = %#v, want %#v", prop.Name, prop.Type, expected[prop.Name])
+		}
+	}
+}
+
+func TestConvertSpec_DeduplicateEnums(t *testing.T) {
+	source := `
+openapi: 3.0.0
+components:
+  schemas:
+    Color:
+      type: string
+      enum: [red, green]
+    User:
+      type: object
+      properties:
+        status:
+          type: string
+          enum: [active, inactive]
+        favorite:
+          type: string
+          enum: [red, green]
+    Team:
+      type: object
+      properties:
+        status:
+          type: string
+          description: Team status
+          enum: [active, inactive]
+        size:
+          type: string
+          enum: [small, large]
+`
+
+	dtos, _ := convertSpecWithOptions(t, source, ConversionOptions{Enums: EnumOptions{Deduplicate: true}})
+
+	status := findDTO(t, dtos, "Status")
+	if status.Type != "enum" || !slices.Equal(status.EnumValues, []string{"active", "inactive"}) {
+		t.Errorf("Status = %+v, want a shared enum of active, inactive", status)
+	}
+
+	expected := map[string]map[string]generator.IRType{
+		"User": {
+			"status":   generator.ReferenceType{RefName: "Status"},
+			"favorite": generator.ReferenceType{RefName: "Color"},
+		},
+		"Team": {
+			"status": generator.ReferenceType{RefName: "Status"},
+			"size":   generator.EnumType{Name: "SizeEnum", UnderlyingType: "string", Values: []string{"small", "large"}},
+		},
+	}
+	for dtoName, props := range expected {
+		for _, prop := range findDTO(t, dtos, dtoName).Properties {
+			if !reflect.DeepEqual(prop.Type, props[prop.Name]) {
+				t.Errorf("%s.%s type = %#v, want %#v", dtoName, prop.Name, prop.Type, props[prop.Name])
+			}
+		}
+	}
+
+	// Without the option every enum stays inline
+	dtos, _ = convertSpec(t, source)
+	if _, ok := findDTO(t, dtos, "User").Properties[1].Type.(generator.EnumType); !ok {
+		t.Errorf("expected inline enums without enums.deduplicate")
+	}
+}
+
+func TestConvertSpec_UnionDTOs(t *testing.T) {
+	dtos, _ := convertSpec(t, `
+openapi: 3.0.0
+components:
+  schemas:
+    Cat:
+      type: object
+      properties:
+        meow:
+          type: boolean
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Cat'
+        - type: object
+          properties:
+            bark:
+              type: boolean
+        - type: 'null'
+    Id:
+      anyOf:
+        - type: string
+`)
+
+	pet := findDTO(t, dtos, "Pet")
+	expected := []generator.IRType{
+		generator.ReferenceType{RefName: "Cat"},
+		generator.ReferenceType{RefName: "PetOption2"},
+		generator.LiteralType{Value: nil, Kind: "null"},
+	}
+	if pet.Type != "union" || !reflect.DeepEqual(pet.UnionMembers, expected) {
+		t.Errorf("Pet = %+v, want a union of %v", pet, expected)
+	}
+	findDTO(t, dtos, "PetOption2")
+
+	id := findDTO(t, dtos, "Id")
+	if id.Type != "union" || !reflect.DeepEqual(id.Union(), generator.PrimitiveType{Name: "string"}) {
+		t.Errorf("Id = %+v, want a union of string", id)
+	}
+}
+
+func TestConvertSpec_Discriminator(t *testing.T) {
+	dtos, _ := convertSpec(t, `
+openapi: 3.0.0
+components:
+  schemas:
+    Cat:
+      type: object
+      properties:
+        kind:
+          type: string
+          enum: [cat]
+    Dog:
+      type: object
+      properties:
+        kind:
+          type: string
+          enum: [dog]
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Cat'
+        - $ref: '#/components/schemas/Dog'
+      discriminator:
+        propertyName: kind
+    Owner:
+      type: object
+      properties:
+        pet:
+          anyOf:
+            - $ref: '#/components/schemas/Cat'
+            - $ref: '#/components/schemas/Dog'
+          discriminator:
+            propertyName: kind
+`)
+
+	if pet := findDTO(t, dtos, "Pet"); pet.Union().(generator.UnionType).Discriminator != "kind" {
+		t.Errorf("Pet discriminator = %q, want kind", pet.Discriminator)
+	}
+	owner := findDTO(t, dtos, "Owner")
+	if union, ok := owner.Properties[0].Type.(generator.UnionType); !ok || union.Discriminator != "kind" {
+		t.Errorf("Owner.pet = %+v, want a union discriminated by kind", owner.Properties[0].Type)
+	}
+}
+
+func TestStreamOutput(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "schemas.ts"), []byte("export {};\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "package.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	if err := streamOutput(dir, &out); err != nil {
+		t.Fatalf("streamOutput: %v", err)
+	}
+	if out.String() != "export {};\n" {
+		t.Errorf("streamed %q, want the contents of schemas.ts", out.String())
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "index.ts"), []byte("export {};\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	err := streamOutput(dir, &strings.Builder{})
+	if err == nil || !strings.Contains(err.Error(), "output.mode: single") {
+		t.Errorf("streamOutput with two files: err = %v, want a single-file error", err)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	configErr := &generator.ConfigError{Path: "dtoforge.config.yaml", Err: errors.New("invalid output mode 'bogus'")}
+	if got := configErr.Error(); got != "invalid output mode 'bogus'" {
+		t.Errorf("ConfigError.Error() = %q", got)
+	}
+
+	for _, tt := range []struct {
+		err  error
+		want int
+	}{
+		{configErr, exitConfigError},
+		{fmt.Errorf("generating: %w", configErr), exitConfigError},
+		{&usageError{errors.New("flag provided but not defined: -bogus")}, exitUsage},
+		{&specError{errors.New("failed to read spec")}, exitSpecError},
+		{errors.New("failed to generate file for DTO Pet"), exitGenerationError},
+	} {
+		if got := exitCode(tt.err); got != tt.want {
+			t.Errorf("exitCode(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestApplyEnvironment(t *testing.T) {
+	environment := map[string]string{
+		"DTOFORGE_OPENAPI": "env.yaml",
+		"DTOFORGE_OUT":     "./env-out",
+		"DTOFORGE_LANG":    "java",
+		"DTOFORGE_CONFIG":  "",
+	}
+	lookup := func(name string) (string, bool) {
+		value, ok := environment[name]
+		return value, ok
+	}
+
+	// -lang was given, so DTOFORGE_LANG is ignored; an empty variable is unset
+	config := Config{OutputFolder: "./generated", TargetLanguage: "prisma", ConfigFile: "flag.yaml", SetFlags: map[string]bool{"lang": true}}
+	applyEnvironment(&config, lookup)
+
+	want := Config{
+		OpenAPIFile:    "env.yaml",
+		OutputFolder:   "./env-out",
+		TargetLanguage: "prisma",
+		ConfigFile:     "flag.yaml",
+		SetFlags:       map[string]bool{"lang": true, "openapi": true, "out": true},
+	}
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("applyEnvironment = %+v, want %+v", config, want)
+	}
+
+	// The environment wins over the config file
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "dtoforge.config.yaml")
+	if err := os.WriteFile(configPath, []byte("openapi: api.yaml\nlang: graphql\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config = Config{TargetLanguage: "typescript"}
+	applyEnvironment(&config, lookup)
+	if err := applyProjectOptions(&config, configPath); err != nil {
+		t.Fatal(err)
+	}
+	if config.OpenAPIFile != "env.yaml" || config.TargetLanguage != "java" {
+		t.Errorf("config = %+v, want the spec and language of the environment", config)
+	}
+}
@@ -0,0 +1,375 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"dtoForge/internal/generator"
+)
+
+// manifestFile is written to the output folder to record what a run generated
+const manifestFile = ".dtoforge-manifest.json"
+
+// manifestVersion is bumped when the manifest format changes. Manifests of
+// other versions are ignored, as if the folder had none.
+const manifestVersion = 4
+
+// generationManifest records what a run generated, so the next run can skip
+// generation when nothing changed, keep the files of unchanged schemas, and
+// tell which files it no longer generates
+type generationManifest struct {
+	Version      int               `json:"version"`
+	InputHash    string            `json:"inputHash"`    // hash of everything the output depends on, see hashInputs
+	SettingsHash string            `json:"settingsHash"` // hash of the inputs besides the schemas, see hashSettings
+	Schemas      map[string]string `json:"schemas"`      // schema name -> hash of its IR
+	Files        map[string]string `json:"files"`        // file relative to the output folder -> hash of its content
+
+	// Warnings the generator reported, replayed when a later run is up to
+	// date so it reports the same warnings as the run that generated
+	Warnings []generator.Warning `json:"warnings,omitempty"`
+}
+
+// readManifest reads the manifest of the output folder, returning an empty
+// manifest if there is none
+func readManifest(dir string) (generationManifest, error) {
+	var manifest generationManifest
+	data, err := os.ReadFile(filepath.Join(dir, manifestFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return manifest, nil
+	}
+	if err != nil {
+		return manifest, fmt.Errorf("failed to read %s: %w", manifestFile, err)
+	}
+
+	var version struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &version); err != nil {
+		return manifest, fmt.Errorf("failed to parse %s: %w", manifestFile, err)
+	}
+	if version.Version != manifestVersion {
+		return manifest, nil
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return manifest, fmt.Errorf("failed to parse %s: %w", manifestFile, err)
+	}
+	return manifest, nil
+}
+
+// newManifest records the files this run generated with the hashes of their
+// content and of the schemas they were generated from, and the warnings the
+// generator reported
+func newManifest(dir string, files []string, inputs runInputs, dtos []generator.DTO, warnings []generator.Warning) (generationManifest, error) {
+	manifest := generationManifest{
+		Version:      manifestVersion,
+		InputHash:    inputs.hash,
+		SettingsHash: inputs.settings,
+		Schemas:      make(map[string]string, len(dtos)),
+		Files:        make(map[string]string, len(files)),
+		Warnings:     warnings,
+	}
+	for _, dto := range dtos {
+		hash, err := schemaHash(dto)
+		if err != nil {
+			return manifest, err
+		}
+		manifest.Schemas[dto.Name] = hash
+	}
+	for _, file := range files {
+		if file == manifestFile {
+			continue
+		}
+		hash, err := fileHash(filepath.Join(dir, filepath.FromSlash(file)))
+		if err != nil {
+			return manifest, err
+		}
+		manifest.Files[file] = hash
+	}
+	return manifest, nil
+}
+
+// writeManifest writes the manifest to the output folder
+func writeManifest(dir string, manifest generationManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", manifestFile, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, manifestFile), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", manifestFile, err)
+	}
+	return nil
+}
+
+// upToDate reports whether the output folder holds exactly what the manifest
+// recorded for the same inputs, so generating again would change nothing
+func (m generationManifest) upToDate(dir, inputHash string) bool {
+	if m.InputHash == "" || m.InputHash != inputHash {
+		return false
+	}
+	return m.untouched(dir)
+}
+
+// untouched reports whether the files the manifest records are all still in
+// the output folder as they were generated
+func (m generationManifest) untouched(dir string) bool {
+	for file, hash := range m.Files {
+		current, err := fileHash(filepath.Join(dir, filepath.FromSlash(file)))
+		if err != nil || current != hash {
+			return false
+		}
+	}
+	return true
+}
+
+// files returns the files the manifest records, sorted
+func (m generationManifest) files() []string {
+	files := make([]string, 0, len(m.Files))
+	for file := range m.Files {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// changedSchemas returns the schemas of current that are new or differ from
+// the manifest
+func (m generationManifest) changedSchemas(current generationManifest) []string {
+	var changed []string
+	for name, hash := range current.Schemas {
+		if m.Schemas[name] != hash {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// unchangedSchemas returns the schemas whose files the previous run generated
+// can be kept. The files of a changed schema are generated again, along with
+// those of the schemas referencing it, directly or transitively, and of the
+// schemas it references directly, e.g. the base listing its subtypes.
+// Everything is generated again when the settings differ, a generated file
+// was edited or removed, or schemas were added or removed, since names and
+// imports may then change anywhere.
+func (m generationManifest) unchangedSchemas(dir string, settingsHash string, dtos []generator.DTO) (map[string]bool, error) {
+	if m.SettingsHash == "" || m.SettingsHash != settingsHash || len(m.Schemas) != len(dtos) || !m.untouched(dir) {
+		return nil, nil
+	}
+
+	graph := generator.BuildGraph(dtos)
+	regenerate := make(map[string]bool)
+	var addDependents func(name string)
+	addDependents = func(name string) {
+		if regenerate[name] {
+			return
+		}
+		regenerate[name] = true
+		for _, dependent := range graph.Dependents(name) {
+			addDependents(dependent)
+		}
+	}
+	for _, dto := range dtos {
+		previous, known := m.Schemas[dto.Name]
+		if !known {
+			return nil, nil
+		}
+		hash, err := schemaHash(dto)
+		if err != nil {
+			return nil, err
+		}
+		if hash != previous {
+			addDependents(dto.Name)
+			for _, dependency := range graph.Dependencies(dto.Name) {
+				regenerate[dependency] = true
+			}
+		}
+	}
+
+	unchanged := make(map[string]bool, len(dtos)-len(regenerate))
+	for _, dto := range dtos {
+		if !regenerate[dto.Name] {
+			unchanged[dto.Name] = true
+		}
+	}
+	return unchanged, nil
+}
+
+// keptWarnings returns the warnings the previous run reported for the
+// schemas whose files were kept, which the generator did not get to report
+// again, leaving out those it did
+func (m generationManifest) keptWarnings(unchanged map[string]bool, reported []generator.Warning) []generator.Warning {
+	key := func(w generator.Warning) string {
+		return strings.Join([]string{w.Schema, w.Property, w.Code, w.Message}, "\x00")
+	}
+	seen := make(map[string]bool, len(reported))
+	for _, warning := range reported {
+		seen[key(warning)] = true
+	}
+	var kept []generator.Warning
+	for _, warning := range m.Warnings {
+		if unchanged[warning.Schema] && !seen[key(warning)] {
+			kept = append(kept, warning)
+		}
+	}
+	return kept
+}
+
+// pruneStale removes the files of the previous manifest that this run did not
+// generate, and the folders that leaves empty. Files the manifest does not
+// list, such as hand-written ones, are never touched. It returns the removed
+// files.
+func pruneStale(dir string, previous generationManifest, generated []string) ([]string, error) {
+	keep := make(map[string]bool, len(generated))
+	for _, file := range generated {
+		keep[file] = true
+	}
+	var removed []string
+	for _, file := range previous.files() {
+		if keep[file] || !prunable(file) {
+			continue
+		}
+		path := filepath.Join(dir, filepath.FromSlash(file))
+		if err := os.Remove(path); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return removed, fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+		removed = append(removed, file)
+
+		// Remove folders emptied by the removal, e.g. of a tag that is gone
+		for folder := filepath.Dir(file); folder != "."; folder = filepath.Dir(folder) {
+			if os.Remove(filepath.Join(dir, folder)) != nil {
+				break
+			}
+		}
+	}
+	return removed, nil
+}
+
+// prunable reports whether a file of a previous manifest may be removed when
+// a run no longer generates it. A manifest is only trusted to name files
+// inside the output folder. package.json is written once and then left to the
+// user, so a run that keeps it does not rewrite it.
+func prunable(file string) bool {
+	return filepath.IsLocal(filepath.FromSlash(file)) && filepath.Base(file) != "package.json"
+}
+
+// updateManifest writes the manifest of this run, first removing stale files
+// of the previous run when prune is set
+func updateManifest(dir string, previous, current generationManifest, prune bool) ([]string, error) {
+	var removed []string
+	if prune {
+		var err error
+		if removed, err = pruneStale(dir, previous, current.files()); err != nil {
+			return removed, err
+		}
+	}
+	return removed, writeManifest(dir, current)
+}
+
+// finishOutput completes a run into dir: stale files are pruned if asked
+// to, and the manifest of the run is written with the warnings of the
+// generator
+func finishOutput(dir string, previous generationManifest, files []string, inputs runInputs, dtos []generator.DTO, unchanged map[string]bool, warnings []generator.Warning, prune bool) error {
+	current, err := newManifest(dir, files, inputs, dtos, warnings)
+	if err != nil {
+		return err
+	}
+	if len(previous.Schemas) > 0 {
+		fmt.Printf("♻️  %d of %d schemas changed since the last run, kept the files of %d\n", len(previous.changedSchemas(current)), len(current.Schemas), len(unchanged))
+	}
+
+	// The manifest lets a later run find the files it no longer generates
+	removed, err := updateManifest(dir, previous, current, prune)
+	for _, file := range removed {
+		fmt.Printf("🧹 Removed stale %s\n", file)
+	}
+	return err
+}
+
+// runInputs are the hashes of what a run generates from
+type runInputs struct {
+	hash     string // everything, see hashInputs
+	settings string // everything but the schemas, see hashSettings
+}
+
+// hashInputs hashes everything generated code depends on: the settings of
+// hashSettings and the DTOs
+func hashInputs(language, packageName, configFile string, dtos []generator.DTO) (runInputs, error) {
+	settings, err := hashSettings(language, packageName, configFile)
+	if err != nil {
+		return runInputs{}, err
+	}
+	ir, err := generator.MarshalIR(dtos)
+	if err != nil {
+		return runInputs{}, fmt.Errorf("failed to encode IR: %w", err)
+	}
+	hash := sha256.New()
+	io.WriteString(hash, settings)
+	hash.Write(ir)
+	return runInputs{hash: hex.EncodeToString(hash.Sum(nil)), settings: settings}, nil
+}
+
+// hashSettings hashes what generated code depends on besides the schemas:
+// the DtoForge binary, the target language and package, and the config file
+func hashSettings(language, packageName, configFile string) (string, error) {
+	hash := sha256.New()
+	if executable, err := os.Executable(); err == nil {
+		binary, err := fileHash(executable)
+		if err != nil {
+			return "", err
+		}
+		io.WriteString(hash, binary)
+	}
+	fmt.Fprintf(hash, "\x00%s\x00%s\x00", language, packageName)
+	if configFile != "" {
+		config, err := os.ReadFile(configFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read config file %s: %w", configFile, err)
+		}
+		hash.Write(config)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// schemaHash hashes the IR of a single DTO. Source locations are left out,
+// so editing another part of the spec does not change the hash.
+func schemaHash(dto generator.DTO) (string, error) {
+	dto.Source = nil
+	dto.Properties = slices.Clone(dto.Properties)
+	for i := range dto.Properties {
+		dto.Properties[i].Source = nil
+	}
+	ir, err := generator.MarshalIR([]generator.DTO{dto})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode IR of %s: %w", dto.Name, err)
+	}
+	sum := sha256.Sum256(ir)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// fileHash hashes the content of a file
+func fileHash(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
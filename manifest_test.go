@@ -0,0 +1,333 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"dtoForge/internal/generator"
+	"dtoForge/internal/testutils"
+)
+
+// writeTestFiles writes files below dir with the given content
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for file, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// testManifest records files of dir as generated by a run
+func testManifest(t *testing.T, dir string, files ...string) generationManifest {
+	t.Helper()
+	manifest, err := newManifest(dir, files, runInputs{hash: "inputs", settings: "settings"}, nil, nil)
+	if err != nil {
+		t.Fatalf("newManifest: %v", err)
+	}
+	return manifest
+}
+
+func TestUpdateManifest(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"pet.ts":          "export {};\n",
+		"pets/cat.ts":     "export {};\n",
+		"owners/owner.ts": "export {};\n",
+		"package.json":    "{}\n",
+		"custom.ts":       "export {};\n",
+		"index.ts":        "export {};\n",
+	})
+
+	// Without a manifest nothing is pruned
+	first := testManifest(t, dir, "pet.ts", "pets/cat.ts", "owners/owner.ts", "package.json", "index.ts")
+	removed, err := updateManifest(dir, generationManifest{}, first, true)
+	if err != nil || len(removed) != 0 {
+		t.Fatalf("first updateManifest = %v, %v, want nothing removed", removed, err)
+	}
+	previous, err := readManifest(dir)
+	if err != nil {
+		t.Fatalf("readManifest: %v", err)
+	}
+	if !reflect.DeepEqual(previous, first) {
+		t.Errorf("readManifest = %+v, want %+v", previous, first)
+	}
+
+	// Without pruning the stale files stay
+	current := testManifest(t, dir, "pet.ts", "pets/cat.ts", "index.ts")
+	if removed, err := updateManifest(dir, previous, current, false); err != nil || len(removed) != 0 {
+		t.Fatalf("updateManifest without prune = %v, %v, want nothing removed", removed, err)
+	}
+
+	// The owners tag is gone and package.json was kept rather than rewritten
+	removed, err = updateManifest(dir, previous, current, true)
+	if err != nil {
+		t.Fatalf("updateManifest: %v", err)
+	}
+	if want := []string{"owners/owner.ts"}; !reflect.DeepEqual(removed, want) {
+		t.Errorf("removed = %v, want %v", removed, want)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "owners")); !os.IsNotExist(err) {
+		t.Errorf("emptied owners folder still exists: %v", err)
+	}
+	for _, file := range []string{"custom.ts", "package.json", "pets/cat.ts"} {
+		if _, err := os.Stat(filepath.Join(dir, file)); err != nil {
+			t.Errorf("%s was removed: %v", file, err)
+		}
+	}
+}
+
+func TestPruneStale_OutsideOutputFolder(t *testing.T) {
+	parent := t.TempDir()
+	dir := filepath.Join(parent, "generated")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	outside := filepath.Join(parent, "keep.ts")
+	if err := os.WriteFile(outside, []byte("export {};\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	previous := generationManifest{Files: map[string]string{"../keep.ts": "", outside: ""}}
+	removed, err := pruneStale(dir, previous, nil)
+	if err != nil || len(removed) != 0 {
+		t.Errorf("pruneStale = %v, %v, want nothing removed", removed, err)
+	}
+	if _, err := os.Stat(outside); err != nil {
+		t.Errorf("file outside the output folder was removed: %v", err)
+	}
+}
+
+func TestManifest_UpToDate(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{"pet.ts": "export {};\n"})
+	manifest := testManifest(t, dir, "pet.ts")
+
+	if !manifest.upToDate(dir, "inputs") {
+		t.Error("upToDate with the same inputs = false, want true")
+	}
+	if manifest.upToDate(dir, "other inputs") {
+		t.Error("upToDate with other inputs = true, want false")
+	}
+	writeTestFiles(t, dir, map[string]string{"pet.ts": "export const edited = true;\n"})
+	if manifest.upToDate(dir, "inputs") {
+		t.Error("upToDate after a generated file was edited = true, want false")
+	}
+	if (generationManifest{}).upToDate(dir, "") {
+		t.Error("upToDate without a manifest = true, want false")
+	}
+}
+
+func TestManifest_Warnings(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{"pet.ts": "export {};\n"})
+	warnings := []generator.Warning{{Schema: "Pet", Property: "born", Code: "unmapped-format", Message: "format 'year' has no mapping"}}
+	manifest, err := newManifest(dir, []string{"pet.ts"}, runInputs{hash: "inputs"}, nil, warnings)
+	if err != nil {
+		t.Fatalf("newManifest: %v", err)
+	}
+	if err := writeManifest(dir, manifest); err != nil {
+		t.Fatalf("writeManifest: %v", err)
+	}
+
+	// An up-to-date run replays the warnings of the run that generated
+	previous, err := readManifest(dir)
+	if err != nil {
+		t.Fatalf("readManifest: %v", err)
+	}
+	if !previous.upToDate(dir, "inputs") {
+		t.Fatal("upToDate = false, want true")
+	}
+	if !reflect.DeepEqual(previous.Warnings, warnings) {
+		t.Errorf("Warnings = %+v, want %+v", previous.Warnings, warnings)
+	}
+	if want := []string{"pet.ts"}; !reflect.DeepEqual(previous.files(), want) {
+		t.Errorf("files = %v, want %v", previous.files(), want)
+	}
+}
+
+func TestReadManifest_OtherVersion(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{manifestFile: `{"version": 1, "files": ["pet.ts"]}`})
+
+	manifest, err := readManifest(dir)
+	if err != nil {
+		t.Fatalf("readManifest: %v", err)
+	}
+	if manifest.Version != 0 || len(manifest.Files) != 0 {
+		t.Errorf("readManifest = %+v, want an empty manifest", manifest)
+	}
+}
+
+func TestUnchangedSchemas(t *testing.T) {
+	ref := func(name string) generator.Property {
+		return generator.Property{Name: strings.ToLower(name), Type: generator.ReferenceType{RefName: name}}
+	}
+	// Order -> Cart -> Item -> Price, and Tag on its own
+	price := generator.DTO{Name: "Price", Type: "object"}
+	item := generator.DTO{Name: "Item", Type: "object", Properties: []generator.Property{ref("Price")}}
+	cart := generator.DTO{Name: "Cart", Type: "object", Properties: []generator.Property{ref("Item")}}
+	order := generator.DTO{Name: "Order", Type: "object", Properties: []generator.Property{ref("Cart")}}
+	tag := generator.DTO{Name: "Tag", Type: "object"}
+	dtos := []generator.DTO{price, item, cart, order, tag}
+
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{"item.ts": "export {};\n"})
+	previous, err := newManifest(dir, []string{"item.ts"}, runInputs{settings: "settings"}, dtos, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Item changed: the schemas referencing it and the one it references
+	// are generated again
+	changed := slices.Clone(dtos)
+	changed[1].Description = "A line of a cart"
+	unchanged, err := previous.unchangedSchemas(dir, "settings", changed)
+	if err != nil {
+		t.Fatalf("unchangedSchemas: %v", err)
+	}
+	if want := map[string]bool{"Tag": true}; !reflect.DeepEqual(unchanged, want) {
+		t.Errorf("unchangedSchemas = %v, want %v", unchanged, want)
+	}
+
+	unchanged, err = previous.unchangedSchemas(dir, "settings", dtos)
+	if err != nil || len(unchanged) != len(dtos) {
+		t.Errorf("unchangedSchemas of the same schemas = %v, %v, want all", unchanged, err)
+	}
+
+	// Anything else generates everything again
+	for name, run := range map[string]func() (map[string]bool, error){
+		"other settings": func() (map[string]bool, error) { return previous.unchangedSchemas(dir, "other", dtos) },
+		"added schema": func() (map[string]bool, error) {
+			return previous.unchangedSchemas(dir, "settings", append(slices.Clone(dtos), generator.DTO{Name: "Extra", Type: "object"}))
+		},
+		"renamed schema": func() (map[string]bool, error) {
+			renamed := slices.Clone(dtos)
+			renamed[4].Name = "Label"
+			return previous.unchangedSchemas(dir, "settings", renamed)
+		},
+		"no manifest": func() (map[string]bool, error) {
+			return generationManifest{}.unchangedSchemas(dir, "settings", dtos)
+		},
+	} {
+		if unchanged, err := run(); err != nil || unchanged != nil {
+			t.Errorf("unchangedSchemas with %s = %v, %v, want nil", name, unchanged, err)
+		}
+	}
+	writeTestFiles(t, dir, map[string]string{"item.ts": "export const edited = true;\n"})
+	if unchanged, err := previous.unchangedSchemas(dir, "settings", dtos); err != nil || unchanged != nil {
+		t.Errorf("unchangedSchemas after a generated file was edited = %v, %v, want nil", unchanged, err)
+	}
+}
+
+func TestKeptWarnings(t *testing.T) {
+	renamed := generator.Warning{Schema: "class", Code: "reserved-name", Message: "'class' is a reserved Java name, renamed to 'class_'"}
+	untyped := generator.Warning{Schema: "Pet", Property: "tags", Code: "untyped-java", Message: "Java has no equivalent of tuple and it is generated as List<Object>"}
+	changed := generator.Warning{Schema: "Owner", Property: "pets", Code: "untyped-java", Message: "Java has no equivalent of union and it is generated as Object"}
+	previous := generationManifest{Warnings: []generator.Warning{renamed, untyped, changed}}
+
+	// The generator reported the reserved name again but skipped Pet's file
+	kept := previous.keptWarnings(map[string]bool{"class": true, "Pet": true}, []generator.Warning{renamed})
+	if want := []generator.Warning{untyped}; !reflect.DeepEqual(kept, want) {
+		t.Errorf("keptWarnings = %+v, want %+v", kept, want)
+	}
+}
+
+func TestIncrementalGeneration(t *testing.T) {
+	dir := t.TempDir()
+	spec := `openapi: 3.0.0
+info: {title: Test, version: "1"}
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name: {type: string}
+        owner: {$ref: '#/components/schemas/Owner'}
+    Owner:
+      type: object
+      properties:
+        name: {type: string}
+    Tag:
+      type: object
+      properties:
+        label: {type: string}
+`
+	writeTestFiles(t, dir, map[string]string{"api.yaml": spec})
+	args := []string{"-openapi", "api.yaml", "-out", "out", "-lang", "typescript-zod", "-no-config"}
+	if code, output := runDtoForge(t, dir, args...); code != 0 {
+		t.Fatalf("first run exited with %d:\n%s", code, output)
+	}
+
+	// Mark every file as old, to tell the ones written again
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	files := []string{"pet.ts", "owner.ts", "tag.ts", "index.ts"}
+	for _, file := range files {
+		if err := os.Chtimes(filepath.Join(dir, "out", file), past, past); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeTestFiles(t, dir, map[string]string{"api.yaml": strings.Replace(spec, "name: {type: string}\n    Tag:", "name: {type: string}\n        email: {type: string}\n    Tag:", 1)})
+	code, output := runDtoForge(t, dir, args...)
+	if code != 0 {
+		t.Fatalf("second run exited with %d:\n%s", code, output)
+	}
+	if !strings.Contains(output, "1 of 3 schemas changed since the last run, kept the files of 1") {
+		t.Errorf("output does not report the kept files:\n%s", output)
+	}
+
+	// Owner changed and Pet references it; Tag is left alone, and the
+	// shared index is written again
+	for _, file := range files {
+		info, err := os.Stat(filepath.Join(dir, "out", file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if kept := info.ModTime().Equal(past); kept != (file == "tag.ts") {
+			t.Errorf("%s kept = %v, want %v", file, kept, file == "tag.ts")
+		}
+	}
+	if content := testutils.ReadFile(t, filepath.Join(dir, "out", "owner.ts")); !strings.Contains(content, "email") {
+		t.Errorf("owner.ts was not generated again:\n%s", content)
+	}
+	manifest, err := readManifest(filepath.Join(dir, "out"))
+	if err != nil || manifest.Files["tag.ts"] == "" {
+		t.Errorf("manifest = %+v, %v, want the kept tag.ts recorded", manifest, err)
+	}
+}
+
+func TestChangedSchemas(t *testing.T) {
+	pet := generator.DTO{Name: "Pet", Type: "object", Properties: []generator.Property{{Name: "name", Type: generator.PrimitiveType{Name: "string"}}}}
+	owner := generator.DTO{Name: "Owner", Type: "object"}
+	previous, err := newManifest("", nil, runInputs{}, []generator.DTO{pet, owner}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Moving a schema in the spec does not change it
+	moved := pet
+	moved.Source = &generator.SourceLocation{File: "api.yaml", Line: 40}
+	described := owner
+	described.Description = "The owner of a pet"
+	tag := generator.DTO{Name: "Tag", Type: "object"}
+	current, err := newManifest("", nil, runInputs{}, []generator.DTO{moved, described, tag}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changed := previous.changedSchemas(current)
+	if want := []string{"Owner", "Tag"}; !slices.Equal(changed, want) {
+		t.Errorf("changedSchemas = %v, want %v", changed, want)
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// convertOperations generates DTOs for the inline request body and success
+// response schemas of every path operation. Schemas that are $refs already
+// have a DTO and are skipped.
+func (c *specConverter) convertOperations(spec *OpenAPISpec) error {
+	var paths []string
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		item, ok := spec.Paths[path].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, method := range httpMethods {
+			operation, ok := item[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			operationID, _ := operation["operationId"].(string)
+			base := operationName(method, path, operationID)
+
+			var tags []string
+			if opTags, ok := operation["tags"].([]interface{}); ok {
+				for _, tag := range opTags {
+					if name, ok := tag.(string); ok {
+						tags = append(tags, name)
+					}
+				}
+			}
+
+			if body, ok := operation["requestBody"].(map[string]interface{}); ok {
+				if err := c.convertOperationSchema(base, "Request", contentSchema(body), tags); err != nil {
+					return fmt.Errorf("failed to convert request body of %s %s: %w", strings.ToUpper(method), path, err)
+				}
+			}
+			if response := successResponse(operation); response != nil {
+				if err := c.convertOperationSchema(base, "Response", contentSchema(response), tags); err != nil {
+					return fmt.Errorf("failed to convert response of %s %s: %w", strings.ToUpper(method), path, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// convertOperationSchema hoists an inline object schema, or the inline object
+// items of an array schema, into a DTO named after the operation
+func (c *specConverter) convertOperationSchema(base, kind string, schema map[string]interface{}, tags []string) error {
+	if schema == nil {
+		return nil
+	}
+
+	name := c.operationDTOName(base, kind)
+	if schema["type"] == "array" {
+		items, ok := schema["items"].(map[string]interface{})
+		if !ok || !isInlineObject(items) {
+			return nil
+		}
+		schema, name = items, name+"Item"
+	} else if !isInlineObject(schema) {
+		return nil
+	}
+
+	name, err := c.hoistInlineDTO(name, schema)
+	if err != nil {
+		return err
+	}
+	if c.operationTags == nil {
+		c.operationTags = make(map[string][]string)
+	}
+	c.operationTags[name] = tags
+	return nil
+}
+
+// operationDTOName fills the configured name template. {Operation} and
+// {operation} are the operation name in PascalCase and camelCase, {Kind}
+// and {kind} are "Request" or "Response" and their lowercase forms.
+func (c *specConverter) operationDTOName(operation, kind string) string {
+	template := c.options.Operations.NameTemplate
+	if template == "" {
+		template = "{Operation}{Kind}"
+	}
+	return strings.NewReplacer(
+		"{Operation}", operation,
+		"{operation}", strings.ToLower(operation[:1])+operation[1:],
+		"{Kind}", kind,
+		"{kind}", strings.ToLower(kind),
+	).Replace(template)
+}
+
+// operationName derives a PascalCase name from an operationId, e.g. listPets
+// -> ListPets, or from the method and path when there is none, e.g.
+// GET /pets/{petId} -> GetPetsPetId
+func operationName(method, path, operationID string) string {
+	source := operationID
+	if source == "" {
+		source = method + " " + path
+	}
+
+	var b strings.Builder
+	upperNext := true
+	for _, r := range source {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upperNext = true
+			continue
+		}
+		if upperNext {
+			r = unicode.ToUpper(r)
+			upperNext = false
+		}
+		b.WriteRune(r)
+	}
+
+	name := b.String()
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "Operation" + name
+	}
+	return name
+}
+
+// contentSchema returns the schema of a request body or response, preferring
+// application/json over other media types
+func contentSchema(body map[string]interface{}) map[string]interface{} {
+	content, ok := body["content"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	if media, ok := content["application/json"].(map[string]interface{}); ok {
+		schema, _ := media["schema"].(map[string]interface{})
+		return schema
+	}
+
+	var types []string
+	for mediaType := range content {
+		types = append(types, mediaType)
+	}
+	sort.Strings(types)
+	for _, mediaType := range types {
+		if media, ok := content[mediaType].(map[string]interface{}); ok {
+			if schema, ok := media["schema"].(map[string]interface{}); ok {
+				return schema
+			}
+		}
+	}
+	return nil
+}
+
+// successResponse returns the operation's lowest 2xx response, if any
+func successResponse(operation map[string]interface{}) map[string]interface{} {
+	responses, ok := stringMap(operation["responses"])
+	if !ok {
+		return nil
+	}
+
+	var codes []string
+	for code := range responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		if response, ok := stringMap(responses[code]); ok {
+			return response
+		}
+	}
+	return nil
+}
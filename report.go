@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+
+	"dtoForge/internal/generator"
+)
+
+// runReport is the machine-readable summary of a run written by -report json
+type runReport struct {
+	Language       string              `json:"language"`
+	Output         string              `json:"output"` // output folder, or "-" for stdout
+	Schemas        []string            `json:"schemas"`
+	SkippedSchemas []string            `json:"skippedSchemas"` // components the converter skipped
+	Files          []string            `json:"files"`          // relative to the output folder
+	Warnings       []generator.Warning `json:"warnings"`
+	Durations      reportDurations     `json:"durations"`
+}
+
+// reportDurations are the times spent in each stage of the run, in milliseconds
+type reportDurations struct {
+	Parse    int64 `json:"parseMs"`
+	Convert  int64 `json:"convertMs"`
+	Generate int64 `json:"generateMs"`
+	Total    int64 `json:"totalMs"`
+}
+
+// stageTimer measures the duration of consecutive stages of a run
+type stageTimer struct {
+	start time.Time
+	last  time.Time
+}
+
+func newStageTimer() *stageTimer {
+	now := time.Now()
+	return &stageTimer{start: now, last: now}
+}
+
+// lap returns the milliseconds since the previous lap and starts the next stage
+func (s *stageTimer) lap() int64 {
+	now := time.Now()
+	elapsed := now.Sub(s.last).Milliseconds()
+	s.last = now
+	return elapsed
+}
+
+// total returns the milliseconds since the timer was created
+func (s *stageTimer) total() int64 {
+	return time.Since(s.start).Milliseconds()
+}
+
+// skippedSchemaCode is the warning code of a schema the converter skipped
+const skippedSchemaCode = "skipped-schema"
+
+// skippedSchemas lists the schemas the converter skipped, as recorded in its
+// warnings where each one was dropped
+func skippedSchemas(warnings []generator.Warning) []string {
+	var skipped []string
+	for _, warning := range warnings {
+		if warning.Code == skippedSchemaCode && !slices.Contains(skipped, warning.Schema) {
+			skipped = append(skipped, warning.Schema)
+		}
+	}
+	sort.Strings(skipped)
+	return skipped
+}
+
+// generatedFiles turns the paths a run recorded writing into files relative
+// to dir. The manifest is bookkeeping rather than generated output, and files
+// outside dir, such as a proto manifest kept elsewhere, are left out.
+func generatedFiles(dir string, paths []string) []string {
+	var files []string
+	for _, path := range paths {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil || !filepath.IsLocal(rel) || rel == manifestFile {
+			continue
+		}
+		files = append(files, filepath.ToSlash(rel))
+	}
+	sort.Strings(files)
+	return files
+}
+
+// writeReport writes the report in the given format to w
+func writeReport(w io.Writer, format string, report runReport) error {
+	if format != "json" {
+		return fmt.Errorf("unsupported report format '%s', must be 'json'", format)
+	}
+	// Empty lists are written as [] so consumers need no null checks
+	for _, list := range []*[]string{&report.Schemas, &report.SkippedSchemas, &report.Files} {
+		if *list == nil {
+			*list = []string{}
+		}
+	}
+	if report.Warnings == nil {
+		report.Warnings = []generator.Warning{}
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// saveReport writes the report to path, or to stdout for "-"
+func saveReport(path, format string, report runReport, stdout io.Writer) error {
+	if path == "-" {
+		return writeReport(stdout, format, report)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	defer file.Close()
+	return writeReport(file, format, report)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"dtoForge/internal/generator"
+)
+
+func TestWriteReport(t *testing.T) {
+	var out strings.Builder
+	report := runReport{
+		Language: "typescript",
+		Output:   "./generated",
+		Schemas:  []string{"Pet"},
+		Warnings: []generator.Warning{{Schema: "Pet", Code: "unsupported-not", Message: "'not' is not supported"}},
+	}
+	if err := writeReport(&out, "json", report); err != nil {
+		t.Fatalf("writeReport: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(out.String()), &decoded); err != nil {
+		t.Fatalf("report is not JSON: %v\n%s", err, out.String())
+	}
+	if files, ok := decoded["files"].([]interface{}); !ok || len(files) != 0 {
+		t.Errorf("files = %v, want an empty list", decoded["files"])
+	}
+	if _, ok := decoded["durations"].(map[string]interface{})["totalMs"]; !ok {
+		t.Errorf("durations = %v, want totalMs", decoded["durations"])
+	}
+
+	if err := writeReport(&out, "xml", report); err == nil {
+		t.Error("writeReport with format xml: want an error")
+	}
+}
+
+func TestSkippedSchemas(t *testing.T) {
+	spec := &OpenAPISpec{Components: map[string]interface{}{
+		"schemas": map[string]interface{}{
+			"Pet":   map[string]interface{}{"type": "object"},
+			"Empty": nil,
+			"Any":   true,
+		},
+	}}
+	dtos, warnings, err := convertToGeneratorDTOs(spec, ConversionOptions{})
+	if err != nil {
+		t.Fatalf("convertToGeneratorDTOs: %v", err)
+	}
+	if len(dtos) != 1 || dtos[0].Name != "Pet" {
+		t.Errorf("dtos = %+v, want only Pet", dtos)
+	}
+	if got, want := skippedSchemas(warnings), []string{"Any", "Empty"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("skippedSchemas = %v, want %v", got, want)
+	}
+	if got := skippedSchemas(nil); got != nil {
+		t.Errorf("skippedSchemas(nil) = %v, want nil", got)
+	}
+}
+
+func TestGeneratedFiles(t *testing.T) {
+	dir := t.TempDir()
+	// A hand-written file saved while generating is not generated output
+	writeTestFiles(t, dir, map[string]string{"custom.ts": "export {};\n"})
+
+	gen, err := newRegistry().Get("typescript")
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := generator.Config{OutputFolder: dir, TargetLanguage: "typescript", Files: &generator.FileRecorder{}}
+	dtos := []generator.DTO{{Name: "Pet", Properties: []generator.Property{{Name: "name", Type: generator.PrimitiveType{Name: "string"}, Required: true}}}}
+	if err := gen.Generate(dtos, config); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	writeTestFiles(t, dir, map[string]string{manifestFile: "{}\n"})
+
+	paths := append(config.Files.Paths(), filepath.Join(dir, manifestFile), filepath.Join(filepath.Dir(dir), "outside.ts"))
+	files := generatedFiles(dir, paths)
+	for _, want := range []string{"index.ts", "package.json", "pet.ts"} {
+		if !slices.Contains(files, want) {
+			t.Errorf("generatedFiles = %v, want %s", files, want)
+		}
+	}
+	for _, unwanted := range []string{"custom.ts", manifestFile, "../outside.ts"} {
+		if slices.Contains(files, unwanted) {
+			t.Errorf("generatedFiles = %v, want no %s", files, unwanted)
+		}
+	}
+}
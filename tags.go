@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"dtoForge/internal/generator"
+)
+
+// httpMethods are the operation keys of an OpenAPI path item
+var httpMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// schemaTags maps each component schema key to the tags of the operations
+// that reference it directly, through requestBody, responses or parameters.
+// References into other component sections are followed.
+func schemaTags(spec *OpenAPISpec) map[string]map[string]bool {
+	tags := make(map[string]map[string]bool)
+
+	for _, pathItem := range spec.Paths {
+		item, ok := pathItem.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, method := range httpMethods {
+			operation, ok := item[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			opTags, _ := operation["tags"].([]interface{})
+			if len(opTags) == 0 {
+				continue
+			}
+
+			refs := make(map[string]bool)
+			visited := make(map[string]bool)
+			collectSchemaRefs(spec, operation, refs, visited)
+			collectSchemaRefs(spec, item["parameters"], refs, visited)
+
+			for key := range refs {
+				if tags[key] == nil {
+					tags[key] = make(map[string]bool)
+				}
+				for _, tag := range opTags {
+					if name, ok := tag.(string); ok {
+						tags[key][name] = true
+					}
+				}
+			}
+		}
+	}
+
+	return tags
+}
+
+// collectSchemaRefs records the component schemas referenced anywhere in
+// value, resolving references to other components such as shared responses
+func collectSchemaRefs(spec *OpenAPISpec, value interface{}, refs, visited map[string]bool) {
+	if list, ok := value.([]interface{}); ok {
+		for _, child := range list {
+			collectSchemaRefs(spec, child, refs, visited)
+		}
+		return
+	}
+
+	m, ok := stringMap(value)
+	if !ok {
+		return
+	}
+	ref, ok := m["$ref"].(string)
+	if !ok {
+		for _, child := range m {
+			collectSchemaRefs(spec, child, refs, visited)
+		}
+		return
+	}
+
+	const prefix = "#/components/"
+	if !strings.HasPrefix(ref, prefix) || visited[ref] {
+		return
+	}
+	visited[ref] = true
+
+	section, name, _ := strings.Cut(strings.TrimPrefix(ref, prefix), "/")
+	if section == "schemas" {
+		refs[name] = true
+		return
+	}
+	if components, ok := spec.Components[section].(map[string]interface{}); ok {
+		collectSchemaRefs(spec, components[name], refs, visited)
+	}
+}
+
+// stringMap returns value as a map with string keys. YAML mappings with
+// non-string keys, such as unquoted response codes, decode as
+// map[interface{}]interface{}.
+func stringMap(value interface{}) (map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			m[fmt.Sprint(key)] = child
+		}
+		return m, true
+	}
+	return nil, false
+}
+
+// assignTags sets every DTO's tags to those of the operations using it,
+// directly or through other DTOs
+func (c *specConverter) assignTags(spec *OpenAPISpec, dtos []generator.DTO) {
+	byName := make(map[string]int, len(dtos))
+	for i, dto := range dtos {
+		byName[dto.Name] = i
+	}
+
+	tags := make(map[string]map[string]bool)
+	var spread func(name, tag string)
+	spread = func(name, tag string) {
+		i, known := byName[name]
+		if !known || tags[name][tag] {
+			return
+		}
+		if tags[name] == nil {
+			tags[name] = make(map[string]bool)
+		}
+		tags[name][tag] = true
+		for _, dep := range generator.Dependencies(dtos[i]) {
+			spread(dep, tag)
+		}
+	}
+
+	for key, keyTags := range schemaTags(spec) {
+		for tag := range keyTags {
+			spread(c.schemaName(key), tag)
+		}
+	}
+	for name, opTags := range c.operationTags {
+		for _, tag := range opTags {
+			spread(name, tag)
+		}
+	}
+
+	for name, nameTags := range tags {
+		sorted := make([]string, 0, len(nameTags))
+		for tag := range nameTags {
+			sorted = append(sorted, tag)
+		}
+		sort.Strings(sorted)
+		dtos[byName[name]].Tags = sorted
+	}
+}
@@ -1,19 +1,21 @@
 // Generated by DtoForge - DO NOT EDIT
 import * as t from 'io-ts';
+import { decodeOrThrow } from './helpers';
 
 
 /**
  * Product category
  */
 // Schema: Category
-export const CategoryCodec = t.type({
+export const CategoryCodec = t.intersection([t.type({
   // Category identifier
   id: t.string,
   // Category name
   name: t.string,
+}), t.partial({
   // Parent category ID (for nested categories)
-  parentId: t.union([t.string, t.undefined]),
-});
+  parentId: t.string,
+})]);
 
 export type Category = t.TypeOf<typeof CategoryCodec>;
 
@@ -25,6 +27,9 @@ export const isCategory = (value: unknown): value is Category =>
 export const decodeCategory = (value: unknown) =>
   CategoryCodec.decode(value);
 
+// Decode helper throwing a DecodeError with readable messages
+export const decodeCategoryOrThrow = decodeOrThrow(CategoryCodec);
+
 // Partial codec for updates (all fields optional)
 export const CategoryPartialCodec = t.partial({
   id: t.string,
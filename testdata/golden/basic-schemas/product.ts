@@ -1,22 +1,25 @@
 // Generated by DtoForge - DO NOT EDIT
 import * as t from 'io-ts';
+import { CategoryCodec } from './category';
+import { decodeOrThrow } from './helpers';
 
 
 /**
  * A product in the catalog
  */
 // Schema: Product
-export const ProductCodec = t.type({
-  category: t.union([CategoryCodec, t.undefined]),
-  // Product description
-  description: t.union([t.string, t.undefined]),
+export const ProductCodec = t.intersection([t.type({
   // Product identifier
   id: t.string,
   // Product name
   name: t.string,
   // Product price
   price: t.number,
-});
+}), t.partial({
+  category: CategoryCodec,
+  // Product description
+  description: t.string,
+})]);
 
 export type Product = t.TypeOf<typeof ProductCodec>;
 
@@ -28,6 +31,9 @@ export const isProduct = (value: unknown): value is Product =>
 export const decodeProduct = (value: unknown) =>
   ProductCodec.decode(value);
 
+// Decode helper throwing a DecodeError with readable messages
+export const decodeProductOrThrow = decodeOrThrow(ProductCodec);
+
 // Partial codec for updates (all fields optional)
 export const ProductPartialCodec = t.partial({
   category: CategoryCodec,
@@ -1,5 +1,6 @@
 // Generated by DtoForge - DO NOT EDIT
 import * as t from 'io-ts';
+import { decodeOrThrow } from './helpers';
 
 
 /**
@@ -25,3 +26,6 @@ export const isStatus = (value: unknown): value is Status =>
 export const decodeStatus = (value: unknown) =>
   StatusCodec.decode(value);
 
+// Decode helper throwing a DecodeError with readable messages
+export const decodeStatusOrThrow = decodeOrThrow(StatusCodec);
+
@@ -1,23 +1,25 @@
 // Generated by DtoForge - DO NOT EDIT
 import * as t from 'io-ts';
+import { decodeOrThrow } from './helpers';
 
 
 /**
  * A user in the system
  */
 // Schema: User
-export const UserCodec = t.type({
-  // User's age (optional)
-  age: t.union([t.number, t.undefined]),
-  // User's email address
-  email: t.union([t.string, t.undefined]),
+export const UserCodec = t.intersection([t.type({
   // Unique user identifier
   id: t.string,
-  // Whether the user account is active
-  isActive: t.union([t.boolean, t.undefined]),
   // Full name of the user
   name: t.string,
-});
+}), t.partial({
+  // User's age (optional)
+  age: t.number,
+  // User's email address
+  email: t.string,
+  // Whether the user account is active
+  isActive: t.boolean,
+})]);
 
 export type User = t.TypeOf<typeof UserCodec>;
 
@@ -29,6 +31,9 @@ export const isUser = (value: unknown): value is User =>
 export const decodeUser = (value: unknown) =>
   UserCodec.decode(value);
 
+// Decode helper throwing a DecodeError with readable messages
+export const decodeUserOrThrow = decodeOrThrow(UserCodec);
+
 // Partial codec for updates (all fields optional)
 export const UserPartialCodec = t.partial({
   age: t.number,
@@ -10,16 +10,17 @@ import { UUID } from './branded-types';
  * Document with metadata
  */
 // Schema: Document
-export const DocumentCodec = t.type({
-  // Document content (base64 encoded)
-  content: t.union([Base64String, t.undefined]),
+export const DocumentCodec = t.intersection([t.type({
   // Document identifier
   documentId: UUID,
-  // Download URL
-  downloadUrl: t.union([URLString, t.undefined]),
   // Upload timestamp
   uploadedAt: DateTimeString,
-});
+}), t.partial({
+  // Document content (base64 encoded)
+  content: Base64String,
+  // Download URL
+  downloadUrl: URLString,
+})]);
 
 export type Document = t.TypeOf<typeof DocumentCodec>;
 
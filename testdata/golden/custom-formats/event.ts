@@ -10,18 +10,19 @@ import { UUID } from './branded-types';
  * System event with timestamps
  */
 // Schema: Event
-export const EventCodec = t.type({
-  // Date of the event (without time)
-  eventDate: t.union([DateString, t.undefined]),
+export const EventCodec = t.intersection([t.type({
   // Event identifier
   eventId: UUID,
-  // Related resource URL
-  resourceUrl: t.union([URLString, t.undefined]),
-  // When the event is scheduled
-  scheduledFor: t.union([DateTimeString, t.undefined]),
   // When the event occurred
   timestamp: DateTimeString,
-});
+}), t.partial({
+  // Date of the event (without time)
+  eventDate: DateString,
+  // Related resource URL
+  resourceUrl: URLString,
+  // When the event is scheduled
+  scheduledFor: DateTimeString,
+})]);
 
 export type Event = t.TypeOf<typeof EventCodec>;
 
@@ -1,6 +1,13 @@
 // Generated by DtoForge - DO NOT EDIT
 // generated-schemas - OpenAPI Schema Validators
 
+import * as t from 'io-ts';
+import { isRight } from 'fp-ts/Either';
+
+import { DocumentCodec } from './document';
+import { EventCodec } from './event';
+import { UserCodec } from './user';
+
 export * from './document';
 export * from './event';
 export * from './user';
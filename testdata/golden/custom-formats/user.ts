@@ -12,20 +12,21 @@ import { UUID } from './branded-types';
  * User with various formatted fields
  */
 // Schema: User
-export const UserCodec = t.type({
-  // Base64 encoded avatar image
-  avatarData: t.union([Base64String, t.undefined]),
-  // User's birth date
-  birthDate: t.union([DateString, t.undefined]),
+export const UserCodec = t.intersection([t.type({
   // Account creation timestamp
   createdAt: DateTimeString,
   // User's email address
   email: EmailString,
   // Unique user identifier (UUID)
   id: UUID,
+}), t.partial({
+  // Base64 encoded avatar image
+  avatarData: Base64String,
+  // User's birth date
+  birthDate: DateString,
   // URL to user's profile picture
-  profilePicture: t.union([URLString, t.undefined]),
-});
+  profilePicture: URLString,
+})]);
 
 export type User = t.TypeOf<typeof UserCodec>;
 
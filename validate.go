@@ -0,0 +1,326 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"dtoForge/internal/generator"
+)
+
+// specIssue is a problem found while validating a spec before conversion
+type specIssue struct {
+	Path     string // location in the spec, e.g. components.schemas.Pet.properties.tag
+	Severity string // "error" stops generation, "warning" is reported only
+	Code     string
+	Message  string
+	Source   *generator.SourceLocation // position in the spec file, nil if unknown
+}
+
+func (i specIssue) String() string {
+	if i.Source != nil {
+		return fmt.Sprintf("%-7s %s: %s (%s)", i.Severity, i.Path, i.Message, i.Source)
+	}
+	return fmt.Sprintf("%-7s %s: %s", i.Severity, i.Path, i.Message)
+}
+
+// Warning converts the issue for the run's warning summary, locating it by
+// schema name and the path below it. Issues outside components.schemas, such
+// as in operations, keep their whole path as the schema.
+func (i specIssue) Warning() generator.Warning {
+	rest, isComponent := strings.CutPrefix(i.Path, "components.schemas.")
+	if !isComponent {
+		return generator.Warning{Schema: i.Path, Code: i.Code, Message: i.Message, Source: i.Source}
+	}
+	schema, property, _ := strings.Cut(rest, ".")
+	return generator.Warning{
+		Schema:   schema,
+		Property: strings.ReplaceAll(strings.TrimPrefix(property, "properties."), ".properties.", "."),
+		Code:     i.Code,
+		Message:  i.Message,
+		Source:   i.Source,
+	}
+}
+
+// unsupportedKeywords are schema keywords the converter ignores
+var unsupportedKeywords = []string{
+	"if", "then", "else", "patternProperties",
+	"propertyNames", "dependentSchemas", "dependentRequired", "contains",
+	"unevaluatedProperties", "unevaluatedItems", "$dynamicRef",
+}
+
+var knownTypes = map[string]bool{
+	"string": true, "number": true, "integer": true, "boolean": true,
+	"array": true, "object": true, "null": true,
+}
+
+// specValidator walks every component and operation schema and collects all
+// issues at once
+type specValidator struct {
+	spec    *OpenAPISpec
+	schemas map[string]interface{}
+	issues  []specIssue
+}
+
+// validateSpec checks the parsed spec for problems that would make conversion
+// fail or produce silently wrong output
+func validateSpec(spec *OpenAPISpec) []specIssue {
+	v := &specValidator{spec: spec}
+	schemas, _ := spec.Components["schemas"].(map[string]interface{})
+	v.schemas = schemas
+
+	var names []string
+	for name := range schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	v.checkDuplicateNames(names)
+	for _, name := range names {
+		path := "components.schemas." + name
+		schema, ok := schemas[name].(map[string]interface{})
+		if !ok {
+			v.add(path, "error", "invalid-schema", "schema must be an object")
+			continue
+		}
+		v.checkSchema(path, schema)
+	}
+	v.checkOperations()
+
+	return v.issues
+}
+
+// checkOperations validates the parameter, request body and response schemas
+// of every path operation
+func (v *specValidator) checkOperations() {
+	var paths []string
+	for path := range v.spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		item, ok := v.spec.Paths[path].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		prefix := "paths." + path
+		v.checkParameters(prefix+".parameters", item["parameters"])
+		for _, method := range httpMethods {
+			operation, ok := item[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			opPath := prefix + "." + method
+			v.checkParameters(opPath+".parameters", operation["parameters"])
+			if body, ok := operation["requestBody"].(map[string]interface{}); ok {
+				v.checkContent(opPath+".requestBody.content", body["content"])
+			}
+			responses, _ := operation["responses"].(map[string]interface{})
+			var codes []string
+			for code := range responses {
+				codes = append(codes, code)
+			}
+			sort.Strings(codes)
+			for _, code := range codes {
+				if response, ok := responses[code].(map[string]interface{}); ok {
+					v.checkContent(opPath+".responses."+code+".content", response["content"])
+				}
+			}
+		}
+	}
+}
+
+// checkParameters validates the schemas of a parameter list
+func (v *specValidator) checkParameters(path string, value interface{}) {
+	parameters, _ := value.([]interface{})
+	for i, item := range parameters {
+		parameter, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		if schema, ok := parameter["schema"]; ok {
+			v.checkNested(itemPath+".schema", schema)
+		}
+		v.checkContent(itemPath+".content", parameter["content"])
+	}
+}
+
+// checkContent validates the schema of each media type of a content map
+func (v *specValidator) checkContent(path string, value interface{}) {
+	content, _ := value.(map[string]interface{})
+	var mediaTypes []string
+	for mediaType := range content {
+		mediaTypes = append(mediaTypes, mediaType)
+	}
+	sort.Strings(mediaTypes)
+	for _, mediaType := range mediaTypes {
+		media, ok := content[mediaType].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if schema, ok := media["schema"]; ok {
+			v.checkNested(path+"."+mediaType+".schema", schema)
+		}
+	}
+}
+
+// hasErrors reports whether any issue should stop generation
+func hasErrors(issues []specIssue) bool {
+	for _, issue := range issues {
+		if issue.Severity == "error" {
+			return true
+		}
+	}
+	return false
+}
+
+// printSpecIssues prints a validation report, errors first
+func printSpecIssues(issues []specIssue) {
+	if len(issues) == 0 {
+		return
+	}
+
+	errors := 0
+	for _, issue := range issues {
+		if issue.Severity == "error" {
+			errors++
+		}
+	}
+	fmt.Printf("🔍 Spec validation found %d error(s) and %d warning(s):\n", errors, len(issues)-errors)
+
+	sorted := make([]specIssue, len(issues))
+	copy(sorted, issues)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Severity == "error" && sorted[j].Severity != "error"
+	})
+	for _, issue := range sorted {
+		fmt.Printf("  %s\n", issue)
+	}
+}
+
+func (v *specValidator) add(path, severity, code, message string) {
+	v.issues = append(v.issues, specIssue{Path: path, Severity: severity, Code: code, Message: message, Source: v.spec.location(path)})
+}
+
+// checkDuplicateNames finds schemas that would be written to the same file,
+// such as UserProfile and user_profile
+func (v *specValidator) checkDuplicateNames(names []string) {
+	seen := make(map[string]string)
+	for _, name := range names {
+		key := normalizeName(name)
+		if first, exists := seen[key]; exists {
+			v.add("components.schemas."+name, "error", "name-collision", fmt.Sprintf("name collides with '%s' in generated file names", first))
+			continue
+		}
+		seen[key] = name
+	}
+}
+
+// normalizeName reduces a schema name to the parts that survive file naming
+func normalizeName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
+
+// checkSchema validates one schema and everything nested in it
+func (v *specValidator) checkSchema(path string, schema map[string]interface{}) {
+	if ref, ok := schema["$ref"].(string); ok {
+		v.checkRef(path, ref)
+		return
+	}
+
+	for _, keyword := range unsupportedKeywords {
+		if _, ok := schema[keyword]; ok {
+			v.add(path, "warning", "unsupported-keyword", fmt.Sprintf("'%s' is not supported and will be ignored", keyword))
+		}
+	}
+
+	switch typ := schema["type"].(type) {
+	case string:
+		if !knownTypes[typ] {
+			v.add(path, "error", "unknown-type", fmt.Sprintf("unknown type '%s'", typ))
+		}
+	case nil:
+		if !describesType(schema) {
+			v.add(path, "warning", "missing-type", "schema has no type and will be generated as unknown")
+		}
+	default:
+		if _, isNullable := nullableVariant(schema); !isNullable {
+			v.add(path, "warning", "type-list", "type lists are not supported and will be generated as unknown")
+		}
+	}
+
+	if props, ok := schema["properties"].(map[string]interface{}); ok {
+		var names []string
+		for name := range props {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			v.checkNested(path+".properties."+name, props[name])
+		}
+	}
+
+	switch items := schema["items"].(type) {
+	case map[string]interface{}:
+		v.checkSchema(path+".items", items)
+	case []interface{}:
+		for i, item := range items {
+			v.checkNested(fmt.Sprintf("%s.items[%d]", path, i), item)
+		}
+	}
+
+	for _, keyword := range []string{"prefixItems", "allOf", "anyOf", "oneOf"} {
+		if members, ok := schema[keyword].([]interface{}); ok {
+			for i, member := range members {
+				v.checkNested(fmt.Sprintf("%s.%s[%d]", path, keyword, i), member)
+			}
+		}
+	}
+
+	if additional, ok := schema["additionalProperties"].(map[string]interface{}); ok {
+		v.checkSchema(path+".additionalProperties", additional)
+	}
+}
+
+// checkNested validates a nested value that must itself be a schema
+func (v *specValidator) checkNested(path string, value interface{}) {
+	schema, ok := value.(map[string]interface{})
+	if !ok {
+		v.add(path, "error", "invalid-schema", "schema must be an object")
+		return
+	}
+	v.checkSchema(path, schema)
+}
+
+// checkRef verifies that a $ref points at an existing component schema.
+// Other references, e.g. into another file of a split spec, are not resolved
+// and are generated as references to a type named after their last segment.
+func (v *specValidator) checkRef(path, ref string) {
+	const prefix = "#/components/schemas/"
+	if !strings.HasPrefix(ref, prefix) {
+		v.add(path, "warning", "external-ref", fmt.Sprintf("$ref '%s' is not resolved, it is generated as a reference to '%s'", ref, extractRefName(ref)))
+		return
+	}
+	if _, exists := v.schemas[strings.TrimPrefix(ref, prefix)]; !exists {
+		v.add(path, "error", "unresolved-ref", fmt.Sprintf("$ref '%s' does not resolve to a schema", ref))
+	}
+}
+
+// describesType reports whether a schema without 'type' still implies one
+func describesType(schema map[string]interface{}) bool {
+	for _, keyword := range []string{"enum", "const", "allOf", "anyOf", "oneOf", "properties", "items", "prefixItems", "not"} {
+		if _, ok := schema[keyword]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestValidateSpec(t *testing.T) {
+	var spec OpenAPISpec
+	if err := yaml.Unmarshal([]byte(`
+openapi: 3.0.0
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        owner:
+          $ref: '#/components/schemas/Owner'
+        remote:
+          $ref: 'common.yaml#/Address'
+        pet:
+          type: string
+          if:
+            minLength: 1
+        blob:
+          description: anything
+        kind:
+          type: strnig
+        tags:
+          type: array
+          items:
+            type: [string, integer]
+    user_:
+      type: string
+    Address:
+      type: object
+      properties:
+        city:
+          type: string
+`), &spec); err != nil {
+		t.Fatalf("Failed to parse spec: %v", err)
+	}
+
+	issues := validateSpec(&spec)
+	if !hasErrors(issues) {
+		t.Fatal("Expected validation errors")
+	}
+
+	expected := map[string]string{
+		"components.schemas.user_":                      "error",
+		"components.schemas.User.properties.owner":      "error",
+		"components.schemas.User.properties.remote":     "warning",
+		"components.schemas.User.properties.kind":       "error",
+		"components.schemas.User.properties.pet":        "warning",
+		"components.schemas.User.properties.blob":       "warning",
+		"components.schemas.User.properties.tags.items": "warning",
+	}
+	if len(issues) != len(expected) {
+		t.Errorf("Expected %d issues, got %d: %v", len(expected), len(issues), issues)
+	}
+	for _, issue := range issues {
+		if severity, ok := expected[issue.Path]; !ok || severity != issue.Severity {
+			t.Errorf("Unexpected issue: %s", issue)
+		}
+	}
+}
+
+func TestValidateSpec_Operations(t *testing.T) {
+	var spec OpenAPISpec
+	if err := yaml.Unmarshal([]byte(`
+openapi: 3.0.0
+paths:
+  /pets/{id}:
+    parameters:
+      - name: id
+        in: path
+        schema:
+          type: strnig
+    post:
+      parameters:
+        - name: filter
+          in: query
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Filter'
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                owner:
+                  $ref: 'common.yaml#/Owner'
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  type: strnig
+components:
+  schemas:
+    Pet:
+      type: object
+`), &spec); err != nil {
+		t.Fatalf("Failed to parse spec: %v", err)
+	}
+
+	expected := map[string]string{
+		"paths./pets/{id}.parameters[0].schema":                                              "error",
+		"paths./pets/{id}.post.parameters[0].content.application/json.schema":                "error",
+		"paths./pets/{id}.post.requestBody.content.application/json.schema.properties.owner": "warning",
+		"paths./pets/{id}.post.responses.200.content.application/json.schema.items":          "error",
+	}
+	issues := validateSpec(&spec)
+	if len(issues) != len(expected) {
+		t.Errorf("Expected %d issues, got %d: %v", len(expected), len(issues), issues)
+	}
+	for _, issue := range issues {
+		if severity, ok := expected[issue.Path]; !ok || severity != issue.Severity {
+			t.Errorf("Unexpected issue: %s", issue)
+		}
+	}
+}
+
+func TestValidateSpec_Valid(t *testing.T) {
+	spec, err := readOpenAPISpec("testdata/basic-api.yaml")
+	if err != nil {
+		t.Fatalf("Failed to read spec: %v", err)
+	}
+
+	if issues := validateSpec(spec); len(issues) != 0 {
+		t.Errorf("Expected no issues, got %v", issues)
+	}
+}
+
+func TestSpecIssue_Warning(t *testing.T) {
+	issue := specIssue{
+		Path:     "components.schemas.User.properties.address.properties.city",
+		Severity: "warning",
+		Code:     "missing-type",
+		Message:  "schema has no type",
+	}
+
+	warning := issue.Warning()
+	if warning.Schema != "User" || warning.Property != "address.city" || warning.Code != "missing-type" {
+		t.Errorf("Unexpected warning: %+v", warning)
+	}
+}